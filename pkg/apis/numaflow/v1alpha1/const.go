@@ -150,6 +150,9 @@ const (
 	EnvServingMinPipelineSpec           = "NUMAFLOW_SERVING_MIN_PIPELINE_SPEC"
 	EnvServingHostIP                    = "NUMAFLOW_SERVING_HOST_IP"
 	EnvServingStoreTTL                  = "NUMAFLOW_SERVING_STORE_TTL"
+	EnvPBQS3Bucket                      = "NUMAFLOW_PBQ_S3_BUCKET"
+	EnvPBQS3Region                      = "NUMAFLOW_PBQ_S3_REGION"
+	EnvPBQS3Endpoint                    = "NUMAFLOW_PBQ_S3_ENDPOINT"
 	PathVarRun                          = "/var/run/numaflow"
 	VertexMetricsPort                   = 2469
 	VertexMetricsPortName               = "metrics"
@@ -194,6 +197,9 @@ const (
 	DefaultWALSegmentRotationDuration = 60 * time.Second       // Default segment rotation duration
 	DefaultWALSegmentSize             = 30 * 1024 * 1024       // Default segment size
 
+	// Default BoltDB WAL options
+	DefaultBoltDBWALPath = PathPBQMount + "/boltdb-wals" // Default store path for the BoltDB file
+
 	// Default GC-events WAL options
 	DefaultGCEventsWALRotationDuration    = 60 * time.Second         // Default rotation duration for the GC tracker
 	DefaultGCEventsWALEventsPath          = PathPBQMount + "/events" // Default store path for operations
@@ -2851,510 +2851,507 @@ func init() {
 }
 
 var fileDescriptor_9d0d1b17d3865563 = []byte{
-	// 8046 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x7d, 0x5d, 0x6c, 0x24, 0x57,
-	0x76, 0x9e, 0xfa, 0xbf, 0xfb, 0x34, 0xff, 0x74, 0x67, 0x34, 0xe2, 0xcc, 0x4a, 0xd3, 0xe3, 0x5a,
-	0xef, 0x7a, 0x1c, 0xdb, 0x64, 0x44, 0xaf, 0xb4, 0x5a, 0xdb, 0xbb, 0x12, 0x9b, 0x1c, 0x72, 0x38,
-	0x43, 0xce, 0x70, 0x4f, 0x93, 0x23, 0xad, 0x15, 0xaf, 0x52, 0xac, 0xba, 0x6c, 0x96, 0x58, 0x5d,
-	0xd5, 0x5b, 0x55, 0xcd, 0x19, 0xca, 0x09, 0xd6, 0xb6, 0x12, 0x68, 0x83, 0x24, 0x48, 0xe0, 0x27,
-	0x03, 0x81, 0x13, 0x24, 0x08, 0xe0, 0x07, 0xc3, 0x79, 0x08, 0xb2, 0x79, 0x08, 0x90, 0x1f, 0x07,
-	0x41, 0xb2, 0xf9, 0x5f, 0x04, 0x01, 0xb2, 0x79, 0x21, 0xb2, 0x0c, 0xf2, 0x90, 0x00, 0x0e, 0x8c,
-	0x18, 0x89, 0x9d, 0x81, 0x11, 0x07, 0xf7, 0xaf, 0xfe, 0xba, 0x7a, 0x86, 0xec, 0x6a, 0x8e, 0x46,
-	0x89, 0xde, 0xba, 0xef, 0x39, 0xf7, 0x3b, 0xb7, 0x6e, 0xdd, 0xba, 0xf7, 0xdc, 0x73, 0xce, 0x3d,
-	0x17, 0xd6, 0xbb, 0x56, 0x70, 0x30, 0xd8, 0x5b, 0x30, 0xdc, 0xde, 0xa2, 0x33, 0xe8, 0xe9, 0x7d,
-	0xcf, 0xfd, 0x80, 0xff, 0xd8, 0xb7, 0xdd, 0x87, 0x8b, 0xfd, 0xc3, 0xee, 0xa2, 0xde, 0xb7, 0xfc,
-	0xa8, 0xe4, 0xe8, 0x35, 0xdd, 0xee, 0x1f, 0xe8, 0xaf, 0x2d, 0x76, 0xa9, 0x43, 0x3d, 0x3d, 0xa0,
-	0xe6, 0x42, 0xdf, 0x73, 0x03, 0x97, 0x7c, 0x39, 0x02, 0x5a, 0x50, 0x40, 0x0b, 0xaa, 0xda, 0x42,
-	0xff, 0xb0, 0xbb, 0xc0, 0x80, 0xa2, 0x12, 0x05, 0x74, 0xed, 0xa7, 0x62, 0x2d, 0xe8, 0xba, 0x5d,
-	0x77, 0x91, 0xe3, 0xed, 0x0d, 0xf6, 0xf9, 0x3f, 0xfe, 0x87, 0xff, 0x12, 0x72, 0xae, 0x69, 0x87,
-	0x6f, 0xfa, 0x0b, 0x96, 0xcb, 0x9a, 0xb5, 0x68, 0xb8, 0x1e, 0x5d, 0x3c, 0x1a, 0x6a, 0xcb, 0xb5,
-	0x2f, 0x45, 0x3c, 0x3d, 0xdd, 0x38, 0xb0, 0x1c, 0xea, 0x1d, 0xab, 0x67, 0x59, 0xf4, 0xa8, 0xef,
-	0x0e, 0x3c, 0x83, 0x9e, 0xab, 0x96, 0xbf, 0xd8, 0xa3, 0x81, 0x9e, 0x25, 0x6b, 0x71, 0x54, 0x2d,
-	0x6f, 0xe0, 0x04, 0x56, 0x6f, 0x58, 0xcc, 0x1b, 0x4f, 0xab, 0xe0, 0x1b, 0x07, 0xb4, 0xa7, 0x0f,
-	0xd5, 0xfb, 0xe9, 0x51, 0xf5, 0x06, 0x81, 0x65, 0x2f, 0x5a, 0x4e, 0xe0, 0x07, 0x5e, 0xba, 0x92,
-	0xf6, 0xdb, 0x00, 0x97, 0x96, 0xf7, 0xfc, 0xc0, 0xd3, 0x8d, 0x60, 0xdb, 0x35, 0x77, 0x68, 0xaf,
-	0x6f, 0xeb, 0x01, 0x25, 0x87, 0x50, 0x67, 0x0f, 0x64, 0xea, 0x81, 0x3e, 0x5f, 0xb8, 0x51, 0xb8,
-	0xd9, 0x5c, 0x5a, 0x5e, 0x18, 0xf3, 0x05, 0x2e, 0x6c, 0x49, 0xa0, 0xf6, 0xd4, 0xe9, 0x49, 0xab,
-	0xae, 0xfe, 0x61, 0x28, 0x80, 0xfc, 0x5a, 0x01, 0xa6, 0x1c, 0xd7, 0xa4, 0x1d, 0x6a, 0x53, 0x23,
-	0x70, 0xbd, 0xf9, 0xe2, 0x8d, 0xd2, 0xcd, 0xe6, 0xd2, 0x37, 0xc7, 0x96, 0x98, 0xf1, 0x44, 0x0b,
-	0xf7, 0x62, 0x02, 0x6e, 0x39, 0x81, 0x77, 0xdc, 0xbe, 0xfc, 0xbd, 0x93, 0xd6, 0x0b, 0xa7, 0x27,
-	0xad, 0xa9, 0x38, 0x09, 0x13, 0x2d, 0x21, 0xbb, 0xd0, 0x0c, 0x5c, 0x9b, 0x75, 0x99, 0xe5, 0x3a,
-	0xfe, 0x7c, 0x89, 0x37, 0xec, 0xfa, 0x82, 0xe8, 0x6a, 0x26, 0x7e, 0x81, 0x8d, 0xb1, 0x85, 0xa3,
-	0xd7, 0x16, 0x76, 0x42, 0xb6, 0xf6, 0x25, 0x09, 0xdc, 0x8c, 0xca, 0x7c, 0x8c, 0xe3, 0x10, 0x0a,
-	0xb3, 0x3e, 0x35, 0x06, 0x9e, 0x15, 0x1c, 0xaf, 0xb8, 0x4e, 0x40, 0x1f, 0x05, 0xf3, 0x65, 0xde,
-	0xcb, 0x5f, 0xcc, 0x82, 0xde, 0x76, 0xcd, 0x4e, 0x92, 0xbb, 0x7d, 0xe9, 0xf4, 0xa4, 0x35, 0x9b,
-	0x2a, 0xc4, 0x34, 0x26, 0x71, 0x60, 0xce, 0xea, 0xe9, 0x5d, 0xba, 0x3d, 0xb0, 0xed, 0x0e, 0x35,
-	0x3c, 0x1a, 0xf8, 0xf3, 0x15, 0xfe, 0x08, 0x37, 0xb3, 0xe4, 0x6c, 0xba, 0x86, 0x6e, 0xdf, 0xdf,
-	0xfb, 0x80, 0x1a, 0x01, 0xd2, 0x7d, 0xea, 0x51, 0xc7, 0xa0, 0xed, 0x79, 0xf9, 0x30, 0x73, 0x1b,
-	0x29, 0x24, 0x1c, 0xc2, 0x26, 0xeb, 0xf0, 0x62, 0xdf, 0xb3, 0x5c, 0xde, 0x04, 0x5b, 0xf7, 0xfd,
-	0x7b, 0x7a, 0x8f, 0xce, 0x57, 0x6f, 0x14, 0x6e, 0x36, 0xda, 0x57, 0x25, 0xcc, 0x8b, 0xdb, 0x69,
-	0x06, 0x1c, 0xae, 0x43, 0x6e, 0x42, 0x5d, 0x15, 0xce, 0xd7, 0x6e, 0x14, 0x6e, 0x56, 0xc4, 0xd8,
-	0x51, 0x75, 0x31, 0xa4, 0x92, 0x35, 0xa8, 0xeb, 0xfb, 0xfb, 0x96, 0xc3, 0x38, 0xeb, 0xbc, 0x0b,
-	0x5f, 0xc9, 0x7a, 0xb4, 0x65, 0xc9, 0x23, 0x70, 0xd4, 0x3f, 0x0c, 0xeb, 0x92, 0x3b, 0x40, 0x7c,
-	0xea, 0x1d, 0x59, 0x06, 0x5d, 0x36, 0x0c, 0x77, 0xe0, 0x04, 0xbc, 0xed, 0x0d, 0xde, 0xf6, 0x6b,
-	0xb2, 0xed, 0xa4, 0x33, 0xc4, 0x81, 0x19, 0xb5, 0xc8, 0xdb, 0x30, 0x27, 0xbf, 0xd5, 0xa8, 0x17,
-	0x80, 0x23, 0x5d, 0x66, 0x1d, 0x89, 0x29, 0x1a, 0x0e, 0x71, 0x13, 0x13, 0x5e, 0xd1, 0x07, 0x81,
-	0xdb, 0x63, 0x90, 0x49, 0xa1, 0x3b, 0xee, 0x21, 0x75, 0xe6, 0x9b, 0x37, 0x0a, 0x37, 0xeb, 0xed,
-	0x1b, 0xa7, 0x27, 0xad, 0x57, 0x96, 0x9f, 0xc0, 0x87, 0x4f, 0x44, 0x21, 0xf7, 0xa1, 0x61, 0x3a,
-	0xfe, 0xb6, 0x6b, 0x5b, 0xc6, 0xf1, 0xfc, 0x14, 0x6f, 0xe0, 0x6b, 0xf2, 0x51, 0x1b, 0xab, 0xf7,
-	0x3a, 0x82, 0xf0, 0xf8, 0xa4, 0xf5, 0xca, 0xf0, 0x94, 0xba, 0x10, 0xd2, 0x31, 0xc2, 0x20, 0x5b,
-	0x1c, 0x70, 0xc5, 0x75, 0xf6, 0xad, 0xee, 0xfc, 0x34, 0x7f, 0x1b, 0x37, 0x46, 0x0c, 0xe8, 0xd5,
-	0x7b, 0x1d, 0xc1, 0xd7, 0x9e, 0x96, 0xe2, 0xc4, 0x5f, 0x8c, 0x10, 0x88, 0x09, 0x33, 0x6a, 0x32,
-	0x5e, 0xb1, 0x75, 0xab, 0xe7, 0xcf, 0xcf, 0xf0, 0xc1, 0xfb, 0xa3, 0x23, 0x30, 0x31, 0xce, 0xdc,
-	0xbe, 0x22, 0x1f, 0x65, 0x26, 0x51, 0xec, 0x63, 0x0a, 0xf3, 0xda, 0x5b, 0xf0, 0xe2, 0xd0, 0xdc,
-	0x40, 0xe6, 0xa0, 0x74, 0x48, 0x8f, 0xf9, 0xd4, 0xd7, 0x40, 0xf6, 0x93, 0x5c, 0x86, 0xca, 0x91,
-	0x6e, 0x0f, 0xe8, 0x7c, 0x91, 0x97, 0x89, 0x3f, 0x3f, 0x53, 0x7c, 0xb3, 0xa0, 0xfd, 0x8d, 0x12,
-	0x4c, 0xa9, 0x19, 0xa7, 0x63, 0x39, 0x87, 0xe4, 0x1d, 0x28, 0xd9, 0x6e, 0x57, 0xce, 0x9b, 0x3f,
-	0x37, 0xf6, 0x2c, 0xb6, 0xe9, 0x76, 0xdb, 0xb5, 0xd3, 0x93, 0x56, 0x69, 0xd3, 0xed, 0x22, 0x43,
-	0x24, 0x06, 0x54, 0x0e, 0xf5, 0xfd, 0x43, 0x9d, 0xb7, 0xa1, 0xb9, 0xd4, 0x1e, 0x1b, 0xfa, 0x2e,
-	0x43, 0x61, 0x6d, 0x6d, 0x37, 0x4e, 0x4f, 0x5a, 0x15, 0xfe, 0x17, 0x05, 0x36, 0x71, 0xa1, 0xb1,
-	0x67, 0xeb, 0xc6, 0xe1, 0x81, 0x6b, 0xd3, 0xf9, 0x52, 0x4e, 0x41, 0x6d, 0x85, 0x24, 0x5e, 0x73,
-	0xf8, 0x17, 0x23, 0x19, 0xc4, 0x80, 0xea, 0xc0, 0xf4, 0x2d, 0xe7, 0x50, 0xce, 0x81, 0x6f, 0x8d,
-	0x2d, 0x6d, 0x77, 0x95, 0x3f, 0x13, 0x9c, 0x9e, 0xb4, 0xaa, 0xe2, 0x37, 0x4a, 0x68, 0xed, 0x0f,
-	0xa6, 0x60, 0x46, 0xbd, 0xa4, 0x07, 0xd4, 0x0b, 0xe8, 0x23, 0x72, 0x03, 0xca, 0x0e, 0xfb, 0x34,
-	0xf9, 0x4b, 0x6e, 0x4f, 0xc9, 0xe1, 0x52, 0xe6, 0x9f, 0x24, 0xa7, 0xb0, 0x96, 0x89, 0xa1, 0x22,
-	0x3b, 0x7c, 0xfc, 0x96, 0x75, 0x38, 0x8c, 0x68, 0x99, 0xf8, 0x8d, 0x12, 0x9a, 0xbc, 0x07, 0x65,
-	0xfe, 0xf0, 0xa2, 0xab, 0xbf, 0x3a, 0xbe, 0x08, 0xf6, 0xe8, 0x75, 0xf6, 0x04, 0xfc, 0xc1, 0x39,
-	0x28, 0x1b, 0x8a, 0x03, 0x73, 0x5f, 0x76, 0xec, 0xcf, 0xe5, 0xe8, 0xd8, 0x35, 0x31, 0x14, 0x77,
-	0x57, 0xd7, 0x90, 0x21, 0x92, 0xbf, 0x54, 0x80, 0x17, 0x0d, 0xd7, 0x09, 0x74, 0xa6, 0x67, 0xa8,
-	0x45, 0x76, 0xbe, 0xc2, 0xe5, 0xdc, 0x19, 0x5b, 0xce, 0x4a, 0x1a, 0xb1, 0xfd, 0x12, 0x5b, 0x33,
-	0x86, 0x8a, 0x71, 0x58, 0x36, 0xf9, 0x2b, 0x05, 0x78, 0x89, 0xcd, 0xe5, 0x43, 0xcc, 0x7c, 0x05,
-	0x9a, 0x6c, 0xab, 0xae, 0x9e, 0x9e, 0xb4, 0x5e, 0xda, 0xc8, 0x12, 0x86, 0xd9, 0x6d, 0x60, 0xad,
-	0xbb, 0xa4, 0x0f, 0xab, 0x25, 0x7c, 0x75, 0x6b, 0x2e, 0x6d, 0x4e, 0x52, 0xd5, 0x69, 0x7f, 0x4e,
-	0x0e, 0xe5, 0x2c, 0xcd, 0x0e, 0xb3, 0x5a, 0x41, 0x6e, 0x41, 0xed, 0xc8, 0xb5, 0x07, 0x3d, 0xea,
-	0xcf, 0xd7, 0xf9, 0x14, 0x7b, 0x2d, 0x6b, 0x8a, 0x7d, 0xc0, 0x59, 0xda, 0xb3, 0x12, 0xbe, 0x26,
-	0xfe, 0xfb, 0xa8, 0xea, 0x12, 0x0b, 0xaa, 0xb6, 0xd5, 0xb3, 0x02, 0x9f, 0x2f, 0x9c, 0xcd, 0xa5,
-	0x5b, 0x63, 0x3f, 0x96, 0xf8, 0x44, 0x37, 0x39, 0x98, 0xf8, 0x6a, 0xc4, 0x6f, 0x94, 0x02, 0xd8,
-	0x54, 0xe8, 0x1b, 0xba, 0x2d, 0x16, 0xd6, 0xe6, 0xd2, 0xd7, 0xc6, 0xff, 0x6c, 0x18, 0x4a, 0x7b,
-	0x5a, 0x3e, 0x53, 0x85, 0xff, 0x45, 0x81, 0x4d, 0x7e, 0x01, 0x66, 0x12, 0x6f, 0xd3, 0x9f, 0x6f,
-	0xf2, 0xde, 0x79, 0x35, 0xab, 0x77, 0x42, 0xae, 0x68, 0xe5, 0x49, 0x8c, 0x10, 0x1f, 0x53, 0x60,
-	0xe4, 0x2e, 0xd4, 0x7d, 0xcb, 0xa4, 0x86, 0xee, 0xf9, 0xf3, 0x53, 0x67, 0x01, 0x9e, 0x93, 0xc0,
-	0xf5, 0x8e, 0xac, 0x86, 0x21, 0x00, 0x59, 0x00, 0xe8, 0xeb, 0x5e, 0x60, 0x09, 0x45, 0x75, 0x9a,
-	0x2b, 0x4d, 0x33, 0xa7, 0x27, 0x2d, 0xd8, 0x0e, 0x4b, 0x31, 0xc6, 0xc1, 0xf8, 0x59, 0xdd, 0x0d,
-	0xa7, 0x3f, 0x08, 0xc4, 0xc2, 0xda, 0x10, 0xfc, 0x9d, 0xb0, 0x14, 0x63, 0x1c, 0xe4, 0xb7, 0x0a,
-	0xf0, 0xb9, 0xe8, 0xef, 0xf0, 0x47, 0x36, 0x3b, 0xf1, 0x8f, 0xac, 0x75, 0x7a, 0xd2, 0xfa, 0x5c,
-	0x67, 0xb4, 0x48, 0x7c, 0x52, 0x7b, 0xc8, 0xc7, 0x05, 0x98, 0x19, 0xf4, 0x4d, 0x3d, 0xa0, 0x9d,
-	0x80, 0xed, 0x78, 0xba, 0xc7, 0xf3, 0x73, 0xbc, 0x89, 0xeb, 0xe3, 0xcf, 0x82, 0x09, 0xb8, 0xe8,
-	0x35, 0x27, 0xcb, 0x31, 0x25, 0x56, 0x7b, 0x07, 0xa6, 0x97, 0x07, 0xc1, 0x81, 0xeb, 0x59, 0x1f,
-	0x72, 0xf5, 0x9f, 0xac, 0x41, 0x25, 0xe0, 0x6a, 0x9c, 0xd0, 0x10, 0xbe, 0x90, 0xf5, 0xd2, 0x85,
-	0x4a, 0x7d, 0x97, 0x1e, 0x2b, 0xbd, 0x44, 0xac, 0xd4, 0x42, 0xad, 0x13, 0xd5, 0xb5, 0x3f, 0x53,
-	0x80, 0x5a, 0x5b, 0x37, 0x0e, 0xdd, 0xfd, 0x7d, 0xf2, 0x2e, 0xd4, 0x2d, 0x27, 0xa0, 0xde, 0x91,
-	0x6e, 0x4b, 0xd8, 0x85, 0x18, 0x6c, 0xb8, 0x21, 0x8c, 0x1e, 0x8f, 0xed, 0xbe, 0x98, 0xa0, 0xd5,
-	0x81, 0xdc, 0xb5, 0x70, 0xcd, 0x78, 0x43, 0x62, 0x60, 0x88, 0x46, 0x5a, 0x50, 0xf1, 0x03, 0xda,
-	0xf7, 0xf9, 0x1a, 0x38, 0x2d, 0x9a, 0xd1, 0x61, 0x05, 0x28, 0xca, 0xb5, 0xbf, 0x5e, 0x80, 0x46,
-	0x5b, 0xf7, 0x2d, 0x83, 0x3d, 0x25, 0x59, 0x81, 0xf2, 0xc0, 0xa7, 0xde, 0xf9, 0x9e, 0x8d, 0x2f,
-	0x5b, 0xbb, 0x3e, 0xf5, 0x90, 0x57, 0x26, 0xf7, 0xa1, 0xde, 0xd7, 0x7d, 0xff, 0xa1, 0xeb, 0x99,
-	0x72, 0xe9, 0x3d, 0x23, 0x90, 0xd8, 0x26, 0xc8, 0xaa, 0x18, 0x82, 0x68, 0x4d, 0x88, 0x74, 0x0f,
-	0xed, 0xf7, 0x0a, 0x70, 0xa9, 0x3d, 0xd8, 0xdf, 0xa7, 0x9e, 0xd4, 0x8a, 0xa5, 0xbe, 0x49, 0xa1,
-	0xe2, 0x51, 0xd3, 0xf2, 0x65, 0xdb, 0x57, 0xc7, 0x1e, 0x28, 0xc8, 0x50, 0xa4, 0x7a, 0xcb, 0xfb,
-	0x8b, 0x17, 0xa0, 0x40, 0x27, 0x03, 0x68, 0x7c, 0x40, 0xd9, 0x6e, 0x9c, 0xea, 0x3d, 0xf9, 0x74,
-	0xb7, 0xc7, 0x16, 0x75, 0x87, 0x06, 0x1d, 0x8e, 0x14, 0xd7, 0xa6, 0xc3, 0x42, 0x8c, 0x24, 0x69,
-	0xbf, 0x5d, 0x81, 0xa9, 0x15, 0xb7, 0xb7, 0x67, 0x39, 0xd4, 0xbc, 0x65, 0x76, 0x29, 0x79, 0x1f,
-	0xca, 0xd4, 0xec, 0x52, 0xf9, 0xb4, 0xe3, 0x2b, 0x1e, 0x0c, 0x2c, 0x52, 0x9f, 0xd8, 0x3f, 0xe4,
-	0xc0, 0x64, 0x13, 0x66, 0xf6, 0x3d, 0xb7, 0x27, 0xe6, 0xf2, 0x9d, 0xe3, 0xbe, 0xd4, 0x9d, 0xdb,
-	0x3f, 0xaa, 0x3e, 0x9c, 0xb5, 0x04, 0xf5, 0xf1, 0x49, 0x0b, 0xa2, 0x7f, 0x98, 0xaa, 0x4b, 0xde,
-	0x85, 0xf9, 0xa8, 0x24, 0x9c, 0xd4, 0x56, 0xd8, 0x76, 0x86, 0xeb, 0x4e, 0x95, 0xf6, 0x2b, 0xa7,
-	0x27, 0xad, 0xf9, 0xb5, 0x11, 0x3c, 0x38, 0xb2, 0x36, 0x9b, 0x2a, 0xe6, 0x22, 0xa2, 0x58, 0x68,
-	0xa4, 0xca, 0x34, 0xa1, 0x15, 0x8c, 0xef, 0xfb, 0xd6, 0x52, 0x22, 0x70, 0x48, 0x28, 0x59, 0x83,
-	0xa9, 0xc0, 0x8d, 0xf5, 0x57, 0x85, 0xf7, 0x97, 0xa6, 0x0c, 0x15, 0x3b, 0xee, 0xc8, 0xde, 0x4a,
-	0xd4, 0x23, 0x08, 0x57, 0xd4, 0xff, 0x54, 0x4f, 0x55, 0x79, 0x4f, 0x5d, 0x3b, 0x3d, 0x69, 0x5d,
-	0xd9, 0xc9, 0xe4, 0xc0, 0x11, 0x35, 0xc9, 0x2f, 0x17, 0x60, 0x46, 0x91, 0x64, 0x1f, 0xd5, 0x26,
-	0xd9, 0x47, 0x84, 0x8d, 0x88, 0x9d, 0x84, 0x00, 0x4c, 0x09, 0xd4, 0x7e, 0xa7, 0x0a, 0x8d, 0x70,
-	0xaa, 0x27, 0x9f, 0x87, 0x0a, 0x37, 0x41, 0x48, 0x0d, 0x3e, 0x5c, 0xc3, 0xb9, 0xa5, 0x02, 0x05,
-	0x8d, 0x7c, 0x01, 0x6a, 0x86, 0xdb, 0xeb, 0xe9, 0x8e, 0xc9, 0xcd, 0x4a, 0x8d, 0x76, 0x93, 0xa9,
-	0x2e, 0x2b, 0xa2, 0x08, 0x15, 0x8d, 0xbc, 0x02, 0x65, 0xdd, 0xeb, 0x0a, 0x0b, 0x4f, 0x43, 0xcc,
-	0x47, 0xcb, 0x5e, 0xd7, 0x47, 0x5e, 0x4a, 0xbe, 0x02, 0x25, 0xea, 0x1c, 0xcd, 0x97, 0x47, 0xeb,
-	0x46, 0xb7, 0x9c, 0xa3, 0x07, 0xba, 0xd7, 0x6e, 0xca, 0x36, 0x94, 0x6e, 0x39, 0x47, 0xc8, 0xea,
-	0x90, 0x4d, 0xa8, 0x51, 0xe7, 0x88, 0xbd, 0x7b, 0x69, 0x7a, 0xf9, 0x91, 0x11, 0xd5, 0x19, 0x8b,
-	0xdc, 0x26, 0x84, 0x1a, 0x96, 0x2c, 0x46, 0x05, 0x41, 0xbe, 0x01, 0x53, 0x42, 0xd9, 0xda, 0x62,
-	0xef, 0xc4, 0x9f, 0xaf, 0x72, 0xc8, 0xd6, 0x68, 0x6d, 0x8d, 0xf3, 0x45, 0xa6, 0xae, 0x58, 0xa1,
-	0x8f, 0x09, 0x28, 0xf2, 0x0d, 0x68, 0xa8, 0x9d, 0xb1, 0x7a, 0xb3, 0x99, 0x56, 0x22, 0xb5, 0x9d,
-	0x46, 0xfa, 0xad, 0x81, 0xe5, 0xd1, 0x1e, 0x75, 0x02, 0xbf, 0xfd, 0xa2, 0xb2, 0x1b, 0x28, 0xaa,
-	0x8f, 0x11, 0x1a, 0xd9, 0x1b, 0x36, 0x77, 0x09, 0x5b, 0xcd, 0xe7, 0x47, 0xcc, 0xea, 0x63, 0xd8,
-	0xba, 0xbe, 0x09, 0xb3, 0xa1, 0x3d, 0x4a, 0x9a, 0x34, 0x84, 0xf5, 0xe6, 0x4b, 0xac, 0xfa, 0x46,
-	0x92, 0xf4, 0xf8, 0xa4, 0xf5, 0x6a, 0x86, 0x51, 0x23, 0x62, 0xc0, 0x34, 0x18, 0xf9, 0x10, 0x66,
-	0x3c, 0xaa, 0x9b, 0x96, 0x43, 0x7d, 0x7f, 0xdb, 0x73, 0xf7, 0xf2, 0x6b, 0x9e, 0x1c, 0x45, 0x0c,
-	0x7b, 0x4c, 0x20, 0x63, 0x4a, 0x12, 0x79, 0x08, 0xd3, 0xb6, 0x75, 0x44, 0x23, 0xd1, 0xcd, 0x89,
-	0x88, 0x7e, 0xf1, 0xf4, 0xa4, 0x35, 0xbd, 0x19, 0x07, 0xc6, 0xa4, 0x1c, 0xed, 0xef, 0x54, 0x60,
-	0x78, 0xf3, 0x95, 0x1c, 0x29, 0x85, 0x49, 0x8f, 0x94, 0xf4, 0x5b, 0x14, 0x6b, 0xc6, 0x9b, 0xb2,
-	0xda, 0x04, 0xde, 0x64, 0xc6, 0x68, 0x2c, 0x4d, 0x7a, 0x34, 0x3e, 0x37, 0x13, 0xc6, 0xf0, 0xb0,
-	0xad, 0x7e, 0x72, 0xc3, 0xb6, 0xf6, 0x8c, 0x86, 0xed, 0x77, 0xca, 0x30, 0xb3, 0xaa, 0xd3, 0x9e,
-	0xeb, 0x3c, 0x75, 0xff, 0x5d, 0x78, 0x2e, 0xf6, 0xdf, 0x37, 0xa1, 0xee, 0xd1, 0xbe, 0x6d, 0x19,
-	0xba, 0x50, 0xb3, 0xa5, 0xbd, 0x1b, 0x65, 0x19, 0x86, 0xd4, 0x11, 0x76, 0x97, 0xd2, 0x73, 0x69,
-	0x77, 0x29, 0x7f, 0xf2, 0x76, 0x17, 0xed, 0x97, 0x8b, 0xc0, 0x55, 0x52, 0x72, 0x03, 0xca, 0x4c,
-	0xdd, 0x4a, 0x5b, 0xfb, 0xf8, 0xd7, 0xc2, 0x29, 0xe4, 0x1a, 0x14, 0x03, 0x57, 0x4e, 0x37, 0x20,
-	0xe9, 0xc5, 0x1d, 0x17, 0x8b, 0x81, 0x4b, 0x3e, 0x04, 0x30, 0x5c, 0xc7, 0xb4, 0x94, 0x1b, 0x28,
-	0xdf, 0x83, 0xad, 0xb9, 0xde, 0x43, 0xdd, 0x33, 0x57, 0x42, 0x44, 0xb1, 0xf3, 0x8e, 0xfe, 0x63,
-	0x4c, 0x1a, 0x79, 0x0b, 0xaa, 0xae, 0xb3, 0x36, 0xb0, 0x6d, 0xde, 0xa1, 0x8d, 0xf6, 0x8f, 0x9d,
-	0x9e, 0xb4, 0xaa, 0xf7, 0x79, 0xc9, 0xe3, 0x93, 0xd6, 0x55, 0xb1, 0x93, 0x61, 0xff, 0xde, 0xf1,
-	0xac, 0xc0, 0x72, 0xba, 0xe1, 0x46, 0x54, 0x56, 0xd3, 0x7e, 0xb5, 0x00, 0xcd, 0x35, 0xeb, 0x11,
-	0x35, 0xdf, 0xb1, 0x1c, 0xd3, 0x7d, 0x48, 0x10, 0xaa, 0x36, 0x75, 0xba, 0xc1, 0xc1, 0x98, 0x3b,
-	0x45, 0x61, 0x8f, 0xe1, 0x08, 0x28, 0x91, 0xc8, 0x22, 0x34, 0xc4, 0x3e, 0xc3, 0x72, 0xba, 0xbc,
-	0x0f, 0xeb, 0xd1, 0x4c, 0xdf, 0x51, 0x04, 0x8c, 0x78, 0xb4, 0x63, 0x78, 0x71, 0xa8, 0x1b, 0x88,
-	0x09, 0xe5, 0x40, 0xef, 0xaa, 0x45, 0x65, 0x6d, 0xec, 0x0e, 0xde, 0xd1, 0xbb, 0xb1, 0xce, 0xe5,
-	0xda, 0xdc, 0x8e, 0xce, 0xb4, 0x39, 0x86, 0xae, 0xfd, 0x61, 0x01, 0xea, 0x6b, 0x03, 0xc7, 0xe0,
-	0x9b, 0xf1, 0xa7, 0x5b, 0x81, 0x95, 0x6a, 0x58, 0xcc, 0x54, 0x0d, 0x07, 0x50, 0x3d, 0x7c, 0x18,
-	0xaa, 0x8e, 0xcd, 0xa5, 0xad, 0xf1, 0x47, 0x85, 0x6c, 0xd2, 0xc2, 0x5d, 0x8e, 0x27, 0x9c, 0x94,
-	0x33, 0xb2, 0x41, 0xd5, 0xbb, 0xef, 0x70, 0xa1, 0x52, 0xd8, 0xb5, 0xaf, 0x40, 0x33, 0xc6, 0x76,
-	0x2e, 0x7f, 0xc5, 0xdf, 0x2d, 0x43, 0x75, 0xbd, 0xd3, 0x59, 0xde, 0xde, 0x20, 0xaf, 0x43, 0x53,
-	0xfa, 0xaf, 0xee, 0x45, 0x7d, 0x10, 0xba, 0x2f, 0x3b, 0x11, 0x09, 0xe3, 0x7c, 0x4c, 0xf1, 0xf6,
-	0xa8, 0x6e, 0xf7, 0xe4, 0xc7, 0x12, 0x2a, 0xde, 0xc8, 0x0a, 0x51, 0xd0, 0x88, 0x0e, 0x33, 0x6c,
-	0x2f, 0xcf, 0xba, 0x50, 0xec, 0xd3, 0xe5, 0x67, 0x73, 0xc6, 0x9d, 0x3c, 0x5f, 0x60, 0x76, 0x13,
-	0x00, 0x98, 0x02, 0x24, 0x6f, 0x42, 0x5d, 0x1f, 0x04, 0x07, 0x7c, 0xab, 0x24, 0xbe, 0x8d, 0x57,
-	0xb8, 0x7b, 0x4f, 0x96, 0x3d, 0x3e, 0x69, 0x4d, 0xdd, 0xc5, 0xf6, 0xeb, 0xea, 0x3f, 0x86, 0xdc,
-	0xac, 0x71, 0xca, 0x36, 0x20, 0x1b, 0x57, 0x39, 0x77, 0xe3, 0xb6, 0x13, 0x00, 0x98, 0x02, 0x24,
-	0xef, 0xc1, 0xd4, 0x21, 0x3d, 0x0e, 0xf4, 0x3d, 0x29, 0xa0, 0x7a, 0x1e, 0x01, 0x73, 0x4c, 0x59,
-	0xbf, 0x1b, 0xab, 0x8e, 0x09, 0x30, 0xe2, 0xc3, 0xe5, 0x43, 0xea, 0xed, 0x51, 0xcf, 0x95, 0x76,
-	0x06, 0x29, 0xa4, 0x76, 0x1e, 0x21, 0xf3, 0xa7, 0x27, 0xad, 0xcb, 0x77, 0x33, 0x60, 0x30, 0x13,
-	0x5c, 0xfb, 0xdf, 0x45, 0x98, 0x5d, 0x17, 0x01, 0x04, 0xae, 0x27, 0x34, 0x0f, 0x72, 0x15, 0x4a,
-	0x5e, 0x7f, 0xc0, 0x47, 0x4e, 0x49, 0xb8, 0x08, 0x70, 0x7b, 0x17, 0x59, 0x19, 0x79, 0x17, 0xea,
-	0xa6, 0x9c, 0x32, 0xa4, 0x99, 0x63, 0x2c, 0x93, 0x94, 0xfa, 0x87, 0x21, 0x1a, 0xdb, 0xd3, 0xf5,
-	0xfc, 0x6e, 0xc7, 0xfa, 0x90, 0xca, 0x9d, 0x3f, 0xdf, 0xd3, 0x6d, 0x89, 0x22, 0x54, 0x34, 0xb6,
-	0xaa, 0x1e, 0xd2, 0x63, 0xb1, 0xef, 0x2d, 0x47, 0xab, 0xea, 0x5d, 0x59, 0x86, 0x21, 0x95, 0xb4,
-	0xd4, 0xc7, 0xc2, 0x46, 0x41, 0x59, 0xd8, 0x6c, 0x1e, 0xb0, 0x02, 0xf9, 0xdd, 0xb0, 0x29, 0xf3,
-	0x03, 0x2b, 0x08, 0xa8, 0x27, 0x5f, 0xe3, 0x58, 0x53, 0xe6, 0x1d, 0x8e, 0x80, 0x12, 0x89, 0xfc,
-	0x04, 0x34, 0x38, 0x78, 0xdb, 0x76, 0xf7, 0xf8, 0x8b, 0x6b, 0x08, 0xeb, 0xcd, 0x03, 0x55, 0x88,
-	0x11, 0x5d, 0xfb, 0xa3, 0x22, 0x5c, 0x59, 0xa7, 0x81, 0xd0, 0x6a, 0x56, 0x69, 0xdf, 0x76, 0x8f,
-	0x99, 0x3e, 0x8d, 0xf4, 0x5b, 0xe4, 0x6d, 0x00, 0xcb, 0xdf, 0xeb, 0x1c, 0x19, 0xfc, 0x3b, 0x10,
-	0xdf, 0xf0, 0x0d, 0xf9, 0x49, 0xc2, 0x46, 0xa7, 0x2d, 0x29, 0x8f, 0x13, 0xff, 0x30, 0x56, 0x27,
-	0xda, 0x48, 0x17, 0x9f, 0xb0, 0x91, 0xee, 0x00, 0xf4, 0x23, 0xad, 0xbc, 0xc4, 0x39, 0x7f, 0x5a,
-	0x89, 0x39, 0x8f, 0x42, 0x1e, 0x83, 0xc9, 0xa3, 0x27, 0x3b, 0x30, 0x67, 0xd2, 0x7d, 0x7d, 0x60,
-	0x07, 0xe1, 0x4e, 0x42, 0x7e, 0xc4, 0x67, 0xdf, 0x8c, 0x84, 0xc1, 0x0d, 0xab, 0x29, 0x24, 0x1c,
-	0xc2, 0xd6, 0xfe, 0x5e, 0x09, 0xae, 0xad, 0xd3, 0x20, 0xb4, 0xad, 0xc9, 0xd9, 0xb1, 0xd3, 0xa7,
-	0x06, 0x7b, 0x0b, 0x1f, 0x17, 0xa0, 0x6a, 0xeb, 0x7b, 0xd4, 0x66, 0xab, 0x17, 0x7b, 0x9a, 0xf7,
-	0xc7, 0x5e, 0x08, 0x46, 0x4b, 0x59, 0xd8, 0xe4, 0x12, 0x52, 0x4b, 0x83, 0x28, 0x44, 0x29, 0x9e,
-	0x4d, 0xea, 0x86, 0x3d, 0xf0, 0x03, 0xea, 0x6d, 0xbb, 0x5e, 0x20, 0xf5, 0xc9, 0x70, 0x52, 0x5f,
-	0x89, 0x48, 0x18, 0xe7, 0x23, 0x4b, 0x00, 0x86, 0x6d, 0x51, 0x27, 0xe0, 0xb5, 0xc4, 0x77, 0x45,
-	0xd4, 0xfb, 0x5d, 0x09, 0x29, 0x18, 0xe3, 0x62, 0xa2, 0x7a, 0xae, 0x63, 0x05, 0xae, 0x10, 0x55,
-	0x4e, 0x8a, 0xda, 0x8a, 0x48, 0x18, 0xe7, 0xe3, 0xd5, 0x68, 0xe0, 0x59, 0x86, 0xcf, 0xab, 0x55,
-	0x52, 0xd5, 0x22, 0x12, 0xc6, 0xf9, 0xd8, 0x9a, 0x17, 0x7b, 0xfe, 0x73, 0xad, 0x79, 0xbf, 0xd9,
-	0x80, 0xeb, 0x89, 0x6e, 0x0d, 0xf4, 0x80, 0xee, 0x0f, 0xec, 0x0e, 0x0d, 0xd4, 0x0b, 0x1c, 0x73,
-	0x2d, 0xfc, 0xf3, 0xd1, 0x7b, 0x17, 0x61, 0x4b, 0xc6, 0x64, 0xde, 0xfb, 0x50, 0x03, 0xcf, 0xf4,
-	0xee, 0x17, 0xa1, 0xe1, 0xe8, 0x81, 0xcf, 0x3f, 0x5c, 0xf9, 0x8d, 0x86, 0x6a, 0xd8, 0x3d, 0x45,
-	0xc0, 0x88, 0x87, 0x6c, 0xc3, 0x65, 0xd9, 0xc5, 0xb7, 0x1e, 0xf5, 0x5d, 0x2f, 0xa0, 0x9e, 0xa8,
-	0x2b, 0x97, 0x53, 0x59, 0xf7, 0xf2, 0x56, 0x06, 0x0f, 0x66, 0xd6, 0x24, 0x5b, 0x70, 0xc9, 0x10,
-	0xa1, 0x1c, 0xd4, 0x76, 0x75, 0x53, 0x01, 0x0a, 0x53, 0x66, 0xb8, 0x35, 0x5a, 0x19, 0x66, 0xc1,
-	0xac, 0x7a, 0xe9, 0xd1, 0x5c, 0x1d, 0x6b, 0x34, 0xd7, 0xc6, 0x19, 0xcd, 0xf5, 0xf1, 0x46, 0x73,
-	0xe3, 0x6c, 0xa3, 0x99, 0xf5, 0x3c, 0x1b, 0x47, 0xd4, 0x63, 0xea, 0x89, 0x58, 0x61, 0x63, 0x91,
-	0x42, 0x61, 0xcf, 0x77, 0x32, 0x78, 0x30, 0xb3, 0x26, 0xd9, 0x83, 0x6b, 0xa2, 0xfc, 0x96, 0x63,
-	0x78, 0xc7, 0x7d, 0xb6, 0xf0, 0xc4, 0x70, 0x9b, 0x09, 0x5b, 0xf2, 0xb5, 0xce, 0x48, 0x4e, 0x7c,
-	0x02, 0x0a, 0xf9, 0x59, 0x98, 0x16, 0x6f, 0x69, 0x4b, 0xef, 0x73, 0x58, 0x11, 0x37, 0xf4, 0x92,
-	0x84, 0x9d, 0x5e, 0x89, 0x13, 0x31, 0xc9, 0x4b, 0x96, 0x61, 0xb6, 0x7f, 0x64, 0xb0, 0x9f, 0x1b,
-	0xfb, 0xf7, 0x28, 0x35, 0xa9, 0xc9, 0x1d, 0x95, 0x8d, 0xf6, 0xcb, 0xca, 0xba, 0xb3, 0x9d, 0x24,
-	0x63, 0x9a, 0x9f, 0xbc, 0x09, 0x53, 0x7e, 0xa0, 0x7b, 0x81, 0x34, 0xe0, 0xce, 0xcf, 0x88, 0xb8,
-	0x2a, 0x65, 0xdf, 0xec, 0xc4, 0x68, 0x98, 0xe0, 0xcc, 0x5c, 0x2f, 0x66, 0x2f, 0x6e, 0xbd, 0xc8,
-	0x33, 0x5b, 0xfd, 0xb3, 0x22, 0xdc, 0x58, 0xa7, 0xc1, 0x96, 0xeb, 0x48, 0xf3, 0x77, 0xd6, 0xb2,
-	0x7f, 0x26, 0xeb, 0x77, 0x72, 0xd1, 0x2e, 0x4e, 0x74, 0xd1, 0x2e, 0x4d, 0x68, 0xd1, 0x2e, 0x5f,
-	0xe0, 0xa2, 0xfd, 0x0f, 0x8a, 0xf0, 0x72, 0xa2, 0x27, 0xb7, 0x5d, 0x53, 0x4d, 0xf8, 0x9f, 0x75,
-	0xe0, 0x19, 0x3a, 0xf0, 0xb1, 0xd0, 0x3b, 0xb9, 0x03, 0x33, 0xa5, 0xf1, 0x7c, 0x94, 0xd6, 0x78,
-	0xde, 0xcb, 0xb3, 0xf2, 0x65, 0x48, 0x38, 0xd3, 0x8a, 0x77, 0x07, 0x88, 0x27, 0xdd, 0xad, 0xc2,
-	0xf4, 0x13, 0x53, 0x7a, 0xc2, 0xc0, 0x4d, 0x1c, 0xe2, 0xc0, 0x8c, 0x5a, 0xa4, 0x03, 0x2f, 0xf9,
-	0xd4, 0x09, 0x2c, 0x87, 0xda, 0x49, 0x38, 0xa1, 0x0d, 0xbd, 0x2a, 0xe1, 0x5e, 0xea, 0x64, 0x31,
-	0x61, 0x76, 0xdd, 0x3c, 0xf3, 0xc0, 0xbf, 0x02, 0xae, 0x72, 0x8a, 0xae, 0x99, 0x98, 0xc6, 0xf2,
-	0x71, 0x5a, 0x63, 0x79, 0x3f, 0xff, 0x7b, 0x1b, 0x4f, 0x5b, 0x59, 0x02, 0xe0, 0x6f, 0x21, 0xae,
-	0xae, 0x84, 0x8b, 0x34, 0x86, 0x14, 0x8c, 0x71, 0xb1, 0x05, 0x48, 0xf5, 0x73, 0x5c, 0x53, 0x09,
-	0x17, 0xa0, 0x4e, 0x9c, 0x88, 0x49, 0xde, 0x91, 0xda, 0x4e, 0x65, 0x6c, 0x6d, 0xe7, 0x0e, 0x90,
-	0x84, 0xe1, 0x51, 0xe0, 0x55, 0x93, 0x71, 0xc3, 0x1b, 0x43, 0x1c, 0x98, 0x51, 0x6b, 0xc4, 0x50,
-	0xae, 0x4d, 0x76, 0x28, 0xd7, 0xc7, 0x1f, 0xca, 0xe4, 0x7d, 0xb8, 0xca, 0x45, 0xc9, 0xfe, 0x49,
-	0x02, 0x0b, 0xbd, 0xe7, 0x47, 0x24, 0xf0, 0x55, 0x1c, 0xc5, 0x88, 0xa3, 0x31, 0xd8, 0xfb, 0x31,
-	0x3c, 0x6a, 0x32, 0xe1, 0xba, 0x3d, 0x5a, 0x27, 0x5a, 0xc9, 0xe0, 0xc1, 0xcc, 0x9a, 0x6c, 0x88,
-	0x05, 0x6c, 0x18, 0xea, 0x7b, 0x36, 0x35, 0x65, 0xdc, 0x74, 0x38, 0xc4, 0x76, 0x36, 0x3b, 0x92,
-	0x82, 0x31, 0xae, 0x2c, 0x35, 0x65, 0xea, 0x9c, 0x6a, 0xca, 0x3a, 0xb7, 0xd2, 0xef, 0x27, 0xb4,
-	0x21, 0xa9, 0xeb, 0x84, 0x91, 0xf0, 0x2b, 0x69, 0x06, 0x1c, 0xae, 0xc3, 0xb5, 0x44, 0xc3, 0xb3,
-	0xfa, 0x81, 0x9f, 0xc4, 0x9a, 0x49, 0x69, 0x89, 0x19, 0x3c, 0x98, 0x59, 0x93, 0xe9, 0xe7, 0x07,
-	0x54, 0xb7, 0x83, 0x83, 0x24, 0xe0, 0x6c, 0x52, 0x3f, 0xbf, 0x3d, 0xcc, 0x82, 0x59, 0xf5, 0x32,
-	0x17, 0xa4, 0xb9, 0xe7, 0x53, 0xad, 0xfa, 0x95, 0x12, 0x5c, 0x5d, 0xa7, 0x41, 0x18, 0x52, 0xf6,
-	0x99, 0x19, 0xe5, 0x13, 0x30, 0xa3, 0xfc, 0x46, 0x05, 0x2e, 0xad, 0xd3, 0x60, 0x48, 0x1b, 0xfb,
-	0xff, 0xb4, 0xfb, 0xb7, 0xe0, 0x52, 0x14, 0xc5, 0xd8, 0x09, 0x5c, 0x4f, 0xac, 0xe5, 0xa9, 0xdd,
-	0x72, 0x67, 0x98, 0x05, 0xb3, 0xea, 0x91, 0x6f, 0xc0, 0xcb, 0x7c, 0xa9, 0x77, 0xba, 0xc2, 0x3e,
-	0x2b, 0x8c, 0x09, 0xb1, 0x73, 0x38, 0x2d, 0x09, 0xf9, 0x72, 0x27, 0x9b, 0x0d, 0x47, 0xd5, 0x27,
-	0xdf, 0x86, 0xa9, 0xbe, 0xd5, 0xa7, 0xb6, 0xe5, 0x70, 0xfd, 0x2c, 0x77, 0xf0, 0xcf, 0x76, 0x0c,
-	0x2c, 0xda, 0xc0, 0xc5, 0x4b, 0x31, 0x21, 0x30, 0x73, 0xa4, 0xd6, 0x2f, 0x70, 0xa4, 0xfe, 0x8f,
-	0x22, 0xd4, 0xd6, 0x3d, 0x77, 0xd0, 0x6f, 0x1f, 0x93, 0x2e, 0x54, 0x1f, 0x72, 0xe7, 0x99, 0x74,
-	0x4d, 0x8d, 0x7f, 0x12, 0x40, 0xf8, 0xe0, 0x22, 0x95, 0x48, 0xfc, 0x47, 0x09, 0xcf, 0x06, 0xf1,
-	0x21, 0x3d, 0xa6, 0xa6, 0xf4, 0xa1, 0x85, 0x83, 0xf8, 0x2e, 0x2b, 0x44, 0x41, 0x23, 0x3d, 0x98,
-	0xd5, 0x6d, 0xdb, 0x7d, 0x48, 0xcd, 0x4d, 0x3d, 0xe0, 0x7e, 0x6f, 0xe9, 0x5b, 0x39, 0xaf, 0x59,
-	0x9a, 0x07, 0x33, 0x2c, 0x27, 0xa1, 0x30, 0x8d, 0x4d, 0x3e, 0x80, 0x9a, 0x1f, 0xb8, 0x9e, 0x52,
-	0xb6, 0x9a, 0x4b, 0x2b, 0xe3, 0xbf, 0xf4, 0xf6, 0xd7, 0x3b, 0x02, 0x4a, 0xd8, 0xec, 0xe5, 0x1f,
-	0x54, 0x02, 0xb4, 0x5f, 0x2f, 0x00, 0xdc, 0xde, 0xd9, 0xd9, 0x96, 0xee, 0x05, 0x13, 0xca, 0xfa,
-	0x20, 0x74, 0x54, 0x8e, 0xef, 0x10, 0x4c, 0x04, 0xe0, 0x4a, 0x1f, 0xde, 0x20, 0x38, 0x40, 0x8e,
-	0x4e, 0x7e, 0x1c, 0x6a, 0x52, 0x41, 0x96, 0xdd, 0x1e, 0xc6, 0x53, 0x48, 0x25, 0x1a, 0x15, 0x5d,
-	0xfb, 0xdb, 0x45, 0x80, 0x0d, 0xd3, 0xa6, 0x1d, 0x75, 0x78, 0xa3, 0x11, 0x1c, 0x78, 0xd4, 0x3f,
-	0x70, 0x6d, 0x73, 0x4c, 0x6f, 0x2a, 0xb7, 0xf9, 0xef, 0x28, 0x10, 0x8c, 0xf0, 0x88, 0x09, 0x53,
-	0x7e, 0x40, 0xfb, 0x2a, 0x26, 0x77, 0x4c, 0x27, 0xca, 0x9c, 0xb0, 0x8b, 0x44, 0x38, 0x98, 0x40,
-	0x25, 0x3a, 0x34, 0x2d, 0xc7, 0x10, 0x1f, 0x48, 0xfb, 0x78, 0xcc, 0x81, 0x34, 0xcb, 0x76, 0x1c,
-	0x1b, 0x11, 0x0c, 0xc6, 0x31, 0xb5, 0xdf, 0x2d, 0xc2, 0x15, 0x2e, 0x8f, 0x35, 0x23, 0x11, 0x79,
-	0x4b, 0xfe, 0xe4, 0xd0, 0x41, 0xd3, 0x3f, 0x7e, 0x36, 0xd1, 0xe2, 0x9c, 0xe2, 0x16, 0x0d, 0xf4,
-	0x48, 0x9f, 0x8b, 0xca, 0x62, 0xa7, 0x4b, 0x07, 0x50, 0xf6, 0xd9, 0x7c, 0x25, 0x7a, 0xaf, 0x33,
-	0xf6, 0x10, 0xca, 0x7e, 0x00, 0x3e, 0x7b, 0x85, 0x5e, 0x63, 0x3e, 0x6b, 0x71, 0x71, 0xe4, 0x4f,
-	0x43, 0xd5, 0x0f, 0xf4, 0x60, 0xa0, 0x3e, 0xcd, 0xdd, 0x49, 0x0b, 0xe6, 0xe0, 0xd1, 0x3c, 0x22,
-	0xfe, 0xa3, 0x14, 0xaa, 0xfd, 0x6e, 0x01, 0xae, 0x65, 0x57, 0xdc, 0xb4, 0xfc, 0x80, 0xfc, 0x89,
-	0xa1, 0x6e, 0x3f, 0xe3, 0x1b, 0x67, 0xb5, 0x79, 0xa7, 0x87, 0x67, 0x11, 0x54, 0x49, 0xac, 0xcb,
-	0x03, 0xa8, 0x58, 0x01, 0xed, 0xa9, 0xfd, 0xe5, 0xfd, 0x09, 0x3f, 0x7a, 0x6c, 0x69, 0x67, 0x52,
-	0x50, 0x08, 0xd3, 0xbe, 0x53, 0x1c, 0xf5, 0xc8, 0x7c, 0xf9, 0xb0, 0x93, 0xd1, 0xdd, 0x77, 0xf3,
-	0x45, 0x77, 0x27, 0x1b, 0x34, 0x1c, 0xe4, 0xfd, 0xa7, 0x86, 0x83, 0xbc, 0xef, 0xe7, 0x0f, 0xf2,
-	0x4e, 0x75, 0xc3, 0xc8, 0x58, 0xef, 0x1f, 0x94, 0xe0, 0x95, 0x27, 0x0d, 0x1b, 0xb6, 0x9e, 0xc9,
-	0xd1, 0x99, 0x77, 0x3d, 0x7b, 0xf2, 0x38, 0x24, 0x4b, 0x50, 0xe9, 0x1f, 0xe8, 0xbe, 0x52, 0xca,
-	0xd4, 0x86, 0xa5, 0xb2, 0xcd, 0x0a, 0x1f, 0xb3, 0x49, 0x83, 0x2b, 0x73, 0xfc, 0x2f, 0x0a, 0x56,
-	0x36, 0x1d, 0xf7, 0xa8, 0xef, 0x47, 0x36, 0x81, 0x70, 0x3a, 0xde, 0x12, 0xc5, 0xa8, 0xe8, 0x24,
-	0x80, 0xaa, 0x30, 0x31, 0xcb, 0x95, 0x69, 0xfc, 0x40, 0xae, 0x8c, 0x03, 0x01, 0xd1, 0x43, 0x49,
-	0x6f, 0x85, 0x94, 0x45, 0x16, 0xa0, 0x1c, 0x44, 0xe1, 0xd9, 0x6a, 0x6b, 0x5e, 0xce, 0xd0, 0x4f,
-	0x39, 0x1f, 0xdb, 0xd8, 0xbb, 0x7b, 0xdc, 0xa8, 0x6e, 0x4a, 0xff, 0xb9, 0xe5, 0x3a, 0x5c, 0x21,
-	0x2b, 0x45, 0x1b, 0xfb, 0xfb, 0x43, 0x1c, 0x98, 0x51, 0x4b, 0xfb, 0xb7, 0x75, 0xb8, 0x92, 0x3d,
-	0x1e, 0x58, 0xbf, 0x1d, 0x51, 0xcf, 0x67, 0xd8, 0x85, 0x64, 0xbf, 0x3d, 0x10, 0xc5, 0xa8, 0xe8,
-	0x9f, 0xea, 0x80, 0xb3, 0xdf, 0x28, 0xc0, 0x55, 0x4f, 0xfa, 0x88, 0x9e, 0x45, 0xd0, 0xd9, 0xab,
-	0xc2, 0x9c, 0x31, 0x42, 0x20, 0x8e, 0x6e, 0x0b, 0xf9, 0x9b, 0x05, 0x98, 0xef, 0xa5, 0xec, 0x1c,
-	0x17, 0x78, 0x56, 0x92, 0x9f, 0x7f, 0xd8, 0x1a, 0x21, 0x0f, 0x47, 0xb6, 0x84, 0x7c, 0x1b, 0x9a,
-	0x7d, 0x36, 0x2e, 0xfc, 0x80, 0x3a, 0x86, 0x0a, 0x10, 0x1d, 0xff, 0x4b, 0xda, 0x8e, 0xb0, 0xc2,
-	0xb3, 0x52, 0x5c, 0x3f, 0x88, 0x11, 0x30, 0x2e, 0xf1, 0x39, 0x3f, 0x1c, 0x79, 0x13, 0xea, 0x3e,
-	0x0d, 0x02, 0xcb, 0xe9, 0x8a, 0xfd, 0x46, 0x43, 0x7c, 0x2b, 0x1d, 0x59, 0x86, 0x21, 0x95, 0xfc,
-	0x04, 0x34, 0xb8, 0xcb, 0x69, 0xd9, 0xeb, 0xfa, 0xf3, 0x0d, 0x1e, 0x2e, 0x36, 0x2d, 0x02, 0xe0,
-	0x64, 0x21, 0x46, 0x74, 0xf2, 0x25, 0x98, 0xda, 0xe3, 0x9f, 0xaf, 0x3c, 0x2f, 0x2f, 0x6c, 0x5c,
-	0x5c, 0x5b, 0x6b, 0xc7, 0xca, 0x31, 0xc1, 0x45, 0x96, 0x00, 0x68, 0xe8, 0x97, 0x4b, 0xdb, 0xb3,
-	0x22, 0x8f, 0x1d, 0xc6, 0xb8, 0xc8, 0xab, 0x50, 0x0a, 0x6c, 0x9f, 0xdb, 0xb0, 0xea, 0xd1, 0x16,
-	0x74, 0x67, 0xb3, 0x83, 0xac, 0x5c, 0xfb, 0xa3, 0x02, 0xcc, 0xa6, 0x8e, 0x11, 0xb1, 0x2a, 0x03,
-	0xcf, 0x96, 0xd3, 0x48, 0x58, 0x65, 0x17, 0x37, 0x91, 0x95, 0x93, 0xf7, 0xa5, 0x5a, 0x5e, 0xcc,
-	0x99, 0x1a, 0xe4, 0x9e, 0x1e, 0xf8, 0x4c, 0x0f, 0x1f, 0xd2, 0xc8, 0xb9, 0x9b, 0x2f, 0x6a, 0x8f,
-	0x5c, 0x07, 0x62, 0x6e, 0xbe, 0x88, 0x86, 0x09, 0xce, 0x94, 0xc1, 0xaf, 0x7c, 0x16, 0x83, 0x9f,
-	0xf6, 0xab, 0xc5, 0x58, 0x0f, 0x48, 0xcd, 0xfe, 0x29, 0x3d, 0xf0, 0x45, 0xb6, 0x80, 0x86, 0x8b,
-	0x7b, 0x23, 0xbe, 0xfe, 0xf1, 0xc5, 0x58, 0x52, 0xc9, 0x3b, 0xa2, 0xef, 0x4b, 0x39, 0x0f, 0x60,
-	0xef, 0x6c, 0x76, 0x44, 0x74, 0x95, 0x7a, 0x6b, 0xe1, 0x2b, 0x28, 0x5f, 0xd0, 0x2b, 0xd0, 0xfe,
-	0x45, 0x09, 0x9a, 0x77, 0xdc, 0xbd, 0x4f, 0x49, 0x04, 0x75, 0xf6, 0x32, 0x55, 0xfc, 0x04, 0x97,
-	0xa9, 0x5d, 0x78, 0x39, 0x08, 0xec, 0x0e, 0x35, 0x5c, 0xc7, 0xf4, 0x97, 0xf7, 0x03, 0xea, 0xad,
-	0x59, 0x8e, 0xe5, 0x1f, 0x50, 0x53, 0xba, 0x93, 0x3e, 0x77, 0x7a, 0xd2, 0x7a, 0x79, 0x67, 0x67,
-	0x33, 0x8b, 0x05, 0x47, 0xd5, 0xe5, 0xd3, 0x86, 0x38, 0xf3, 0xc9, 0xcf, 0x44, 0xc9, 0x98, 0x1b,
-	0x31, 0x6d, 0xc4, 0xca, 0x31, 0xc1, 0xa5, 0x7d, 0xb7, 0x08, 0x8d, 0x30, 0xe9, 0x03, 0xf9, 0x02,
-	0xd4, 0xf6, 0x3c, 0xf7, 0x90, 0x7a, 0xc2, 0x73, 0x27, 0xcf, 0x44, 0xb5, 0x45, 0x11, 0x2a, 0x1a,
-	0xf9, 0x3c, 0x54, 0x02, 0xb7, 0x6f, 0x19, 0x69, 0x83, 0xda, 0x0e, 0x2b, 0x44, 0x41, 0xbb, 0xb8,
-	0x01, 0xfe, 0xc5, 0x84, 0x6a, 0xd7, 0x18, 0xa9, 0x8c, 0xbd, 0x07, 0x65, 0x5f, 0xf7, 0x6d, 0xb9,
-	0x9e, 0xe6, 0xc8, 0x9f, 0xb0, 0xdc, 0xd9, 0x94, 0xf9, 0x13, 0x96, 0x3b, 0x9b, 0xc8, 0x41, 0xb5,
-	0x3f, 0x28, 0x42, 0x53, 0xf4, 0x9b, 0x98, 0x15, 0x26, 0xd9, 0x73, 0x6f, 0xf1, 0x50, 0x0a, 0x7f,
-	0xd0, 0xa3, 0x1e, 0x37, 0x33, 0xc9, 0x49, 0x2e, 0xee, 0x1f, 0x88, 0x88, 0x61, 0x38, 0x45, 0x54,
-	0xa4, 0xba, 0xbe, 0x7c, 0x81, 0x5d, 0x5f, 0x39, 0x53, 0xd7, 0x57, 0x2f, 0xa2, 0xeb, 0x3f, 0x2e,
-	0x42, 0x63, 0xd3, 0xda, 0xa7, 0xc6, 0xb1, 0x61, 0xf3, 0xd3, 0x9f, 0x26, 0xb5, 0x69, 0x40, 0xd7,
-	0x3d, 0xdd, 0xa0, 0xdb, 0xd4, 0xb3, 0x78, 0x52, 0x24, 0xf6, 0x7d, 0xf0, 0x19, 0x48, 0x9e, 0xfe,
-	0x5c, 0x1d, 0xc1, 0x83, 0x23, 0x6b, 0x93, 0x0d, 0x98, 0x32, 0xa9, 0x6f, 0x79, 0xd4, 0xdc, 0x8e,
-	0x6d, 0x54, 0xbe, 0xa0, 0x96, 0x9a, 0xd5, 0x18, 0xed, 0xf1, 0x49, 0x6b, 0x5a, 0x19, 0x28, 0xc5,
-	0x8e, 0x25, 0x51, 0x95, 0x7d, 0xf2, 0x7d, 0x7d, 0xe0, 0x67, 0xb5, 0x31, 0xf6, 0xc9, 0x6f, 0x67,
-	0xb3, 0xe0, 0xa8, 0xba, 0x5a, 0x05, 0x4a, 0x9b, 0x6e, 0x57, 0xfb, 0x4e, 0x09, 0xc2, 0xec, 0x59,
-	0xe4, 0xcf, 0x15, 0xa0, 0xa9, 0x3b, 0x8e, 0x1b, 0xc8, 0xcc, 0x54, 0xc2, 0x03, 0x8f, 0xb9, 0x93,
-	0x74, 0x2d, 0x2c, 0x47, 0xa0, 0xc2, 0x79, 0x1b, 0x3a, 0x94, 0x63, 0x14, 0x8c, 0xcb, 0x26, 0x83,
-	0x94, 0x3f, 0x79, 0x2b, 0x7f, 0x2b, 0xce, 0xe0, 0x3d, 0xbe, 0xf6, 0x35, 0x98, 0x4b, 0x37, 0xf6,
-	0x3c, 0xee, 0xa0, 0x5c, 0x8e, 0xf9, 0x22, 0x40, 0x14, 0x53, 0xf2, 0x0c, 0x8c, 0x58, 0x56, 0xc2,
-	0x88, 0x35, 0x7e, 0x0a, 0x83, 0xa8, 0xd1, 0x23, 0x0d, 0x57, 0xdf, 0x4a, 0x19, 0xae, 0x36, 0x26,
-	0x21, 0xec, 0xc9, 0xc6, 0xaa, 0xbf, 0x55, 0x80, 0xb9, 0x88, 0x59, 0x9e, 0x85, 0xfe, 0x32, 0x4c,
-	0x7b, 0x54, 0x37, 0xdb, 0x7a, 0x60, 0x1c, 0xf0, 0x50, 0xef, 0x02, 0x8f, 0xcd, 0xe6, 0xa7, 0xbf,
-	0x30, 0x4e, 0xc0, 0x24, 0x1f, 0xd1, 0xa1, 0xc9, 0x0a, 0x76, 0xac, 0x1e, 0x75, 0x07, 0xc1, 0x98,
-	0x56, 0x53, 0xbe, 0x61, 0xc1, 0x08, 0x06, 0xe3, 0x98, 0xda, 0x0f, 0x0a, 0x30, 0x13, 0x6f, 0xf0,
-	0x85, 0x5b, 0xd4, 0x0e, 0x92, 0x16, 0xb5, 0x95, 0x09, 0xbc, 0x93, 0x11, 0x56, 0xb4, 0x8f, 0x20,
-	0xfe, 0x68, 0xdc, 0x72, 0x16, 0x37, 0x16, 0x14, 0x9e, 0x68, 0x2c, 0xf8, 0xf4, 0x27, 0x4c, 0x1a,
-	0xa5, 0xe5, 0x96, 0x9f, 0x63, 0x2d, 0xf7, 0x93, 0xcc, 0xba, 0x14, 0xcb, 0x1c, 0x54, 0xcd, 0x91,
-	0x39, 0xa8, 0x17, 0x66, 0x0e, 0xaa, 0x4d, 0x6c, 0xd2, 0x39, 0x4b, 0xf6, 0xa0, 0xfa, 0x33, 0xcd,
-	0x1e, 0xd4, 0xb8, 0xa8, 0xec, 0x41, 0x90, 0x37, 0x7b, 0xd0, 0x47, 0x05, 0x98, 0x31, 0x13, 0x27,
-	0x66, 0xe5, 0x19, 0xf3, 0xf1, 0x97, 0x9a, 0xe4, 0x01, 0x5c, 0x71, 0x64, 0x2a, 0x59, 0x86, 0x29,
-	0x91, 0x59, 0x39, 0x7b, 0xa6, 0x3e, 0x99, 0x9c, 0x3d, 0xbf, 0x5f, 0x8b, 0xaf, 0x48, 0xcf, 0xda,
-	0x68, 0xfe, 0x46, 0xd2, 0x68, 0x7e, 0x23, 0x6d, 0x34, 0x9f, 0x8d, 0xc5, 0xb3, 0xc6, 0x0d, 0xe7,
-	0x3f, 0x19, 0x9b, 0xa8, 0x4b, 0x3c, 0x5b, 0x4f, 0xf8, 0xce, 0x33, 0x26, 0xeb, 0x65, 0x98, 0x95,
-	0xda, 0xab, 0x22, 0xf2, 0x59, 0x6e, 0x3a, 0x0a, 0x73, 0x5a, 0x4d, 0x92, 0x31, 0xcd, 0xcf, 0x04,
-	0xfa, 0x2a, 0x69, 0xab, 0xd8, 0x2a, 0x44, 0x83, 0x4c, 0x25, 0x54, 0x0d, 0x39, 0xd8, 0xb6, 0xc2,
-	0xa3, 0xba, 0x2f, 0x4d, 0xdf, 0xb1, 0x6d, 0x05, 0xf2, 0x52, 0x94, 0xd4, 0xb8, 0xfd, 0xbf, 0xf6,
-	0x14, 0xfb, 0xbf, 0x0e, 0x4d, 0x5b, 0xf7, 0x03, 0xf1, 0x36, 0x4d, 0xf9, 0x39, 0xff, 0xb1, 0xb3,
-	0x2d, 0xbc, 0x6c, 0x31, 0x8f, 0xb4, 0xdb, 0xcd, 0x08, 0x06, 0xe3, 0x98, 0xc4, 0x84, 0x29, 0xf6,
-	0x97, 0x7f, 0xda, 0xe6, 0x72, 0x20, 0x53, 0x9b, 0x9d, 0x47, 0x46, 0x68, 0xb6, 0xda, 0x8c, 0xe1,
-	0x60, 0x02, 0x75, 0x84, 0x8b, 0x00, 0xc6, 0x71, 0x11, 0x90, 0x9f, 0x15, 0x9a, 0xd3, 0x71, 0xf8,
-	0x5a, 0x9b, 0xfc, 0xb5, 0x86, 0x21, 0x92, 0x18, 0x27, 0x62, 0x92, 0x97, 0x8d, 0x8a, 0x81, 0xec,
-	0x06, 0x55, 0x7d, 0x2a, 0x39, 0x2a, 0x76, 0x93, 0x64, 0x4c, 0xf3, 0x93, 0x6d, 0xb8, 0x1c, 0x16,
-	0xc5, 0x9b, 0x31, 0xcd, 0x71, 0xc2, 0x98, 0xb5, 0xdd, 0x0c, 0x1e, 0xcc, 0xac, 0xc9, 0x0f, 0x81,
-	0x0c, 0x3c, 0x8f, 0x3a, 0xc1, 0x6d, 0xdd, 0x3f, 0x90, 0xc1, 0x6f, 0xd1, 0x21, 0x90, 0x88, 0x84,
-	0x71, 0x3e, 0xb2, 0x04, 0x20, 0xe0, 0x78, 0xad, 0xd9, 0x64, 0x7c, 0xe9, 0x6e, 0x48, 0xc1, 0x18,
-	0x97, 0xf6, 0x51, 0x03, 0x9a, 0xf7, 0xf4, 0xc0, 0x3a, 0xa2, 0xdc, 0x9f, 0x77, 0x31, 0x4e, 0x95,
-	0xbf, 0x5a, 0x80, 0x2b, 0xc9, 0xa0, 0xcd, 0x0b, 0xf4, 0xac, 0xf0, 0x64, 0x3f, 0x98, 0x29, 0x0d,
-	0x47, 0xb4, 0x82, 0xfb, 0x58, 0x86, 0x62, 0x40, 0x2f, 0xda, 0xc7, 0xd2, 0x19, 0x25, 0x10, 0x47,
-	0xb7, 0xe5, 0xd3, 0xe2, 0x63, 0x79, 0xbe, 0xb3, 0x53, 0xa6, 0x3c, 0x40, 0xb5, 0xe7, 0xc6, 0x03,
-	0x54, 0x7f, 0x2e, 0xd4, 0xee, 0x7e, 0xcc, 0x03, 0xd4, 0xc8, 0x19, 0x89, 0x24, 0xcf, 0x39, 0x08,
-	0xb4, 0x51, 0x9e, 0x24, 0x9e, 0xa2, 0x40, 0x59, 0xe6, 0x99, 0xb6, 0xba, 0xa7, 0xfb, 0x96, 0x21,
-	0xd5, 0x8e, 0x1c, 0xd9, 0x78, 0x55, 0x96, 0x3e, 0x11, 0xb0, 0xc0, 0xff, 0xa2, 0xc0, 0x8e, 0x92,
-	0x12, 0x16, 0x73, 0x25, 0x25, 0x24, 0x2b, 0x50, 0x76, 0x0e, 0xe9, 0xf1, 0xf9, 0x0e, 0xfb, 0xf3,
-	0x5d, 0xd8, 0xbd, 0xbb, 0xf4, 0x18, 0x79, 0x65, 0xed, 0xbb, 0x45, 0x00, 0xf6, 0xf8, 0x67, 0xf3,
-	0xc5, 0xfc, 0x38, 0xd4, 0xfc, 0x01, 0xb7, 0x9a, 0x48, 0x85, 0x29, 0x0a, 0xdf, 0x12, 0xc5, 0xa8,
-	0xe8, 0xe4, 0xf3, 0x50, 0xf9, 0xd6, 0x80, 0x0e, 0x54, 0x60, 0x41, 0xa8, 0xb8, 0x7f, 0x9d, 0x15,
-	0xa2, 0xa0, 0x5d, 0x9c, 0x5d, 0x55, 0xf9, 0x6c, 0x2a, 0x17, 0xe5, 0xb3, 0x69, 0x40, 0xed, 0x9e,
-	0xcb, 0xa3, 0x41, 0xb5, 0xff, 0x56, 0x04, 0x88, 0xa2, 0xed, 0xc8, 0xaf, 0x17, 0xe0, 0xa5, 0xf0,
-	0x83, 0x0b, 0xc4, 0xfe, 0x8b, 0x27, 0xc0, 0xce, 0xed, 0xbf, 0xc9, 0xfa, 0xd8, 0xf9, 0x0c, 0xb4,
-	0x9d, 0x25, 0x0e, 0xb3, 0x5b, 0x41, 0x10, 0xea, 0xb4, 0xd7, 0x0f, 0x8e, 0x57, 0x2d, 0x4f, 0x8e,
-	0xc0, 0xcc, 0xa0, 0xce, 0x5b, 0x92, 0x47, 0x54, 0x95, 0x46, 0x02, 0xfe, 0x11, 0x29, 0x0a, 0x86,
-	0x38, 0xe4, 0x00, 0xea, 0x8e, 0xfb, 0xbe, 0xcf, 0xba, 0x43, 0x0e, 0xc7, 0xb7, 0xc7, 0xef, 0x72,
-	0xd1, 0xad, 0xc2, 0xde, 0x2f, 0xff, 0x60, 0xcd, 0x91, 0x9d, 0xfd, 0x6b, 0x45, 0xb8, 0x94, 0xd1,
-	0x0f, 0xe4, 0x6d, 0x98, 0x93, 0x81, 0x8d, 0x51, 0x26, 0xf8, 0x42, 0x94, 0x09, 0xbe, 0x93, 0xa2,
-	0xe1, 0x10, 0x37, 0x79, 0x1f, 0x40, 0x37, 0x0c, 0xea, 0xfb, 0x5b, 0xae, 0xa9, 0xf6, 0x03, 0x6f,
-	0x31, 0xf5, 0x65, 0x39, 0x2c, 0x7d, 0x7c, 0xd2, 0xfa, 0xa9, 0xac, 0x58, 0xe5, 0x54, 0x3f, 0x47,
-	0x15, 0x30, 0x06, 0x49, 0xbe, 0x09, 0x20, 0x36, 0xe1, 0x61, 0x3a, 0x85, 0xa7, 0x58, 0xae, 0x16,
-	0x54, 0xb6, 0xae, 0x85, 0xaf, 0x0f, 0x74, 0x27, 0xb0, 0x82, 0x63, 0x91, 0xbd, 0xe6, 0x41, 0x88,
-	0x82, 0x31, 0x44, 0xed, 0x9f, 0x16, 0xa1, 0xae, 0x6c, 0xe6, 0xcf, 0xc0, 0x50, 0xda, 0x4d, 0x18,
-	0x4a, 0x27, 0x14, 0x9d, 0x9c, 0x65, 0x26, 0x75, 0x53, 0x66, 0xd2, 0xf5, 0xfc, 0xa2, 0x9e, 0x6c,
-	0x24, 0xfd, 0xad, 0x22, 0xcc, 0x28, 0xd6, 0xbc, 0x26, 0xd2, 0xaf, 0xc2, 0xac, 0x88, 0x2a, 0xd8,
-	0xd2, 0x1f, 0x89, 0x44, 0x3e, 0xbc, 0xc3, 0xca, 0x22, 0x20, 0xb8, 0x9d, 0x24, 0x61, 0x9a, 0x97,
-	0x0d, 0x6b, 0x51, 0xb4, 0xcb, 0x36, 0x61, 0xc2, 0x0f, 0x29, 0xf6, 0x9b, 0x7c, 0x58, 0xb7, 0x53,
-	0x34, 0x1c, 0xe2, 0x4e, 0xdb, 0x68, 0xcb, 0x17, 0x60, 0xa3, 0xfd, 0xf7, 0x05, 0x98, 0x8a, 0xfa,
-	0xeb, 0xc2, 0x2d, 0xb4, 0xfb, 0x49, 0x0b, 0xed, 0x72, 0xee, 0xe1, 0x30, 0xc2, 0x3e, 0xfb, 0x17,
-	0x6b, 0x90, 0x08, 0x92, 0x27, 0x7b, 0x70, 0xcd, 0xca, 0x0c, 0xf5, 0x8b, 0xcd, 0x36, 0xe1, 0xa9,
-	0xef, 0x8d, 0x91, 0x9c, 0xf8, 0x04, 0x14, 0x32, 0x80, 0xfa, 0x11, 0xf5, 0x02, 0xcb, 0xa0, 0xea,
-	0xf9, 0xd6, 0x73, 0xab, 0x64, 0xd2, 0x0a, 0x1d, 0xf6, 0xe9, 0x03, 0x29, 0x00, 0x43, 0x51, 0x64,
-	0x0f, 0x2a, 0xd4, 0xec, 0x52, 0x95, 0x5a, 0x29, 0x67, 0x8a, 0xda, 0xb0, 0x3f, 0xd9, 0x3f, 0x1f,
-	0x05, 0x34, 0xf1, 0xa1, 0x61, 0x2b, 0x2f, 0xa3, 0x1c, 0x87, 0xe3, 0x2b, 0x58, 0xa1, 0xbf, 0x32,
-	0xca, 0xba, 0x10, 0x16, 0x61, 0x24, 0x87, 0x1c, 0x86, 0xe6, 0xce, 0xca, 0x84, 0x26, 0x8f, 0x27,
-	0x18, 0x3b, 0x7d, 0x68, 0x3c, 0xd4, 0x03, 0xea, 0xf5, 0x74, 0xef, 0x50, 0xee, 0x36, 0xc6, 0x7f,
-	0xc2, 0x77, 0x14, 0x52, 0xf4, 0x84, 0x61, 0x11, 0x46, 0x72, 0x88, 0x0b, 0x8d, 0x40, 0xaa, 0xcf,
-	0xca, 0xa6, 0x3b, 0xbe, 0x50, 0xa5, 0x88, 0xfb, 0x32, 0x58, 0x5e, 0xfd, 0xc5, 0x48, 0x06, 0x39,
-	0x4a, 0xe4, 0x33, 0x17, 0x59, 0xec, 0xdb, 0x39, 0x7c, 0x03, 0x12, 0x2a, 0x5a, 0x6e, 0xb2, 0xf3,
-	0xa2, 0x6b, 0xff, 0xb3, 0x12, 0x4d, 0xcb, 0xcf, 0xda, 0x4e, 0xf8, 0xa5, 0xa4, 0x9d, 0xf0, 0x7a,
-	0xda, 0x4e, 0x98, 0x72, 0x56, 0x9f, 0x3f, 0xbc, 0x36, 0x65, 0x5e, 0x2b, 0x5f, 0x80, 0x79, 0xed,
-	0x35, 0x68, 0x1e, 0xf1, 0x99, 0x40, 0xe4, 0x69, 0xaa, 0xf0, 0x65, 0x84, 0xcf, 0xec, 0x0f, 0xa2,
-	0x62, 0x8c, 0xf3, 0xb0, 0x2a, 0xf2, 0x06, 0x97, 0x30, 0xa5, 0xb1, 0xac, 0xd2, 0x89, 0x8a, 0x31,
-	0xce, 0xc3, 0x23, 0xf3, 0x2c, 0xe7, 0x50, 0x54, 0xa8, 0xf1, 0x0a, 0x22, 0x32, 0x4f, 0x15, 0x62,
-	0x44, 0x27, 0x37, 0xa1, 0x3e, 0x30, 0xf7, 0x05, 0x6f, 0x9d, 0xf3, 0x72, 0x0d, 0x73, 0x77, 0x75,
-	0x4d, 0xe6, 0x8d, 0x52, 0x54, 0xd6, 0x92, 0x9e, 0xde, 0x57, 0x04, 0xbe, 0x37, 0x94, 0x2d, 0xd9,
-	0x8a, 0x8a, 0x31, 0xce, 0x43, 0x7e, 0x06, 0x66, 0x3c, 0x6a, 0x0e, 0x0c, 0x1a, 0xd6, 0x02, 0x5e,
-	0x4b, 0x26, 0xd4, 0x8c, 0x53, 0x30, 0xc5, 0x39, 0xc2, 0x48, 0xd8, 0x1c, 0xcb, 0x48, 0xf8, 0x35,
-	0x98, 0x31, 0x3d, 0xdd, 0x72, 0xa8, 0x79, 0xdf, 0xe1, 0x11, 0x09, 0x32, 0x3e, 0x30, 0xb4, 0x90,
-	0xaf, 0x26, 0xa8, 0x98, 0xe2, 0xd6, 0xfe, 0x65, 0x11, 0x2a, 0x22, 0xcd, 0xe7, 0x06, 0x5c, 0xb2,
-	0x1c, 0x2b, 0xb0, 0x74, 0x7b, 0x95, 0xda, 0xfa, 0x71, 0x32, 0x2a, 0xe3, 0x65, 0xb6, 0xd1, 0xde,
-	0x18, 0x26, 0x63, 0x56, 0x1d, 0xd6, 0x39, 0x81, 0x58, 0xbe, 0x15, 0x8a, 0xb0, 0xa3, 0x89, 0xdc,
-	0xd0, 0x09, 0x0a, 0xa6, 0x38, 0x99, 0x32, 0xd4, 0xcf, 0x08, 0xb9, 0xe0, 0xca, 0x50, 0x32, 0xd0,
-	0x22, 0xc9, 0xc7, 0x95, 0xf4, 0x01, 0x57, 0x88, 0xc3, 0x53, 0x38, 0x32, 0xaa, 0x4a, 0x28, 0xe9,
-	0x29, 0x1a, 0x0e, 0x71, 0x33, 0x84, 0x7d, 0xdd, 0xb2, 0x07, 0x1e, 0x8d, 0x10, 0x2a, 0x11, 0xc2,
-	0x5a, 0x8a, 0x86, 0x43, 0xdc, 0xda, 0x7f, 0x2f, 0x00, 0x19, 0x3e, 0x57, 0x40, 0x0e, 0xa0, 0xea,
-	0x70, 0x5b, 0x64, 0xee, 0x94, 0xf4, 0x31, 0x93, 0xa6, 0x58, 0x24, 0x64, 0x81, 0xc4, 0x27, 0x0e,
-	0xd4, 0xe9, 0xa3, 0x80, 0x7a, 0x4e, 0x78, 0xce, 0x68, 0x32, 0xe9, 0xef, 0xc5, 0xde, 0x4c, 0x22,
-	0x63, 0x28, 0x43, 0xfb, 0xbd, 0x22, 0x34, 0x63, 0x7c, 0x4f, 0xdb, 0xe2, 0xf3, 0x54, 0x07, 0xc2,
-	0x04, 0xb8, 0xeb, 0xd9, 0x72, 0xbe, 0x8b, 0xa5, 0x3a, 0x90, 0x24, 0xdc, 0xc4, 0x38, 0x1f, 0x59,
-	0x02, 0xe8, 0xe9, 0x7e, 0x40, 0x3d, 0xae, 0x0b, 0xa5, 0x12, 0x0c, 0x6c, 0x85, 0x14, 0x8c, 0x71,
-	0x91, 0x1b, 0xf2, 0x02, 0x83, 0x72, 0x32, 0x21, 0xe4, 0x88, 0xdb, 0x09, 0x2a, 0x13, 0xb8, 0x9d,
-	0x80, 0x74, 0x61, 0x4e, 0xb5, 0x5a, 0x51, 0xcf, 0x97, 0x2e, 0x50, 0x0c, 0xd4, 0x14, 0x04, 0x0e,
-	0x81, 0x6a, 0xdf, 0x2d, 0xc0, 0x74, 0xc2, 0x00, 0x25, 0x52, 0x39, 0xaa, 0x53, 0x31, 0x89, 0x54,
-	0x8e, 0xb1, 0xc3, 0x2c, 0x5f, 0x84, 0xaa, 0xe8, 0xa0, 0x74, 0xb0, 0xab, 0xe8, 0x42, 0x94, 0x54,
-	0xb6, 0xb2, 0x48, 0x13, 0x77, 0x7a, 0x65, 0x91, 0x36, 0x70, 0x54, 0x74, 0xe1, 0x39, 0x12, 0xad,
-	0x93, 0x3d, 0x1d, 0xf3, 0x1c, 0x89, 0x72, 0x0c, 0x39, 0xb4, 0x7f, 0xc8, 0xdb, 0x1d, 0x78, 0xc7,
-	0xe1, 0xce, 0xba, 0x0b, 0x35, 0x19, 0xe0, 0x28, 0x3f, 0x8d, 0xb7, 0x73, 0x58, 0xc5, 0x38, 0x8e,
-	0x0c, 0xe5, 0xd3, 0x8d, 0xc3, 0xfb, 0xfb, 0xfb, 0xa8, 0xd0, 0xc9, 0x2d, 0x68, 0xb8, 0x8e, 0xfc,
-	0x82, 0xe5, 0xe3, 0xff, 0x18, 0x5b, 0x39, 0xee, 0xab, 0xc2, 0xc7, 0x27, 0xad, 0x2b, 0xe1, 0x9f,
-	0x44, 0x23, 0x31, 0xaa, 0xa9, 0xfd, 0xd9, 0x02, 0xbc, 0x84, 0xae, 0x6d, 0x5b, 0x4e, 0x37, 0xe9,
-	0x7a, 0x24, 0x36, 0xcc, 0xf4, 0xf4, 0x47, 0xbb, 0x8e, 0x7e, 0xa4, 0x5b, 0xb6, 0xbe, 0x67, 0xd3,
-	0xa7, 0xee, 0x8c, 0x07, 0x81, 0x65, 0x2f, 0x88, 0x0b, 0x1d, 0x17, 0x36, 0x9c, 0xe0, 0xbe, 0xd7,
-	0x09, 0x3c, 0xcb, 0xe9, 0x8a, 0x59, 0x72, 0x2b, 0x81, 0x85, 0x29, 0x6c, 0xed, 0xf7, 0x4b, 0xc0,
-	0x83, 0xec, 0xc8, 0x97, 0xa1, 0xd1, 0xa3, 0xc6, 0x81, 0xee, 0x58, 0xbe, 0x4a, 0x8a, 0x7b, 0x95,
-	0x3d, 0xd7, 0x96, 0x2a, 0x7c, 0xcc, 0x5e, 0xc5, 0x72, 0x67, 0x93, 0x9f, 0x63, 0x89, 0x78, 0x89,
-	0x01, 0xd5, 0xae, 0xef, 0xeb, 0x7d, 0x2b, 0x77, 0x8c, 0x87, 0x48, 0x42, 0x2a, 0xa6, 0x23, 0xf1,
-	0x1b, 0x25, 0x34, 0x31, 0xa0, 0xd2, 0xb7, 0x75, 0xcb, 0xc9, 0x7d, 0x01, 0x19, 0x7b, 0x82, 0x6d,
-	0x86, 0x24, 0x4c, 0x95, 0xfc, 0x27, 0x0a, 0x6c, 0x32, 0x80, 0xa6, 0x6f, 0x78, 0x7a, 0xcf, 0x3f,
-	0xd0, 0x97, 0x5e, 0x7f, 0x23, 0xb7, 0xf2, 0x1f, 0x89, 0x12, 0xba, 0xc8, 0x0a, 0x2e, 0x6f, 0x75,
-	0x6e, 0x2f, 0x2f, 0xbd, 0xfe, 0x06, 0xc6, 0xe5, 0xc4, 0xc5, 0xbe, 0xfe, 0xda, 0x92, 0x9c, 0x41,
-	0x26, 0x2e, 0xf6, 0xf5, 0xd7, 0x96, 0x30, 0x2e, 0x47, 0xfb, 0x5f, 0x05, 0x68, 0x84, 0xbc, 0x64,
-	0x17, 0x80, 0xcd, 0x65, 0x32, 0x6d, 0xe8, 0xb9, 0x2e, 0x6b, 0xe1, 0xd6, 0x9e, 0xdd, 0xb0, 0x32,
-	0xc6, 0x80, 0x32, 0xf2, 0xaa, 0x16, 0x27, 0x9d, 0x57, 0x75, 0x11, 0x1a, 0x07, 0xba, 0x63, 0xfa,
-	0x07, 0xfa, 0xa1, 0x98, 0xd2, 0x63, 0x99, 0x86, 0x6f, 0x2b, 0x02, 0x46, 0x3c, 0xda, 0x3f, 0xae,
-	0x82, 0x08, 0xcc, 0x60, 0x93, 0x8e, 0x69, 0xf9, 0xe2, 0x64, 0x40, 0x81, 0xd7, 0x0c, 0x27, 0x9d,
-	0x55, 0x59, 0x8e, 0x21, 0x07, 0xb9, 0x0a, 0xa5, 0x9e, 0xe5, 0x48, 0x0d, 0x84, 0x1b, 0x72, 0xb7,
-	0x2c, 0x07, 0x59, 0x19, 0x27, 0xe9, 0x8f, 0xa4, 0x86, 0x21, 0x48, 0xfa, 0x23, 0x64, 0x65, 0xe4,
-	0xab, 0x30, 0x6b, 0xbb, 0xee, 0x21, 0x9b, 0x3e, 0x94, 0x22, 0x22, 0xbc, 0xea, 0xdc, 0xb4, 0xb2,
-	0x99, 0x24, 0x61, 0x9a, 0x97, 0xec, 0xc2, 0xcb, 0x1f, 0x52, 0xcf, 0x95, 0xf3, 0x65, 0xc7, 0xa6,
-	0xb4, 0xaf, 0x60, 0x84, 0x6a, 0xcc, 0x43, 0x48, 0x7f, 0x3e, 0x9b, 0x05, 0x47, 0xd5, 0xe5, 0xc1,
-	0xe8, 0xba, 0xd7, 0xa5, 0xc1, 0xb6, 0xe7, 0x32, 0xdd, 0xc5, 0x72, 0xba, 0x0a, 0xb6, 0x1a, 0xc1,
-	0xee, 0x64, 0xb3, 0xe0, 0xa8, 0xba, 0xe4, 0x5d, 0x98, 0x17, 0x24, 0xa1, 0xb6, 0x2c, 0x8b, 0x69,
-	0xc6, 0xb2, 0xd5, 0xbd, 0x9d, 0xd3, 0xc2, 0x5f, 0xb6, 0x33, 0x82, 0x07, 0x47, 0xd6, 0x26, 0x77,
-	0x60, 0x4e, 0x79, 0x4b, 0xb7, 0xa9, 0xd7, 0x09, 0x83, 0x75, 0xa6, 0xdb, 0xd7, 0x4f, 0x4f, 0x5a,
-	0xd7, 0x56, 0x69, 0xdf, 0xa3, 0x46, 0xdc, 0xeb, 0xac, 0xb8, 0x70, 0xa8, 0x1e, 0x41, 0xb8, 0xc2,
-	0x23, 0x72, 0x76, 0xfb, 0x2b, 0xae, 0x6b, 0x9b, 0xee, 0x43, 0x47, 0x3d, 0xbb, 0x50, 0xd8, 0xb9,
-	0x83, 0xb4, 0x93, 0xc9, 0x81, 0x23, 0x6a, 0xb2, 0x27, 0xe7, 0x94, 0x55, 0xf7, 0xa1, 0x93, 0x46,
-	0x85, 0xe8, 0xc9, 0x3b, 0x23, 0x78, 0x70, 0x64, 0x6d, 0xb2, 0x06, 0x24, 0xfd, 0x04, 0xbb, 0x7d,
-	0xe9, 0xc2, 0xbf, 0x22, 0x32, 0x00, 0xa5, 0xa9, 0x98, 0x51, 0x83, 0x6c, 0xc2, 0xe5, 0x74, 0x29,
-	0x13, 0x27, 0xbd, 0xf9, 0x3c, 0xf7, 0x2f, 0x66, 0xd0, 0x31, 0xb3, 0x96, 0xf6, 0x4f, 0x8a, 0x30,
-	0x9d, 0x48, 0x19, 0xf1, 0xdc, 0x1d, 0xcd, 0x67, 0x9b, 0x87, 0x9e, 0xdf, 0xdd, 0x58, 0xbd, 0x4d,
-	0x75, 0x93, 0x7a, 0x77, 0xa9, 0x4a, 0xef, 0x21, 0x96, 0xc5, 0x04, 0x05, 0x53, 0x9c, 0x64, 0x1f,
-	0x2a, 0xc2, 0x4f, 0x90, 0xf7, 0xda, 0x1f, 0xd5, 0x47, 0xdc, 0x59, 0x20, 0xef, 0xca, 0x72, 0x3d,
-	0x8a, 0x02, 0x5e, 0x0b, 0x60, 0x2a, 0xce, 0xc1, 0x26, 0x92, 0x48, 0xed, 0xad, 0x25, 0x54, 0xde,
-	0x0d, 0x28, 0x05, 0xc1, 0xb8, 0x87, 0xfe, 0x85, 0xdf, 0x69, 0x67, 0x13, 0x19, 0x86, 0xb6, 0xcf,
-	0xde, 0x9d, 0xef, 0x5b, 0xae, 0x23, 0x33, 0xc0, 0xef, 0x42, 0x4d, 0xee, 0x9e, 0xc6, 0x4c, 0x5a,
-	0xc0, 0x75, 0x25, 0x65, 0x76, 0x55, 0x58, 0xda, 0x7f, 0x28, 0x42, 0x23, 0x34, 0x93, 0x9c, 0x21,
-	0xb3, 0xba, 0x0b, 0x8d, 0x30, 0xa2, 0x30, 0xf7, 0x9d, 0xa6, 0x51, 0xa0, 0x1b, 0xdf, 0xd9, 0x87,
-	0x7f, 0x31, 0x92, 0x11, 0x8f, 0x56, 0x2c, 0xe5, 0x88, 0x56, 0xec, 0x43, 0x2d, 0xf0, 0xac, 0x6e,
-	0x57, 0xee, 0x12, 0xf2, 0x84, 0x2b, 0x86, 0xdd, 0xb5, 0x23, 0x00, 0x65, 0xcf, 0x8a, 0x3f, 0xa8,
-	0xc4, 0x68, 0x1f, 0xc0, 0x5c, 0x9a, 0x93, 0xab, 0xd0, 0xc6, 0x01, 0x35, 0x07, 0xb6, 0xea, 0xe3,
-	0x48, 0x85, 0x96, 0xe5, 0x18, 0x72, 0x90, 0x9b, 0x50, 0x67, 0xaf, 0xe9, 0x43, 0xd7, 0x51, 0x6a,
-	0x2c, 0xdf, 0x8d, 0xec, 0xc8, 0x32, 0x0c, 0xa9, 0xda, 0x7f, 0x2d, 0xc1, 0xd5, 0xc8, 0xd8, 0xb5,
-	0xa5, 0x3b, 0x7a, 0xf7, 0x0c, 0x17, 0x59, 0x7e, 0x76, 0x0c, 0xec, 0xbc, 0xd7, 0x63, 0x94, 0x9e,
-	0x83, 0xeb, 0x31, 0xfe, 0x4f, 0x11, 0x78, 0xf4, 0x33, 0xf9, 0x36, 0x4c, 0xe9, 0xb1, 0x3b, 0x8c,
-	0xe5, 0xeb, 0xbc, 0x95, 0xfb, 0x75, 0xf2, 0x20, 0xeb, 0x30, 0x00, 0x2e, 0x5e, 0x8a, 0x09, 0x81,
-	0xc4, 0x85, 0xfa, 0xbe, 0x6e, 0xdb, 0x4c, 0x17, 0xca, 0xed, 0xbc, 0x4b, 0x08, 0xe7, 0xc3, 0x7c,
-	0x4d, 0x42, 0x63, 0x28, 0x84, 0x7c, 0x54, 0x80, 0x69, 0x2f, 0xbe, 0x5d, 0x93, 0x2f, 0x24, 0x4f,
-	0x68, 0x47, 0x0c, 0x2d, 0x1e, 0x6e, 0x17, 0xdf, 0x13, 0x26, 0x65, 0x6a, 0xff, 0xa5, 0x00, 0xd3,
-	0x1d, 0xdb, 0x32, 0x2d, 0xa7, 0x7b, 0x81, 0xb7, 0x73, 0xdc, 0x87, 0x8a, 0x6f, 0x5b, 0x26, 0x1d,
-	0x73, 0x35, 0x11, 0xeb, 0x18, 0x03, 0x40, 0x81, 0x93, 0xbc, 0xee, 0xa3, 0x74, 0x86, 0xeb, 0x3e,
-	0xfe, 0xb0, 0x0a, 0x32, 0x8e, 0x9f, 0x0c, 0xa0, 0xd1, 0x55, 0xb7, 0x08, 0xc8, 0x67, 0xbc, 0x9d,
-	0x23, 0x03, 0x65, 0xe2, 0x3e, 0x02, 0x31, 0xf7, 0x87, 0x85, 0x18, 0x49, 0x22, 0x34, 0x79, 0x79,
-	0xf6, 0x6a, 0xce, 0xcb, 0xb3, 0x85, 0xb8, 0xe1, 0xeb, 0xb3, 0x75, 0x28, 0x1f, 0x04, 0x41, 0x5f,
-	0x0e, 0xa6, 0xf1, 0x0f, 0x6a, 0x44, 0x49, 0x90, 0x84, 0x4e, 0xc4, 0xfe, 0x23, 0x87, 0x66, 0x22,
-	0x1c, 0x3d, 0xbc, 0xa2, 0x70, 0x25, 0x57, 0x18, 0x49, 0x5c, 0x04, 0xfb, 0x8f, 0x1c, 0x9a, 0xfc,
-	0x22, 0x34, 0x03, 0x4f, 0x77, 0xfc, 0x7d, 0xd7, 0xeb, 0x51, 0x4f, 0xee, 0x51, 0xd7, 0x72, 0xdc,
-	0x1f, 0xbd, 0x13, 0xa1, 0x09, 0x93, 0x6c, 0xa2, 0x08, 0xe3, 0xd2, 0xc8, 0x21, 0xd4, 0x07, 0xa6,
-	0x68, 0x98, 0x34, 0x83, 0x2d, 0xe7, 0xb9, 0x12, 0x3c, 0x16, 0x24, 0xa2, 0xfe, 0x61, 0x28, 0x20,
-	0x79, 0x1b, 0x67, 0x6d, 0x52, 0xb7, 0x71, 0xc6, 0x47, 0x63, 0x56, 0x86, 0x16, 0xd2, 0x93, 0x7a,
-	0xad, 0xd3, 0x95, 0x31, 0x6e, 0x6b, 0xb9, 0x55, 0x4e, 0x21, 0xb2, 0x19, 0xea, 0xc6, 0x4e, 0x17,
-	0x95, 0x0c, 0xad, 0x07, 0xd2, 0x77, 0x44, 0x8c, 0xc4, 0x4d, 0x46, 0xe2, 0xd8, 0xe0, 0xe2, 0xd9,
-	0xe6, 0x83, 0xf0, 0x4a, 0x9d, 0x58, 0x26, 0xf5, 0xcc, 0x2b, 0x8b, 0xb4, 0xff, 0x58, 0x84, 0xd2,
-	0xce, 0x66, 0x47, 0x64, 0x47, 0xe5, 0x77, 0xa3, 0xd1, 0xce, 0xa1, 0xd5, 0x7f, 0x40, 0x3d, 0x6b,
-	0xff, 0x58, 0x6e, 0xbd, 0x63, 0xd9, 0x51, 0xd3, 0x1c, 0x98, 0x51, 0x8b, 0xbc, 0x07, 0x53, 0x86,
-	0xbe, 0x42, 0xbd, 0x60, 0x1c, 0xc3, 0x02, 0x3f, 0x1f, 0xbd, 0xb2, 0x1c, 0x55, 0xc7, 0x04, 0x18,
-	0xd9, 0x05, 0x30, 0x22, 0xe8, 0xd2, 0xb9, 0xcd, 0x21, 0x31, 0xe0, 0x18, 0x10, 0x41, 0x68, 0x1c,
-	0x32, 0x56, 0x8e, 0x5a, 0x3e, 0x0f, 0x2a, 0x1f, 0x39, 0x77, 0x55, 0x5d, 0x8c, 0x60, 0x34, 0x07,
-	0xa6, 0x13, 0xd7, 0x1b, 0x91, 0xaf, 0x40, 0xdd, 0xed, 0xc7, 0xa6, 0xd3, 0x06, 0x8f, 0xa6, 0xad,
-	0xdf, 0x97, 0x65, 0x8f, 0x4f, 0x5a, 0xd3, 0x9b, 0x6e, 0xd7, 0x32, 0x54, 0x01, 0x86, 0xec, 0x44,
-	0x83, 0x2a, 0x3f, 0xd4, 0xa8, 0x2e, 0x37, 0xe2, 0x6b, 0x07, 0xbf, 0x7f, 0xc4, 0x47, 0x49, 0xd1,
-	0x7e, 0xa9, 0x0c, 0x91, 0xc7, 0x95, 0xf8, 0x50, 0x15, 0x87, 0x36, 0xe4, 0xcc, 0x7d, 0xa1, 0xe7,
-	0x43, 0xa4, 0x28, 0xd2, 0x85, 0xd2, 0x07, 0xee, 0x5e, 0xee, 0x89, 0x3b, 0x96, 0xcd, 0x40, 0xd8,
-	0xca, 0x62, 0x05, 0xc8, 0x24, 0x90, 0xbf, 0x56, 0x80, 0x17, 0xfd, 0xb4, 0xea, 0x2b, 0x87, 0x03,
-	0xe6, 0xd7, 0xf1, 0xd3, 0xca, 0xb4, 0x0c, 0x7b, 0x1e, 0x45, 0xc6, 0xe1, 0xb6, 0xb0, 0xfe, 0x17,
-	0xae, 0x50, 0x39, 0x9c, 0xd6, 0x73, 0x5e, 0xbe, 0x9a, 0xec, 0xff, 0x64, 0x19, 0x4a, 0x51, 0xda,
-	0xaf, 0x14, 0xa1, 0x19, 0x9b, 0xad, 0x73, 0xdf, 0x99, 0xf5, 0x28, 0x75, 0x67, 0xd6, 0xf6, 0xf8,
-	0x91, 0x01, 0x51, 0xab, 0x2e, 0xfa, 0xda, 0xac, 0x7f, 0x5e, 0x84, 0xd2, 0xee, 0xea, 0x5a, 0x72,
-	0xd3, 0x5a, 0x78, 0x06, 0x9b, 0xd6, 0x03, 0xa8, 0xed, 0x0d, 0x2c, 0x3b, 0xb0, 0x9c, 0xdc, 0xf9,
-	0x56, 0xd4, 0x15, 0x63, 0xd2, 0xd7, 0x21, 0x50, 0x51, 0xc1, 0x93, 0x2e, 0xd4, 0xba, 0x22, 0xe1,
-	0x65, 0xee, 0x78, 0x49, 0x99, 0x38, 0x53, 0x08, 0x92, 0x7f, 0x50, 0xa1, 0x6b, 0xc7, 0x50, 0xdd,
-	0x5d, 0x95, 0x6a, 0xff, 0xb3, 0xed, 0x4d, 0xed, 0x17, 0x21, 0xd4, 0x02, 0x9e, 0xbd, 0xf0, 0xdf,
-	0x29, 0x40, 0x52, 0xf1, 0x79, 0xf6, 0xa3, 0xe9, 0x30, 0x3d, 0x9a, 0x56, 0x27, 0xf1, 0xf1, 0x65,
-	0x0f, 0x28, 0xed, 0xdf, 0x15, 0x20, 0x75, 0xd2, 0x8e, 0xbc, 0x21, 0x73, 0xa7, 0x25, 0x03, 0xd3,
-	0x54, 0xee, 0x34, 0x92, 0xe4, 0x8e, 0xe5, 0x50, 0xfb, 0x98, 0x6d, 0xd7, 0xe2, 0x0e, 0x34, 0xd9,
-	0xfc, 0x7b, 0xe3, 0x6f, 0xd7, 0xb2, 0xdc, 0x71, 0x32, 0x78, 0x32, 0x4e, 0xc2, 0xa4, 0x5c, 0xed,
-	0x1f, 0x15, 0xa1, 0xfa, 0xcc, 0x0e, 0xfe, 0xd3, 0x44, 0x3c, 0xeb, 0x4a, 0xce, 0xd9, 0x7e, 0x64,
-	0x34, 0x6b, 0x2f, 0x15, 0xcd, 0x9a, 0xf7, 0x4e, 0xef, 0xa7, 0xc4, 0xb2, 0xfe, 0x9b, 0x02, 0xc8,
-	0xb5, 0x66, 0xc3, 0xf1, 0x03, 0xdd, 0x31, 0x28, 0x31, 0xc2, 0x85, 0x2d, 0x6f, 0xd0, 0x94, 0x0c,
-	0x2c, 0x14, 0xba, 0x0c, 0xff, 0xad, 0x16, 0x32, 0xf2, 0x93, 0x50, 0x3f, 0x70, 0xfd, 0x80, 0x2f,
-	0x5e, 0xc5, 0xa4, 0xc9, 0xec, 0xb6, 0x2c, 0xc7, 0x90, 0x23, 0xed, 0xce, 0xae, 0x8c, 0x76, 0x67,
-	0x6b, 0xbf, 0x59, 0x84, 0xa9, 0x4f, 0x4b, 0xf6, 0x82, 0xac, 0xe8, 0xdf, 0x52, 0xce, 0xe8, 0xdf,
-	0xf2, 0x79, 0xa2, 0x7f, 0xb5, 0xef, 0x17, 0x00, 0x9e, 0x59, 0xea, 0x04, 0x33, 0x19, 0x98, 0x9b,
-	0x7b, 0x5c, 0x65, 0x87, 0xe5, 0xfe, 0xfd, 0x8a, 0x7a, 0x24, 0x1e, 0x94, 0xfb, 0x71, 0x01, 0x66,
-	0xf4, 0x44, 0xa0, 0x6b, 0x6e, 0x7d, 0x39, 0x15, 0x37, 0x1b, 0xc6, 0x69, 0x25, 0xcb, 0x31, 0x25,
-	0x96, 0xbc, 0x19, 0xa5, 0xed, 0xbe, 0x17, 0x0d, 0xfb, 0xa1, 0x7c, 0xdb, 0x5c, 0x77, 0x4b, 0x70,
-	0x3e, 0x25, 0xb0, 0xb8, 0x34, 0x91, 0xc0, 0xe2, 0xf8, 0x91, 0xc9, 0xf2, 0x13, 0x8f, 0x4c, 0x1e,
-	0x41, 0x63, 0xdf, 0x73, 0x7b, 0x3c, 0x76, 0x57, 0x5e, 0x8c, 0x7d, 0x2b, 0xc7, 0x42, 0xd9, 0xdb,
-	0xb3, 0x1c, 0x6a, 0xf2, 0xb8, 0xe0, 0xd0, 0x70, 0xb5, 0xa6, 0xf0, 0x31, 0x12, 0xc5, 0x6d, 0xfd,
-	0xae, 0x90, 0x5a, 0x9d, 0xa4, 0xd4, 0x70, 0x2e, 0xd9, 0x11, 0xe8, 0xa8, 0xc4, 0x24, 0xe3, 0x75,
-	0x6b, 0xcf, 0x26, 0x5e, 0x57, 0xfb, 0x0b, 0x35, 0x35, 0x81, 0x3d, 0x77, 0x19, 0x62, 0x3f, 0x3b,
-	0xe8, 0xde, 0xa5, 0x43, 0xa7, 0xd0, 0xeb, 0xcf, 0xf0, 0x14, 0x7a, 0x63, 0x32, 0xa7, 0xd0, 0x21,
-	0xdf, 0x29, 0xf4, 0xe6, 0x84, 0x4e, 0xa1, 0x4f, 0x4d, 0xea, 0x14, 0xfa, 0xf4, 0x58, 0xa7, 0xd0,
-	0x67, 0xce, 0x74, 0x0a, 0xfd, 0xa4, 0x04, 0xa9, 0xcd, 0xf8, 0x67, 0x8e, 0xb7, 0xff, 0xa7, 0x1c,
-	0x6f, 0xdf, 0x29, 0x42, 0x34, 0x11, 0x9f, 0x33, 0x30, 0xe9, 0x5d, 0xa8, 0xf7, 0xf4, 0x47, 0x3c,
-	0x70, 0x3a, 0xcf, 0xc5, 0xca, 0x5b, 0x12, 0x03, 0x43, 0x34, 0xe2, 0x03, 0x58, 0xe1, 0xe5, 0x06,
-	0xb9, 0x5d, 0x18, 0xd1, 0x3d, 0x09, 0xc2, 0x48, 0x1a, 0xfd, 0xc7, 0x98, 0x18, 0xed, 0x5f, 0x17,
-	0x41, 0xde, 0x82, 0x41, 0x28, 0x54, 0xf6, 0xad, 0x47, 0xd4, 0xcc, 0x1d, 0xee, 0x1c, 0xbb, 0xee,
-	0x5e, 0xf8, 0x68, 0x78, 0x01, 0x0a, 0x74, 0x6e, 0x7c, 0x17, 0x3e, 0x37, 0xd9, 0x7f, 0x39, 0x8c,
-	0xef, 0x71, 0xdf, 0x9d, 0x34, 0xbe, 0x8b, 0x22, 0x54, 0x32, 0x84, 0xad, 0x9f, 0x87, 0x5f, 0xe4,
-	0x76, 0x31, 0x26, 0xc2, 0x38, 0x94, 0xad, 0xdf, 0x17, 0x69, 0x28, 0xa4, 0x8c, 0xf6, 0x2f, 0x7c,
-	0xef, 0x87, 0xd7, 0x5f, 0xf8, 0xfe, 0x0f, 0xaf, 0xbf, 0xf0, 0x83, 0x1f, 0x5e, 0x7f, 0xe1, 0x97,
-	0x4e, 0xaf, 0x17, 0xbe, 0x77, 0x7a, 0xbd, 0xf0, 0xfd, 0xd3, 0xeb, 0x85, 0x1f, 0x9c, 0x5e, 0x2f,
-	0xfc, 0xa7, 0xd3, 0xeb, 0x85, 0xbf, 0xfc, 0x9f, 0xaf, 0xbf, 0xf0, 0xf3, 0x5f, 0x8e, 0x9a, 0xb0,
-	0xa8, 0x9a, 0xb0, 0xa8, 0x04, 0x2e, 0xf6, 0x0f, 0xbb, 0x8b, 0xac, 0x09, 0x51, 0x89, 0x6a, 0xc2,
-	0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xea, 0x57, 0x1d, 0x48, 0x12, 0x9e, 0x00, 0x00,
+	// 7998 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x7d, 0x6b, 0x6c, 0x1c, 0xc9,
+	0xb5, 0xde, 0xce, 0x7b, 0xe6, 0x0c, 0x5f, 0x2a, 0x3d, 0xb6, 0xa5, 0xd5, 0x8a, 0x72, 0xdb, 0xde,
+	0x28, 0x0f, 0x93, 0x5e, 0x79, 0x5f, 0x76, 0x62, 0xaf, 0x39, 0xa4, 0x28, 0x51, 0x22, 0x25, 0xfa,
+	0x0c, 0xa9, 0xf5, 0x23, 0xf6, 0xa6, 0x39, 0x53, 0x1c, 0xf6, 0xb2, 0xa7, 0x7b, 0xb6, 0xbb, 0x87,
+	0x12, 0xd7, 0x09, 0xfc, 0xd8, 0x04, 0xbb, 0x41, 0x12, 0x24, 0xf0, 0x2f, 0x03, 0x89, 0x13, 0x24,
+	0x08, 0xe0, 0x1f, 0x86, 0xf3, 0x23, 0x88, 0xf3, 0x23, 0x40, 0x1e, 0x0e, 0x82, 0xc4, 0x79, 0x1b,
+	0x41, 0x80, 0x6c, 0xfe, 0x10, 0x31, 0x83, 0xfc, 0xc8, 0x0f, 0x5f, 0x18, 0xd7, 0xb8, 0xd7, 0xbe,
+	0x82, 0x71, 0x7d, 0x51, 0xaf, 0x7e, 0x4d, 0x8f, 0x44, 0x4e, 0x0f, 0xb5, 0xda, 0x7b, 0xf7, 0xdf,
+	0x74, 0x9d, 0x53, 0xdf, 0xa9, 0xae, 0xae, 0xa9, 0x3a, 0x75, 0xce, 0xa9, 0x53, 0x70, 0xbd, 0x63,
+	0xfa, 0x3b, 0xfd, 0xad, 0xb9, 0x96, 0xd3, 0x9d, 0xb7, 0xfb, 0x5d, 0xa3, 0xe7, 0x3a, 0x6f, 0xf0,
+	0x1f, 0xdb, 0x96, 0x73, 0x6f, 0xbe, 0xb7, 0xdb, 0x99, 0x37, 0x7a, 0xa6, 0x17, 0x96, 0xec, 0x3d,
+	0x6f, 0x58, 0xbd, 0x1d, 0xe3, 0xf9, 0xf9, 0x0e, 0xb5, 0xa9, 0x6b, 0xf8, 0xb4, 0x3d, 0xd7, 0x73,
+	0x1d, 0xdf, 0x21, 0x2f, 0x87, 0x40, 0x73, 0x0a, 0x68, 0x4e, 0x55, 0x9b, 0xeb, 0xed, 0x76, 0xe6,
+	0x18, 0x50, 0x58, 0xa2, 0x80, 0x2e, 0x7c, 0x22, 0xd2, 0x82, 0x8e, 0xd3, 0x71, 0xe6, 0x39, 0xde,
+	0x56, 0x7f, 0x9b, 0x3f, 0xf1, 0x07, 0xfe, 0x4b, 0xc8, 0xb9, 0xa0, 0xef, 0xbe, 0xe2, 0xcd, 0x99,
+	0x0e, 0x6b, 0xd6, 0x7c, 0xcb, 0x71, 0xe9, 0xfc, 0xde, 0x40, 0x5b, 0x2e, 0xbc, 0x10, 0xf2, 0x74,
+	0x8d, 0xd6, 0x8e, 0x69, 0x53, 0x77, 0x5f, 0xbd, 0xcb, 0xbc, 0x4b, 0x3d, 0xa7, 0xef, 0xb6, 0xe8,
+	0xb1, 0x6a, 0x79, 0xf3, 0x5d, 0xea, 0x1b, 0x69, 0xb2, 0xe6, 0x87, 0xd5, 0x72, 0xfb, 0xb6, 0x6f,
+	0x76, 0x07, 0xc5, 0xbc, 0xf4, 0xa8, 0x0a, 0x5e, 0x6b, 0x87, 0x76, 0x8d, 0x81, 0x7a, 0x9f, 0x1a,
+	0x56, 0xaf, 0xef, 0x9b, 0xd6, 0xbc, 0x69, 0xfb, 0x9e, 0xef, 0x26, 0x2b, 0xe9, 0x3f, 0x06, 0x38,
+	0xbd, 0xb0, 0xe5, 0xf9, 0xae, 0xd1, 0xf2, 0xd7, 0x9d, 0xf6, 0x06, 0xed, 0xf6, 0x2c, 0xc3, 0xa7,
+	0x64, 0x17, 0xaa, 0xec, 0x85, 0xda, 0x86, 0x6f, 0x68, 0xb9, 0xcb, 0xb9, 0x2b, 0xf5, 0xab, 0x0b,
+	0x73, 0x23, 0x7e, 0xc0, 0xb9, 0x35, 0x09, 0xd4, 0x98, 0x38, 0x3c, 0x98, 0xad, 0xaa, 0x27, 0x0c,
+	0x04, 0x90, 0xef, 0xe6, 0x60, 0xc2, 0x76, 0xda, 0xb4, 0x49, 0x2d, 0xda, 0xf2, 0x1d, 0x57, 0xcb,
+	0x5f, 0x2e, 0x5c, 0xa9, 0x5f, 0xfd, 0xda, 0xc8, 0x12, 0x53, 0xde, 0x68, 0xee, 0x76, 0x44, 0xc0,
+	0x35, 0xdb, 0x77, 0xf7, 0x1b, 0x67, 0x7e, 0x72, 0x30, 0xfb, 0xd4, 0xe1, 0xc1, 0xec, 0x44, 0x94,
+	0x84, 0xb1, 0x96, 0x90, 0x4d, 0xa8, 0xfb, 0x8e, 0xc5, 0xba, 0xcc, 0x74, 0x6c, 0x4f, 0x2b, 0xf0,
+	0x86, 0x5d, 0x9a, 0x13, 0x5d, 0xcd, 0xc4, 0xcf, 0xb1, 0x31, 0x36, 0xb7, 0xf7, 0xfc, 0xdc, 0x46,
+	0xc0, 0xd6, 0x38, 0x2d, 0x81, 0xeb, 0x61, 0x99, 0x87, 0x51, 0x1c, 0x42, 0x61, 0xda, 0xa3, 0xad,
+	0xbe, 0x6b, 0xfa, 0xfb, 0x8b, 0x8e, 0xed, 0xd3, 0xfb, 0xbe, 0x56, 0xe4, 0xbd, 0xfc, 0x5c, 0x1a,
+	0xf4, 0xba, 0xd3, 0x6e, 0xc6, 0xb9, 0x1b, 0xa7, 0x0f, 0x0f, 0x66, 0xa7, 0x13, 0x85, 0x98, 0xc4,
+	0x24, 0x36, 0xcc, 0x98, 0x5d, 0xa3, 0x43, 0xd7, 0xfb, 0x96, 0xd5, 0xa4, 0x2d, 0x97, 0xfa, 0x9e,
+	0x56, 0xe2, 0xaf, 0x70, 0x25, 0x4d, 0xce, 0xaa, 0xd3, 0x32, 0xac, 0x3b, 0x5b, 0x6f, 0xd0, 0x96,
+	0x8f, 0x74, 0x9b, 0xba, 0xd4, 0x6e, 0xd1, 0x86, 0x26, 0x5f, 0x66, 0x66, 0x25, 0x81, 0x84, 0x03,
+	0xd8, 0xe4, 0x3a, 0x9c, 0xea, 0xb9, 0xa6, 0xc3, 0x9b, 0x60, 0x19, 0x9e, 0x77, 0xdb, 0xe8, 0x52,
+	0xad, 0x7c, 0x39, 0x77, 0xa5, 0xd6, 0x38, 0x2f, 0x61, 0x4e, 0xad, 0x27, 0x19, 0x70, 0xb0, 0x0e,
+	0xb9, 0x02, 0x55, 0x55, 0xa8, 0x55, 0x2e, 0xe7, 0xae, 0x94, 0xc4, 0xd8, 0x51, 0x75, 0x31, 0xa0,
+	0x92, 0x65, 0xa8, 0x1a, 0xdb, 0xdb, 0xa6, 0xcd, 0x38, 0xab, 0xbc, 0x0b, 0x2f, 0xa6, 0xbd, 0xda,
+	0x82, 0xe4, 0x11, 0x38, 0xea, 0x09, 0x83, 0xba, 0xe4, 0x26, 0x10, 0x8f, 0xba, 0x7b, 0x66, 0x8b,
+	0x2e, 0xb4, 0x5a, 0x4e, 0xdf, 0xf6, 0x79, 0xdb, 0x6b, 0xbc, 0xed, 0x17, 0x64, 0xdb, 0x49, 0x73,
+	0x80, 0x03, 0x53, 0x6a, 0x91, 0xcf, 0xc3, 0x8c, 0xfc, 0xaf, 0x86, 0xbd, 0x00, 0x1c, 0xe9, 0x0c,
+	0xeb, 0x48, 0x4c, 0xd0, 0x70, 0x80, 0x9b, 0xb4, 0xe1, 0xa2, 0xd1, 0xf7, 0x9d, 0x2e, 0x83, 0x8c,
+	0x0b, 0xdd, 0x70, 0x76, 0xa9, 0xad, 0xd5, 0x2f, 0xe7, 0xae, 0x54, 0x1b, 0x97, 0x0f, 0x0f, 0x66,
+	0x2f, 0x2e, 0x3c, 0x84, 0x0f, 0x1f, 0x8a, 0x42, 0xee, 0x40, 0xad, 0x6d, 0x7b, 0xeb, 0x8e, 0x65,
+	0xb6, 0xf6, 0xb5, 0x09, 0xde, 0xc0, 0xe7, 0xe5, 0xab, 0xd6, 0x96, 0x6e, 0x37, 0x05, 0xe1, 0xc1,
+	0xc1, 0xec, 0xc5, 0xc1, 0x29, 0x75, 0x2e, 0xa0, 0x63, 0x88, 0x41, 0xd6, 0x38, 0xe0, 0xa2, 0x63,
+	0x6f, 0x9b, 0x1d, 0x6d, 0x92, 0x7f, 0x8d, 0xcb, 0x43, 0x06, 0xf4, 0xd2, 0xed, 0xa6, 0xe0, 0x6b,
+	0x4c, 0x4a, 0x71, 0xe2, 0x11, 0x43, 0x04, 0xd2, 0x86, 0x29, 0x35, 0x19, 0x2f, 0x5a, 0x86, 0xd9,
+	0xf5, 0xb4, 0x29, 0x3e, 0x78, 0x3f, 0x36, 0x04, 0x13, 0xa3, 0xcc, 0x8d, 0x73, 0xf2, 0x55, 0xa6,
+	0x62, 0xc5, 0x1e, 0x26, 0x30, 0x2f, 0xbc, 0x0a, 0xa7, 0x06, 0xe6, 0x06, 0x32, 0x03, 0x85, 0x5d,
+	0xba, 0xcf, 0xa7, 0xbe, 0x1a, 0xb2, 0x9f, 0xe4, 0x0c, 0x94, 0xf6, 0x0c, 0xab, 0x4f, 0xb5, 0x3c,
+	0x2f, 0x13, 0x0f, 0x9f, 0xc9, 0xbf, 0x92, 0xd3, 0xff, 0x51, 0x01, 0x26, 0xd4, 0x8c, 0xd3, 0x34,
+	0xed, 0x5d, 0xf2, 0x1a, 0x14, 0x2c, 0xa7, 0x23, 0xe7, 0xcd, 0xbf, 0x30, 0xf2, 0x2c, 0xb6, 0xea,
+	0x74, 0x1a, 0x95, 0xc3, 0x83, 0xd9, 0xc2, 0xaa, 0xd3, 0x41, 0x86, 0x48, 0x5a, 0x50, 0xda, 0x35,
+	0xb6, 0x77, 0x0d, 0xde, 0x86, 0xfa, 0xd5, 0xc6, 0xc8, 0xd0, 0xb7, 0x18, 0x0a, 0x6b, 0x6b, 0xa3,
+	0x76, 0x78, 0x30, 0x5b, 0xe2, 0x8f, 0x28, 0xb0, 0x89, 0x03, 0xb5, 0x2d, 0xcb, 0x68, 0xed, 0xee,
+	0x38, 0x16, 0xd5, 0x0a, 0x19, 0x05, 0x35, 0x14, 0x92, 0xf8, 0xcc, 0xc1, 0x23, 0x86, 0x32, 0x48,
+	0x0b, 0xca, 0xfd, 0xb6, 0x67, 0xda, 0xbb, 0x72, 0x0e, 0x7c, 0x75, 0x64, 0x69, 0x9b, 0x4b, 0xfc,
+	0x9d, 0xe0, 0xf0, 0x60, 0xb6, 0x2c, 0x7e, 0xa3, 0x84, 0xd6, 0x7f, 0x3d, 0x01, 0x53, 0xea, 0x23,
+	0xdd, 0xa5, 0xae, 0x4f, 0xef, 0x93, 0xcb, 0x50, 0xb4, 0xd9, 0x5f, 0x93, 0x7f, 0xe4, 0xc6, 0x84,
+	0x1c, 0x2e, 0x45, 0xfe, 0x97, 0xe4, 0x14, 0xd6, 0x32, 0x31, 0x54, 0xb4, 0x7c, 0xc6, 0x96, 0x35,
+	0x39, 0x8c, 0x68, 0x99, 0xf8, 0x8d, 0x12, 0x9a, 0x7c, 0x05, 0x8a, 0xfc, 0xe5, 0x45, 0x57, 0x7f,
+	0x76, 0x74, 0x11, 0xec, 0xd5, 0xab, 0xec, 0x0d, 0xf8, 0x8b, 0x17, 0x3d, 0x39, 0x14, 0xfb, 0xed,
+	0x6d, 0xad, 0x98, 0x71, 0x28, 0x6e, 0x2e, 0x2d, 0x8b, 0xa1, 0xb8, 0xb9, 0xb4, 0x8c, 0x0c, 0x91,
+	0xfc, 0xed, 0x1c, 0x9c, 0x6a, 0x39, 0xb6, 0x6f, 0x30, 0x3d, 0x43, 0x2d, 0xb2, 0x5a, 0x89, 0xcb,
+	0xb9, 0x39, 0xb2, 0x9c, 0xc5, 0x24, 0x62, 0xe3, 0x2c, 0x5b, 0x33, 0x06, 0x8a, 0x71, 0x50, 0x36,
+	0xf9, 0xbb, 0x39, 0x38, 0xcb, 0xe6, 0xf2, 0x01, 0x66, 0xad, 0x3c, 0xf6, 0x56, 0x9d, 0x3f, 0x3c,
+	0x98, 0x3d, 0xbb, 0x92, 0x26, 0x0c, 0xd3, 0xdb, 0xc0, 0x5a, 0x77, 0xda, 0x18, 0x54, 0x4b, 0xf8,
+	0xea, 0x56, 0xbf, 0xba, 0x3a, 0x4e, 0x55, 0xa7, 0xf1, 0x8c, 0x1c, 0xca, 0x69, 0x9a, 0x1d, 0xa6,
+	0xb5, 0x82, 0x5c, 0x83, 0xca, 0x9e, 0x63, 0xf5, 0xbb, 0xd4, 0xd3, 0xaa, 0x7c, 0x8a, 0xbd, 0x90,
+	0x36, 0xc5, 0xde, 0xe5, 0x2c, 0x8d, 0x69, 0x09, 0x5f, 0x11, 0xcf, 0x1e, 0xaa, 0xba, 0xc4, 0x84,
+	0xb2, 0x65, 0x76, 0x4d, 0xdf, 0xe3, 0x0b, 0x67, 0xfd, 0xea, 0xb5, 0x91, 0x5f, 0x4b, 0xfc, 0x45,
+	0x57, 0x39, 0x98, 0xf8, 0xd7, 0x88, 0xdf, 0x28, 0x05, 0xb0, 0xa9, 0xd0, 0x6b, 0x19, 0x96, 0x58,
+	0x58, 0xeb, 0x57, 0x3f, 0x37, 0xfa, 0xdf, 0x86, 0xa1, 0x34, 0x26, 0xe5, 0x3b, 0x95, 0xf8, 0x23,
+	0x0a, 0x6c, 0xf2, 0x55, 0x98, 0x8a, 0x7d, 0x4d, 0x4f, 0xab, 0xf3, 0xde, 0x79, 0x36, 0xad, 0x77,
+	0x02, 0xae, 0x70, 0xe5, 0x89, 0x8d, 0x10, 0x0f, 0x13, 0x60, 0xe4, 0x16, 0x54, 0x3d, 0xb3, 0x4d,
+	0x5b, 0x86, 0xeb, 0x69, 0x13, 0x47, 0x01, 0x9e, 0x91, 0xc0, 0xd5, 0xa6, 0xac, 0x86, 0x01, 0x00,
+	0x99, 0x03, 0xe8, 0x19, 0xae, 0x6f, 0x0a, 0x45, 0x75, 0x92, 0x2b, 0x4d, 0x53, 0x87, 0x07, 0xb3,
+	0xb0, 0x1e, 0x94, 0x62, 0x84, 0x83, 0xf1, 0xb3, 0xba, 0x2b, 0x76, 0xaf, 0xef, 0x8b, 0x85, 0xb5,
+	0x26, 0xf8, 0x9b, 0x41, 0x29, 0x46, 0x38, 0xc8, 0x0f, 0x73, 0xf0, 0x4c, 0xf8, 0x38, 0xf8, 0x27,
+	0x9b, 0x1e, 0xfb, 0x9f, 0x6c, 0xf6, 0xf0, 0x60, 0xf6, 0x99, 0xe6, 0x70, 0x91, 0xf8, 0xb0, 0xf6,
+	0x90, 0x77, 0x72, 0x30, 0xd5, 0xef, 0xb5, 0x0d, 0x9f, 0x36, 0x7d, 0xb6, 0xe3, 0xe9, 0xec, 0x6b,
+	0x33, 0xbc, 0x89, 0xd7, 0x47, 0x9f, 0x05, 0x63, 0x70, 0xe1, 0x67, 0x8e, 0x97, 0x63, 0x42, 0xac,
+	0xfe, 0x1a, 0x4c, 0x2e, 0xf4, 0xfd, 0x1d, 0xc7, 0x35, 0xdf, 0xe2, 0xea, 0x3f, 0x59, 0x86, 0x92,
+	0xcf, 0xd5, 0x38, 0xa1, 0x21, 0x7c, 0x3c, 0xed, 0xa3, 0x0b, 0x95, 0xfa, 0x16, 0xdd, 0x57, 0x7a,
+	0x89, 0x58, 0xa9, 0x85, 0x5a, 0x27, 0xaa, 0xeb, 0x7f, 0x35, 0x07, 0x95, 0x86, 0xd1, 0xda, 0x75,
+	0xb6, 0xb7, 0xc9, 0x17, 0xa1, 0x6a, 0xda, 0x3e, 0x75, 0xf7, 0x0c, 0x4b, 0xc2, 0xce, 0x45, 0x60,
+	0x83, 0x0d, 0x61, 0xf8, 0x7a, 0x6c, 0xf7, 0xc5, 0x04, 0x2d, 0xf5, 0xe5, 0xae, 0x85, 0x6b, 0xc6,
+	0x2b, 0x12, 0x03, 0x03, 0x34, 0x32, 0x0b, 0x25, 0xcf, 0xa7, 0x3d, 0x8f, 0xaf, 0x81, 0x93, 0xa2,
+	0x19, 0x4d, 0x56, 0x80, 0xa2, 0x5c, 0xff, 0x87, 0x39, 0xa8, 0x35, 0x0c, 0xcf, 0x6c, 0xb1, 0xb7,
+	0x24, 0x8b, 0x50, 0xec, 0x7b, 0xd4, 0x3d, 0xde, 0xbb, 0xf1, 0x65, 0x6b, 0xd3, 0xa3, 0x2e, 0xf2,
+	0xca, 0xe4, 0x0e, 0x54, 0x7b, 0x86, 0xe7, 0xdd, 0x73, 0xdc, 0xb6, 0x96, 0x3f, 0x0e, 0x90, 0xd8,
+	0x26, 0xc8, 0xaa, 0x18, 0x80, 0xe8, 0x75, 0x08, 0x75, 0x0f, 0xfd, 0x97, 0x39, 0x38, 0xdd, 0xe8,
+	0x6f, 0x6f, 0x53, 0x57, 0x6a, 0xc5, 0x52, 0xdf, 0xa4, 0x50, 0x72, 0x69, 0xdb, 0xf4, 0x64, 0xdb,
+	0x97, 0x46, 0x1e, 0x28, 0xc8, 0x50, 0xa4, 0x7a, 0xcb, 0xfb, 0x8b, 0x17, 0xa0, 0x40, 0x27, 0x7d,
+	0xa8, 0xbd, 0x41, 0xd9, 0x6e, 0x9c, 0x1a, 0x5d, 0xf9, 0x76, 0x37, 0x46, 0x16, 0x75, 0x93, 0xfa,
+	0x4d, 0x8e, 0x14, 0xd5, 0xa6, 0x83, 0x42, 0x0c, 0x25, 0xe9, 0x3f, 0x2e, 0xc1, 0xc4, 0xa2, 0xd3,
+	0xdd, 0x32, 0x6d, 0xda, 0xbe, 0xd6, 0xee, 0x50, 0xf2, 0x3a, 0x14, 0x69, 0xbb, 0x43, 0xb5, 0x5c,
+	0x46, 0xc5, 0x83, 0x81, 0x85, 0xea, 0x13, 0x7b, 0x42, 0x0e, 0x4c, 0x56, 0x61, 0x6a, 0xdb, 0x75,
+	0xba, 0x62, 0x2e, 0xdf, 0xd8, 0xef, 0x49, 0xdd, 0xb9, 0xf1, 0x31, 0xf5, 0xc7, 0x59, 0x8e, 0x51,
+	0x1f, 0x1c, 0xcc, 0x42, 0xf8, 0x84, 0x89, 0xba, 0xe4, 0x8b, 0xa0, 0x85, 0x25, 0xc1, 0xa4, 0xb6,
+	0xc8, 0xb6, 0x33, 0x5c, 0x77, 0x2a, 0x35, 0x2e, 0x1e, 0x1e, 0xcc, 0x6a, 0xcb, 0x43, 0x78, 0x70,
+	0x68, 0x6d, 0x36, 0x55, 0xcc, 0x84, 0x44, 0xb1, 0xd0, 0x68, 0xc5, 0x71, 0xae, 0x60, 0x7c, 0xdf,
+	0xb7, 0x9c, 0x10, 0x81, 0x03, 0x42, 0xc9, 0x32, 0x4c, 0xf8, 0x4e, 0xa4, 0xbf, 0x4a, 0xbc, 0xbf,
+	0x74, 0x65, 0xa8, 0xd8, 0x70, 0x86, 0xf6, 0x56, 0xac, 0x1e, 0x41, 0x38, 0xe7, 0x3b, 0x69, 0xef,
+	0xca, 0x75, 0xa1, 0x52, 0xe3, 0xc2, 0xe1, 0xc1, 0xec, 0xb9, 0x8d, 0x54, 0x0e, 0x1c, 0x52, 0x93,
+	0x7c, 0x2b, 0x07, 0x53, 0xbe, 0x13, 0x6d, 0xae, 0x56, 0x19, 0x67, 0x1f, 0x11, 0x36, 0x22, 0x36,
+	0x62, 0x02, 0x30, 0x21, 0x50, 0xff, 0x79, 0x19, 0x6a, 0xc1, 0x54, 0x4f, 0x3e, 0x0a, 0x25, 0x6e,
+	0x82, 0x90, 0x1a, 0x7c, 0xb0, 0x86, 0x73, 0x4b, 0x05, 0x0a, 0x1a, 0xf9, 0x38, 0x54, 0x5a, 0x4e,
+	0xb7, 0x6b, 0xd8, 0x6d, 0x6e, 0x56, 0xaa, 0x35, 0xea, 0x4c, 0x75, 0x59, 0x14, 0x45, 0xa8, 0x68,
+	0xe4, 0x22, 0x14, 0x0d, 0xb7, 0x23, 0x2c, 0x3c, 0x35, 0x31, 0x1f, 0x2d, 0xb8, 0x1d, 0x0f, 0x79,
+	0x29, 0xf9, 0x34, 0x14, 0xa8, 0xbd, 0xa7, 0x15, 0x87, 0xeb, 0x46, 0xd7, 0xec, 0xbd, 0xbb, 0x86,
+	0xdb, 0xa8, 0xcb, 0x36, 0x14, 0xae, 0xd9, 0x7b, 0xc8, 0xea, 0x90, 0x55, 0xa8, 0x50, 0x7b, 0x8f,
+	0x7d, 0x7b, 0x69, 0x7a, 0xf9, 0xc8, 0x90, 0xea, 0x8c, 0x45, 0x6e, 0x13, 0x02, 0x0d, 0x4b, 0x16,
+	0xa3, 0x82, 0x20, 0x5f, 0x82, 0x09, 0xa1, 0x6c, 0xad, 0xb1, 0x6f, 0xe2, 0x69, 0x65, 0x0e, 0x39,
+	0x3b, 0x5c, 0x5b, 0xe3, 0x7c, 0xa1, 0xa9, 0x2b, 0x52, 0xe8, 0x61, 0x0c, 0x8a, 0x7c, 0x09, 0x6a,
+	0x6a, 0x67, 0xac, 0xbe, 0x6c, 0xaa, 0x95, 0x48, 0x6d, 0xa7, 0x91, 0xbe, 0xd9, 0x37, 0x5d, 0xda,
+	0xa5, 0xb6, 0xef, 0x35, 0x4e, 0x29, 0xbb, 0x81, 0xa2, 0x7a, 0x18, 0xa2, 0x91, 0xad, 0x41, 0x73,
+	0x97, 0xb0, 0xd5, 0x7c, 0x74, 0xc8, 0xac, 0x3e, 0x82, 0xad, 0xeb, 0x6b, 0x30, 0x1d, 0xd8, 0xa3,
+	0xa4, 0x49, 0x43, 0x58, 0x6f, 0x5e, 0x60, 0xd5, 0x57, 0xe2, 0xa4, 0x07, 0x07, 0xb3, 0xcf, 0xa6,
+	0x18, 0x35, 0x42, 0x06, 0x4c, 0x82, 0x91, 0xb7, 0x98, 0x31, 0xc2, 0x68, 0x9b, 0x36, 0xf5, 0xbc,
+	0x75, 0xd7, 0xd9, 0xca, 0xae, 0x79, 0x72, 0x14, 0x31, 0xec, 0x31, 0x86, 0x8c, 0x09, 0x49, 0xe4,
+	0x1e, 0x4c, 0x5a, 0xe6, 0x1e, 0x0d, 0x45, 0xd7, 0xc7, 0x22, 0xfa, 0xd4, 0xe1, 0xc1, 0xec, 0xe4,
+	0x6a, 0x14, 0x18, 0xe3, 0x72, 0xf4, 0x7f, 0x56, 0x82, 0xc1, 0xcd, 0x57, 0x7c, 0xa4, 0xe4, 0xc6,
+	0x3d, 0x52, 0x92, 0x5f, 0x51, 0xac, 0x19, 0xaf, 0xc8, 0x6a, 0x63, 0xf8, 0x92, 0x29, 0xa3, 0xb1,
+	0x30, 0xee, 0xd1, 0xf8, 0xc4, 0x4c, 0x18, 0x83, 0xc3, 0xb6, 0xfc, 0xfe, 0x0d, 0xdb, 0xca, 0x63,
+	0x1a, 0xb6, 0xef, 0x16, 0x61, 0x6a, 0xc9, 0xa0, 0x5d, 0xc7, 0x7e, 0xe4, 0xfe, 0x3b, 0xf7, 0x44,
+	0xec, 0xbf, 0xaf, 0x40, 0xd5, 0xa5, 0x3d, 0xcb, 0x6c, 0x19, 0x42, 0xcd, 0x96, 0xf6, 0x6e, 0x94,
+	0x65, 0x18, 0x50, 0x87, 0xd8, 0x5d, 0x0a, 0x4f, 0xa4, 0xdd, 0xa5, 0xf8, 0xfe, 0xdb, 0x5d, 0xf4,
+	0x6f, 0xe5, 0x81, 0xab, 0xa4, 0xcc, 0xda, 0xc7, 0xd4, 0xad, 0xa4, 0xb5, 0x8f, 0xff, 0x5b, 0x38,
+	0x85, 0x5c, 0x80, 0xbc, 0xef, 0xc8, 0xe9, 0x06, 0x24, 0x3d, 0xbf, 0xe1, 0x60, 0xde, 0x77, 0xc8,
+	0x5b, 0x00, 0x2d, 0xc7, 0x6e, 0x9b, 0xca, 0x0d, 0x94, 0xed, 0xc5, 0x96, 0x1d, 0xf7, 0x9e, 0xe1,
+	0xb6, 0x17, 0x03, 0x44, 0xb1, 0xf3, 0x0e, 0x9f, 0x31, 0x22, 0x8d, 0xbc, 0x0a, 0x65, 0xc7, 0x5e,
+	0xee, 0x5b, 0x16, 0xef, 0xd0, 0x5a, 0xe3, 0x4f, 0x31, 0x73, 0xc8, 0x1d, 0x5e, 0xf2, 0xe0, 0x60,
+	0xf6, 0xbc, 0xd8, 0xc9, 0xb0, 0xa7, 0xd7, 0x5c, 0xd3, 0x37, 0xed, 0x4e, 0xb0, 0x11, 0x95, 0xd5,
+	0xf4, 0xef, 0xe4, 0xa0, 0xbe, 0x6c, 0xde, 0xa7, 0xed, 0xd7, 0x4c, 0xbb, 0xed, 0xdc, 0x23, 0x08,
+	0x65, 0x8b, 0xda, 0x1d, 0x7f, 0x67, 0xc4, 0x9d, 0xa2, 0xb0, 0xc7, 0x70, 0x04, 0x94, 0x48, 0x64,
+	0x1e, 0x6a, 0x62, 0x9f, 0x61, 0xda, 0x1d, 0xde, 0x87, 0xd5, 0x70, 0xa6, 0x6f, 0x2a, 0x02, 0x86,
+	0x3c, 0xfa, 0x3e, 0x9c, 0x1a, 0xe8, 0x06, 0xd2, 0x86, 0xa2, 0x6f, 0x74, 0xd4, 0xa2, 0xb2, 0x3c,
+	0x72, 0x07, 0x6f, 0x18, 0x9d, 0x48, 0xe7, 0x72, 0x6d, 0x6e, 0xc3, 0x60, 0xda, 0x1c, 0x43, 0xd7,
+	0x7f, 0x93, 0x83, 0xea, 0x72, 0xdf, 0x6e, 0x31, 0xea, 0x11, 0xac, 0xc0, 0x4a, 0x35, 0xcc, 0xa7,
+	0xaa, 0x86, 0x7d, 0x28, 0xef, 0xde, 0x0b, 0x54, 0xc7, 0xfa, 0xd5, 0xb5, 0xd1, 0x47, 0x85, 0x6c,
+	0xd2, 0xdc, 0x2d, 0x8e, 0x27, 0x9c, 0x94, 0x53, 0xb2, 0x41, 0xe5, 0x5b, 0xaf, 0x71, 0xa1, 0x52,
+	0xd8, 0x85, 0x4f, 0x43, 0x3d, 0xc2, 0x76, 0x2c, 0x7f, 0xc5, 0x3f, 0x2f, 0x42, 0xf9, 0x7a, 0xb3,
+	0xb9, 0xb0, 0xbe, 0x42, 0x5e, 0x84, 0xba, 0xf4, 0x5f, 0xdd, 0x0e, 0xfb, 0x20, 0x70, 0x5f, 0x36,
+	0x43, 0x12, 0x46, 0xf9, 0x98, 0xe2, 0xed, 0x52, 0xc3, 0xea, 0x6a, 0xf9, 0xb8, 0xe2, 0x8d, 0xac,
+	0x10, 0x05, 0x8d, 0x18, 0x30, 0xc5, 0xf6, 0xf2, 0xac, 0x0b, 0xc5, 0x3e, 0x5d, 0x2b, 0x1c, 0x67,
+	0x27, 0xcf, 0x17, 0x98, 0xcd, 0x18, 0x00, 0x26, 0x00, 0xc9, 0x2b, 0x50, 0x35, 0xfa, 0xfe, 0x0e,
+	0xdf, 0x2a, 0x89, 0xff, 0xc6, 0x45, 0xee, 0xde, 0x93, 0x65, 0x0f, 0x0e, 0x66, 0x27, 0x6e, 0x61,
+	0xe3, 0x45, 0xf5, 0x8c, 0x01, 0x37, 0x6b, 0x9c, 0xb2, 0x0d, 0xc8, 0xc6, 0x95, 0x8e, 0xdd, 0xb8,
+	0xf5, 0x18, 0x00, 0x26, 0x00, 0xc9, 0x57, 0x60, 0x62, 0x97, 0xee, 0xfb, 0xc6, 0x96, 0x14, 0x50,
+	0x3e, 0x8e, 0x80, 0x19, 0xa6, 0xac, 0xdf, 0x8a, 0x54, 0xc7, 0x18, 0x18, 0xf1, 0xe0, 0xcc, 0x2e,
+	0x75, 0xb7, 0xa8, 0xeb, 0x48, 0x3b, 0x83, 0x14, 0x52, 0x39, 0x8e, 0x10, 0xed, 0xf0, 0x60, 0xf6,
+	0xcc, 0xad, 0x14, 0x18, 0x4c, 0x05, 0xd7, 0xff, 0x20, 0x0f, 0xd3, 0xd7, 0x45, 0x00, 0x81, 0xe3,
+	0x0a, 0xcd, 0x83, 0x9c, 0x87, 0x82, 0xdb, 0xeb, 0xf3, 0x91, 0x53, 0x10, 0x2e, 0x02, 0x5c, 0xdf,
+	0x44, 0x56, 0xc6, 0x4c, 0x52, 0x6d, 0x39, 0x65, 0x68, 0xf9, 0x91, 0x26, 0x1a, 0xbe, 0x08, 0xaa,
+	0x27, 0x0c, 0xd0, 0xd8, 0x9e, 0xae, 0xeb, 0x75, 0x9a, 0xe6, 0x5b, 0x54, 0xee, 0xfc, 0xf9, 0x9e,
+	0x6e, 0x4d, 0x14, 0xa1, 0xa2, 0xb1, 0x55, 0x75, 0x97, 0xee, 0x8b, 0x7d, 0x6f, 0x31, 0x5c, 0x55,
+	0x6f, 0xc9, 0x32, 0x0c, 0xa8, 0xcc, 0xc6, 0x25, 0xfe, 0x2c, 0x6c, 0x14, 0x14, 0x85, 0xcd, 0xe6,
+	0x2e, 0x2b, 0x90, 0xff, 0x1b, 0x36, 0x65, 0xbe, 0x61, 0xfa, 0x3e, 0x75, 0xb5, 0xf2, 0x48, 0x6f,
+	0xc2, 0xa7, 0xcc, 0x9b, 0x1c, 0x01, 0x25, 0x12, 0xf9, 0xb3, 0x50, 0xe3, 0xe0, 0x0d, 0xcb, 0xd9,
+	0xe2, 0x1f, 0xae, 0x26, 0xac, 0x37, 0x77, 0x55, 0x21, 0x86, 0x74, 0xfd, 0xb7, 0x79, 0x38, 0x77,
+	0x9d, 0xfa, 0x42, 0xab, 0x59, 0xa2, 0x3d, 0xcb, 0xd9, 0x67, 0xfa, 0x34, 0xd2, 0x37, 0xc9, 0xe7,
+	0x01, 0x4c, 0x6f, 0xab, 0xb9, 0xd7, 0xe2, 0xff, 0x03, 0xf1, 0x1f, 0xbe, 0x2c, 0xff, 0x92, 0xb0,
+	0xd2, 0x6c, 0x48, 0xca, 0x83, 0xd8, 0x13, 0x46, 0xea, 0x84, 0x1b, 0xe9, 0xfc, 0x43, 0x36, 0xd2,
+	0x4d, 0x80, 0x5e, 0xa8, 0x95, 0x17, 0x38, 0xe7, 0xa7, 0x94, 0x98, 0xe3, 0x28, 0xe4, 0x11, 0x98,
+	0x2c, 0x7a, 0xb2, 0x0d, 0x33, 0x6d, 0xba, 0x6d, 0xf4, 0x2d, 0x3f, 0xd8, 0x49, 0x68, 0xa5, 0x63,
+	0x6e, 0x46, 0x82, 0xe0, 0x86, 0xa5, 0x04, 0x12, 0x0e, 0x60, 0xeb, 0xff, 0xa2, 0x00, 0x17, 0xae,
+	0x53, 0x3f, 0xb0, 0xad, 0xc9, 0xd9, 0xb1, 0xd9, 0xa3, 0x2d, 0xf6, 0x15, 0xde, 0xc9, 0x41, 0xd9,
+	0x32, 0xb6, 0xa8, 0xc5, 0x56, 0x2f, 0xf6, 0x36, 0xaf, 0x8f, 0xbc, 0x10, 0x0c, 0x97, 0x32, 0xb7,
+	0xca, 0x25, 0x24, 0x96, 0x06, 0x51, 0x88, 0x52, 0x3c, 0x9b, 0xd4, 0x5b, 0x56, 0xdf, 0xf3, 0xa9,
+	0xbb, 0xee, 0xb8, 0xbe, 0xd4, 0x27, 0x83, 0x49, 0x7d, 0x31, 0x24, 0x61, 0x94, 0x8f, 0x5c, 0x05,
+	0x68, 0x59, 0x26, 0xb5, 0x7d, 0x5e, 0x4b, 0xfc, 0xaf, 0x88, 0xfa, 0xbe, 0x8b, 0x01, 0x05, 0x23,
+	0x5c, 0x4c, 0x54, 0xd7, 0xb1, 0x4d, 0xdf, 0x11, 0xa2, 0x8a, 0x71, 0x51, 0x6b, 0x21, 0x09, 0xa3,
+	0x7c, 0xbc, 0x1a, 0xf5, 0x5d, 0xb3, 0xe5, 0xf1, 0x6a, 0xa5, 0x44, 0xb5, 0x90, 0x84, 0x51, 0x3e,
+	0xb6, 0xe6, 0x45, 0xde, 0xff, 0x58, 0x6b, 0xde, 0x0f, 0x6a, 0x70, 0x29, 0xd6, 0xad, 0xbe, 0xe1,
+	0xd3, 0xed, 0xbe, 0xd5, 0xa4, 0xbe, 0xfa, 0x80, 0x23, 0xae, 0x85, 0x7f, 0x23, 0xfc, 0xee, 0x22,
+	0x6c, 0xa9, 0x35, 0x9e, 0xef, 0x3e, 0xd0, 0xc0, 0x23, 0x7d, 0xfb, 0x79, 0xa8, 0xd9, 0x86, 0xef,
+	0xf1, 0x3f, 0xae, 0xfc, 0x8f, 0x06, 0x6a, 0xd8, 0x6d, 0x45, 0xc0, 0x90, 0x87, 0xac, 0xc3, 0x19,
+	0xd9, 0xc5, 0xd7, 0xee, 0xf7, 0x1c, 0xd7, 0xa7, 0xae, 0xa8, 0x2b, 0x97, 0x53, 0x59, 0xf7, 0xcc,
+	0x5a, 0x0a, 0x0f, 0xa6, 0xd6, 0x24, 0x6b, 0x70, 0xba, 0x25, 0x42, 0x39, 0xa8, 0xe5, 0x18, 0x6d,
+	0x05, 0x28, 0x4c, 0x99, 0xc1, 0xd6, 0x68, 0x71, 0x90, 0x05, 0xd3, 0xea, 0x25, 0x47, 0x73, 0x79,
+	0xa4, 0xd1, 0x5c, 0x19, 0x65, 0x34, 0x57, 0x47, 0x1b, 0xcd, 0xb5, 0xa3, 0x8d, 0x66, 0xd6, 0xf3,
+	0x6c, 0x1c, 0x51, 0x97, 0xa9, 0x27, 0x62, 0x85, 0x8d, 0x44, 0x0a, 0x05, 0x3d, 0xdf, 0x4c, 0xe1,
+	0xc1, 0xd4, 0x9a, 0x64, 0x0b, 0x2e, 0x88, 0xf2, 0x6b, 0x76, 0xcb, 0xdd, 0xef, 0xb1, 0x85, 0x27,
+	0x82, 0x5b, 0x8f, 0xd9, 0x92, 0x2f, 0x34, 0x87, 0x72, 0xe2, 0x43, 0x50, 0xc8, 0x9f, 0x87, 0x49,
+	0xf1, 0x95, 0xd6, 0x8c, 0x1e, 0x87, 0x15, 0x71, 0x43, 0x67, 0x25, 0xec, 0xe4, 0x62, 0x94, 0x88,
+	0x71, 0x5e, 0xb2, 0x00, 0xd3, 0xbd, 0xbd, 0x16, 0xfb, 0xb9, 0xb2, 0x7d, 0x9b, 0xd2, 0x36, 0x6d,
+	0x73, 0x47, 0x65, 0xad, 0xf1, 0xb4, 0xb2, 0xee, 0xac, 0xc7, 0xc9, 0x98, 0xe4, 0x27, 0xaf, 0xc0,
+	0x84, 0xe7, 0x1b, 0xae, 0x2f, 0x0d, 0xb8, 0xda, 0x94, 0x88, 0xab, 0x52, 0xf6, 0xcd, 0x66, 0x84,
+	0x86, 0x31, 0xce, 0xd4, 0xf5, 0x62, 0xfa, 0xe4, 0xd6, 0x8b, 0x2c, 0xb3, 0xd5, 0x7f, 0xc8, 0xc3,
+	0xe5, 0xeb, 0xd4, 0x5f, 0x73, 0x6c, 0x69, 0xfe, 0x4e, 0x5b, 0xf6, 0x8f, 0x64, 0xfd, 0x8e, 0x2f,
+	0xda, 0xf9, 0xb1, 0x2e, 0xda, 0x85, 0x31, 0x2d, 0xda, 0xc5, 0x13, 0x5c, 0xb4, 0xff, 0x55, 0x1e,
+	0x9e, 0x8e, 0xf5, 0x24, 0x8b, 0xa5, 0x94, 0x13, 0xfe, 0x87, 0x1d, 0x78, 0x84, 0x0e, 0x7c, 0x20,
+	0xf4, 0x4e, 0xee, 0xc0, 0x4c, 0x68, 0x3c, 0x6f, 0x27, 0x35, 0x9e, 0xaf, 0x64, 0x59, 0xf9, 0x52,
+	0x24, 0x1c, 0x69, 0xc5, 0xbb, 0x09, 0xc4, 0x95, 0xee, 0x56, 0x61, 0xfa, 0x89, 0x28, 0x3d, 0x41,
+	0xe0, 0x26, 0x0e, 0x70, 0x60, 0x4a, 0x2d, 0xd2, 0x84, 0xb3, 0x1e, 0xb5, 0x7d, 0xd3, 0xa6, 0x56,
+	0x1c, 0x4e, 0x68, 0x43, 0xcf, 0x4a, 0xb8, 0xb3, 0xcd, 0x34, 0x26, 0x4c, 0xaf, 0x9b, 0x65, 0x1e,
+	0xf8, 0x2f, 0xc0, 0x55, 0x4e, 0xd1, 0x35, 0x63, 0xd3, 0x58, 0xde, 0x49, 0x6a, 0x2c, 0xaf, 0x67,
+	0xff, 0x6e, 0xa3, 0x69, 0x2b, 0x57, 0x01, 0xf8, 0x57, 0x88, 0xaa, 0x2b, 0xc1, 0x22, 0x8d, 0x01,
+	0x05, 0x23, 0x5c, 0x6c, 0x01, 0x52, 0xfd, 0x1c, 0xd5, 0x54, 0x82, 0x05, 0xa8, 0x19, 0x25, 0x62,
+	0x9c, 0x77, 0xa8, 0xb6, 0x53, 0x1a, 0x59, 0xdb, 0xb9, 0x09, 0x24, 0x66, 0x78, 0x14, 0x78, 0xe5,
+	0x78, 0xdc, 0xf0, 0xca, 0x00, 0x07, 0xa6, 0xd4, 0x1a, 0x32, 0x94, 0x2b, 0xe3, 0x1d, 0xca, 0xd5,
+	0xd1, 0x87, 0x32, 0x79, 0x1d, 0xce, 0x73, 0x51, 0xb2, 0x7f, 0xe2, 0xc0, 0x42, 0xef, 0xf9, 0x88,
+	0x04, 0x3e, 0x8f, 0xc3, 0x18, 0x71, 0x38, 0x06, 0xfb, 0x3e, 0x2d, 0x97, 0xb6, 0x99, 0x70, 0xc3,
+	0x1a, 0xae, 0x13, 0x2d, 0xa6, 0xf0, 0x60, 0x6a, 0x4d, 0x36, 0xc4, 0x7c, 0x36, 0x0c, 0x8d, 0x2d,
+	0x8b, 0xb6, 0x65, 0xdc, 0x74, 0x30, 0xc4, 0x36, 0x56, 0x9b, 0x92, 0x82, 0x11, 0xae, 0x34, 0x35,
+	0x65, 0xe2, 0x98, 0x6a, 0xca, 0x75, 0x6e, 0xa5, 0xdf, 0x8e, 0x69, 0x43, 0xda, 0x64, 0x3c, 0x12,
+	0x7e, 0x31, 0xc9, 0x80, 0x83, 0x75, 0xb8, 0x96, 0xd8, 0x72, 0xcd, 0x9e, 0xef, 0xc5, 0xb1, 0xa6,
+	0x12, 0x5a, 0x62, 0x0a, 0x0f, 0xa6, 0xd6, 0x64, 0xfa, 0xf9, 0x0e, 0x35, 0x2c, 0x7f, 0x27, 0x0e,
+	0x38, 0x1d, 0xd7, 0xcf, 0x6f, 0x0c, 0xb2, 0x60, 0x5a, 0xbd, 0xd4, 0x05, 0x69, 0xe6, 0xc9, 0x54,
+	0xab, 0xbe, 0x5d, 0x80, 0xf3, 0xd7, 0xa9, 0x1f, 0x84, 0x94, 0x7d, 0x68, 0x46, 0x79, 0x1f, 0xcc,
+	0x28, 0xdf, 0x2f, 0xc1, 0xe9, 0xeb, 0xd4, 0x1f, 0xd0, 0xc6, 0xfe, 0x84, 0x76, 0xff, 0x1a, 0x9c,
+	0x0e, 0xa3, 0x18, 0x9b, 0xbe, 0xe3, 0x8a, 0xb5, 0x3c, 0xb1, 0x5b, 0x6e, 0x0e, 0xb2, 0x60, 0x5a,
+	0x3d, 0xf2, 0x25, 0x78, 0x9a, 0x2f, 0xf5, 0x76, 0x47, 0xd8, 0x67, 0x85, 0x31, 0x21, 0x72, 0x0e,
+	0x67, 0x56, 0x42, 0x3e, 0xdd, 0x4c, 0x67, 0xc3, 0x61, 0xf5, 0xc9, 0x37, 0x60, 0xa2, 0x67, 0xf6,
+	0xa8, 0x65, 0xda, 0x5c, 0x3f, 0xcb, 0x1c, 0xfc, 0xb3, 0x1e, 0x01, 0x0b, 0x37, 0x70, 0xd1, 0x52,
+	0x8c, 0x09, 0x4c, 0x1d, 0xa9, 0xd5, 0x13, 0x1c, 0xa9, 0xbf, 0x9b, 0x87, 0xca, 0x75, 0xd7, 0xe9,
+	0xf7, 0x1a, 0xfb, 0xa4, 0x03, 0xe5, 0x7b, 0xdc, 0x79, 0xa6, 0xe5, 0x32, 0x9e, 0x04, 0x10, 0x3e,
+	0xb8, 0x50, 0x25, 0x12, 0xcf, 0x28, 0xe1, 0xd9, 0x20, 0xde, 0xa5, 0xfb, 0xb4, 0x2d, 0x7d, 0x68,
+	0xc1, 0x20, 0xbe, 0xc5, 0x0a, 0x51, 0xd0, 0x48, 0x17, 0xa6, 0x0d, 0xcb, 0x72, 0xee, 0xd1, 0xf6,
+	0xaa, 0xe1, 0x73, 0xbf, 0xb7, 0x56, 0x18, 0xc9, 0x2c, 0xcd, 0x83, 0x19, 0x16, 0xe2, 0x50, 0x98,
+	0xc4, 0x26, 0x6f, 0x40, 0xc5, 0xf3, 0x1d, 0x57, 0x29, 0x5b, 0xf5, 0xab, 0x8b, 0xa3, 0x7f, 0xf4,
+	0xc6, 0x17, 0x9a, 0x02, 0x4a, 0xd8, 0xec, 0xe5, 0x03, 0x2a, 0x01, 0xfa, 0xf7, 0x72, 0x00, 0x37,
+	0x36, 0x36, 0xd6, 0xa5, 0x7b, 0xa1, 0x0d, 0x45, 0xe6, 0xb3, 0xc9, 0xec, 0x10, 0x8c, 0x05, 0xe0,
+	0x4a, 0x1f, 0x5e, 0xdf, 0xdf, 0x41, 0x8e, 0x4e, 0xfe, 0x34, 0x54, 0xa4, 0x82, 0x2c, 0xbb, 0x3d,
+	0x88, 0xa7, 0x90, 0x4a, 0x34, 0x2a, 0xba, 0xfe, 0x4f, 0xf3, 0x00, 0x2b, 0x6d, 0x8b, 0x36, 0xd5,
+	0xe1, 0x8d, 0x9a, 0xbf, 0xe3, 0x52, 0x6f, 0xc7, 0xb1, 0xda, 0x23, 0x7a, 0x53, 0xb9, 0xcd, 0x7f,
+	0x43, 0x81, 0x60, 0x88, 0x47, 0xda, 0xcc, 0xd6, 0x41, 0x7b, 0x2a, 0x26, 0x77, 0x44, 0x27, 0xca,
+	0x8c, 0xb0, 0x8b, 0x84, 0x38, 0x18, 0x43, 0x25, 0x06, 0xd4, 0x4d, 0xbb, 0x25, 0xfe, 0x20, 0x8d,
+	0xfd, 0x11, 0x07, 0xd2, 0x34, 0xdb, 0x71, 0xac, 0x84, 0x30, 0x18, 0xc5, 0xd4, 0x7f, 0x91, 0x87,
+	0x73, 0x5c, 0x1e, 0x6b, 0x46, 0x2c, 0xf2, 0x96, 0xfc, 0xa5, 0x81, 0x83, 0xa6, 0x9f, 0x3c, 0x9a,
+	0x68, 0x71, 0x4e, 0x91, 0x9d, 0x26, 0x0d, 0xf5, 0xb9, 0xb0, 0x2c, 0x72, 0xba, 0xb4, 0x0f, 0x45,
+	0x8f, 0xcd, 0x57, 0xa2, 0xf7, 0x9a, 0x23, 0x0f, 0xa1, 0xf4, 0x17, 0xe0, 0xb3, 0x57, 0xe0, 0x35,
+	0x66, 0x4f, 0xc8, 0xc5, 0x91, 0xbf, 0x02, 0x65, 0xcf, 0x37, 0xfc, 0xbe, 0xfa, 0x6b, 0x6e, 0x8e,
+	0x5b, 0x30, 0x07, 0x0f, 0xe7, 0x11, 0xf1, 0x8c, 0x52, 0xa8, 0xfe, 0x8b, 0x1c, 0x5c, 0x48, 0xaf,
+	0xb8, 0x6a, 0x7a, 0x3e, 0xf9, 0x8b, 0x03, 0xdd, 0x7e, 0xc4, 0x2f, 0xce, 0x6a, 0xf3, 0x4e, 0x0f,
+	0xce, 0x22, 0xa8, 0x92, 0x48, 0x97, 0xfb, 0x50, 0x32, 0x7d, 0xda, 0x55, 0xfb, 0xcb, 0x3b, 0x63,
+	0x7e, 0xf5, 0xc8, 0xd2, 0xce, 0xa4, 0xa0, 0x10, 0xa6, 0xbf, 0x9b, 0x1f, 0xf6, 0xca, 0x7c, 0xf9,
+	0xb0, 0xe2, 0xd1, 0xdd, 0xb7, 0xb2, 0x45, 0x77, 0xc7, 0x1b, 0x34, 0x18, 0xe4, 0xfd, 0x97, 0x07,
+	0x83, 0xbc, 0xef, 0x64, 0x0f, 0xf2, 0x4e, 0x74, 0xc3, 0xd0, 0x58, 0xef, 0xf7, 0x0a, 0x70, 0xf1,
+	0x61, 0xc3, 0x86, 0xad, 0x67, 0x72, 0x74, 0x66, 0x5d, 0xcf, 0x1e, 0x3e, 0x0e, 0xc9, 0x55, 0x28,
+	0xf5, 0x76, 0x0c, 0x4f, 0x29, 0x65, 0x6a, 0xc3, 0x52, 0x5a, 0x67, 0x85, 0x0f, 0xd8, 0xa4, 0xc1,
+	0x95, 0x39, 0xfe, 0x88, 0x82, 0x95, 0x4d, 0xc7, 0x5d, 0xea, 0x79, 0xa1, 0x4d, 0x20, 0x98, 0x8e,
+	0xd7, 0x44, 0x31, 0x2a, 0x3a, 0xf1, 0xa1, 0x2c, 0x4c, 0xcc, 0x5a, 0x31, 0x63, 0x20, 0x57, 0xca,
+	0x81, 0x80, 0xf0, 0xa5, 0xc4, 0x33, 0x4a, 0x59, 0x64, 0x0e, 0x8a, 0x7e, 0x18, 0x9e, 0xad, 0xb6,
+	0xe6, 0xc5, 0x14, 0xfd, 0x94, 0xf3, 0xb1, 0x8d, 0xbd, 0xb3, 0xc5, 0x8d, 0xea, 0x6d, 0xe9, 0x3f,
+	0x67, 0x3e, 0xf1, 0x32, 0xf7, 0x99, 0xab, 0xda, 0xe4, 0xce, 0x00, 0x07, 0xa6, 0xd4, 0xd2, 0xff,
+	0x7b, 0x15, 0xce, 0xa5, 0x8f, 0x07, 0xd6, 0x6f, 0x7b, 0xd4, 0xf5, 0x18, 0x76, 0x2e, 0xde, 0x6f,
+	0x77, 0x45, 0x31, 0x2a, 0xfa, 0x07, 0x3a, 0xe0, 0xec, 0xfb, 0x39, 0x66, 0x86, 0x10, 0x3e, 0xa2,
+	0xc7, 0x11, 0x74, 0xf6, 0xac, 0x30, 0x67, 0x0c, 0x11, 0x88, 0xc3, 0xdb, 0x42, 0xfe, 0x71, 0x0e,
+	0xb4, 0x6e, 0xc2, 0xce, 0x71, 0x82, 0x67, 0x25, 0xf9, 0xf9, 0x87, 0xb5, 0x21, 0xf2, 0x70, 0x68,
+	0x4b, 0xc8, 0x37, 0xa0, 0xde, 0x63, 0xe3, 0xc2, 0xf3, 0xa9, 0xdd, 0x52, 0x01, 0xa2, 0xa3, 0xff,
+	0x93, 0xd6, 0x43, 0xac, 0xe0, 0xac, 0x14, 0xd7, 0x0f, 0x22, 0x04, 0x8c, 0x4a, 0x7c, 0xc2, 0x0f,
+	0x47, 0x5e, 0x81, 0xaa, 0x47, 0x7d, 0x16, 0x59, 0x27, 0xf6, 0x1b, 0x35, 0xf1, 0x5f, 0x69, 0xca,
+	0x32, 0x0c, 0xa8, 0x2c, 0xa2, 0x83, 0xbb, 0x9c, 0x58, 0xa4, 0x96, 0x56, 0xe3, 0xe1, 0x62, 0x93,
+	0x22, 0x00, 0x4e, 0x16, 0x62, 0x48, 0x27, 0x2f, 0xc0, 0xc4, 0x16, 0xff, 0xfb, 0xca, 0xf3, 0xf2,
+	0xc2, 0xc6, 0xc5, 0xb5, 0xb5, 0x46, 0xa4, 0x1c, 0x63, 0x5c, 0xcc, 0x9e, 0x45, 0x03, 0xbf, 0x5c,
+	0xd2, 0x9e, 0x15, 0x7a, 0xec, 0x30, 0xc2, 0x45, 0x9e, 0x85, 0x82, 0x6f, 0x79, 0xdc, 0x86, 0x55,
+	0x0d, 0xb7, 0xa0, 0x1b, 0xab, 0x4d, 0x64, 0xe5, 0xfa, 0x6f, 0x73, 0x30, 0x9d, 0x38, 0x46, 0xc4,
+	0xaa, 0xf4, 0x5d, 0x4b, 0x4e, 0x23, 0x41, 0x95, 0x4d, 0x5c, 0x45, 0x56, 0xce, 0x8e, 0x0e, 0x71,
+	0xb5, 0x3c, 0x9f, 0x31, 0x35, 0x08, 0x73, 0x49, 0x33, 0x3d, 0x7c, 0x40, 0x23, 0xe7, 0x6e, 0xbe,
+	0xb0, 0x3d, 0x72, 0x1d, 0x88, 0xb8, 0xf9, 0x42, 0x1a, 0xc6, 0x38, 0x13, 0x06, 0xbf, 0xe2, 0x51,
+	0x0c, 0x7e, 0xfa, 0x77, 0xf2, 0x91, 0x1e, 0x90, 0x9a, 0xfd, 0x23, 0x7a, 0xe0, 0x39, 0xb6, 0x80,
+	0x06, 0x8b, 0x7b, 0x2d, 0xba, 0xfe, 0xb1, 0x52, 0x94, 0x54, 0xf2, 0x9a, 0xe8, 0xfb, 0x42, 0xc6,
+	0x03, 0xd8, 0x1b, 0xab, 0xcd, 0x46, 0x25, 0xfa, 0xd5, 0x82, 0x4f, 0x50, 0x3c, 0xa1, 0x4f, 0xa0,
+	0xff, 0xa7, 0x02, 0xd4, 0x6f, 0x3a, 0x5b, 0x1f, 0x90, 0x08, 0xea, 0xf4, 0x65, 0x2a, 0xff, 0x3e,
+	0x2e, 0x53, 0x9b, 0xf0, 0xb4, 0xef, 0x33, 0x53, 0xb4, 0x63, 0xb7, 0xbd, 0x85, 0x6d, 0x9f, 0xba,
+	0xcb, 0xa6, 0x6d, 0x7a, 0x3b, 0xb4, 0x2d, 0xdd, 0x49, 0xcf, 0x30, 0x33, 0xcc, 0xc6, 0xc6, 0x6a,
+	0x1a, 0x0b, 0x0e, 0xab, 0xcb, 0xa7, 0x0d, 0x71, 0xe6, 0x93, 0x9f, 0x89, 0x92, 0x31, 0x37, 0x62,
+	0xda, 0x88, 0x94, 0x63, 0x8c, 0x4b, 0xff, 0x51, 0x1e, 0x6a, 0x41, 0xd2, 0x07, 0x16, 0x3f, 0xb7,
+	0xe5, 0x3a, 0xbb, 0xd4, 0x15, 0x9e, 0x3b, 0x79, 0x26, 0xaa, 0x21, 0x8a, 0x50, 0xd1, 0x98, 0x2d,
+	0xc2, 0x77, 0x7a, 0x66, 0x2b, 0x69, 0x50, 0xdb, 0x60, 0x85, 0x28, 0x68, 0x27, 0x37, 0xc0, 0x9f,
+	0x8b, 0xa9, 0x76, 0xb5, 0xa1, 0xca, 0x18, 0xcb, 0x9f, 0x60, 0x78, 0x96, 0x56, 0xca, 0x78, 0x8c,
+	0xb1, 0xb9, 0xd0, 0x5c, 0x95, 0xf9, 0x13, 0x16, 0x9a, 0xab, 0xc8, 0x41, 0xf5, 0x5f, 0xe7, 0xa1,
+	0x2e, 0xfa, 0x4d, 0xcc, 0x0a, 0xe3, 0xec, 0xb9, 0x57, 0x79, 0x28, 0x85, 0xd7, 0xef, 0x52, 0x97,
+	0x9b, 0x99, 0xb4, 0xc2, 0x80, 0x7f, 0x20, 0x24, 0x06, 0xe1, 0x14, 0x61, 0x91, 0xea, 0xfa, 0xe2,
+	0x09, 0x76, 0x7d, 0xe9, 0x48, 0x5d, 0x5f, 0x3e, 0x89, 0xae, 0x7f, 0x27, 0x0f, 0xb5, 0x55, 0x73,
+	0x9b, 0xb6, 0xf6, 0x5b, 0x16, 0x3f, 0xfd, 0xd9, 0xa6, 0x16, 0xf5, 0xe9, 0x75, 0xd7, 0x68, 0xd1,
+	0x75, 0xea, 0x9a, 0x4e, 0x5b, 0xfe, 0x3f, 0xf8, 0x0c, 0x24, 0x4f, 0x7f, 0x2e, 0x0d, 0xe1, 0xc1,
+	0xa1, 0xb5, 0xc9, 0x0a, 0x4c, 0xb4, 0xa9, 0x67, 0xba, 0xb4, 0xbd, 0x1e, 0xd9, 0xa8, 0x7c, 0x5c,
+	0x2d, 0x35, 0x4b, 0x11, 0xda, 0x83, 0x83, 0xd9, 0x49, 0x65, 0xa0, 0xe4, 0x05, 0x18, 0xab, 0xca,
+	0xfe, 0xf2, 0x3d, 0xa3, 0xef, 0xa5, 0xb5, 0x31, 0xf2, 0x97, 0x5f, 0x4f, 0x67, 0xc1, 0x61, 0x75,
+	0xf5, 0x12, 0xb0, 0x14, 0x30, 0xfa, 0xbb, 0x05, 0x08, 0xb2, 0x67, 0x91, 0xbf, 0x9e, 0x83, 0xba,
+	0x61, 0xdb, 0x8e, 0x2f, 0x33, 0x53, 0x09, 0x0f, 0x3c, 0x66, 0x4e, 0xd2, 0x35, 0xb7, 0x10, 0x82,
+	0x0a, 0xe7, 0x6d, 0xe0, 0x50, 0x8e, 0x50, 0x30, 0x2a, 0x9b, 0x85, 0xc0, 0xc7, 0xfc, 0xc9, 0x6b,
+	0xd9, 0x5b, 0x71, 0x04, 0xef, 0xf1, 0x85, 0xcf, 0xc1, 0x4c, 0xb2, 0xb1, 0xc7, 0x71, 0x07, 0x65,
+	0x72, 0xcc, 0xe7, 0x01, 0xc2, 0x98, 0x92, 0xc7, 0x60, 0xc4, 0x32, 0x63, 0x46, 0xac, 0xd1, 0x53,
+	0x18, 0x84, 0x8d, 0x1e, 0x6a, 0xb8, 0x7a, 0x33, 0x61, 0xb8, 0x5a, 0x19, 0x87, 0xb0, 0x87, 0x1b,
+	0xab, 0xfe, 0x49, 0x0e, 0x66, 0x42, 0x66, 0x79, 0x16, 0xfa, 0x65, 0x98, 0x74, 0xa9, 0xd1, 0x6e,
+	0x18, 0x7e, 0x6b, 0x87, 0x87, 0x7a, 0xe7, 0x78, 0x6c, 0x36, 0x3f, 0xfd, 0x85, 0x51, 0x02, 0xc6,
+	0xf9, 0x98, 0x41, 0x93, 0x15, 0x6c, 0x98, 0x5d, 0xea, 0xf4, 0xfd, 0x11, 0xad, 0xa6, 0x7c, 0xc3,
+	0x82, 0x21, 0x0c, 0x46, 0x31, 0xf5, 0xf7, 0x72, 0x30, 0x15, 0x6d, 0xf0, 0x89, 0x5b, 0xd4, 0x76,
+	0xe2, 0x16, 0xb5, 0xc5, 0x31, 0x7c, 0x93, 0x21, 0x56, 0xb4, 0xb7, 0x21, 0xfa, 0x6a, 0xdc, 0x72,
+	0x16, 0x35, 0x16, 0xe4, 0x1e, 0x6a, 0x2c, 0xf8, 0xe0, 0x27, 0x4c, 0x1a, 0xa6, 0xe5, 0x16, 0x9f,
+	0x60, 0x2d, 0xf7, 0xfd, 0xcc, 0xba, 0x14, 0xc9, 0x1c, 0x54, 0xce, 0x90, 0x39, 0xa8, 0x1b, 0x64,
+	0x0e, 0xaa, 0x8c, 0x6d, 0xd2, 0x39, 0x4a, 0xf6, 0xa0, 0xea, 0x63, 0xcd, 0x1e, 0x54, 0x3b, 0xa9,
+	0xec, 0x41, 0x90, 0x35, 0x7b, 0xd0, 0xdb, 0x39, 0x98, 0x6a, 0xc7, 0x4e, 0xcc, 0x6a, 0xf5, 0x8c,
+	0x4b, 0x4d, 0xfc, 0x00, 0xae, 0x38, 0x32, 0x15, 0x2f, 0xc3, 0x84, 0xc8, 0xb4, 0x9c, 0x3d, 0x13,
+	0xef, 0x4f, 0xce, 0x9e, 0x5f, 0x55, 0xa2, 0x2b, 0xd2, 0xe3, 0x36, 0x9a, 0xbf, 0x14, 0x37, 0x9a,
+	0x5f, 0x4e, 0x1a, 0xcd, 0xa7, 0xc3, 0xa6, 0xc5, 0x0c, 0xe7, 0x7f, 0x2e, 0x32, 0x51, 0x17, 0x78,
+	0xb6, 0x9e, 0xe0, 0x9b, 0xa7, 0x4c, 0xd6, 0x0b, 0x30, 0x2d, 0xb5, 0x57, 0x45, 0xe4, 0xb3, 0xdc,
+	0x64, 0x18, 0xe6, 0xb4, 0x14, 0x27, 0x63, 0x92, 0x9f, 0x09, 0xf4, 0x54, 0xd2, 0x56, 0xb1, 0x55,
+	0x08, 0x07, 0x99, 0x2c, 0xc7, 0x80, 0x83, 0x6d, 0x2b, 0x5c, 0x6a, 0x78, 0xd2, 0xf4, 0x1d, 0xd9,
+	0x56, 0x20, 0x2f, 0x45, 0x49, 0x8d, 0xda, 0xff, 0x2b, 0x8f, 0xb0, 0xff, 0x1b, 0x50, 0xb7, 0x0c,
+	0xcf, 0x17, 0x5f, 0xb3, 0x2d, 0xff, 0xce, 0x7f, 0xe6, 0x68, 0x0b, 0x2f, 0x5b, 0xcc, 0x43, 0xed,
+	0x76, 0x35, 0x84, 0xc1, 0x28, 0x26, 0xf3, 0xc2, 0xb2, 0x47, 0xfe, 0xd7, 0x6e, 0x2f, 0xf8, 0x5a,
+	0xed, 0xd8, 0x32, 0x02, 0xb3, 0xd5, 0x6a, 0x04, 0x07, 0x63, 0xa8, 0x43, 0x5c, 0x04, 0x30, 0x8a,
+	0x8b, 0x80, 0x85, 0x48, 0x32, 0x65, 0x65, 0x3f, 0xf8, 0xac, 0x75, 0xfe, 0x59, 0x83, 0x10, 0x49,
+	0x8c, 0x12, 0x31, 0xce, 0xcb, 0x46, 0x45, 0x5f, 0x76, 0x83, 0xaa, 0x3e, 0x11, 0x1f, 0x15, 0x9b,
+	0x71, 0x32, 0x26, 0xf9, 0x59, 0xcc, 0x5a, 0x50, 0x14, 0x6d, 0xc6, 0x24, 0xc7, 0x09, 0x62, 0xd6,
+	0x36, 0x53, 0x78, 0x30, 0xb5, 0x26, 0x3f, 0x04, 0xd2, 0x77, 0x5d, 0x6a, 0xfb, 0x37, 0x0c, 0x6f,
+	0x47, 0x06, 0xbf, 0x85, 0x87, 0x40, 0x42, 0x12, 0x46, 0xf9, 0x98, 0x2d, 0x50, 0xc0, 0xf1, 0x5a,
+	0xd3, 0xf1, 0xf8, 0xd2, 0xcd, 0x80, 0x82, 0x11, 0x2e, 0xfd, 0xed, 0x1a, 0xd4, 0x6f, 0x1b, 0xbe,
+	0xb9, 0x47, 0xb9, 0x3f, 0xef, 0x64, 0x9c, 0x2a, 0x7f, 0x3f, 0x07, 0xe7, 0xe2, 0x41, 0x9b, 0x27,
+	0xe8, 0x59, 0xe1, 0xc9, 0x7e, 0x30, 0x55, 0x1a, 0x0e, 0x69, 0x05, 0xf7, 0xb1, 0x0c, 0xc4, 0x80,
+	0x9e, 0xb4, 0x8f, 0xa5, 0x39, 0x4c, 0x20, 0x0e, 0x6f, 0xcb, 0x07, 0xc5, 0xc7, 0xf2, 0x64, 0x67,
+	0xa7, 0x4c, 0x78, 0x80, 0x2a, 0x4f, 0x8c, 0x07, 0xa8, 0xfa, 0x44, 0xa8, 0xdd, 0xbd, 0x88, 0x07,
+	0xa8, 0x96, 0x31, 0x12, 0x49, 0x9e, 0x73, 0x10, 0x68, 0xc3, 0x3c, 0x49, 0x3c, 0x45, 0x81, 0xb2,
+	0xcc, 0x33, 0x6d, 0x75, 0xcb, 0xf0, 0xcc, 0x96, 0x96, 0xcb, 0x9a, 0x8d, 0x57, 0x65, 0xe9, 0x13,
+	0x01, 0x0b, 0xfc, 0x11, 0x05, 0x76, 0x98, 0x94, 0x30, 0x9f, 0x29, 0x29, 0x21, 0xcb, 0xff, 0x67,
+	0x33, 0x3b, 0x4a, 0xe1, 0xd8, 0xf9, 0xff, 0x6e, 0xdf, 0xa2, 0xfb, 0xc8, 0x2b, 0x33, 0x73, 0x35,
+	0xb0, 0xd7, 0x3f, 0x9a, 0x2f, 0x86, 0x85, 0x6f, 0xf5, 0xb9, 0xd5, 0x44, 0xcb, 0xc7, 0xa7, 0xe8,
+	0xa6, 0x28, 0x46, 0x45, 0x67, 0x86, 0xd9, 0x37, 0xfb, 0xb4, 0xaf, 0x02, 0x0b, 0x02, 0xc5, 0xfd,
+	0x0b, 0xac, 0x10, 0x05, 0xed, 0xe4, 0xec, 0xaa, 0xca, 0x67, 0x53, 0x3a, 0x29, 0x9f, 0x4d, 0x0d,
+	0x2a, 0xb7, 0x1d, 0x1e, 0x0d, 0xaa, 0xff, 0xbd, 0x02, 0x40, 0x18, 0x6d, 0x47, 0xbe, 0x97, 0x83,
+	0xb3, 0xc1, 0x1f, 0xce, 0x17, 0xfb, 0x2f, 0x9e, 0x00, 0x3b, 0xb3, 0xff, 0x26, 0xed, 0xcf, 0xce,
+	0x67, 0xa0, 0xf5, 0x34, 0x71, 0x98, 0xde, 0x0a, 0x82, 0x50, 0xa5, 0xdd, 0x9e, 0xbf, 0xbf, 0x64,
+	0xba, 0x5a, 0x7e, 0x78, 0x50, 0xe7, 0x35, 0xc9, 0x23, 0xaa, 0x4a, 0x23, 0x01, 0xff, 0x13, 0x29,
+	0x0a, 0x06, 0x38, 0x64, 0x07, 0xaa, 0xb6, 0xf3, 0x3a, 0x8b, 0x2c, 0x54, 0xcb, 0xea, 0xe7, 0x47,
+	0xef, 0x72, 0xd1, 0xad, 0xc2, 0xde, 0x2f, 0x1f, 0xb0, 0x62, 0x8b, 0x1f, 0xe4, 0x93, 0x32, 0x20,
+	0x24, 0x7e, 0x6a, 0x56, 0x05, 0x84, 0x4c, 0xc8, 0xef, 0x40, 0xc3, 0x90, 0x10, 0xfd, 0xbb, 0x79,
+	0x38, 0x9d, 0xd2, 0x73, 0x2c, 0x77, 0xbc, 0x0c, 0x85, 0x0c, 0x73, 0xc7, 0xe7, 0xc2, 0xdc, 0xf1,
+	0xcd, 0x04, 0x0d, 0x07, 0xb8, 0xc9, 0xeb, 0x00, 0x46, 0xab, 0x45, 0x3d, 0x6f, 0xcd, 0x69, 0xab,
+	0x1d, 0xc4, 0xab, 0x4c, 0xe1, 0x59, 0x08, 0x4a, 0x1f, 0x1c, 0xcc, 0x7e, 0x22, 0x2d, 0xba, 0x39,
+	0xf1, 0x65, 0xc2, 0x0a, 0x18, 0x81, 0x24, 0x5f, 0x03, 0x10, 0xdb, 0xf6, 0x20, 0x01, 0xc3, 0x23,
+	0x6c, 0x5d, 0x73, 0x2a, 0xbf, 0xd7, 0xdc, 0x17, 0xfa, 0x86, 0xed, 0xb3, 0x34, 0xfc, 0x3c, 0xdf,
+	0xcd, 0xdd, 0x00, 0x05, 0x23, 0x88, 0xfa, 0xbf, 0xcf, 0x43, 0x55, 0x59, 0xd9, 0x1f, 0x83, 0x69,
+	0xb5, 0x13, 0x33, 0xad, 0x8e, 0x29, 0x9e, 0x39, 0xcd, 0xb0, 0xea, 0x24, 0x0c, 0xab, 0xd7, 0xb3,
+	0x8b, 0x7a, 0xb8, 0x59, 0xf5, 0x87, 0x79, 0x98, 0x52, 0xac, 0x59, 0x8d, 0xaa, 0x9f, 0x85, 0x69,
+	0x11, 0x87, 0xb0, 0x66, 0xdc, 0x17, 0xa9, 0x7f, 0x78, 0x87, 0x15, 0x45, 0x08, 0x71, 0x23, 0x4e,
+	0xc2, 0x24, 0x2f, 0x1b, 0xd6, 0xa2, 0x68, 0x93, 0x6d, 0xdb, 0x84, 0xe7, 0x52, 0xec, 0x50, 0xf9,
+	0xb0, 0x6e, 0x24, 0x68, 0x38, 0xc0, 0x9d, 0xb4, 0xea, 0x16, 0x4f, 0xc0, 0xaa, 0xfb, 0x3f, 0x73,
+	0x30, 0x11, 0xf6, 0xd7, 0x89, 0xdb, 0x74, 0xb7, 0xe3, 0x36, 0xdd, 0x85, 0xcc, 0xc3, 0x61, 0x88,
+	0x45, 0xf7, 0x6f, 0x55, 0x20, 0x16, 0x56, 0xcf, 0xce, 0x89, 0x9b, 0xa9, 0xc1, 0x81, 0x91, 0xd9,
+	0x26, 0x38, 0x27, 0xbe, 0x32, 0x94, 0x13, 0x1f, 0x82, 0x42, 0xfa, 0x50, 0xdd, 0xa3, 0xae, 0x6f,
+	0xb6, 0xa8, 0x7a, 0xbf, 0xeb, 0x99, 0x95, 0x38, 0x69, 0xb7, 0x0e, 0xfa, 0xf4, 0xae, 0x14, 0x80,
+	0x81, 0x28, 0xb2, 0x05, 0x25, 0xda, 0xee, 0x50, 0x95, 0x8c, 0x29, 0x63, 0x52, 0xdb, 0xa0, 0x3f,
+	0xd9, 0x93, 0x87, 0x02, 0x9a, 0x78, 0x50, 0xb3, 0x94, 0x5f, 0x52, 0x2b, 0x66, 0x54, 0xc9, 0x02,
+	0x0f, 0x67, 0x98, 0xa7, 0x21, 0x28, 0xc2, 0x50, 0x0e, 0xd9, 0x0d, 0x0c, 0xa4, 0xa5, 0x31, 0x4d,
+	0x1e, 0x0f, 0x31, 0x8f, 0x7a, 0x50, 0xbb, 0x67, 0xf8, 0xd4, 0xed, 0x1a, 0xee, 0xae, 0x56, 0xce,
+	0xf8, 0x86, 0xaf, 0x29, 0xa4, 0xf0, 0x0d, 0x83, 0x22, 0x0c, 0xe5, 0xb0, 0x7b, 0x27, 0x7c, 0xa9,
+	0x70, 0x2b, 0x2b, 0xf0, 0xe8, 0x42, 0x95, 0xea, 0xee, 0xc9, 0xf0, 0x7a, 0xf5, 0x88, 0xa1, 0x0c,
+	0xb2, 0x17, 0xcb, 0x80, 0x2e, 0xf2, 0xde, 0x37, 0x32, 0x78, 0x13, 0x24, 0x54, 0xb8, 0xdc, 0xa4,
+	0x67, 0x52, 0xd7, 0x7f, 0xaf, 0x14, 0x4e, 0xcb, 0x8f, 0xdb, 0xb2, 0xf8, 0x42, 0xdc, 0xb2, 0x78,
+	0x29, 0x69, 0x59, 0x4c, 0xb8, 0xb7, 0x8f, 0x1f, 0x90, 0x9b, 0x30, 0xc8, 0x15, 0x4f, 0xc0, 0x20,
+	0xf7, 0x3c, 0xd4, 0xf7, 0xf8, 0x4c, 0x20, 0x32, 0x3b, 0x95, 0xf8, 0x32, 0xc2, 0x67, 0xf6, 0xbb,
+	0x61, 0x31, 0x46, 0x79, 0x58, 0x15, 0x79, 0xe7, 0x4b, 0x90, 0x04, 0x59, 0x56, 0x69, 0x86, 0xc5,
+	0x18, 0xe5, 0xe1, 0xb1, 0x7c, 0xa6, 0xbd, 0x2b, 0x2a, 0x54, 0x78, 0x05, 0x11, 0xcb, 0xa7, 0x0a,
+	0x31, 0xa4, 0x33, 0xcb, 0x4f, 0xbf, 0xbd, 0x2d, 0x78, 0xab, 0x9c, 0x97, 0xeb, 0xa4, 0x9b, 0x4b,
+	0xcb, 0x82, 0x35, 0xa0, 0xb2, 0x96, 0x74, 0x8d, 0x9e, 0x22, 0x68, 0xb5, 0xb0, 0x25, 0x6b, 0x61,
+	0x31, 0x46, 0x79, 0xc8, 0x67, 0x58, 0x0a, 0xcf, 0x76, 0xbf, 0x45, 0x83, 0x5a, 0xc0, 0x6b, 0xc9,
+	0x14, 0x9c, 0x51, 0x0a, 0x26, 0x38, 0x87, 0x98, 0x15, 0xeb, 0x23, 0x99, 0x15, 0x3f, 0x07, 0x53,
+	0x6d, 0xd7, 0x30, 0x6d, 0xda, 0xbe, 0x63, 0xf3, 0x18, 0x06, 0x19, 0x51, 0x18, 0xd8, 0xd4, 0x97,
+	0x62, 0x54, 0x4c, 0x70, 0xeb, 0xff, 0x39, 0x0f, 0x25, 0x91, 0x18, 0x74, 0x05, 0x4e, 0x33, 0x3b,
+	0x84, 0x69, 0x58, 0x4b, 0xd4, 0x32, 0xf6, 0xe3, 0x71, 0x1c, 0x4f, 0xb3, 0xad, 0xf9, 0xca, 0x20,
+	0x19, 0xd3, 0xea, 0xb0, 0xce, 0xf1, 0xc5, 0xf2, 0xad, 0x50, 0x84, 0xe5, 0x4d, 0x64, 0x93, 0x8e,
+	0x51, 0x30, 0xc1, 0xc9, 0x94, 0xa1, 0x5e, 0x4a, 0x90, 0x06, 0x57, 0x86, 0xe2, 0xa1, 0x19, 0x71,
+	0x3e, 0xae, 0xa4, 0xf7, 0xb9, 0x42, 0x1c, 0x9c, 0xdb, 0x91, 0x71, 0x58, 0x42, 0x49, 0x4f, 0xd0,
+	0x70, 0x80, 0x9b, 0x21, 0x6c, 0x1b, 0xa6, 0xd5, 0x77, 0x69, 0x88, 0x50, 0x0a, 0x11, 0x96, 0x13,
+	0x34, 0x1c, 0xe0, 0xd6, 0x7f, 0x27, 0x07, 0x64, 0xf0, 0x24, 0x02, 0xd9, 0x81, 0xb2, 0xcd, 0xad,
+	0x97, 0x99, 0x93, 0xd8, 0x47, 0x8c, 0xa0, 0x62, 0x91, 0x90, 0x05, 0x12, 0x9f, 0xd8, 0x50, 0xa5,
+	0xf7, 0x7d, 0xea, 0xda, 0xc1, 0xc9, 0xa4, 0xf1, 0x24, 0xcc, 0x17, 0xbb, 0x39, 0x89, 0x8c, 0x81,
+	0x0c, 0xfd, 0x97, 0x79, 0xa8, 0x47, 0xf8, 0x1e, 0x65, 0x14, 0xe0, 0xc9, 0x11, 0x84, 0xd1, 0x70,
+	0xd3, 0xb5, 0xe4, 0x7c, 0x17, 0x49, 0x8e, 0x20, 0x49, 0xb8, 0x8a, 0x51, 0x3e, 0x66, 0x32, 0xee,
+	0x1a, 0x9e, 0x4f, 0x5d, 0xae, 0x0b, 0x25, 0x52, 0x12, 0xac, 0x05, 0x14, 0x8c, 0x70, 0xb1, 0x14,
+	0x92, 0xfc, 0xca, 0x83, 0x62, 0x3c, 0x85, 0xe4, 0x90, 0xfb, 0x0c, 0x4a, 0x63, 0xb8, 0xcf, 0x80,
+	0x74, 0x60, 0x46, 0xb5, 0x5a, 0x51, 0x8f, 0x97, 0x60, 0x50, 0x0c, 0xd4, 0x04, 0x04, 0x0e, 0x80,
+	0xea, 0x3f, 0xca, 0xc1, 0x64, 0xcc, 0x64, 0x45, 0x3e, 0x1a, 0x3d, 0x47, 0x13, 0x4b, 0xfe, 0x18,
+	0x39, 0xfe, 0xf2, 0x1c, 0x94, 0x45, 0x07, 0x25, 0xc3, 0x63, 0x45, 0x17, 0xa2, 0xa4, 0xb2, 0x95,
+	0x45, 0x1a, 0xc5, 0x93, 0x2b, 0x8b, 0xb4, 0x9a, 0xa3, 0xa2, 0x0b, 0x5f, 0x93, 0x68, 0x9d, 0xec,
+	0xe9, 0x88, 0xaf, 0x49, 0x94, 0x63, 0xc0, 0xa1, 0xff, 0x6b, 0xde, 0x6e, 0xdf, 0xdd, 0x0f, 0x76,
+	0xd6, 0x1d, 0xa8, 0xc8, 0x90, 0x48, 0x2d, 0x97, 0xd1, 0x18, 0x20, 0x03, 0x2d, 0x65, 0xf0, 0x9f,
+	0xd1, 0xda, 0xbd, 0xb3, 0xbd, 0x8d, 0x0a, 0x9d, 0x5c, 0x83, 0x9a, 0x63, 0xcb, 0x7f, 0xb0, 0x96,
+	0x0f, 0x52, 0xb6, 0xd6, 0xee, 0xa8, 0xc2, 0x07, 0x07, 0xb3, 0xe7, 0x82, 0x87, 0x58, 0x23, 0x31,
+	0xac, 0xa9, 0xff, 0xb5, 0x1c, 0x9c, 0x45, 0xc7, 0xb2, 0x4c, 0xbb, 0x13, 0x77, 0x56, 0x12, 0x0b,
+	0xa6, 0xba, 0xc6, 0xfd, 0x4d, 0xdb, 0xd8, 0x33, 0x4c, 0x8b, 0x45, 0x30, 0x3f, 0x72, 0x67, 0xdc,
+	0xf7, 0x4d, 0x6b, 0x4e, 0x5c, 0x01, 0xc9, 0xce, 0x54, 0xdd, 0x71, 0x9b, 0xbe, 0x6b, 0xda, 0x1d,
+	0x31, 0x4b, 0xae, 0xc5, 0xb0, 0x30, 0x81, 0xad, 0xff, 0xaa, 0x00, 0x3c, 0x2c, 0x8f, 0xbc, 0x0c,
+	0xb5, 0x2e, 0x6d, 0xed, 0x18, 0xb6, 0xe9, 0xa9, 0x34, 0xba, 0xcc, 0xce, 0x53, 0x5b, 0x53, 0x85,
+	0x0f, 0xd8, 0xa7, 0x58, 0x68, 0xae, 0x72, 0x33, 0x47, 0xc8, 0xcb, 0xa2, 0x42, 0x3a, 0x9e, 0x67,
+	0xf4, 0xcc, 0xcc, 0x51, 0x21, 0x22, 0x6d, 0xa9, 0x98, 0x8e, 0xc4, 0x6f, 0x94, 0xd0, 0xcc, 0x48,
+	0xda, 0xb3, 0x0c, 0xd3, 0xce, 0x7c, 0x65, 0x19, 0x7b, 0x83, 0x75, 0x86, 0x24, 0x8c, 0x9b, 0xfc,
+	0x27, 0x0a, 0x6c, 0xd2, 0x87, 0xba, 0xd7, 0x72, 0x8d, 0xae, 0xb7, 0x63, 0x5c, 0x7d, 0xf1, 0x25,
+	0xad, 0x38, 0x36, 0x51, 0x42, 0x17, 0x59, 0xc4, 0x85, 0xb5, 0xe6, 0x8d, 0x85, 0xab, 0x2f, 0xbe,
+	0x84, 0x51, 0x39, 0x51, 0xb1, 0x2f, 0x3e, 0x7f, 0x55, 0x2b, 0x9d, 0x8c, 0xd8, 0x17, 0x9f, 0xbf,
+	0x8a, 0x51, 0x39, 0xfa, 0xef, 0xe7, 0xa0, 0x16, 0xf0, 0x92, 0x4d, 0x00, 0x36, 0x97, 0xc9, 0x44,
+	0xa3, 0xc7, 0xba, 0xde, 0x85, 0x5b, 0x7b, 0x36, 0x83, 0xca, 0x18, 0x01, 0x4a, 0xc9, 0xc4, 0x9a,
+	0x1f, 0x77, 0x26, 0xd6, 0x79, 0xa8, 0xed, 0x18, 0x76, 0xdb, 0xdb, 0x31, 0x76, 0xc5, 0x94, 0x1e,
+	0xc9, 0x4d, 0x7c, 0x43, 0x11, 0x30, 0xe4, 0xd1, 0xff, 0x6d, 0x19, 0x44, 0x28, 0x07, 0x9b, 0x74,
+	0xda, 0xa6, 0x27, 0xce, 0x12, 0xe4, 0x78, 0xcd, 0x60, 0xd2, 0x59, 0x92, 0xe5, 0x18, 0x70, 0xb0,
+	0x64, 0xa8, 0x5d, 0xd3, 0x96, 0x1a, 0x08, 0x37, 0xfd, 0xae, 0x99, 0x36, 0xb2, 0x32, 0x4e, 0x32,
+	0xee, 0x6b, 0x85, 0x08, 0xc9, 0xb8, 0x8f, 0xac, 0x8c, 0x99, 0x56, 0x2c, 0xc7, 0xd9, 0x65, 0xd3,
+	0x87, 0x52, 0x44, 0x84, 0x1f, 0x9e, 0x9b, 0x56, 0x56, 0xe3, 0x24, 0x4c, 0xf2, 0xb2, 0xa0, 0xd3,
+	0xb7, 0xa8, 0xeb, 0xc8, 0xf9, 0xb2, 0x69, 0x51, 0xda, 0x53, 0x30, 0x42, 0x35, 0xe6, 0x41, 0xa7,
+	0x5f, 0x4e, 0x67, 0xc1, 0x61, 0x75, 0x19, 0xac, 0x6f, 0xb8, 0x1d, 0xea, 0xaf, 0xbb, 0x0e, 0xd3,
+	0x5d, 0x58, 0x4a, 0x00, 0x09, 0x5b, 0x0e, 0x61, 0x37, 0xd2, 0x59, 0x70, 0x58, 0x5d, 0x16, 0xc7,
+	0x2b, 0x48, 0x42, 0x6d, 0x59, 0x10, 0xd3, 0x8c, 0x69, 0xa9, 0x9b, 0x3e, 0x27, 0x85, 0x87, 0x6d,
+	0x63, 0x08, 0x0f, 0x0e, 0xad, 0x4d, 0x6e, 0xc2, 0x8c, 0xf2, 0xaf, 0xae, 0x53, 0xb7, 0x19, 0x84,
+	0xf7, 0x4c, 0x36, 0x2e, 0x31, 0x3b, 0xc6, 0x12, 0xed, 0xb9, 0xb4, 0x15, 0xf5, 0x53, 0x2b, 0x2e,
+	0x1c, 0xa8, 0xc7, 0xee, 0x4f, 0xe1, 0x31, 0x3c, 0x9b, 0xbd, 0x45, 0xc7, 0xb1, 0xda, 0xce, 0x3d,
+	0x5b, 0xbd, 0xbb, 0x50, 0xd8, 0xb9, 0x4b, 0xb5, 0x99, 0xca, 0x81, 0x43, 0x6a, 0xb2, 0x37, 0xe7,
+	0x94, 0x25, 0xe7, 0x9e, 0x9d, 0x44, 0x85, 0xf0, 0xcd, 0x9b, 0x43, 0x78, 0x70, 0x68, 0x6d, 0xb2,
+	0x0c, 0x24, 0xf9, 0x06, 0x9b, 0x3d, 0xe9, 0xf4, 0x3f, 0x27, 0x72, 0x06, 0x25, 0xa9, 0x98, 0x52,
+	0x83, 0xac, 0xc2, 0x99, 0x64, 0x29, 0x13, 0x27, 0xfd, 0xff, 0x3c, 0x5b, 0x30, 0xa6, 0xd0, 0x31,
+	0xb5, 0x96, 0xfe, 0xef, 0xf2, 0x30, 0x19, 0x4b, 0x32, 0xf1, 0xc4, 0x1d, 0xe6, 0x67, 0x9b, 0x87,
+	0xae, 0xd7, 0x59, 0x59, 0xba, 0x41, 0x8d, 0x36, 0x75, 0x6f, 0x51, 0x95, 0x10, 0x44, 0x2c, 0x8b,
+	0x31, 0x0a, 0x26, 0x38, 0x99, 0xf5, 0x4e, 0x78, 0x16, 0xb2, 0x5e, 0x14, 0xa4, 0xfa, 0x88, 0x81,
+	0xa9, 0xdb, 0xb5, 0x98, 0x73, 0x41, 0xc0, 0xeb, 0x3e, 0x4c, 0x44, 0x39, 0xc8, 0xf9, 0xa8, 0xda,
+	0x5b, 0x89, 0xa9, 0xbc, 0x2b, 0x50, 0xf0, 0xfd, 0x51, 0xd3, 0x04, 0x08, 0x4f, 0xd5, 0xc6, 0x2a,
+	0x32, 0x0c, 0x7d, 0x9b, 0x7d, 0x3b, 0x8f, 0x45, 0x35, 0xc8, 0x9c, 0xf1, 0x9b, 0x50, 0x91, 0xbb,
+	0xa7, 0x11, 0xd3, 0x1c, 0x70, 0x5d, 0x49, 0x99, 0x5d, 0x15, 0x96, 0xfe, 0xbf, 0xf2, 0x50, 0x0b,
+	0xcc, 0x24, 0x47, 0xc8, 0xc5, 0xee, 0x40, 0x2d, 0x88, 0x41, 0xcc, 0x7c, 0x0b, 0x6a, 0x18, 0x1a,
+	0xc7, 0x77, 0xf6, 0xc1, 0x23, 0x86, 0x32, 0xa2, 0xf1, 0x8d, 0x85, 0x0c, 0xf1, 0x8d, 0x3d, 0xa8,
+	0xf8, 0xae, 0xd9, 0xe9, 0xc8, 0x5d, 0x42, 0x96, 0x00, 0xc7, 0xa0, 0xbb, 0x36, 0x04, 0xa0, 0xec,
+	0x59, 0xf1, 0x80, 0x4a, 0x8c, 0xfe, 0x06, 0xcc, 0x24, 0x39, 0xb9, 0x0a, 0xdd, 0xda, 0xa1, 0xed,
+	0xbe, 0xa5, 0xfa, 0x38, 0x54, 0xa1, 0x65, 0x39, 0x06, 0x1c, 0xcc, 0xa8, 0xc1, 0x3e, 0xd3, 0x5b,
+	0x8e, 0xad, 0xd4, 0x58, 0xbe, 0x1b, 0xd9, 0x90, 0x65, 0x18, 0x50, 0xf5, 0xff, 0x5f, 0x80, 0xf3,
+	0x81, 0x30, 0x6f, 0xcd, 0xb0, 0x8d, 0xce, 0x11, 0xae, 0xbe, 0xfc, 0xf0, 0xe0, 0xd8, 0x71, 0x2f,
+	0xd4, 0x28, 0x3c, 0x01, 0x17, 0x6a, 0xfc, 0x61, 0x1e, 0x78, 0xbc, 0x34, 0xcb, 0x07, 0x64, 0x44,
+	0x6e, 0x3d, 0xd6, 0x72, 0x19, 0xe7, 0xc1, 0xe8, 0x15, 0xca, 0x61, 0xc8, 0x5c, 0xb4, 0x14, 0x63,
+	0x02, 0x89, 0x03, 0xd5, 0x6d, 0xc3, 0xb2, 0x98, 0x2e, 0x94, 0xd9, 0x79, 0x17, 0x13, 0xce, 0x87,
+	0xf9, 0xb2, 0x84, 0xc6, 0x40, 0x08, 0x0b, 0x92, 0x9d, 0x74, 0xa3, 0xdb, 0x35, 0xad, 0x90, 0x71,
+	0x25, 0x8b, 0x6d, 0xfe, 0xa2, 0x01, 0x7a, 0x91, 0x62, 0x8c, 0xcb, 0xd4, 0xff, 0x5f, 0x0e, 0x26,
+	0x9b, 0x96, 0xd9, 0x36, 0xed, 0xce, 0x09, 0xde, 0xe7, 0x71, 0x07, 0x4a, 0x9e, 0x65, 0xb6, 0xe9,
+	0x88, 0xab, 0x89, 0x58, 0xc7, 0x18, 0x00, 0x0a, 0x9c, 0xf8, 0x05, 0x21, 0x85, 0x23, 0x5c, 0x10,
+	0xf2, 0x9b, 0x32, 0xc8, 0xc8, 0x7f, 0x76, 0x63, 0x62, 0x47, 0xdd, 0x3b, 0xa0, 0xe5, 0x32, 0xde,
+	0x98, 0x98, 0xb8, 0xc1, 0x40, 0xcc, 0xfd, 0x41, 0x21, 0x86, 0x92, 0xd8, 0x7d, 0x90, 0xd1, 0xeb,
+	0xb6, 0x97, 0x32, 0x5e, 0xb7, 0x2d, 0xc4, 0x0d, 0x5e, 0xb8, 0x6d, 0x40, 0x71, 0xc7, 0xf7, 0x7b,
+	0x72, 0x30, 0x8d, 0x7e, 0xb4, 0x23, 0x4c, 0x9b, 0x24, 0x74, 0x22, 0xf6, 0x8c, 0x1c, 0x9a, 0x89,
+	0xb0, 0x8d, 0xe0, 0x52, 0xc3, 0xc5, 0x4c, 0x81, 0x27, 0x51, 0x11, 0xec, 0x19, 0x39, 0x34, 0xf9,
+	0x3a, 0xd4, 0x7d, 0xd7, 0xb0, 0xbd, 0x6d, 0xc7, 0xed, 0x52, 0x57, 0x2b, 0x65, 0xfc, 0x67, 0x6c,
+	0x2e, 0x6d, 0x84, 0x68, 0xc2, 0x24, 0x1b, 0x2b, 0xc2, 0xa8, 0x34, 0xb2, 0xcb, 0xec, 0xef, 0xa2,
+	0x61, 0xd2, 0x0c, 0xb6, 0x90, 0x41, 0x72, 0x34, 0xac, 0x44, 0x3d, 0x61, 0x20, 0x20, 0x7e, 0x7f,
+	0x67, 0x65, 0x5c, 0xf7, 0x77, 0x46, 0x47, 0x63, 0x5a, 0x4e, 0x17, 0xd2, 0x95, 0x7a, 0xad, 0xdd,
+	0xd1, 0xaa, 0x19, 0x3b, 0x37, 0xa6, 0x96, 0xcb, 0x44, 0x5c, 0xa2, 0x08, 0x95, 0x0c, 0xbd, 0x0b,
+	0xd2, 0x77, 0x44, 0x5a, 0xb1, 0xbb, 0x8f, 0xc4, 0x41, 0xc3, 0xf9, 0xa3, 0xcd, 0x07, 0xc1, 0x25,
+	0x3c, 0x91, 0xdc, 0xeb, 0xa9, 0x97, 0x1c, 0xe9, 0xff, 0x3b, 0x0f, 0x2c, 0x4e, 0x4a, 0xe4, 0x53,
+	0xe5, 0xb7, 0xa9, 0xd1, 0xe6, 0xae, 0xd9, 0xbb, 0x4b, 0x5d, 0x73, 0x7b, 0x5f, 0x6e, 0xbd, 0x23,
+	0xf9, 0x54, 0x93, 0x1c, 0x98, 0x52, 0x8b, 0xdd, 0xc0, 0xd2, 0x32, 0x16, 0xa9, 0xeb, 0x8f, 0x62,
+	0x58, 0xe0, 0x27, 0xaa, 0x17, 0x17, 0xc2, 0xea, 0x18, 0x03, 0x63, 0xe6, 0x90, 0x56, 0x08, 0x5d,
+	0x38, 0xb6, 0x39, 0x24, 0x02, 0x1c, 0x01, 0x22, 0x08, 0xb5, 0x5d, 0xba, 0x2f, 0x1e, 0xb4, 0xe2,
+	0x71, 0x50, 0xf9, 0xc8, 0xb9, 0xa5, 0xea, 0x62, 0x08, 0xa3, 0xdb, 0x30, 0x19, 0xbb, 0x10, 0x89,
+	0x7c, 0x1a, 0xaa, 0x4e, 0x2f, 0x32, 0x9d, 0xd6, 0x78, 0xfc, 0x6d, 0xf5, 0x8e, 0x2c, 0x63, 0x7e,
+	0xc0, 0x55, 0xa7, 0x63, 0xb6, 0x54, 0x01, 0x06, 0xec, 0x44, 0x87, 0x32, 0x3f, 0x06, 0xa9, 0xae,
+	0x43, 0xe2, 0x6b, 0x07, 0xbf, 0xb1, 0xc4, 0x43, 0x49, 0xd1, 0xbf, 0x59, 0x84, 0xd0, 0xe3, 0x4a,
+	0x3c, 0x28, 0x8b, 0x63, 0x1e, 0x5a, 0x2e, 0xa3, 0xe7, 0xfa, 0x08, 0x27, 0x4a, 0xa4, 0x28, 0xd2,
+	0x81, 0xc2, 0x1b, 0xce, 0x56, 0xe6, 0x89, 0x3b, 0x92, 0xff, 0x40, 0xd8, 0xca, 0x22, 0x05, 0xc8,
+	0x24, 0x90, 0x7f, 0x90, 0x83, 0x53, 0x5e, 0x52, 0xf5, 0x95, 0xc3, 0x01, 0xb3, 0xeb, 0xf8, 0x49,
+	0x65, 0x5a, 0x06, 0x4a, 0x0f, 0x23, 0xe3, 0x60, 0x5b, 0x58, 0xff, 0x0b, 0x57, 0xa8, 0x56, 0xcc,
+	0xd8, 0xff, 0xf2, 0x82, 0xd9, 0x58, 0xff, 0xc7, 0xcb, 0x50, 0x8a, 0xd2, 0xbf, 0x9d, 0x87, 0x7a,
+	0x64, 0xb6, 0xce, 0x7c, 0xcb, 0xd6, 0xfd, 0xc4, 0x2d, 0x5b, 0xeb, 0xa3, 0x47, 0x06, 0x84, 0xad,
+	0x3a, 0xe9, 0x8b, 0xb6, 0xfe, 0x63, 0x1e, 0x0a, 0x9b, 0x4b, 0xcb, 0xf1, 0x4d, 0x6b, 0xee, 0x31,
+	0x6c, 0x5a, 0x77, 0xa0, 0xb2, 0xd5, 0x37, 0x2d, 0xdf, 0xb4, 0x33, 0x67, 0x68, 0x51, 0x97, 0x92,
+	0x49, 0x5f, 0x87, 0x40, 0x45, 0x05, 0xcf, 0x9c, 0x2a, 0x1d, 0x91, 0x22, 0x33, 0x73, 0x84, 0xa5,
+	0x4c, 0xb5, 0x29, 0x04, 0xc9, 0x07, 0x54, 0xe8, 0xfa, 0x3e, 0x94, 0x37, 0x97, 0xa4, 0xda, 0xff,
+	0x78, 0x7b, 0x53, 0xff, 0x3a, 0x04, 0x5a, 0xc0, 0xe3, 0x17, 0xfe, 0xf3, 0x1c, 0xc4, 0x15, 0x9f,
+	0xc7, 0x3f, 0x9a, 0x76, 0x93, 0xa3, 0x69, 0x69, 0x1c, 0x7f, 0xbe, 0xf4, 0x01, 0xa5, 0xff, 0x8f,
+	0x1c, 0x24, 0xce, 0xe6, 0x91, 0x97, 0x64, 0x70, 0x6d, 0x3c, 0x30, 0x4d, 0x05, 0xd7, 0x92, 0x38,
+	0x77, 0x24, 0xeb, 0xda, 0x3b, 0x6c, 0xbb, 0x16, 0x75, 0xa0, 0xc9, 0xe6, 0xdf, 0x1e, 0x7d, 0xbb,
+	0x96, 0xe6, 0x8e, 0x93, 0xc1, 0x93, 0x51, 0x12, 0xc6, 0xe5, 0xea, 0xff, 0x26, 0x0f, 0xe5, 0xc7,
+	0x96, 0x2a, 0x80, 0xc6, 0xe2, 0x59, 0x17, 0x33, 0xce, 0xf6, 0x43, 0xa3, 0x59, 0xbb, 0x89, 0x68,
+	0xd6, 0xac, 0xb7, 0x80, 0x3f, 0x22, 0x96, 0xf5, 0xbf, 0xe5, 0x40, 0xae, 0x35, 0x2b, 0xb6, 0xe7,
+	0x1b, 0xec, 0xcc, 0x48, 0x2b, 0x58, 0xd8, 0xb2, 0x06, 0x4d, 0x09, 0x60, 0xa9, 0xcb, 0xf0, 0xdf,
+	0x6a, 0x21, 0x63, 0x26, 0xb3, 0x1d, 0xc7, 0xf3, 0xf9, 0xe2, 0x95, 0x8f, 0x9b, 0xcc, 0x6e, 0xc8,
+	0x72, 0x0c, 0x38, 0x92, 0xee, 0xec, 0xd2, 0x70, 0x77, 0xb6, 0xfe, 0x83, 0x3c, 0x4c, 0x7c, 0x50,
+	0xf2, 0x1d, 0xa4, 0x45, 0xff, 0x16, 0x32, 0x46, 0xff, 0x16, 0x8f, 0x13, 0xfd, 0xab, 0xff, 0x34,
+	0x07, 0xf0, 0xd8, 0x92, 0x2d, 0xb4, 0xe3, 0x81, 0xb9, 0x99, 0xc7, 0x55, 0x7a, 0x58, 0xee, 0xbf,
+	0x2c, 0xa9, 0x57, 0xe2, 0x41, 0xb9, 0xec, 0xec, 0xb3, 0x11, 0x0b, 0x74, 0xcd, 0xac, 0x2f, 0x27,
+	0xe2, 0x66, 0x83, 0x38, 0xad, 0x78, 0x39, 0x26, 0xc4, 0xb2, 0xdc, 0x6b, 0x2a, 0xef, 0xf6, 0xed,
+	0x70, 0xd8, 0x0f, 0x64, 0xe8, 0x66, 0x34, 0x8c, 0x71, 0x3e, 0x22, 0xb0, 0xb8, 0x30, 0x96, 0xc0,
+	0xe2, 0xe8, 0x21, 0xcb, 0xe2, 0x43, 0x0f, 0x59, 0xee, 0x41, 0x8d, 0xdd, 0xeb, 0xcb, 0x63, 0x77,
+	0xe5, 0x55, 0xda, 0xd7, 0x32, 0x2c, 0x94, 0xdd, 0x2d, 0x16, 0xcc, 0xc6, 0xd0, 0x42, 0xc3, 0xd5,
+	0xb2, 0xc2, 0xc7, 0x50, 0x14, 0xb7, 0xf5, 0x3b, 0x42, 0x6a, 0x79, 0x9c, 0x52, 0x83, 0xb9, 0x64,
+	0x43, 0xa0, 0xa3, 0x12, 0x13, 0x8f, 0xd7, 0xad, 0x3c, 0x9e, 0x78, 0x5d, 0xfd, 0x6f, 0x56, 0xd4,
+	0x04, 0xf6, 0xc4, 0xe5, 0x94, 0xfd, 0xf0, 0x68, 0x7c, 0x87, 0x0e, 0x9c, 0x5b, 0xaf, 0x3e, 0xc6,
+	0x73, 0xeb, 0xb5, 0xf1, 0x9c, 0x5b, 0x87, 0x6c, 0xe7, 0xd6, 0xeb, 0x63, 0x3a, 0xb7, 0x3e, 0x31,
+	0xae, 0x73, 0xeb, 0x93, 0x23, 0x9d, 0x5b, 0x9f, 0x3a, 0xd2, 0xb9, 0xf5, 0x83, 0x02, 0x24, 0x36,
+	0xe3, 0x1f, 0x3a, 0xde, 0xfe, 0x58, 0x39, 0xde, 0xde, 0xcd, 0x43, 0x38, 0x11, 0x1f, 0x33, 0x30,
+	0xe9, 0x8b, 0x50, 0xed, 0x1a, 0xf7, 0x79, 0xe0, 0x74, 0x96, 0xab, 0x98, 0xd7, 0x24, 0x06, 0x06,
+	0x68, 0xc4, 0x03, 0x30, 0x83, 0xeb, 0x10, 0x32, 0xbb, 0x30, 0xc2, 0x9b, 0x15, 0x84, 0x91, 0x34,
+	0x7c, 0xc6, 0x88, 0x18, 0xfd, 0xbf, 0xe6, 0x41, 0xde, 0x9b, 0xc1, 0x7c, 0x34, 0xdb, 0xe6, 0x7d,
+	0xd9, 0x09, 0x59, 0xb6, 0xa6, 0x91, 0x0b, 0xf2, 0x85, 0x8f, 0x86, 0x17, 0xa0, 0x40, 0xe7, 0xc6,
+	0x77, 0xe1, 0x73, 0xd3, 0xf2, 0x59, 0x8d, 0xef, 0x51, 0xdf, 0x9d, 0x34, 0xbe, 0x8b, 0x22, 0x54,
+	0x32, 0xb8, 0x38, 0x11, 0x7e, 0x91, 0xd9, 0xc5, 0x18, 0x0b, 0xe3, 0x90, 0xe2, 0x44, 0x11, 0x2a,
+	0x19, 0x8d, 0xaf, 0x7e, 0xf9, 0xe5, 0x10, 0x7e, 0x5e, 0xc1, 0xcf, 0x2b, 0xb0, 0xf9, 0xde, 0x6e,
+	0x87, 0x1d, 0x14, 0xf5, 0xc2, 0x12, 0x05, 0xff, 0x93, 0x9f, 0x5d, 0x7a, 0xea, 0xa7, 0x3f, 0xbb,
+	0xf4, 0xd4, 0x7b, 0x3f, 0xbb, 0xf4, 0xd4, 0x37, 0x0f, 0x2f, 0xe5, 0x7e, 0x72, 0x78, 0x29, 0xf7,
+	0xd3, 0xc3, 0x4b, 0xb9, 0xf7, 0x0e, 0x2f, 0xe5, 0xfe, 0xcf, 0xe1, 0xa5, 0xdc, 0xdf, 0xf9, 0xbf,
+	0x97, 0x9e, 0xfa, 0xa3, 0x01, 0x00, 0x0d, 0xde, 0xea, 0xdd, 0x44, 0x9e, 0x00, 0x00,
 }
 
 func (m *AbstractPodTemplate) Marshal() (dAtA []byte, err error) {
@@ -7020,6 +7017,11 @@ func (m *PBQStorage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i -= len(m.Type)
+	copy(dAtA[i:], m.Type)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Type)))
+	i--
+	dAtA[i] = 0x22
 	if m.NoStore != nil {
 		{
 			size, err := m.NoStore.MarshalToSizedBuffer(dAtA[:i])
@@ -10886,6 +10888,8 @@ func (m *PBQStorage) Size() (n int) {
 		l = m.NoStore.Size()
 		n += 1 + l + sovGenerated(uint64(l))
 	}
+	l = len(m.Type)
+	n += 1 + l + sovGenerated(uint64(l))
 	return n
 }
 
@@ -12747,6 +12751,7 @@ func (this *PBQStorage) String() string {
 		`PersistentVolumeClaim:` + strings.Replace(this.PersistentVolumeClaim.String(), "PersistenceStrategy", "PersistenceStrategy", 1) + `,`,
 		`EmptyDir:` + strings.Replace(fmt.Sprintf("%v", this.EmptyDir), "EmptyDirVolumeSource", "v1.EmptyDirVolumeSource", 1) + `,`,
 		`NoStore:` + strings.Replace(this.NoStore.String(), "NoStore", "NoStore", 1) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -25390,6 +25395,38 @@ func (m *PBQStorage) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = PBQStoreType(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
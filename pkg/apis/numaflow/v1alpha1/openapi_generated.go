@@ -3819,6 +3819,13 @@ func schema_pkg_apis_numaflow_v1alpha1_PBQStorage(ref common.ReferenceCallback)
 							Ref: ref("github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1.NoStore"),
 						},
 					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the name of the store type which persists the PBQs, e.g. boltdb, redis, s3 or jetstream, it takes precedence over the persistent volume claim and the empty dir. The store type has to be registered with the WAL registry of the reduce vertex, and is only supported by the aligned windows.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
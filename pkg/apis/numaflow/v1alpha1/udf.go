@@ -180,8 +180,34 @@ type PBQStorage struct {
 	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty" protobuf:"bytes,2,opt,name=emptyDir"`
 	// +optional
 	NoStore *NoStore `json:"no_store,omitempty" protobuf:"bytes,3,opt,name=no_store"`
+	// Type is the name of the store type which persists the PBQs, e.g. boltdb, redis, s3 or jetstream, it takes
+	// precedence over the persistent volume claim and the empty dir. The store type has to be registered with the WAL
+	// registry of the reduce vertex, and is only supported by the aligned windows.
+	// +optional
+	Type PBQStoreType `json:"type,omitempty" protobuf:"bytes,4,opt,name=type,casttype=PBQStoreType"`
 }
 
+// PBQStoreType is the name of a store type which persists the PBQs of a reduce vertex.
+type PBQStoreType string
+
+const (
+	// FSType persists the PBQs in the segment files on the volume of the vertex.
+	FSType PBQStoreType = "fs"
+	// MemoryType keeps the PBQs in memory, they are lost when the pod restarts.
+	MemoryType PBQStoreType = "memory"
+	// BoltDBType persists the PBQs in a BoltDB file on the volume of the vertex.
+	BoltDBType PBQStoreType = "boltdb"
+	// RedisType persists the PBQs in the Redis of the inter-step buffer service, the keys are namespaced by the
+	// pipeline, the vertex and the replica.
+	RedisType PBQStoreType = "redis"
+	// S3Type persists the PBQs in an S3 compatible object store, the bucket, the region and the endpoint are read from
+	// the NUMAFLOW_PBQ_S3_BUCKET, NUMAFLOW_PBQ_S3_REGION and NUMAFLOW_PBQ_S3_ENDPOINT env of the vertex.
+	S3Type PBQStoreType = "s3"
+	// JetStreamType persists the PBQs in the JetStream of the inter-step buffer service, every vertex replica has a
+	// stream of its own.
+	JetStreamType PBQStoreType = "jetstream"
+)
+
 // NoStore means there will be no persistence storage and there will be data loss during pod restarts.
 // Use this option only if you do not care about correctness (e.g., approx statistics pipeline like sampling rate, etc.).
 type NoStore struct{}
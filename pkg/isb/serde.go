@@ -9,6 +9,12 @@ import (
 
 // MarshalBinary encodes Message to proto bytes.
 func (m Message) MarshalBinary() ([]byte, error) {
+	return m.MarshalAppend(nil)
+}
+
+// MarshalAppend appends the proto bytes of the Message to b and returns the extended slice, so that the caller can
+// encode into a reused buffer.
+func (m Message) MarshalAppend(b []byte) ([]byte, error) {
 	pb := &isb.Message{
 		Header: &isb.Header{
 			MessageInfo: &isb.MessageInfo{
@@ -28,7 +34,7 @@ func (m Message) MarshalBinary() ([]byte, error) {
 			Payload: m.Body.Payload,
 		},
 	}
-	return proto.Marshal(pb)
+	return proto.MarshalOptions{}.MarshalAppend(b, pb)
 }
 
 // UnmarshalBinary decodes Message from the proto bytes.
@@ -320,7 +320,7 @@ func validateUDF(udf dfv1.UDF) error {
 		if storage == nil {
 			return fmt.Errorf(`invalid "groupBy", "storage" is missing`)
 		}
-		if storage.PersistentVolumeClaim == nil && storage.EmptyDir == nil && storage.NoStore == nil {
+		if storage.PersistentVolumeClaim == nil && storage.EmptyDir == nil && storage.NoStore == nil && storage.Type == "" {
 			return fmt.Errorf(`invalid "groupBy.storage", type of storage to use is missing`)
 		}
 		if storage.PersistentVolumeClaim != nil && storage.EmptyDir != nil {
@@ -332,6 +332,12 @@ func validateUDF(udf dfv1.UDF) error {
 		if storage.EmptyDir != nil && storage.NoStore != nil {
 			return fmt.Errorf(`invalid "groupBy.storage", either none or emptyDir is allowed, not both`)
 		}
+		if storage.Type != "" && storage.NoStore != nil {
+			return fmt.Errorf(`invalid "groupBy.storage", either none or type is allowed, not both`)
+		}
+		if storage.Type != "" && ss != nil {
+			return fmt.Errorf(`invalid "groupBy.storage", type is not supported by the session windows`)
+		}
 	}
 	return nil
 }
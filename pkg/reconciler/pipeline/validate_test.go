@@ -590,6 +590,23 @@ func TestValidateReducePipeline(t *testing.T) {
 		assert.Contains(t, err.Error(), `either emptyDir or persistentVolumeClaim is allowed, not both`)
 	})
 
+	t.Run("store type", func(t *testing.T) {
+		testObj := testReducePipeline.DeepCopy()
+		testObj.Spec.Vertices[1].UDF.GroupBy.Storage = &dfv1.PBQStorage{Type: dfv1.BoltDBType}
+		assert.NoError(t, ValidatePipeline(testObj))
+
+		testObj.Spec.Vertices[1].UDF.GroupBy.Storage.NoStore = &dfv1.NoStore{}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `either none or type is allowed, not both`)
+
+		testObj.Spec.Vertices[1].UDF.GroupBy.Storage.NoStore = nil
+		testObj.Spec.Vertices[1].UDF.GroupBy.Window = dfv1.Window{Session: &dfv1.SessionWindow{Timeout: &metav1.Duration{Duration: time.Minute}}}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `type is not supported by the session windows`)
+	})
+
 }
 
 func TestValidateVertex(t *testing.T) {
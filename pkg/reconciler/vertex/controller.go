@@ -533,8 +533,8 @@ func (r *vertexReconciler) buildPodSpec(vertex *dfv1.Vertex, pl *dfv1.Pipeline,
 	podSpec.Volumes = append(podSpec.Volumes, vols...)
 	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volMounts...)
 
-	if vertex.IsReduceUDF() && vertex.Spec.UDF.GroupBy.Storage.NoStore == nil {
-		// Add pvc for reduce vertex pods
+	if vertex.IsReduceUDF() && (vertex.Spec.UDF.GroupBy.Storage.PersistentVolumeClaim != nil || vertex.Spec.UDF.GroupBy.Storage.EmptyDir != nil) {
+		// Add pvc or emptyDir for reduce vertex pods
 		storage := vertex.Spec.UDF.GroupBy.Storage
 		volName := "pbq-vol"
 		if storage.PersistentVolumeClaim != nil {
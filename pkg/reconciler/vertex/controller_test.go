@@ -427,6 +427,27 @@ func Test_BuildPodSpec(t *testing.T) {
 		}
 		assert.True(t, containsPVCMount)
 	})
+
+	t.Run("test reduce udf with store type", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		r := fakeReconciler(t, cl)
+		testObj := testVertex.DeepCopy()
+		testObj.Spec.UDF = &dfv1.UDF{
+			Container: &dfv1.Container{
+				Image: "my-image",
+			},
+			GroupBy: &dfv1.GroupBy{
+				Storage: &dfv1.PBQStorage{
+					Type: dfv1.BoltDBType,
+				},
+			},
+		}
+		spec, err := r.buildPodSpec(testObj, testPipeline, fakeIsbSvcConfig, 2)
+		assert.NoError(t, err)
+		for _, m := range spec.Containers[0].VolumeMounts {
+			assert.NotEqual(t, dfv1.PathPBQMount, m.MountPath)
+		}
+	})
 }
 
 func Test_reconcile(t *testing.T) {
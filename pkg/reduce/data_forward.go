@@ -29,6 +29,7 @@ package reduce
 
 import (
 	"context"
+	"errors"
 	"math"
 	"strconv"
 	"sync"
@@ -187,7 +188,7 @@ func (df *DataForward) replayForUnalignedWindows(ctx context.Context, discovered
 		df.log.Infow("Replaying messages from partition: ", zap.String("partitionID", sr.PartitionID().String()))
 		func(ctx context.Context, s wal.WAL) {
 			eg.Go(func() error {
-				readCh, errCh := s.Replay()
+				readCh, errCh := df.replay(ctx, s)
 				for {
 					select {
 					case <-ctx.Done():
@@ -224,8 +225,10 @@ func (df *DataForward) replayForAlignedWindows(ctx context.Context, discoveredWA
 	// create a window for each partition and insert it to the windower,
 	// so that the window can be closed when the watermark crosses the window.
 	// then we can replay the messages from each WAL in parallel.
-	for _, s := range discoveredWALs {
-		p := s.PartitionID()
+	partitionIDs := make([]*partition.ID, len(discoveredWALs))
+	for i, s := range discoveredWALs {
+		p := df.replayPartitionID(s)
+		partitionIDs[i] = p
 
 		df.windower.InsertWindow(window.NewAlignedTimedWindow(p.Start, p.End, p.Slot))
 
@@ -236,15 +239,17 @@ func (df *DataForward) replayForAlignedWindows(ctx context.Context, discoveredWA
 		}
 	}
 	eg := errgroup.Group{}
+	if df.opts.replayConcurrency > 0 {
+		eg.SetLimit(df.opts.replayConcurrency)
+	}
 	df.log.Infow("Number of partitions to replay: ", zap.Int("count", len(discoveredWALs)))
 
-	// replay the messages from each WALs in parallel
-	for _, sr := range discoveredWALs {
-		df.log.Infow("Replaying messages from partition: ", zap.String("partitionID", sr.PartitionID().String()))
-		func(ctx context.Context, s wal.WAL) {
+	// replay the messages from each WALs in parallel, up to the replay concurrency at a time
+	for i, sr := range discoveredWALs {
+		df.log.Infow("Replaying messages from partition: ", zap.String("partitionID", partitionIDs[i].String()))
+		func(ctx context.Context, s wal.WAL, pid *partition.ID) {
 			eg.Go(func() error {
-				pid := s.PartitionID()
-				readCh, errCh := s.Replay()
+				readCh, errCh := df.replay(ctx, s)
 				for {
 					select {
 					case <-ctx.Done():
@@ -275,11 +280,56 @@ func (df *DataForward) replayForAlignedWindows(ctx context.Context, discoveredWA
 					}
 				}
 			})
-		}(ctx, sr)
+		}(ctx, sr, partitionIDs[i])
 	}
 	return eg.Wait()
 }
 
+// replay replays the WAL, iterating over it if streaming replay is enabled or reading it in batches if a replay batch
+// size is configured, reading ahead of the writes to the PBQ if a prefetch depth is configured, checking the order of
+// the event times if the order check is enabled, and skipping the expired messages if a message TTL is configured.
+func (df *DataForward) replay(ctx context.Context, s wal.WAL) (<-chan *isb.ReadMessage, <-chan error) {
+	var readCh <-chan *isb.ReadMessage
+	var errCh <-chan error
+	switch {
+	case df.opts.streamingReplay:
+		readCh, errCh = wal.ReplayWithIterator(ctx, s)
+	case df.opts.replayBatchSize > 0:
+		readCh, errCh = wal.ReplayInBatches(ctx, s, df.opts.replayBatchSize)
+	default:
+		readCh, errCh = s.Replay()
+	}
+	if df.opts.replayPrefetchDepth > 0 {
+		readCh, errCh = wal.Prefetch(ctx, readCh, errCh, df.opts.replayPrefetchDepth)
+	}
+	if df.opts.replayOrderCheck {
+		readCh, errCh = wal.CheckReplayOrder(ctx, readCh, errCh)
+	}
+	if df.opts.messageTTL > 0 {
+		return wal.SkipExpired(ctx, readCh, errCh, df.opts.messageTTL)
+	}
+	return readCh, errCh
+}
+
+// replayPartitionID returns the partition of a discovered WAL. The persisted metadata of the partition is preferred,
+// since it records the window the partition was created for, the partition ID of the WAL is used if the WAL has no
+// metadata.
+func (df *DataForward) replayPartitionID(s wal.WAL) *partition.ID {
+	metadataStore, ok := s.(wal.MetadataStore)
+	if !ok {
+		return s.PartitionID()
+	}
+	metadata, err := metadataStore.LoadMetadata()
+	if err != nil {
+		if !errors.Is(err, wal.ErrMetadataNotFound) {
+			df.log.Warnw("Failed to load the partition metadata, using the partition ID of the WAL", zap.String("partitionID", s.PartitionID().String()), zap.Error(err))
+		}
+		return s.PartitionID()
+	}
+	df.log.Infow("Loaded the partition metadata", zap.String("partitionID", metadata.PartitionID.String()), zap.Strings("keys", metadata.Keys))
+	return &metadata.PartitionID
+}
+
 // forwardAChunk reads a chunk of messages from isb and assigns watermark to messages
 // and writes the windowRequests to pbq
 func (df *DataForward) forwardAChunk(ctx context.Context) {
@@ -397,10 +447,10 @@ func (df *DataForward) forwardAChunk(ctx context.Context) {
 // it to the partition.
 func (df *DataForward) associatePBQAndPnF(ctx context.Context, partitionID *partition.ID) (pbq.ReadWriteCloser, error) {
 	// look for existing pbq
-	q := df.pbqManager.GetPBQ(*partitionID)
+	q, ok := df.pbqManager.GetPBQ(*partitionID)
 
 	// if we do not have already created PBQ, we have to create a new one.
-	if q == nil {
+	if !ok {
 		var pbqErr error
 		var infiniteBackoff = wait.Backoff{
 			Steps:    math.MaxInt,
@@ -411,7 +461,7 @@ func (df *DataForward) associatePBQAndPnF(ctx context.Context, partitionID *part
 		// we manage ctx ourselves
 		pbqErr = wait.ExponentialBackoff(infiniteBackoff, func() (done bool, err error) {
 			var attempt int
-			q, pbqErr = df.pbqManager.CreateNewPBQ(ctx, *partitionID)
+			q, _, pbqErr = df.pbqManager.CreatePBQIfAbsent(ctx, *partitionID)
 			if pbqErr != nil {
 				attempt += 1
 				df.log.Warnw("Failed to create pbq, retrying", zap.Any("attempt", attempt), zap.String("partitionID", partitionID.String()), zap.Error(pbqErr))
@@ -555,7 +605,8 @@ func (df *DataForward) writeMessagesToWindows(ctx context.Context, messages []*i
 
 			err = df.writeToPBQ(ctx, winOp, true)
 			// there is no point continuing because we are seeing an error.
-			// this error will ONLY BE set if we are in an error loop and ctx.Done() has been invoked.
+			// this error will ONLY BE set if we are in an error loop and ctx.Done() has been invoked, or if the pbq
+			// of the partition can no longer be written by this replica.
 			if err != nil {
 				df.log.Errorw("Failed to write message, asked to stop trying", zap.Any("msgOffSet", message.ReadOffset.String()), zap.String("partitionID", winOp.ID.String()), zap.Error(err))
 				failed = true
@@ -633,7 +684,9 @@ func (df *DataForward) handleOnTimeMessage(message *isb.ReadMessage) []*window.T
 }
 
 // writeToPBQ writes to the PBQ. It will return error only if it is not failing to write to PBQ and is in a continuous
-// error loop, and we have received ctx.Done() via SIGTERM.
+// error loop, and we have received ctx.Done() via SIGTERM. A request which the PBQ rejects for good, see
+// isRejectedPBQWrite, is dropped instead of retried, and the error of a pbq which this replica can no longer write, see
+// isTerminalPBQWrite, is returned.
 func (df *DataForward) writeToPBQ(ctx context.Context, winOp *window.TimedWindowRequest, persist bool) error {
 	defer func(t time.Time) {
 		metrics.PBQWriteTime.With(map[string]string{
@@ -664,6 +717,21 @@ func (df *DataForward) writeToPBQ(ctx context.Context, winOp *window.TimedWindow
 				metrics.LabelPipeline:           df.pipelineName,
 				metrics.LabelVertexReplicaIndex: strconv.Itoa(int(df.vertexReplica)),
 			}).Inc()
+			if isRejectedPBQWrite(rErr) {
+				// another attempt would be rejected as well, the request is dropped so that the message is acked
+				// instead of blocking the reads from the ISB forever
+				metrics.ReduceDroppedMessagesCount.With(map[string]string{
+					metrics.LabelVertex:             df.vertexName,
+					metrics.LabelPipeline:           df.pipelineName,
+					metrics.LabelVertexReplicaIndex: strconv.Itoa(int(df.vertexReplica)),
+					metrics.LabelReason:             "pbq_rejected"}).Inc()
+				return true, nil
+			}
+			if isTerminalPBQWrite(rErr) {
+				// the pbq can no longer be written by this replica, the message is not acked so that it is redelivered,
+				// and written to a new pbq if the partition is created again
+				return false, rErr
+			}
 			// no point retrying if ctx.Done has been invoked
 			select {
 			case <-ctx.Done():
@@ -687,6 +755,27 @@ func (df *DataForward) writeToPBQ(ctx context.Context, winOp *window.TimedWindow
 	return err
 }
 
+// isRejectedPBQWrite returns whether the PBQ rejected the write for good, i.e. the book of the partition is closed, in
+// this run or before a restart, or the request carries no message, so the request can never be written.
+func isRejectedPBQWrite(err error) bool {
+	var (
+		cobErr        pbq.COBErr
+		nilMessageErr pbq.NilMessageErr
+	)
+	return errors.As(err, &cobErr) || errors.As(err, &nilMessageErr)
+}
+
+// isTerminalPBQWrite returns whether the pbq of the write can no longer be written by this replica, i.e. the lease of
+// the partition is lost to another replica, or the pbq is garbage collected, so retrying the write with the same pbq
+// cannot succeed.
+func isTerminalPBQWrite(err error) bool {
+	var (
+		leaseLostErr       wal.LeaseLostErr
+		stateTransitionErr pbq.StateTransitionErr
+	)
+	return errors.As(err, &leaseLostErr) || errors.As(err, &stateTransitionErr)
+}
+
 // ackMessages acks messages. Retries until it can succeed or ctx.Done() happens.
 func (df *DataForward) ackMessages(ctx context.Context, messages []*isb.ReadMessage) {
 	var ackBackoff = wait.Backoff{
@@ -769,5 +858,7 @@ func (df *DataForward) ShutDown(ctx context.Context) {
 	}
 
 	// flush pending messages to persistent storage
-	df.pbqManager.ShutDown(ctx)
+	if err := df.pbqManager.ShutDown(ctx); err != nil {
+		df.log.Errorw("Failed to close all the pbqs", zap.Error(err))
+	}
 }
@@ -18,8 +18,10 @@ package reduce
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"runtime"
 	"strconv"
 	"sync"
 	"testing"
@@ -32,10 +34,13 @@ import (
 	"github.com/numaproj/numaflow/pkg/forwarder"
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isb/stores/simplebuffer"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/fs"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
 	"github.com/numaproj/numaflow/pkg/reduce/pnf"
 	"github.com/numaproj/numaflow/pkg/shared/kvs"
 	"github.com/numaproj/numaflow/pkg/watermark/entity"
@@ -1430,6 +1435,315 @@ func TestDataForward_WithContextClose(t *testing.T) {
 
 }
 
+// newWriteTestDataForward returns a DataForward which is not started, over a pbq manager of the store manager, to
+// test the writes to the PBQ.
+func newWriteTestDataForward(ctx context.Context, t *testing.T, storeManager wal.Manager, opts ...pbq.PBQOption) (*DataForward, *pbq.Manager) {
+	fromBuffer := simplebuffer.NewInMemoryBuffer("source-reduce-buffer", 100, 0)
+	toBuffer := map[string][]isb.BufferWriter{
+		"reduce-to-vertex": {simplebuffer.NewInMemoryBuffer("reduce-to-vertex", 10, 0)},
+	}
+	opts = append([]pbq.PBQOption{pbq.WithReadTimeout(1 * time.Second), pbq.WithChannelBufferSize(10)}, opts...)
+	pbqManager, err := pbq.NewManager(ctx, "reduce", pipelineName, 0, storeManager, window.Aligned, opts...)
+	assert.NoError(t, err)
+
+	f, _ := fetcherAndPublisher(ctx, fromBuffer, t.Name())
+	publishersMap, _ := buildPublisherMapAndOTStore(ctx, toBuffer)
+	t.Cleanup(func() {
+		for _, p := range publishersMap {
+			_ = p.Close()
+		}
+	})
+	windower := fixed.NewWindower(5*time.Minute, keyedVertex)
+	idleManager, err := wmb.NewIdleManager(1, len(toBuffer))
+	assert.NoError(t, err)
+	op := pnf.NewProcessAndForward(ctx, keyedVertex, SumReduceTest{}, toBuffer, pbqManager, CounterReduceTest{}, publishersMap, idleManager, windower)
+	reduceDataForward, err := NewDataForward(ctx, keyedVertex, fromBuffer, toBuffer, pbqManager, storeManager, CounterReduceTest{}, f, publishersMap,
+		windower, idleManager, op)
+	assert.NoError(t, err)
+	return reduceDataForward, pbqManager
+}
+
+func TestDataForward_WriteToPBQRejected(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	reduceDataForward, pbqManager := newWriteTestDataForward(ctx, t, memory.NewMemManager(memory.WithStoreSize(100)))
+
+	// the book of the partition is closed, so the write can never succeed
+	partitionID := partition.ID{Start: time.UnixMilli(0), End: time.UnixMilli(300000), Slot: "slot-0"}
+	q, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q.CloseOfBook()
+
+	requests := testutils.BuildTestWindowRequests(1, time.UnixMilli(0), window.Append)
+	requests[0].ID = &partitionID
+	done := make(chan error)
+	go func() {
+		done <- reduceDataForward.writeToPBQ(ctx, &requests[0], true)
+	}()
+	select {
+	case err := <-done:
+		// the request is dropped instead of retried
+		assert.NoError(t, err)
+	case <-ctx.Done():
+		assert.Fail(t, "the rejected write is retried")
+	}
+
+	// a request without a message is rejected by any pbq
+	openPartitionID := partition.ID{Start: time.UnixMilli(300000), End: time.UnixMilli(600000), Slot: "slot-0"}
+	go func() {
+		done <- reduceDataForward.writeToPBQ(ctx, &window.TimedWindowRequest{Operation: window.Append, ID: &openPartitionID}, true)
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-ctx.Done():
+		assert.Fail(t, "the write without a message is retried")
+	}
+}
+
+func TestDataForward_WriteToPBQLeaseLost(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	storeManager := fake.NewManager()
+	reduceDataForward, pbqManager := newWriteTestDataForward(ctx, t, storeManager, pbq.WithLease(time.Second, 10*time.Millisecond))
+
+	partitionID := partition.ID{Start: time.UnixMilli(0), End: time.UnixMilli(300000), Slot: "slot-0"}
+	q, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	requests := testutils.BuildTestWindowRequests(2, time.UnixMilli(0), window.Append)
+	requests[0].ID, requests[1].ID = &partitionID, &partitionID
+
+	// the lease is taken over by another replica, the writes of this replica are refused from then on
+	storeManager.RevokeLease(partitionID)
+	assert.Eventually(t, func() bool {
+		return errors.As(q.Write(ctx, &requests[0], true), &wal.LeaseLostErr{})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	done := make(chan error)
+	go func() {
+		done <- reduceDataForward.writeToPBQ(ctx, &requests[1], true)
+	}()
+	select {
+	case err := <-done:
+		// the error is returned instead of retried, so the message is not acked
+		assert.ErrorAs(t, err, &wal.LeaseLostErr{})
+	case <-ctx.Done():
+		assert.Fail(t, "the write of a lost partition is retried")
+	}
+}
+
+// replayAll returns the messages and the error of the replay of the WAL by the DataForward.
+func replayAll(t *testing.T, df *DataForward, s wal.WAL) ([]*isb.ReadMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var replayed []*isb.ReadMessage
+	readCh, errCh := df.replay(ctx, s)
+	for {
+		select {
+		case <-ctx.Done():
+			assert.Fail(t, "the replay did not finish")
+			return replayed, ctx.Err()
+		case err := <-errCh:
+			if err != nil {
+				return replayed, err
+			}
+		case msg, ok := <-readCh:
+			if !ok {
+				return replayed, nil
+			}
+			replayed = append(replayed, msg)
+		}
+	}
+}
+
+func TestDataForward_ReplayOrderCheck(t *testing.T) {
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	// the store has a message whose event time is before the one of the message written before it
+	messages := testutils.BuildTestReadMessages(4, time.Unix(60, 0), nil)
+	messages[2].EventTime = time.Unix(30, 0)
+	s := fake.NewWAL(partitionID)
+	for i := range messages {
+		assert.NoError(t, s.Write(context.Background(), &messages[i]))
+	}
+
+	opts := DefaultOptions()
+	assert.NoError(t, WithReplayOrderCheck()(opts))
+	_, err := replayAll(t, &DataForward{opts: opts}, s)
+	assert.ErrorAs(t, err, &wal.ReplayOrderErr{})
+
+	replayed, err := replayAll(t, &DataForward{opts: DefaultOptions()}, s)
+	assert.NoError(t, err)
+	assert.Len(t, replayed, len(messages))
+}
+
+// replayTracker is a wal.Manager whose discovered WALs record the max number of replays in progress at the same time.
+type replayTracker struct {
+	wal.Manager
+	active    int
+	maxActive int
+	mu        sync.Mutex
+}
+
+func (r *replayTracker) DiscoverWALs(ctx context.Context) ([]wal.WAL, error) {
+	discovered, err := r.Manager.DiscoverWALs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tracked := make([]wal.WAL, len(discovered))
+	for i, w := range discovered {
+		tracked[i] = &trackedWAL{WAL: w, tracker: r}
+	}
+	return tracked, nil
+}
+
+type trackedWAL struct {
+	wal.WAL
+	tracker *replayTracker
+}
+
+func (t *trackedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	t.tracker.mu.Lock()
+	t.tracker.active++
+	t.tracker.maxActive = max(t.tracker.maxActive, t.tracker.active)
+	t.tracker.mu.Unlock()
+
+	msgCh, errCh := t.WAL.Replay()
+	tracked := make(chan *isb.ReadMessage)
+	go func() {
+		defer close(tracked)
+		// the replay takes a while, so that the replays of the partitions overlap
+		time.Sleep(20 * time.Millisecond)
+		for msg := range msgCh {
+			tracked <- msg
+		}
+		t.tracker.mu.Lock()
+		t.tracker.active--
+		t.tracker.mu.Unlock()
+	}()
+	return tracked, errCh
+}
+
+func TestDataForward_ReplayConcurrency(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	storeManager := memory.NewMemManager(memory.WithStoreSize(100))
+	partitionCount, msgCount := 6, 5
+
+	// the messages persisted before the restart
+	for i := 0; i < partitionCount; i++ {
+		start := time.Unix(int64(i*300), 0)
+		s, err := storeManager.CreateWAL(ctx, partition.ID{Start: start, End: start.Add(5 * time.Minute), Slot: "slot-0"})
+		assert.NoError(t, err)
+		messages := testutils.BuildTestReadMessages(int64(msgCount), start, []string{"key"})
+		for j := range messages {
+			assert.NoError(t, s.Write(ctx, &messages[j]))
+		}
+	}
+
+	tracker := &replayTracker{Manager: storeManager}
+	df, _ := newWriteTestDataForward(ctx, t, tracker)
+	assert.NoError(t, WithReplayConcurrency(2)(df.opts))
+	assert.NoError(t, df.ReplayPersistedMessages(ctx))
+	assert.Equal(t, 2, tracker.maxActive)
+
+	assert.Error(t, WithReplayConcurrency(0)(DefaultOptions()))
+}
+
+func TestDataForward_ReplayBatchSize(t *testing.T) {
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	s, err := memory.NewMemManager(memory.WithStoreSize(100)).CreateWAL(context.Background(), partitionID)
+	assert.NoError(t, err)
+	messages := testutils.BuildTestReadMessages(10, time.Unix(60, 0), nil)
+	for i := range messages {
+		assert.NoError(t, s.Write(context.Background(), &messages[i]))
+	}
+
+	opts := DefaultOptions()
+	assert.NoError(t, WithReplayBatchSize(3)(opts))
+	assert.NoError(t, WithReplayPrefetchDepth(2)(opts))
+	replayed, err := replayAll(t, &DataForward{opts: opts}, s)
+	assert.NoError(t, err)
+	assert.Len(t, replayed, len(messages))
+	for i := range messages {
+		assert.Equal(t, messages[i].Header.ID, replayed[i].Header.ID)
+	}
+
+	assert.Error(t, WithReplayBatchSize(0)(DefaultOptions()))
+}
+
+// replayPeakHeap replays the partition persisted at storePath with the options and returns the peak of the live heap,
+// above the heap before the replay, as sampled by the reader of the replay.
+func replayPeakHeap(t *testing.T, storePath string, msgCount int, opts ...Option) int64 {
+	ctx := context.Background()
+	vertexInstance := &dfv1.VertexInstance{
+		Vertex: &dfv1.Vertex{Spec: dfv1.VertexSpec{
+			PipelineName:   "test-pipeline",
+			AbstractVertex: dfv1.AbstractVertex{Name: "reduce"},
+		}},
+	}
+	discoveredStores, err := fs.NewFSManager(vertexInstance, fs.WithStorePath(storePath)).DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	defer func() { _ = discoveredStores[0].Close() }()
+
+	df := &DataForward{opts: DefaultOptions()}
+	for _, opt := range opts {
+		assert.NoError(t, opt(df.opts))
+	}
+
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	baseline := int64(stats.HeapAlloc)
+	var peak int64
+	msgCh, errCh := df.replay(ctx, discoveredStores[0])
+	replayed := 0
+	for msg := range msgCh {
+		assert.NotNil(t, msg)
+		replayed++
+		if replayed%100 == 0 {
+			runtime.GC()
+			runtime.ReadMemStats(&stats)
+			peak = max(peak, int64(stats.HeapAlloc)-baseline)
+		}
+	}
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, msgCount, replayed)
+	return peak
+}
+
+func TestDataForward_StreamingReplay(t *testing.T) {
+	ctx := context.Background()
+	vertexInstance := &dfv1.VertexInstance{
+		Vertex: &dfv1.Vertex{Spec: dfv1.VertexSpec{
+			PipelineName:   "test-pipeline",
+			AbstractVertex: dfv1.AbstractVertex{Name: "reduce"},
+		}},
+	}
+	storePath := t.TempDir()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	msgCount := 2000
+
+	// a store of about 8MB
+	store, err := fs.NewFSManager(vertexInstance, fs.WithStorePath(storePath)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	messages := testutils.BuildTestReadMessagesIntOffset(int64(msgCount), time.Unix(60, 0), nil)
+	for i := range messages {
+		messages[i].Payload = make([]byte, 4096)
+	}
+	for i := range messages {
+		assert.NoError(t, store.Write(ctx, &messages[i]))
+	}
+	assert.NoError(t, store.Close())
+	messages = nil
+
+	// the batch path holds the whole store in memory, the streaming path only the messages in flight
+	batchPeak := replayPeakHeap(t, storePath, msgCount, WithReplayBatchSize(int64(msgCount)))
+	streamingPeak := replayPeakHeap(t, storePath, msgCount, WithStreamingReplay(), WithReplayBatchSize(int64(msgCount)))
+	assert.Greater(t, batchPeak, int64(msgCount*4096))
+	assert.Less(t, streamingPeak, batchPeak/4)
+}
+
 // Max operation with 5 minutes window and two keys and writing to two partitions
 func TestReduceDataForward_SumMultiPartitions(t *testing.T) {
 	var (
@@ -17,6 +17,7 @@ limitations under the License.
 package reduce
 
 import (
+	"fmt"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
@@ -28,6 +29,19 @@ type Options struct {
 	readBatchSize int64
 	// allowedLateness is the time.Duration it waits after the watermark has progressed for late-date to be included
 	allowedLateness time.Duration
+	// replayConcurrency is the max number of WALs replayed at the same time after a restart, unlimited if zero
+	replayConcurrency int
+	// replayPrefetchDepth is the number of messages read ahead from a WAL during the replay, disabled if zero
+	replayPrefetchDepth int
+	// replayBatchSize is the number of messages read from a WAL at a time during the replay, the WAL replays on its own
+	// terms if zero
+	replayBatchSize int64
+	// streamingReplay replays the WALs one message at a time with an iterator, instead of reading them in batches
+	streamingReplay bool
+	// messageTTL is the age, by event time, after which a persisted message is not replayed, disabled if zero
+	messageTTL time.Duration
+	// replayOrderCheck fails the replay of a WAL whose messages are not in the order of their event times
+	replayOrderCheck bool
 }
 
 type Option func(*Options) error
@@ -54,3 +68,72 @@ func WithAllowedLateness(t time.Duration) Option {
 		return nil
 	}
 }
+
+// WithReplayConcurrency sets the max number of WALs replayed at the same time after a restart, so that the replay of
+// many partitions does not read all their WALs at once. The WALs are replayed all at once by default.
+func WithReplayConcurrency(concurrency int) Option {
+	return func(o *Options) error {
+		if concurrency <= 0 {
+			return fmt.Errorf("replay concurrency should be positive, got %d", concurrency)
+		}
+		o.replayConcurrency = concurrency
+		return nil
+	}
+}
+
+// WithReplayPrefetchDepth sets the number of messages read ahead from a WAL while the replayed messages are written to
+// the PBQ, so that the I/O of the WAL does not stall the replay.
+func WithReplayPrefetchDepth(depth int) Option {
+	return func(o *Options) error {
+		if depth < 0 {
+			return fmt.Errorf("replay prefetch depth should not be negative, got %d", depth)
+		}
+		o.replayPrefetchDepth = depth
+		return nil
+	}
+}
+
+// WithReplayBatchSize sets the number of messages read from a WAL at a time when it is replayed after a restart,
+// regardless of the read batch size used for the live reads. It only applies to the WALs which implement
+// wal.OffsetReader, the other WALs are replayed with wal.WAL.Replay.
+func WithReplayBatchSize(size int64) Option {
+	return func(o *Options) error {
+		if size <= 0 {
+			return fmt.Errorf("replay batch size should be positive, got %d", size)
+		}
+		o.replayBatchSize = size
+		return nil
+	}
+}
+
+// WithStreamingReplay replays the WALs with wal.StoreIterator after a restart, one message at a time, so that the replay
+// of a large WAL does not read it into memory in batches. It only applies to the WALs which implement wal.Iterable, and
+// takes precedence over WithReplayBatchSize.
+func WithStreamingReplay() Option {
+	return func(o *Options) error {
+		o.streamingReplay = true
+		return nil
+	}
+}
+
+// WithMessageTTL sets the age, by the event time, after which the persisted messages of a partition are expired. The
+// expired messages are skipped when the WALs are replayed after a restart.
+func WithMessageTTL(ttl time.Duration) Option {
+	return func(o *Options) error {
+		if ttl < 0 {
+			return fmt.Errorf("message ttl should not be negative, got %s", ttl)
+		}
+		o.messageTTL = ttl
+		return nil
+	}
+}
+
+// WithReplayOrderCheck makes the replay of the WALs after a restart check that the event times of the messages of a
+// partition never decrease, the replay fails with wal.ReplayOrderErr at the first message which is out of order. It is
+// meant to catch the stores which reorder the messages.
+func WithReplayOrderCheck() Option {
+	return func(o *Options) error {
+		o.replayOrderCheck = true
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// ackState tracks the messages read with ReadBatch by their offsets in the store.
+type ackState struct {
+	// next is the offset of the first message which has never been read
+	next int64
+	// inFlight are the offsets of the messages which are read and neither acked nor nacked yet
+	inFlight map[int64]struct{}
+	// nacked are the offsets of the nacked messages, they are read again before the messages which have never been read
+	nacked []int64
+	// acked are the offsets of the acked messages
+	acked map[int64]struct{}
+}
+
+func newAckState() *ackState {
+	return &ackState{
+		inFlight: make(map[int64]struct{}),
+		acked:    make(map[int64]struct{}),
+	}
+}
+
+// ReadBatch reads up to size persisted messages which are neither acked nor in flight, along with their offsets in the
+// store. The nacked messages are read again first, in the order of their offsets, then the messages which have never
+// been read. A message is in flight until it is acked or nacked.
+func (p *PBQ) ReadBatch(size int64) ([]*isb.ReadMessage, []int64, error) {
+	if !p.options.ackTracking {
+		return nil, nil, ErrAckTrackingDisabled
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.store == nil {
+		return nil, nil, fmt.Errorf("pbq for partition %s is garbage collected", p.PartitionID.String())
+	}
+	reader, ok := p.store.(wal.OffsetReader)
+	if !ok {
+		return nil, nil, fmt.Errorf("ack tracking is not supported by the store %T", p.store)
+	}
+
+	messages := make([]*isb.ReadMessage, 0, size)
+	offsets := make([]int64, 0, size)
+	slices.Sort(p.acks.nacked)
+	for len(p.acks.nacked) > 0 && int64(len(messages)) < size {
+		offset := p.acks.nacked[0]
+		read, _, err := reader.ReadAt(offset, 1)
+		if err == nil && len(read) == 0 {
+			err = fmt.Errorf("no message read at offset %d", offset)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		messages = append(messages, read[0])
+		offsets = append(offsets, offset)
+		p.acks.nacked = p.acks.nacked[1:]
+	}
+	if remaining := size - int64(len(messages)); remaining > 0 && p.acks.next < p.store.Size() {
+		read, _, err := reader.ReadAt(p.acks.next, remaining)
+		if err != nil {
+			// the nacked messages which were read are nacked again
+			p.acks.nacked = append(p.acks.nacked, offsets...)
+			return nil, nil, err
+		}
+		for _, msg := range read {
+			messages = append(messages, msg)
+			offsets = append(offsets, p.acks.next)
+			p.acks.next++
+		}
+	}
+	for _, offset := range offsets {
+		p.acks.inFlight[offset] = struct{}{}
+	}
+	return messages, offsets, nil
+}
+
+// Ack marks the messages at the offsets as successfully forwarded, they are never read again. An offset which is not in
+// flight fails the ack, in which case none of the offsets is acked.
+func (p *PBQ) Ack(offsets []int64) error {
+	return p.settle(offsets, func(offset int64) {
+		p.acks.acked[offset] = struct{}{}
+	})
+}
+
+// Nack marks the messages at the offsets as failed to be forwarded, they are read again by the next ReadBatch. An
+// offset which is not in flight fails the nack, in which case none of the offsets is nacked.
+func (p *PBQ) Nack(offsets []int64) error {
+	return p.settle(offsets, func(offset int64) {
+		p.acks.nacked = append(p.acks.nacked, offset)
+	})
+}
+
+// settle takes the offsets out of flight and hands them to the settle func, once all of them are checked to be in flight.
+func (p *PBQ) settle(offsets []int64, settle func(offset int64)) error {
+	if !p.options.ackTracking {
+		return ErrAckTrackingDisabled
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	seen := make(map[int64]struct{}, len(offsets))
+	for _, offset := range offsets {
+		if _, ok := p.acks.inFlight[offset]; !ok {
+			return fmt.Errorf("message at offset %d is not in flight", offset)
+		}
+		if _, ok := seen[offset]; ok {
+			return fmt.Errorf("message at offset %d is settled twice", offset)
+		}
+		seen[offset] = struct{}{}
+	}
+	for _, offset := range offsets {
+		delete(p.acks.inFlight, offset)
+		settle(offset)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_AckTracking(t *testing.T) {
+	ctx := context.Background()
+	count := 10
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned,
+		WithChannelBufferSize(int64(count)), WithAckTracking())
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q := pq.(*PBQ)
+
+	windowRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, q.Write(ctx, &windowRequests[i], true))
+	}
+
+	msgs, offsets, err := q.ReadBatch(int64(count))
+	assert.NoError(t, err)
+	assert.Len(t, msgs, count)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, offsets)
+	// everything is in flight
+	msgs, _, err = q.ReadBatch(int64(count))
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 0)
+
+	// the nacked half is read again, the acked half is not
+	var acked, nacked []int64
+	for _, offset := range offsets {
+		if offset%2 == 0 {
+			nacked = append(nacked, offset)
+		} else {
+			acked = append(acked, offset)
+		}
+	}
+	assert.NoError(t, q.Ack(acked))
+	assert.NoError(t, q.Nack(nacked))
+	msgs, nackedOffsets, err := q.ReadBatch(int64(count))
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 2, 4, 6, 8}, nackedOffsets)
+	for i, msg := range msgs {
+		assert.Equal(t, windowRequests[2*i].ReadMessage.ID, msg.ID)
+	}
+
+	// an offset which is not in flight fails the ack as a whole
+	assert.Error(t, q.Ack([]int64{0, 1}))
+	assert.Error(t, q.Nack([]int64{0, 0}))
+
+	// the unacked messages do not hold back the GC, the reducer consumes the output channel
+	assert.NoError(t, q.GC())
+	_, ok := qManager.GetPBQ(partitionID)
+	assert.False(t, ok)
+
+	// ack tracking is opt-in
+	qManager, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned)
+	assert.NoError(t, err)
+	pq, err = qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	_, _, err = pq.(*PBQ).ReadBatch(1)
+	assert.ErrorIs(t, err, ErrAckTrackingDisabled)
+	assert.ErrorIs(t, pq.(*PBQ).Ack([]int64{0}), ErrAckTrackingDisabled)
+}
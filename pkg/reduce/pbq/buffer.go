@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// The elastic channel buffer keeps the requests in the order they are written across the resizes. A resize swaps in
+// the new buffer, moves the buffered requests to it and then closes the old buffer, and only then are the new requests
+// written to the new buffer. The requests which the forwarding goroutine received from the old buffer are older than
+// all the moved ones, and the goroutine switches to the new buffer once the old one is closed.
+
+// writeCh returns the channel the requests are written to. If the channel buffer is elastic, it grows the buffer when
+// it is full, or shrinks it after it has been idle.
+func (p *PBQ) writeCh() chan<- *window.TimedWindowRequest {
+	if p.buffer.Load() == nil || p.options.maxChannelBufferSize <= p.options.channelBufferSize {
+		return p.currentCh()
+	}
+	buffer := p.currentCh()
+	initialSize := int(p.options.channelBufferSize)
+	switch {
+	case len(buffer) == cap(buffer):
+		p.lastFull = p.options.clock.Now()
+		if maxSize := int(p.options.maxChannelBufferSize); cap(buffer) < maxSize {
+			p.resizeBuffer(min(2*cap(buffer), maxSize))
+		}
+	case cap(buffer) > initialSize && len(buffer) <= initialSize && p.options.clock.Since(p.lastFull) > p.options.channelBufferIdleTimeout:
+		p.resizeBuffer(initialSize)
+	}
+	return p.currentCh()
+}
+
+// currentCh returns the channel the requests are currently written to.
+func (p *PBQ) currentCh() chan *window.TimedWindowRequest {
+	if buffer := p.buffer.Load(); buffer != nil {
+		return *buffer
+	}
+	return p.output
+}
+
+// resizeBuffer replaces the buffer with one of the given size and moves the buffered requests to it. The size should
+// fit the buffered requests, only the writer adds requests to the buffer, so their number cannot grow meanwhile.
+func (p *PBQ) resizeBuffer(size int) {
+	// the lock keeps the buffer from being closed by cob while the requests are moved
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.State() >= StateClosed {
+		return
+	}
+	old := *p.buffer.Load()
+	buffer := make(chan *window.TimedWindowRequest, size)
+	p.buffer.Store(&buffer)
+	for moved := false; !moved; {
+		select {
+		case request := <-old:
+			buffer <- request
+		default:
+			moved = true
+		}
+	}
+	close(old)
+	p.log.Debugw("Resized the channel buffer", zap.String("ID", p.PartitionID.String()), zap.Int("from", cap(old)), zap.Int("to", size))
+}
+
+// forwardBuffer forwards the requests from the buffer to the output channel, it closes the output channel once the
+// buffer is closed by cob and all the requests are forwarded. The requests which are peeked stay in the lookahead until
+// they are forwarded, the forwarding goroutine serves the peeks too, so a peek never returns a request which has been
+// read.
+func (p *PBQ) forwardBuffer() {
+	defer close(p.forwarded)
+	defer close(p.output)
+	buffer := p.currentCh()
+	var lookahead []*window.TimedWindowRequest
+	for {
+		var out chan<- *window.TimedWindowRequest
+		var in <-chan *window.TimedWindowRequest
+		var head *window.TimedWindowRequest
+		if len(lookahead) > 0 {
+			out, head = p.output, lookahead[0]
+		} else if buffer != nil {
+			in = buffer
+		} else {
+			return
+		}
+
+		select {
+		case out <- head:
+			p.forwardedCount.Add(1)
+			lookahead[0] = nil
+			lookahead = lookahead[1:]
+		case request, ok := <-in:
+			if ok {
+				lookahead = append(lookahead, request)
+			} else {
+				buffer = p.nextBuffer(buffer)
+			}
+		case peek := <-p.peekCh:
+			// only the requests which are already buffered are peeked
+			for buffered := true; buffered && len(lookahead) < peek.n && buffer != nil; {
+				select {
+				case request, ok := <-buffer:
+					if ok {
+						lookahead = append(lookahead, request)
+					} else {
+						buffer = p.nextBuffer(buffer)
+					}
+				default:
+					buffered = false
+				}
+			}
+			peeked := make([]*window.TimedWindowRequest, min(peek.n, len(lookahead)))
+			copy(peeked, lookahead)
+			peek.result <- peeked
+		}
+	}
+}
+
+// nextBuffer returns the buffer which replaced the closed buffer, or nil if the buffer is closed by cob.
+func (p *PBQ) nextBuffer(closed chan *window.TimedWindowRequest) chan *window.TimedWindowRequest {
+	if next := p.currentCh(); next != closed {
+		return next
+	}
+	return nil
+}
+
+// peekRequest asks the forwarding goroutine for up to n requests.
+type peekRequest struct {
+	n      int
+	result chan []*window.TimedWindowRequest
+}
+
+// Peek returns up to n of the requests which are buffered in the PBQ, without consuming them, they are still read from
+// the read channel in the same order. It does not wait for the requests to be written, and it returns no requests once
+// all the requests have been read after cob. ErrPeekDisabled is returned unless the PBQ is created WithPeek.
+func (p *PBQ) Peek(n int) ([]*window.TimedWindowRequest, error) {
+	if p.peekCh == nil {
+		return nil, ErrPeekDisabled
+	}
+	result := make(chan []*window.TimedWindowRequest, 1)
+	select {
+	case p.peekCh <- peekRequest{n: n, result: result}:
+		return <-result, nil
+	case <-p.forwarded:
+		return nil, nil
+	}
+}
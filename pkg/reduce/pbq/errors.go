@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+// ErrPeekDisabled is returned by PBQ.Peek when the PBQ is not created WithPeek.
+var ErrPeekDisabled = errors.New("peek is not enabled for the pbq")
+
+// ErrAckTrackingDisabled is returned by PBQ.ReadBatch, PBQ.Ack and PBQ.Nack when the PBQ is not created
+// WithAckTracking.
+var ErrAckTrackingDisabled = errors.New("ack tracking is not enabled for the pbq")
+
+// errMessageDropped is returned by the writes to the store when the full policy drops the message.
+var errMessageDropped = errors.New("message dropped, the store is full")
+
+// errDuplicateMessage is returned by the writes to the store when the message has already been persisted.
+var errDuplicateMessage = errors.New("message has already been persisted")
+
+// PartitionCreateErr is returned when the store of a partition cannot be created.
+type PartitionCreateErr struct {
+	PartitionID partition.ID
+	// StoreType is the type of the WAL manager which failed to create the store.
+	StoreType string
+	Err       error
+}
+
+func (e PartitionCreateErr) Error() string {
+	return fmt.Sprintf("failed to create a PBQ store (%s) for partition %s, %v", e.StoreType, e.PartitionID.String(), e.Err)
+}
+
+// Unwrap returns the underlying error of the store creation.
+func (e PartitionCreateErr) Unwrap() error {
+	return e.Err
+}
+
+// PartitionExistsErr is returned when a pbq is created for a partition which already has one.
+type PartitionExistsErr struct {
+	PartitionID partition.ID
+}
+
+func (e PartitionExistsErr) Error() string {
+	return fmt.Sprintf("pbq for partition %s already exists", e.PartitionID.String())
+}
+
+// COBErr is returned by the writes to a pbq whose book is closed, either in this run or before a restart.
+type COBErr struct {
+	PartitionID partition.ID
+}
+
+func (e COBErr) Error() string {
+	return fmt.Sprintf("pbq for partition %s is closed", e.PartitionID.String())
+}
+
+// NilMessageErr is returned by the writes of a request which carries a message, i.e. an open, append or expand, but
+// whose message is nil, or of a nil request. The request is rejected, so a nil message is never persisted nor sent to the
+// output channel.
+type NilMessageErr struct {
+	PartitionID partition.ID
+	// Operation is the operation of the request, it is empty for a nil request.
+	Operation string
+}
+
+func (e NilMessageErr) Error() string {
+	if e.Operation == "" {
+		return fmt.Sprintf("nil request written to pbq for partition %s", e.PartitionID.String())
+	}
+	return fmt.Sprintf("%s request without a message written to pbq for partition %s", e.Operation, e.PartitionID.String())
+}
+
+// StateTransitionErr is returned when an operation would move a pbq to a state which cannot be reached from its current
+// state, e.g. a write to a garbage collected pbq.
+type StateTransitionErr struct {
+	PartitionID partition.ID
+	From        State
+	To          State
+}
+
+func (e StateTransitionErr) Error() string {
+	return fmt.Sprintf("pbq for partition %s cannot move from %s to %s", e.PartitionID.String(), e.From, e.To)
+}
+
+// MaxPartitionsExceededErr is returned when a pbq cannot be created because the limit of the number of partitions is
+// hit. Err is the error of the context if the creation gave up waiting for a partition to be deregistered.
+type MaxPartitionsExceededErr struct {
+	Limit int
+	Err   error
+}
+
+func (e MaxPartitionsExceededErr) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("max partitions %d exceeded, %v", e.Limit, e.Err)
+	}
+	return fmt.Sprintf("max partitions %d exceeded", e.Limit)
+}
+
+// Unwrap returns the error of the context.
+func (e MaxPartitionsExceededErr) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryableWriteError is the default classifier of the write retry, see WithWriteRetry. The errors which another
+// attempt cannot fix are not retryable: the store is full or closed, or the context of the write is done. The full store
+// is handled by the full policy instead.
+func IsRetryableWriteError(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, aligned.ErrWriteStoreFull), errors.Is(err, aligned.ErrWriteStoreClosed):
+		return false
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	default:
+		return true
+	}
+}
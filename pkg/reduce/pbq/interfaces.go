@@ -36,6 +36,13 @@ type Reader interface {
 	GC() error
 }
 
+// ContextGC is implemented by the readers which can garbage collect with a context, e.g. to trace the garbage collection
+// as a child of the span of the context.
+type ContextGC interface {
+	// GCWithContext does garbage collection like GC
+	GCWithContext(ctx context.Context) error
+}
+
 // WriteCloser provides methods to write data to the PQB and close the PBQ.
 // No data can be written to PBQ after cob.
 type WriteCloser interface {
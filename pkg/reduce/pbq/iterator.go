@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// readErrBufferSize is the number of read errors kept for the iterator, the errors which do not fit are only logged
+const readErrBufferSize = 16
+
+// ReadResult is either a request read from the PBQ, or an error the PBQ ran into while reading the persisted messages
+// from its store, in which case the Request is nil.
+type ReadResult struct {
+	Request *window.TimedWindowRequest
+	Err     error
+}
+
+// Iterator returns a channel of the requests of the PBQ, like ReadCh, along with the errors of the reads from the store,
+// i.e. a failed replay or a failed delivery of the spilled messages, so that the reader does not mistake them for the
+// end of the messages. An error is delivered after the requests which are already in the output channel. The channel is
+// closed on close of book. It consumes the output channel, so a reader should use either ReadCh or Iterator.
+func (p *PBQ) Iterator() <-chan ReadResult {
+	results := make(chan ReadResult)
+	go func() {
+		defer close(results)
+		for {
+			select {
+			case request, ok := <-p.output:
+				if !ok {
+					p.flushReadErrs(results)
+					return
+				}
+				results <- ReadResult{Request: request}
+			case err := <-p.readErrs:
+				// the requests which were written before the error are delivered first
+				for drained := false; !drained; {
+					select {
+					case request, ok := <-p.output:
+						if !ok {
+							results <- ReadResult{Err: err}
+							p.flushReadErrs(results)
+							return
+						}
+						results <- ReadResult{Request: request}
+					default:
+						drained = true
+					}
+				}
+				results <- ReadResult{Err: err}
+			}
+		}
+	}()
+	return results
+}
+
+// flushReadErrs delivers the read errors which are left once the output channel is closed.
+func (p *PBQ) flushReadErrs(results chan<- ReadResult) {
+	for {
+		select {
+		case err := <-p.readErrs:
+			results <- ReadResult{Err: err}
+		default:
+			return
+		}
+	}
+}
+
+// reportReadError hands the error of a read from the store to the iterator, the error is dropped if the errors are not
+// consumed.
+func (p *PBQ) reportReadError(err error) {
+	select {
+	case p.readErrs <- err:
+	default:
+		p.log.Warnw("Dropping the read error of the PBQ, the errors are not consumed", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+	}
+}
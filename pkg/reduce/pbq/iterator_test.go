@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_Iterator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "new-partition"}
+
+	readErr := errors.New("corrupted record")
+	writeMessages := testutils.BuildTestReadMessages(5, time.Unix(60, 0), nil)
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// the replay fails at the third message
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, pq.Write(ctx, &window.TimedWindowRequest{ReadMessage: &writeMessages[i]}, false))
+	}
+	pq.(*PBQ).reportReadError(readErr)
+
+	// the replayed messages are followed by the error instead of a silent end of the messages
+	results := pq.(*PBQ).Iterator()
+	for i := 0; i < 2; i++ {
+		result := <-results
+		assert.NoError(t, result.Err)
+		assert.Equal(t, writeMessages[i].Header.ID, result.Request.ReadMessage.Header.ID)
+	}
+	result := <-results
+	assert.ErrorIs(t, result.Err, readErr)
+	assert.Nil(t, result.Request)
+
+	// the live messages are read as usual and the iterator ends on close of book
+	assert.NoError(t, pq.Write(ctx, &window.TimedWindowRequest{ReadMessage: &writeMessages[3]}, true))
+	result = <-results
+	assert.NoError(t, result.Err)
+	assert.Equal(t, writeMessages[3].Header.ID, result.Request.ReadMessage.Header.ID)
+	pq.CloseOfBook()
+	_, ok := <-results
+	assert.False(t, ok)
+}
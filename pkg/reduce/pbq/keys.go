@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// MessageKey returns the key of the message, which is the keys in its header joined with the keys delimiter, the way the
+// keys of a window are combined. It is empty if the message has no keys.
+func MessageKey(msg *isb.Message) string {
+	return strings.Join(msg.Keys, dfv1.KeysDelimitter)
+}
+
+// trackedKeys are the distinct keys of the messages written to the PBQ in the order they were first seen, up to the max
+// tracked keys, and seen is their set. A snapshot is never modified, a new key swaps in a copy.
+type trackedKeys struct {
+	keys []string
+	seen map[string]struct{}
+}
+
+// Keys returns the distinct keys of the messages written to the PBQ, the live and the replayed ones, in the order they
+// were first written, see MessageKey. The messages without keys are left out. Only the first keys up to the cap set by
+// WithKeyTracking are returned, and none if the keys are not tracked.
+func (p *PBQ) Keys() []string {
+	tracked := p.keys.Load()
+	if tracked == nil {
+		return nil
+	}
+	return slices.Clone(tracked.keys)
+}
+
+// trackKey records the key of the message, unless the keys are not tracked, the message has no keys, or the cap of the
+// tracked keys is reached.
+func (p *PBQ) trackKey(msg *isb.Message) {
+	if p.keys.Load() == nil || len(msg.Keys) == 0 {
+		return
+	}
+	key := MessageKey(msg)
+	for {
+		tracked := p.keys.Load()
+		if tracked == nil || len(tracked.keys) >= p.options.maxTrackedKeys {
+			return
+		}
+		if _, ok := tracked.seen[key]; ok {
+			return
+		}
+		next := &trackedKeys{keys: append(slices.Clip(tracked.keys), key), seen: maps.Clone(tracked.seen)}
+		next.seen[key] = struct{}{}
+		if p.keys.CompareAndSwap(tracked, next) {
+			return
+		}
+	}
+}
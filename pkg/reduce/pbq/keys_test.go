@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_Keys(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	keys := [][]string{{"a"}, {"b", "c"}, {"a"}, nil, {"d"}, {"e"}}
+	windowRequests := testutils.BuildTestWindowRequests(int64(len(keys)), time.Now(), window.Append)
+	for i := range windowRequests {
+		windowRequests[i].ReadMessage.Keys = keys[i]
+	}
+
+	t.Run("tracked", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned,
+			WithChannelBufferSize(10), WithKeyTracking(3))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		// the replayed messages are tracked too
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], false))
+		for i := 1; i < len(windowRequests); i++ {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		// the repeated key and the message without keys are left out, and the last key is beyond the cap
+		assert.Equal(t, []string{"a", "b" + dfv1.KeysDelimitter + "c", "d"}, pq.(*PBQ).Keys())
+		assert.Equal(t, "b"+dfv1.KeysDelimitter+"c", MessageKey(&windowRequests[1].ReadMessage.Message))
+		assert.Equal(t, "", MessageKey(&windowRequests[3].ReadMessage.Message))
+	})
+
+	t.Run("not tracked", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithChannelBufferSize(10))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		assert.Empty(t, pq.(*PBQ).Keys())
+
+		_, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithKeyTracking(0))
+		assert.Error(t, err)
+	})
+}
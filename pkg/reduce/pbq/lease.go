@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// leaseOwner is the owner of the leases acquired by the manager, see WithLeaseOwner.
+func (m *Manager) leaseOwner() string {
+	return m.pbqOptions.leaseOwner
+}
+
+// podLeaseOwner returns the name of the pod, the pod rather than the vertex replica owns the leases, since the pod which
+// replaces a replica has to be fenced off from the partitions of the stale pod.
+func podLeaseOwner() (string, error) {
+	if pod := os.Getenv(dfv1.EnvPod); pod != "" {
+		return pod, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "", fmt.Errorf("failed to find the pod which owns the leases, set %s, %v", dfv1.EnvPod, err)
+	}
+	return hostname, nil
+}
+
+// keepLease renews the lease of the partition of the newly registered pbq in the background, until the pbq is garbage
+// collected or closed. It is a no-op if the partitions are not leased.
+func (m *Manager) keepLease(p *PBQ) {
+	if m.leaser == nil {
+		return
+	}
+	p.leaseStop = make(chan struct{})
+	go p.renewLease(m.leaser, m.leaseOwner())
+}
+
+// releaseLease releases the lease of the partition, a failure is only logged since the lease expires anyway.
+func (m *Manager) releaseLease(partitionID partition.ID) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.pbqOptions.leaseHeartbeat)
+	defer cancel()
+	if err := m.leaser.ReleaseLease(ctx, partitionID, m.leaseOwner()); err != nil {
+		m.log.Warnw("Failed to release the lease of the partition", zap.String("ID", partitionID.String()), zap.Error(err))
+	}
+}
+
+// renewLease renews the lease of the partition every heartbeat until stopLease is called. The lease is lost if the
+// renewal is refused, or if the lease could not be renewed within the ttl, e.g. because the store is unreachable, since
+// another replica can take the partition over once the lease expires.
+func (p *PBQ) renewLease(leaser wal.Leaser, owner string) {
+	timer := p.options.clock.NewTimer(p.options.leaseHeartbeat)
+	defer timer.Stop()
+	lastRenewed := p.options.clock.Now()
+	for {
+		select {
+		case <-p.leaseStop:
+			return
+		case <-timer.C():
+		}
+		timer.Reset(p.options.leaseHeartbeat)
+		ctx, cancel := context.WithTimeout(context.Background(), p.options.leaseHeartbeat)
+		err := leaser.RenewLease(ctx, p.PartitionID, owner, p.options.leaseTTL)
+		cancel()
+		if err == nil {
+			lastRenewed = p.options.clock.Now()
+			continue
+		}
+		var lostErr wal.LeaseLostErr
+		if !errors.As(err, &lostErr) {
+			if p.options.clock.Since(lastRenewed) < p.options.leaseTTL {
+				p.log.Warnw("Failed to renew the lease of the partition, retrying", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+				continue
+			}
+			lostErr = wal.LeaseLostErr{PartitionID: p.PartitionID, Owner: owner}
+		}
+		p.log.Errorw("Lost the lease of the partition, the writes are refused", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+		p.leaseLost.Store(&lostErr)
+		return
+	}
+}
+
+// stopLease stops renewing the lease of the partition, it is safe to call more than once.
+func (p *PBQ) stopLease() {
+	if p.leaseStop == nil {
+		return
+	}
+	p.leaseStopOnce.Do(func() { close(p.leaseStop) })
+}
@@ -22,6 +22,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// The metrics are not labelled by the partition ID. Every window of every key is a partition, so the label would add
+// a series per window which lives on in the registry after the partition is garbage collected. The metrics of the
+// store writes are labelled by the type of the store instead, see labelStoreType.
+
+// labelStoreType is the label of the type of the PBQ store, i.e. the registered store type or the type of the WAL
+// manager.
+const labelStoreType = "store_type"
+
 // activePartitionCount is used to indicate the number of active partitions
 var activePartitionCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Subsystem: "reduce_pbq",
@@ -35,3 +43,98 @@ var pbqChannelSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Name:      "channel_size",
 	Help:      "PBQ Channel size",
 }, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqChannelWriteCount is used to indicate the number of requests written to the pbq channel
+var pbqChannelWriteCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "channel_write_total",
+	Help:      "Total number of requests written to the PBQ channel",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqStoreWriteCount is used to indicate the number of messages persisted to the store
+var pbqStoreWriteCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "store_write_total",
+	Help:      "Total number of messages written to the PBQ store",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex, labelStoreType})
+
+// pbqStoreWriteTime is used to indicate the latency of the writes to the store
+var pbqStoreWriteTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "store_write_time",
+	Help:      "PBQ store write time (1 to 5000000 microseconds)",
+	Buckets:   prometheus.ExponentialBucketsRange(1, 5000000, 10),
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex, labelStoreType})
+
+// pbqReplayMessagesCount is used to indicate the number of messages replayed from the store
+var pbqReplayMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "replay_messages_total",
+	Help:      "Total number of messages replayed to the PBQ",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqReplayBytesCount is used to indicate the payload bytes replayed from the store
+var pbqReplayBytesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "replay_bytes_total",
+	Help:      "Total number of payload bytes replayed to the PBQ",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqSpillMessagesCount is used to indicate the number of messages which skipped the full pbq channel and are delivered
+// from the store
+var pbqSpillMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "spill_messages_total",
+	Help:      "Total number of messages spilled to the PBQ store because the PBQ channel was full",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqStoreFullDropCount is used to indicate the number of messages dropped by the full policy because the store was full
+var pbqStoreFullDropCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "store_full_drop_total",
+	Help:      "Total number of messages dropped because the PBQ store was full",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex, labelStoreType})
+
+// pbqDuplicateMessagesCount is used to indicate the number of redelivered messages which were not written again
+var pbqDuplicateMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "duplicate_messages_total",
+	Help:      "Total number of duplicate messages skipped by the PBQ",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqSubscriberDropCount is used to indicate the number of messages dropped for the slow subscribers of the PBQ
+var pbqSubscriberDropCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "subscriber_drop_total",
+	Help:      "Total number of messages dropped for the slow subscribers of the PBQ",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// pbqStoreWriteRetryCount is used to indicate the number of retries of the failed writes to the store
+var pbqStoreWriteRetryCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "store_write_retry_total",
+	Help:      "Total number of retries of the failed writes to the PBQ store",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex, labelStoreType})
+
+// partitionCreatedCount is used to indicate the number of partitions registered with the manager
+var partitionCreatedCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "partition_created_total",
+	Help:      "Total number of partitions created",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// partitionDeregisteredCount is used to indicate the number of partitions deregistered from the manager by the GC
+var partitionDeregisteredCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "partition_deregistered_total",
+	Help:      "Total number of partitions deregistered",
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
+
+// partitionLifetime is used to indicate the time from the creation of a partition to its GC, a high partition churn
+// shows up as many short lifetimes
+var partitionLifetime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: "reduce_pbq",
+	Name:      "partition_lifetime",
+	Help:      "Lifetimes of the partitions from the creation to the GC in seconds (100 milliseconds to 1 day)",
+	Buckets:   prometheus.ExponentialBucketsRange(0.1, 60*60*24, 12),
+}, []string{metrics.LabelVertex, metrics.LabelPipeline, metrics.LabelVertexReplicaIndex})
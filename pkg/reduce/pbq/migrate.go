@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// MigratePartition moves the store of a live partition to the WAL created by the dst manager, e.g. from the memory
+// store to the file store during an upgrade. The persisted messages are copied in order, the messages written to the
+// PBQ during the migration are buffered and written to the new store before it replaces the old one, and the old store
+// is deleted afterwards. If the migration fails, the PBQ keeps the old store and the buffered messages are written to
+// it. The old store should be replayable, since the messages are read back using Replay.
+func (m *Manager) MigratePartition(ctx context.Context, partitionID partition.ID, dst wal.Manager) error {
+	m.RLock()
+	q, ok := m.pbqMap[partitionID.String()]
+	m.RUnlock()
+	if !ok {
+		return fmt.Errorf("pbq for partition %s does not exist", partitionID.String())
+	}
+
+	dstStore, err := dst.CreateWAL(ctx, partitionID)
+	if err != nil {
+		return PartitionCreateErr{PartitionID: partitionID, StoreType: fmt.Sprintf("%T", dst), Err: err}
+	}
+	srcStore, srcProvider, err := q.swapStore(ctx, dstStore, dst)
+	if err != nil {
+		_ = dstStore.Close()
+		_ = dst.DeleteWAL(partitionID)
+		return err
+	}
+	return q.retireStore(srcStore, srcProvider)
+}
+
+// SwapStore moves the partition to the newStore while the reader keeps consuming the output channel, e.g. from the
+// memory store to the file store during an upgrade. The persisted messages are copied to the newStore in order, the
+// messages written to the PBQ in the meantime are buffered and written to the newStore before it replaces the old one,
+// so no message is lost or reordered. The old store is closed and deleted afterwards. The newStore has to be created by
+// the newStoreProvider, which deletes it when the PBQ is garbage collected. If the swap fails, the PBQ keeps the old
+// store, the buffered messages are written to it, and the newStore is left to the caller.
+func (p *PBQ) SwapStore(ctx context.Context, newStore wal.WAL, newStoreProvider wal.Manager) error {
+	if newStoreProvider == nil {
+		return fmt.Errorf("store provider of the new store of partition %s should not be nil", p.PartitionID.String())
+	}
+	srcStore, srcProvider, err := p.swapStore(ctx, newStore, newStoreProvider)
+	if err != nil {
+		return err
+	}
+	return p.retireStore(srcStore, srcProvider)
+}
+
+// swapStore copies the messages of the current store to the dstStore and replaces the current store with it, it returns
+// the replaced store and its manager. If the swap fails, the current store is kept.
+func (p *PBQ) swapStore(ctx context.Context, dstStore wal.WAL, dstProvider wal.Manager) (wal.WAL, wal.Manager, error) {
+	srcStore, srcProvider, err := p.startMigration(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = wal.Migrate(ctx, srcStore, dstStore)
+	if err == nil {
+		err = p.finishMigration(ctx, dstStore, dstProvider)
+	}
+	if err != nil {
+		p.log.Errorw("Failed to migrate the partition, keeping the old store", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+		if abortErr := p.abortMigration(ctx); abortErr != nil {
+			err = fmt.Errorf("%w, failed to write the buffered messages to the old store, %v", err, abortErr)
+		}
+		return nil, nil, err
+	}
+	return srcStore, srcProvider, nil
+}
+
+// retireStore closes the store replaced by a migration and deletes it, unless it is owned by the caller.
+func (p *PBQ) retireStore(store wal.WAL, storeProvider wal.Manager) error {
+	if err := store.Close(); err != nil {
+		p.log.Warnw("Failed to close the old store of the migrated partition", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+	}
+	if storeProvider == nil {
+		return nil
+	}
+	return storeProvider.DeleteWAL(p.PartitionID)
+}
+
+// startMigration starts buffering the new messages, it returns the current store and its manager.
+func (p *PBQ) startMigration(ctx context.Context) (wal.WAL, wal.Manager, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch {
+	case p.store == nil:
+		return nil, nil, fmt.Errorf("pbq for partition %s is garbage collected", p.PartitionID.String())
+	case p.migrating:
+		return nil, nil, fmt.Errorf("pbq for partition %s is already being migrated", p.PartitionID.String())
+	case p.spilling:
+		// the spilled messages are read from the store at their offsets
+		return nil, nil, fmt.Errorf("pbq for partition %s is spilling", p.PartitionID.String())
+	}
+	p.migrating = true
+	return p.store, p.storeProvider, nil
+}
+
+// finishMigration writes the buffered messages to the new store and replaces the current store with it.
+func (p *PBQ) finishMigration(ctx context.Context, store wal.WAL, storeProvider wal.Manager) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.migrationBuffer) > 0 {
+		if err := store.WriteBatch(ctx, p.migrationBuffer); err != nil {
+			return err
+		}
+	}
+	if p.spillReader != nil {
+		// spilling stays enabled only if the new store can read at the offsets too
+		p.spillReader, _ = store.(wal.OffsetReader)
+	}
+	p.store = store
+	p.storeProvider = storeProvider
+	p.storeMetricLabels = storeMetricLabels(p.metricLabels, storeTypeName(storeProvider))
+	p.migrating = false
+	p.migrationBuffer = nil
+	return nil
+}
+
+// abortMigration writes the buffered messages to the current store and stops buffering.
+func (p *PBQ) abortMigration(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.migrating {
+		return nil
+	}
+	p.migrating = false
+	buffered := p.migrationBuffer
+	p.migrationBuffer = nil
+	if len(buffered) == 0 || p.store == nil {
+		return nil
+	}
+	return p.store.WriteBatch(ctx, buffered)
+}
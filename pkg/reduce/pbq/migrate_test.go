@@ -0,0 +1,217 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/fs"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestManager_MigratePartition(t *testing.T) {
+	ctx := context.Background()
+	vertexInstance := &dfv1.VertexInstance{
+		Vertex: &dfv1.Vertex{Spec: dfv1.VertexSpec{
+			PipelineName:   "test-pipeline",
+			AbstractVertex: dfv1.AbstractVertex{Name: "reduce"},
+		}},
+		Replica: 0,
+	}
+	storePath := t.TempDir()
+	memManager := memory.NewMemManager(memory.WithStoreSize(100))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memManager, window.Aligned, WithChannelBufferSize(100))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	q, err := pbqManager.CreateNewPBQForKey(ctx, partitionID.Start, partitionID.End, "key-1")
+	assert.NoError(t, err)
+	partitionID = q.(*PBQ).PartitionID
+
+	count := 30
+	windowRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+	for i := 0; i < count/2; i++ {
+		assert.NoError(t, q.Write(ctx, &windowRequests[i], true))
+	}
+
+	// the rest of the messages are written while the partition is being migrated
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := count / 2; i < count; i++ {
+			assert.NoError(t, q.Write(ctx, &windowRequests[i], true))
+		}
+	}()
+	assert.NoError(t, pbqManager.MigratePartition(ctx, partitionID, fs.NewFSManager(vertexInstance, fs.WithStorePath(storePath))))
+	wg.Wait()
+	assert.NoError(t, q.Close())
+
+	// the memory store is deleted
+	memStores, err := memManager.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, memStores, 0)
+
+	// all the messages are in the file store, in order
+	fsStores, err := fs.NewFSManager(vertexInstance, fs.WithStorePath(storePath)).DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, fsStores, 1)
+	var migrated []*isb.ReadMessage
+	msgCh, _ := fsStores[0].Replay()
+	for msg := range msgCh {
+		migrated = append(migrated, msg)
+	}
+	assert.Len(t, migrated, count)
+	for i, msg := range migrated {
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, msg.Header.ID)
+	}
+
+	// the metadata of the partition is migrated too
+	metadata, err := fsStores[0].(wal.MetadataStore).LoadMetadata()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key-1"}, metadata.Keys)
+
+	// there is nothing to migrate for an unknown partition
+	err = pbqManager.MigratePartition(ctx, partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"}, memManager)
+	assert.Error(t, err)
+}
+
+func TestPBQ_SwapStore(t *testing.T) {
+	ctx := context.Background()
+	memManager := memory.NewMemManager(memory.WithStoreSize(1000))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memManager, window.Aligned, WithChannelBufferSize(5))
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	q, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	count := 200
+	windowRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+
+	// the reader keeps consuming while the store is swapped
+	var read []*isb.ReadMessage
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for request := range q.ReadCh() {
+			read = append(read, request.ReadMessage)
+		}
+	}()
+	// the store has a message to copy before the first swap
+	assert.NoError(t, q.Write(ctx, &windowRequests[0], true))
+	swapped := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i < count; i++ {
+			if i == count/2 {
+				<-swapped
+			}
+			assert.NoError(t, q.Write(ctx, &windowRequests[i], true))
+		}
+	}()
+
+	// a failed swap keeps the old store
+	failingManager := fake.NewManager(fake.WithWriteErr(1, errors.New("write failed")))
+	failing, err := failingManager.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.Error(t, q.(*PBQ).SwapStore(ctx, failing, failingManager))
+	assert.Len(t, failing.(*fake.WAL).Messages(), 0)
+	// the new store needs a provider which deletes it
+	assert.Error(t, q.(*PBQ).SwapStore(ctx, fake.NewWAL(partitionID), nil))
+
+	newManager := fake.NewManager()
+	newWAL, err := newManager.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	newStore := newWAL.(*fake.WAL)
+	assert.NoError(t, q.(*PBQ).SwapStore(ctx, newStore, newManager))
+	close(swapped)
+	wg.Wait()
+	q.CloseOfBook()
+	<-readDone
+
+	// no message is lost or reordered, neither in the output channel nor in the new store
+	assert.Len(t, read, count)
+	persisted := newStore.Messages()
+	assert.Len(t, persisted, count)
+	for i := 0; i < count; i++ {
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, read[i].Header.ID)
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, persisted[i].Header.ID)
+	}
+
+	// the old store is deleted
+	memStores, err := memManager.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, memStores, 0)
+	// the new store is deleted by its provider when the pbq is garbage collected
+	assert.NoError(t, q.GC())
+	assert.Equal(t, []partition.ID{partitionID}, newManager.Deleted())
+}
+
+func TestPBQ_SpillWhileMigrating(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+		WithChannelBufferSize(1), WithSpillOnBackpressure(5*time.Millisecond))
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	q, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	pq := q.(*PBQ)
+
+	windowRequests := testutils.BuildTestWindowRequests(3, time.Now(), window.Append)
+	assert.NoError(t, q.Write(ctx, &windowRequests[0], true))
+	_, _, err = pq.startMigration(ctx)
+	assert.NoError(t, err)
+	// the channel is full, the buffered message is not spilled, its write waits for the channel
+	written := make(chan error)
+	go func() { written <- q.Write(ctx, &windowRequests[1], true) }()
+	time.Sleep(20 * time.Millisecond)
+	pq.mu.Lock()
+	assert.False(t, pq.spilling)
+	pq.mu.Unlock()
+
+	var read []*isb.ReadMessage
+	read = append(read, (<-q.ReadCh()).ReadMessage)
+	assert.NoError(t, <-written)
+	assert.NoError(t, pq.abortMigration(ctx))
+	go func() { written <- q.Write(ctx, &windowRequests[2], true) }()
+	read = append(read, (<-q.ReadCh()).ReadMessage)
+	assert.NoError(t, <-written)
+	read = append(read, (<-q.ReadCh()).ReadMessage)
+
+	// no message is delivered twice or lost
+	assert.Len(t, read, 3)
+	for i, msg := range read {
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, msg.Header.ID)
+	}
+	q.CloseOfBook()
+	assert.NoError(t, q.GC())
+}
@@ -17,9 +17,15 @@ limitations under the License.
 package pbq
 
 import (
+	"fmt"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 )
 
 type options struct {
@@ -29,15 +35,130 @@ type options struct {
 	readTimeout time.Duration
 	// readBatchSize max size of batch to read from store
 	readBatchSize int64
+	// partitionTTL max duration a closed book partition can stay idle before it is evicted by the manager, disabled if
+	// zero
+	partitionTTL time.Duration
+	// sweepJitter max fraction of the partition TTL added to the TTL of every partition, so that the partitions which
+	// went idle together are not evicted together
+	sweepJitter float64
+	// writeRateLimit max rate of the writes from the ISB to a partition, disabled if zero
+	writeRateLimit float64
+	// writeRateBurst max number of messages or bytes written to a partition at once above the rate limit
+	writeRateBurst int
+	// writeRateUnit is the unit of the write rate limit
+	writeRateUnit RateLimitUnit
+	// partitionResolver maps the message keys to the partitions
+	partitionResolver partition.Resolver
+	// onReplayComplete is invoked once per partition when its replay completes, nil if not set
+	onReplayComplete ReplayCompleteFunc
+	// spillTimeout max duration a write waits on the full channel before the message is spilled, disabled if zero
+	spillTimeout time.Duration
+	// partitionIDValidator validates the partition IDs in addition to wal.ValidatePartitionID, nil if not set
+	partitionIDValidator func(partition.ID) error
+	// maxChannelBufferSize max size the channel buffer grows to under backpressure, disabled if not larger than
+	// channelBufferSize
+	maxChannelBufferSize int64
+	// channelBufferIdleTimeout duration the grown channel buffer has to go without backpressure before it shrinks
+	channelBufferIdleTimeout time.Duration
+	// peek enables peeking at the buffered requests
+	peek bool
+	// fullPolicy decides what a write does when the store is full
+	fullPolicy FullPolicy
+	// dedup skips the writes of the messages which have already been persisted in the partition
+	dedup bool
+	// subscriberBufferSize buffered channel size of each subscriber
+	subscriberBufferSize int64
+	// slowSubscriberPolicy decides what a write does when the channel of a subscriber is full
+	slowSubscriberPolicy SlowSubscriberPolicy
+	// maxPartitions max number of partitions registered with the manager at the same time, disabled if zero
+	maxPartitions int
+	// waitForPartitionSlot makes the creation of a pbq wait for a partition to be deregistered when maxPartitions is hit
+	waitForPartitionSlot bool
+	// writeRetryAttempts max number of attempts of a write to the store, the writes are not retried if not larger than 1
+	writeRetryAttempts int
+	// writeRetryBackoff is the schedule of the delays between the attempts of a write to the store
+	writeRetryBackoff wait.Backoff
+	// writeRetryClassifier tells whether a failed write to the store is retried
+	writeRetryClassifier func(error) bool
+	// storeTracing traces the store operations as OpenTelemetry spans, the tracer is taken from the span of the context
+	storeTracing bool
+	// ackTracking tracks the acks of the messages read with ReadBatch, the GC only reclaims the acked messages
+	ackTracking bool
+	// clock is the source of the time of the timers and the timestamps of the pbqs
+	clock clock.WithTicker
+	// memoryTier is the WAL manager of the memory tier in front of the store provider, nil if the stores are not tiered
+	memoryTier wal.Manager
+	// leaseTTL is the duration a lease of a partition is held for without being renewed, the partitions are not leased
+	// if zero
+	leaseTTL time.Duration
+	// leaseHeartbeat is the interval at which the lease of a partition is renewed
+	leaseHeartbeat time.Duration
+	// leaseOwner is the owner of the leases, the pod of the vertex replica if it is empty
+	leaseOwner string
+	// storeOpenTimeout and storeCloseTimeout are the max durations of the creation and the close of the store of a
+	// partition, unlimited if zero
+	storeOpenTimeout  time.Duration
+	storeCloseTimeout time.Duration
+	// maxTrackedKeys is the max number of distinct keys recorded per partition, the keys are not recorded if zero
+	maxTrackedKeys int
+	// storeType is the name of the registered store type whose WAL manager replaces the store provider, the store
+	// provider is used if empty
+	storeType string
 }
 
+// ReplayCompleteFunc is invoked with the partition and the number of replayed messages when the replay of the
+// persisted messages of a partition completes.
+type ReplayCompleteFunc func(partitionID partition.ID, replayed int64)
+
+// RateLimitUnit is the unit of the write rate limit.
+type RateLimitUnit int
+
+const (
+	// MessagesPerSecond limits the number of messages written per second.
+	MessagesPerSecond RateLimitUnit = iota
+	// BytesPerSecond limits the number of payload bytes written per second.
+	BytesPerSecond
+)
+
+// FullPolicy decides what a write does when the store of the partition is full.
+type FullPolicy int
+
+const (
+	// FullPolicyError fails the write with the error of the store.
+	FullPolicyError FullPolicy = iota
+	// FullPolicyBlock retries the write until the store has room for the message or the context is done.
+	FullPolicyBlock
+	// FullPolicyDropOldest evicts the oldest messages of the store until the message fits, the store has to implement
+	// wal.Evicter.
+	FullPolicyDropOldest
+	// FullPolicyDropNewest drops the message, it is neither persisted nor written to the output channel.
+	FullPolicyDropNewest
+)
+
+// SlowSubscriberPolicy decides what a write does when the channel of a subscriber of the PBQ is full.
+type SlowSubscriberPolicy int
+
+const (
+	// SlowSubscriberDrop drops the message for the subscriber, the other subscribers and the reader still receive it.
+	SlowSubscriberDrop SlowSubscriberPolicy = iota
+	// SlowSubscriberBlock blocks the write until the subscriber has room for the message or the context is done.
+	SlowSubscriberBlock
+)
+
 type PBQOption func(options *options) error
 
 func DefaultOptions() *options {
+	// a single shard maps every key to the shared slot
+	resolver, _ := partition.NewHashResolver(1)
 	return &options{
 		channelBufferSize: dfv1.DefaultPBQChannelBufferSize,
 		readTimeout:       dfv1.DefaultPBQReadTimeout,
 		readBatchSize:     dfv1.DefaultPBQReadBatchSize,
+		partitionResolver: resolver,
+		// the subscribers are buffered like the output channel
+		subscriberBufferSize: dfv1.DefaultPBQChannelBufferSize,
+		writeRetryClassifier: IsRetryableWriteError,
+		clock:                clock.RealClock{},
 	}
 }
 
@@ -64,3 +185,329 @@ func WithReadBatchSize(size int64) PBQOption {
 		return nil
 	}
 }
+
+// WithPartitionTTL sets the max duration a partition whose book is closed can go without writes before the manager
+// evicts it, e.g. when the reduce of its window never garbage collects it. The PBQ of an evicted partition is closed and
+// garbage collected along with its store. The partitions of the open windows are not evicted, their WALs hold the
+// messages which are not yet reduced.
+func WithPartitionTTL(ttl time.Duration) PBQOption {
+	return func(o *options) error {
+		o.partitionTTL = ttl
+		return nil
+	}
+}
+
+// WithSweepJitter adds a random duration of up to the given fraction of the partition TTL to the TTL of every partition,
+// so that the evictions of the partitions which went idle at the same time are spread across the jitter window instead
+// of being done by the same sweep. The fraction has to be within [0, 1], zero disables the jitter.
+func WithSweepJitter(fraction float64) PBQOption {
+	return func(o *options) error {
+		if fraction < 0 || fraction > 1 {
+			return fmt.Errorf("sweep jitter should be within [0, 1], got %v", fraction)
+		}
+		o.sweepJitter = fraction
+		return nil
+	}
+}
+
+// WithWriteRateLimit limits the rate of the writes from the ISB to each partition with a token bucket of the given
+// burst, the unit is either MessagesPerSecond or BytesPerSecond. A write blocks until the rate allows it or the context
+// is done. The replayed messages are not limited.
+func WithWriteRateLimit(limit float64, burst int, unit RateLimitUnit) PBQOption {
+	return func(o *options) error {
+		if limit <= 0 || burst <= 0 {
+			return fmt.Errorf("write rate limit and burst should be positive, got limit %v and burst %d", limit, burst)
+		}
+		o.writeRateLimit = limit
+		o.writeRateBurst = burst
+		o.writeRateUnit = unit
+		return nil
+	}
+}
+
+// WithPartitionResolver sets the resolver which maps the message keys to the partitions, the default maps every key to
+// the shared slot.
+func WithPartitionResolver(resolver partition.Resolver) PBQOption {
+	return func(o *options) error {
+		if resolver == nil {
+			return fmt.Errorf("partition resolver should not be nil")
+		}
+		o.partitionResolver = resolver
+		return nil
+	}
+}
+
+// WithReplayCompleteCallback sets the callback which is invoked exactly once per partition when the PBQ of the partition
+// goes from replaying the persisted messages to accepting the live messages, i.e. when all the persisted messages have
+// been replayed or the first live message is written, whichever happens first. The callback is invoked synchronously
+// from the write, so it should not block.
+func WithReplayCompleteCallback(fn ReplayCompleteFunc) PBQOption {
+	return func(o *options) error {
+		o.onReplayComplete = fn
+		return nil
+	}
+}
+
+// WithSpillOnBackpressure sets the max duration a write from the ISB waits for room in the full channel. Once the
+// duration is exceeded, the message, which is already persisted, skips the channel and the partition starts spilling:
+// the following messages are only persisted, and they are delivered in order from the store as the reader catches up.
+// Spilling is only supported by the aligned windows with a store which implements wal.OffsetReader.
+func WithSpillOnBackpressure(timeout time.Duration) PBQOption {
+	return func(o *options) error {
+		if timeout <= 0 {
+			return fmt.Errorf("spill timeout should be positive, got %v", timeout)
+		}
+		o.spillTimeout = timeout
+		return nil
+	}
+}
+
+// WithPartitionIDValidator sets a validator which is applied to the partition IDs in addition to the default checks of
+// wal.ValidatePartitionID, so that a store can impose stricter rules on the IDs it uses as names. A pbq is not created
+// for a partition whose ID the validator rejects.
+func WithPartitionIDValidator(validator func(partition.ID) error) PBQOption {
+	return func(o *options) error {
+		o.partitionIDValidator = validator
+		return nil
+	}
+}
+
+// WithElasticChannelBuffer lets the channel buffer, which starts at the size set by WithChannelBufferSize, grow up to
+// maxSize when a write finds it full, doubling its size every time. The grown buffer shrinks back to its initial size
+// once it has not been full for idleTimeout and its requests fit in the initial size. The requests are delivered in the
+// order they are written across the resizes.
+func WithElasticChannelBuffer(maxSize int64, idleTimeout time.Duration) PBQOption {
+	return func(o *options) error {
+		if maxSize <= 0 || idleTimeout <= 0 {
+			return fmt.Errorf("max channel buffer size and idle timeout should be positive, got %d and %v", maxSize, idleTimeout)
+		}
+		o.maxChannelBufferSize = maxSize
+		o.channelBufferIdleTimeout = idleTimeout
+		return nil
+	}
+}
+
+// WithPeek lets the reader peek at the buffered requests of the PBQ without consuming them, see PBQ.Peek. The requests
+// are forwarded to the read channel by a goroutine which keeps the peeked requests.
+func WithPeek() PBQOption {
+	return func(o *options) error {
+		o.peek = true
+		return nil
+	}
+}
+
+// WithFullPolicy sets what a write from the ISB does when the store of the partition is full, the default is
+// FullPolicyError. The policy applies to the writes of single messages, a batched write which finds the store full
+// fails regardless of the policy.
+func WithFullPolicy(policy FullPolicy) PBQOption {
+	return func(o *options) error {
+		if policy < FullPolicyError || policy > FullPolicyDropNewest {
+			return fmt.Errorf("unknown full policy %d", policy)
+		}
+		o.fullPolicy = policy
+		return nil
+	}
+}
+
+// WithDedup skips the writes from the ISB of the messages whose ID has already been persisted in the partition, so that
+// the messages redelivered by an at-least-once ISB are not counted twice by the window. The IDs of the persisted and the
+// replayed messages are kept in memory until the PBQ is garbage collected.
+func WithDedup() PBQOption {
+	return func(o *options) error {
+		o.dedup = true
+		return nil
+	}
+}
+
+// WithSubscriberBufferSize sets the buffer size of the channel of each subscriber of the PBQ, see PBQ.Subscribe.
+func WithSubscriberBufferSize(size int64) PBQOption {
+	return func(o *options) error {
+		if size < 0 {
+			return fmt.Errorf("subscriber buffer size should not be negative, got %d", size)
+		}
+		o.subscriberBufferSize = size
+		return nil
+	}
+}
+
+// WithSlowSubscriberPolicy sets what a write does when the channel of a subscriber of the PBQ is full, the default is
+// SlowSubscriberDrop so that a subscriber cannot hold back the reader.
+func WithSlowSubscriberPolicy(policy SlowSubscriberPolicy) PBQOption {
+	return func(o *options) error {
+		if policy < SlowSubscriberDrop || policy > SlowSubscriberBlock {
+			return fmt.Errorf("unknown slow subscriber policy %d", policy)
+		}
+		o.slowSubscriberPolicy = policy
+		return nil
+	}
+}
+
+// WithWriteRetry retries the failed writes to the store up to maxAttempts attempts in total, waiting between the
+// attempts as scheduled by the backoff, whose Steps are ignored. Only the errors deemed retryable by the classifier are
+// retried, see WithWriteRetryClassifier. The lock of the PBQ is held while waiting, and the retry stops once the context
+// of the write is done.
+func WithWriteRetry(maxAttempts int, backoff wait.Backoff) PBQOption {
+	return func(o *options) error {
+		if maxAttempts < 1 {
+			return fmt.Errorf("write retry attempts should be positive, got %d", maxAttempts)
+		}
+		o.writeRetryAttempts = maxAttempts
+		o.writeRetryBackoff = backoff
+		return nil
+	}
+}
+
+// WithWriteRetryClassifier sets the func which tells whether a failed write to the store is retried, the default is
+// IsRetryableWriteError.
+func WithWriteRetryClassifier(classifier func(error) bool) PBQOption {
+	return func(o *options) error {
+		if classifier == nil {
+			return fmt.Errorf("write retry classifier should not be nil")
+		}
+		o.writeRetryClassifier = classifier
+		return nil
+	}
+}
+
+// WithMaxPartitions limits the number of partitions registered with the manager at the same time, so that an explosion
+// of the keys cannot exhaust the memory of the pod. When the limit is hit, the creation of a pbq fails with
+// MaxPartitionsExceededErr, or, if block is true, it waits until a partition is deregistered or the context is done.
+func WithMaxPartitions(limit int, block bool) PBQOption {
+	return func(o *options) error {
+		if limit <= 0 {
+			return fmt.Errorf("max partitions should be positive, got %d", limit)
+		}
+		o.maxPartitions = limit
+		o.waitForPartitionSlot = block
+		return nil
+	}
+}
+
+// WithClock sets the clock of the timers and the timestamps of the pbqs, e.g. the spill timeout and the idle time of the
+// partitions. It defaults to the system clock, a fake clock lets the tests advance the time without sleeping.
+func WithClock(c clock.WithTicker) PBQOption {
+	return func(o *options) error {
+		if c == nil {
+			return fmt.Errorf("clock should not be nil")
+		}
+		o.clock = c
+		return nil
+	}
+}
+
+// WithStoreTracing traces the writes to the store, the reads from the store and the garbage collection of the pbqs as
+// OpenTelemetry spans, with the partition and the number of messages as attributes. A span is started as a child of
+// the span of the context of the operation, with a tracer of the provider of that span, the operations whose context
+// does not carry a span are not traced.
+func WithStoreTracing() PBQOption {
+	return func(o *options) error {
+		o.storeTracing = true
+		return nil
+	}
+}
+
+// WithAckTracking tracks the acks of the persisted messages, so that the messages whose forwarding failed can be read
+// again. The messages are read with PBQ.ReadBatch and then acked or nacked by their offsets in the store. The acks do not
+// gate the GC, the reducer consumes the output channel and the PBQ is garbage collected once the result of the window is
+// forwarded. It requires a store which implements wal.OffsetReader.
+func WithAckTracking() PBQOption {
+	return func(o *options) error {
+		o.ackTracking = true
+		return nil
+	}
+}
+
+// WithTieredStore puts a memory tier in front of the store provider of the manager. Every message is written to the
+// store of the store provider for durability, and to a store of the memoryTier which serves the reads of the live
+// messages. The replay reads the store of the store provider, and the GC deletes the stores of both tiers. The
+// memoryTier is usually a memory.NewMemManager, see tiered.NewManager.
+func WithTieredStore(memoryTier wal.Manager) PBQOption {
+	return func(o *options) error {
+		if memoryTier == nil {
+			return fmt.Errorf("memory tier should not be nil")
+		}
+		o.memoryTier = memoryTier
+		return nil
+	}
+}
+
+// WithLease makes the manager acquire the lease of a partition before it creates the pbq of the partition, so that a
+// single replica operates a partition in a store shared by the replicas, e.g. after a rebalance. The lease is renewed
+// every heartbeat, and released by the GC of the pbq. The writes to a pbq fail with wal.LeaseLostErr once its lease is
+// lost, because the renewal was refused or the lease could not be renewed within the ttl. The store provider has to
+// implement wal.Leaser.
+func WithLease(ttl time.Duration, heartbeat time.Duration) PBQOption {
+	return func(o *options) error {
+		if ttl <= 0 || heartbeat <= 0 || heartbeat >= ttl {
+			return fmt.Errorf("lease ttl and heartbeat should be positive and the heartbeat shorter than the ttl, got %v and %v", ttl, heartbeat)
+		}
+		o.leaseTTL = ttl
+		o.leaseHeartbeat = heartbeat
+		return nil
+	}
+}
+
+// WithLeaseOwner sets the owner of the leases acquired by the manager, see WithLease. It defaults to the name of the pod,
+// so that a rescheduled pod of a replica is fenced off from the partitions which the stale pod still operates. The owner
+// has to be unique among the pods which share the store.
+func WithLeaseOwner(owner string) PBQOption {
+	return func(o *options) error {
+		if owner == "" {
+			return fmt.Errorf("lease owner should not be empty")
+		}
+		o.leaseOwner = owner
+		return nil
+	}
+}
+
+// WithStoreOpenTimeout sets the max duration the creation of the store of a partition can take, so that a remote store
+// which hangs on the network does not block the creation of the pbq forever. The creation fails with wal.TimeoutErr once
+// the timeout is exceeded, the store is closed if it is created afterwards. The store provider gets a ctx which is
+// canceled after the timeout too.
+func WithStoreOpenTimeout(timeout time.Duration) PBQOption {
+	return func(o *options) error {
+		if timeout <= 0 {
+			return fmt.Errorf("store open timeout should be positive, got %v", timeout)
+		}
+		o.storeOpenTimeout = timeout
+		return nil
+	}
+}
+
+// WithStoreCloseTimeout sets the max duration the close of the store of a partition can take, so that a remote store
+// which hangs on the network does not block the shutdown forever. The close of the pbq fails with wal.TimeoutErr once
+// the timeout is exceeded, the close of the store carries on in the background.
+func WithStoreCloseTimeout(timeout time.Duration) PBQOption {
+	return func(o *options) error {
+		if timeout <= 0 {
+			return fmt.Errorf("store close timeout should be positive, got %v", timeout)
+		}
+		o.storeCloseTimeout = timeout
+		return nil
+	}
+}
+
+// WithStoreType makes the manager create the stores of the partitions with the WAL manager of the store type registered
+// under the name with wal.RegisterStoreType, in place of the store provider given to NewManager, which can then be nil.
+func WithStoreType(name string) PBQOption {
+	return func(o *options) error {
+		if name == "" {
+			return fmt.Errorf("store type should not be empty")
+		}
+		o.storeType = name
+		return nil
+	}
+}
+
+// WithKeyTracking makes each PBQ record the distinct keys of the messages written to it, e.g. to find the keys of a
+// partition while debugging, see PBQ.Keys. At most maxKeys keys are recorded per partition, so that the memory of a
+// partition with a high key cardinality stays bounded, the keys seen after the cap is reached are not recorded.
+func WithKeyTracking(maxKeys int) PBQOption {
+	return func(o *options) error {
+		if maxKeys <= 0 {
+			return fmt.Errorf("max tracked keys should be positive, got %d", maxKeys)
+		}
+		o.maxTrackedKeys = maxKeys
+		return nil
+	}
+}
@@ -28,6 +28,7 @@ func TestOptions(t *testing.T) {
 		WithReadBatchSize(100),
 		WithChannelBufferSize(10),
 		WithReadTimeout(2 * time.Second),
+		WithPartitionTTL(time.Minute),
 	}
 
 	queueOption := &options{
@@ -44,4 +45,5 @@ func TestOptions(t *testing.T) {
 	assert.Equal(t, int64(100), queueOption.readBatchSize)
 	assert.Equal(t, int64(10), queueOption.channelBufferSize)
 	assert.Equal(t, 2*time.Second, queueOption.readTimeout)
+	assert.Equal(t, time.Minute, queueOption.partitionTTL)
 }
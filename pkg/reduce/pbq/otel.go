@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the name of the OpenTelemetry tracer of the store operations.
+	tracerName = "github.com/numaproj/numaflow/pkg/reduce/pbq"
+
+	spanStoreWrite = "pbq.store.write"
+	spanStoreRead  = "pbq.store.read"
+	spanStoreGC    = "pbq.store.gc"
+
+	// AttributePartitionID is the span attribute of the partition of a store operation.
+	AttributePartitionID = attribute.Key("pbq.partition_id")
+	// AttributeMessageCount is the span attribute of the number of messages of a store operation.
+	AttributeMessageCount = attribute.Key("pbq.message_count")
+)
+
+// startStoreSpan starts the span of a store operation as a child of the span of the ctx, with a tracer of the provider
+// of that span. The span is nil, and the ctx is returned as is, if the store tracing is not enabled or the ctx does
+// not carry a span, so the untraced operations do not pay for the tracing.
+func (p *PBQ) startStoreSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	if !p.options.storeTracing {
+		return ctx, nil
+	}
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return ctx, nil
+	}
+	return parent.TracerProvider().Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(AttributePartitionID.String(p.PartitionID.String())))
+}
+
+// endStoreSpan records the number of messages and the error of the store operation and ends the span.
+func endStoreSpan(span trace.Span, count int, err error) {
+	span.SetAttributes(AttributeMessageCount.Int(count))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_StoreTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+	ctx, root := provider.Tracer("test").Start(context.Background(), "root")
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(100))
+
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10),
+		WithStoreTracing())
+	assert.NoError(t, err)
+	q, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	windowRequests := testutils.BuildTestWindowRequests(3, time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, q.Write(ctx, &windowRequests[i], true))
+	}
+	// the operations whose context does not carry a span are not traced
+	assert.NoError(t, q.Write(context.Background(), &windowRequests[0], true))
+	q.CloseOfBook()
+	assert.NoError(t, q.(ContextGC).GCWithContext(ctx))
+	root.End()
+
+	counts := make(map[string][]int64)
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "root" {
+			continue
+		}
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent.SpanID())
+		attributes := attribute.NewSet(span.Attributes...)
+		partitionAttr, ok := attributes.Value(AttributePartitionID)
+		assert.True(t, ok)
+		assert.Equal(t, partitionID.String(), partitionAttr.AsString())
+		countAttr, ok := attributes.Value(AttributeMessageCount)
+		assert.True(t, ok)
+		counts[span.Name] = append(counts[span.Name], countAttr.AsInt64())
+	}
+	assert.Equal(t, map[string][]int64{
+		spanStoreWrite: {1, 1, 1},
+		spanStoreGC:    {4},
+	}, counts)
+
+	// no span is produced without the store tracing
+	exporter.Reset()
+	partitionID.Slot = "slot-2"
+	qManager, err = NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	q, err = qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.NoError(t, q.Write(ctx, &windowRequests[0], true))
+	assert.NoError(t, q.(ContextGC).GCWithContext(ctx))
+	assert.Len(t, exporter.GetSpans(), 0)
+}
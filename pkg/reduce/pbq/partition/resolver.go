@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partition
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// Resolver maps the key of a message to the partition of a window, the same key in the same window always maps to the
+// same partition so that the reducers and the PBQ manager agree on the partition.
+type Resolver interface {
+	// Resolve returns the partition ID of the key for the window which starts at start and ends at end.
+	Resolve(start time.Time, end time.Time, key string) ID
+}
+
+// HashResolver is the default Resolver, it hashes the key into one of the shards, and the shard is used as the slot of
+// the partition.
+type HashResolver struct {
+	shards uint64
+}
+
+var _ Resolver = (*HashResolver)(nil)
+
+// NewHashResolver returns a HashResolver which spreads the keys across the given number of shards.
+func NewHashResolver(shards int) (*HashResolver, error) {
+	if shards <= 0 {
+		return nil, fmt.Errorf("number of shards should be positive, got %d", shards)
+	}
+	return &HashResolver{shards: uint64(shards)}, nil
+}
+
+// Shard returns the shard of the key, it is in [0, shards).
+func (h *HashResolver) Shard(key string) int {
+	if h.shards == 1 {
+		return 0
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum64() % h.shards)
+}
+
+// Resolve returns the partition ID of the key, the slot is "slot-<shard>".
+func (h *HashResolver) Resolve(start time.Time, end time.Time, key string) ID {
+	return ID{
+		Start: start,
+		End:   end,
+		Slot:  fmt.Sprintf("slot-%d", h.Shard(key)),
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partition
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashResolver_StableMapping(t *testing.T) {
+	start, end := time.Unix(60, 0), time.Unix(120, 0)
+	resolver, err := NewHashResolver(16)
+	assert.NoError(t, err)
+	another, err := NewHashResolver(16)
+	assert.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		id := resolver.Resolve(start, end, key)
+		assert.Equal(t, start, id.Start)
+		assert.Equal(t, end, id.End)
+		// the same key always maps to the same partition, across resolver instances
+		assert.Equal(t, id, resolver.Resolve(start, end, key))
+		assert.Equal(t, id, another.Resolve(start, end, key))
+		assert.Equal(t, fmt.Sprintf("slot-%d", resolver.Shard(key)), id.Slot)
+	}
+
+	// a single shard keeps the shared slot
+	single, err := NewHashResolver(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "slot-0", single.Resolve(start, end, "any").Slot)
+
+	_, err = NewHashResolver(0)
+	assert.Error(t, err)
+}
+
+func TestHashResolver_EvenDistribution(t *testing.T) {
+	shards := 8
+	keys := 80000
+	resolver, err := NewHashResolver(shards)
+	assert.NoError(t, err)
+
+	counts := make([]int, shards)
+	for i := 0; i < keys; i++ {
+		shard := resolver.Shard(fmt.Sprintf("customer-%d", i))
+		assert.GreaterOrEqual(t, shard, 0)
+		assert.Less(t, shard, shards)
+		counts[shard]++
+	}
+	expected := float64(keys / shards)
+	for shard, count := range counts {
+		assert.InDelta(t, expected, float64(count), expected*0.05, "shard %d", shard)
+	}
+}
@@ -18,18 +18,26 @@ package pbq
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
-	"github.com/numaproj/numaflow/pkg/metrics"
+	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 	"github.com/numaproj/numaflow/pkg/window"
 )
 
+// storeFullRetryInterval is the interval at which a write blocked on the full store is retried
+const storeFullRetryInterval = 10 * time.Millisecond
+
 // PBQ Buffer queue which is backed with a persisted store, each partition
 // will have a PBQ associated with it
 type PBQ struct {
@@ -38,50 +46,214 @@ type PBQ struct {
 	vertexReplica int32
 	store         wal.WAL
 	output        chan *window.TimedWindowRequest
-	cob           bool // cob to avoid panic in case writes happen after close of book
-	PartitionID   partition.ID
-	options       *options
-	manager       *Manager
-	windowType    window.Type
-	log           *zap.SugaredLogger
-	mu            sync.Mutex
+	// state is the State of the PBQ in its lifecycle
+	state atomic.Int32
+	// cobMu is read locked by a write from the state check until the request is written to the output channel, and
+	// locked by CloseOfBook, so that the output channel is never closed in the middle of a write. It guards the
+	// subscribers as well.
+	cobMu sync.RWMutex
+	// closing is closed by CloseOfBook before it waits for the in-flight writes, so that a write which is blocked on
+	// the full channel or on a subscriber bails out instead of holding up the close of book
+	closing     chan struct{}
+	closingOnce sync.Once
+	// writeMu is held by a write of a message from the store write until the request is written to the output channel,
+	// so that the concurrent writers of the partition deliver the messages in the order they are persisted
+	writeMu     sync.Mutex
+	PartitionID partition.ID
+	options     *options
+	manager     *Manager
+	windowType  window.Type
+	log         *zap.SugaredLogger
+	// restoredCOB is true if the book of the partition was closed before a restart, the persisted messages are still
+	// replayed but the new messages are refused
+	restoredCOB bool
+	// metricLabels are the labels of all the metrics emitted by the PBQ, and storeMetricLabels the ones of the metrics of
+	// the store writes, which carry the type of the store as well
+	metricLabels      map[string]string
+	storeMetricLabels map[string]string
+	// registeredAt is the time the PBQ was registered with the manager, it is the start of the lifetime of the partition
+	registeredAt time.Time
+	// lastWriteTime is the unix nano time of the last write, it is used to find the idle partitions
+	lastWriteTime atomic.Int64
+	// sweepJitter is added to the partition TTL before the idle partition is evicted, see WithSweepJitter
+	sweepJitter time.Duration
+	// limiter throttles the writes from the ISB, nil if the writes are not rate limited
+	limiter *rate.Limiter
+	// replayTotal is the number of messages persisted in the store when the PBQ was created
+	replayTotal int64
+	// replayed is the number of replayed messages written to the PBQ
+	replayed atomic.Int64
+	// replayComplete makes sure the replay complete callback is invoked only once
+	replayComplete sync.Once
+	// persistedIDs are the IDs of the messages persisted in the store, nil if the writes are not deduplicated
+	persistedIDs map[string]struct{}
+	// keys are the distinct keys of the messages written to the PBQ, nil if the keys are not tracked
+	keys atomic.Pointer[trackedKeys]
+	// subscribers are the channels of the subscribers, every message written to the PBQ is sent to each of them
+	subscribers []chan *isb.Message
+	// messagesWritten and bytesWritten count the messages accepted by the PBQ, see Stats
+	messagesWritten atomic.Int64
+	bytesWritten    atomic.Int64
+	// channelWriteCount counts the requests written to the channel, and forwardedCount the requests forwarded from the
+	// buffer to the output channel, see Stats
+	channelWriteCount atomic.Int64
+	forwardedCount    atomic.Int64
+	// readErrs are the errors of the reads from the store which are yet to be delivered by the iterator
+	readErrs chan error
+	// spillReader reads the spilled messages from the store, nil if spilling is disabled
+	spillReader wal.OffsetReader
+	// spilling is true while the partition has spilled messages which are yet to be delivered from the store, the new
+	// messages are only persisted until then to keep the order.
+	spilling bool
+	// spillWG waits for the delivery of the spilled messages
+	spillWG sync.WaitGroup
+	// undelivered are the ranges of the offsets of the persisted messages which are not written to the output channel,
+	// they are delivered from the store on close of book, and undeliveredRequest is the request of the first of them
+	undelivered        []offsetRange
+	undeliveredRequest *window.TimedWindowRequest
+	// stopDelivery stops the delivery of the undelivered messages once the PBQ is closed or garbage collected
+	stopDelivery     chan struct{}
+	stopDeliveryOnce sync.Once
+	// storeProvider is the WAL manager of the store, it changes when the partition is migrated to another backend
+	storeProvider wal.Manager
+	// migrating is true while the store is being migrated, the new messages are buffered in migrationBuffer until the
+	// migration is done
+	migrating       bool
+	migrationBuffer []*isb.ReadMessage
+	// acks tracks the acks of the messages read with ReadBatch, nil if the ack tracking is not enabled
+	acks *ackState
+	// buffer is the buffer of the requests which a goroutine forwards to the unbuffered output channel, nil if the
+	// channel buffer is neither elastic nor peekable, in which case the requests are written directly to the output
+	// channel. A resize swaps in the new buffer.
+	buffer atomic.Pointer[chan *window.TimedWindowRequest]
+	// forwarded is closed once the forwarding goroutine is done
+	forwarded chan struct{}
+	// peekCh sends the peeks to the forwarding goroutine, nil if peeking is not enabled
+	peekCh chan peekRequest
+	// leaseStop stops renewing the lease of the partition, nil if the partition is not leased
+	leaseStop     chan struct{}
+	leaseStopOnce sync.Once
+	// leaseLost is set once the lease of the partition is lost, the writes are refused from then on
+	leaseLost atomic.Pointer[wal.LeaseLostErr]
+	// storeClose is the last close of the store with the store close timeout, see closeStore
+	storeClose *storeClose
+	// lastFull is the last time a write found the buffer full
+	lastFull time.Time
+	mu       sync.Mutex
 }
 
 var _ ReadWriteCloser = (*PBQ)(nil)
 
 // Write accepts a window request and writes it to the PBQ, only the isb message is written to the store.
 // The other metadata like operation etc are recomputed from WAL.
-// request can never be nil.
+// A nil request, or an open, append or expand request without a message, is rejected with NilMessageErr, so the reader
+// of the output channel never gets a nil message. Write can be invoked concurrently, the messages are written to the
+// output channel in the order they are written to the store.
 func (p *PBQ) Write(ctx context.Context, request *window.TimedWindowRequest, persist bool) error {
-	var writeErr error
+	if request == nil {
+		return NilMessageErr{PartitionID: p.PartitionID}
+	}
+	switch request.Operation {
+	case window.Open, window.Append, window.Expand:
+		if request.ReadMessage == nil {
+			return NilMessageErr{PartitionID: p.PartitionID, Operation: request.Operation.String()}
+		}
+	}
+	if lost := p.leaseLost.Load(); lost != nil {
+		return *lost
+	}
+	p.lastWriteTime.Store(p.options.clock.Now().UnixNano())
+
+	// only the writes from the ISB are throttled, the replayed messages are already in the store. The write waits for
+	// the rate limit before it holds up the close of book.
+	if persist && request.ReadMessage != nil {
+		if err := p.waitForRateLimit(ctx, request.ReadMessage); err != nil {
+			return err
+		}
+	}
 
 	// if cob we should return
-	if p.cob {
+	p.cobMu.RLock()
+	switch state := p.State(); state {
+	case StateClosed:
+		p.cobMu.RUnlock()
 		p.log.Errorw("Failed to write request to pbq, pbq is closed", zap.Any("ID", p.PartitionID), zap.Any("request", request))
-		return fmt.Errorf("pbq is closed")
+		return COBErr{PartitionID: p.PartitionID}
+	case StateGarbageCollected:
+		p.cobMu.RUnlock()
+		to := StateLive
+		if !persist {
+			to = StateReplaying
+		}
+		return StateTransitionErr{PartitionID: p.PartitionID, From: state, To: to}
+	case StateCreated:
+		if !persist {
+			// a failed transition means the pbq went live concurrently, the replayed message is still written
+			_ = p.transition(StateReplaying)
+		}
 	}
 
 	// if the window operation is delete, we should close the output channel and return
 	if request.Operation == window.Delete {
+		p.cobMu.RUnlock()
 		p.CloseOfBook()
 		return nil
 	}
+	// the book cannot be closed until the request is written to the output channel, so a message is never persisted
+	// once the book is closed, and a persisted message is delivered unless the ctx is done
+	defer p.cobMu.RUnlock()
 
-	// write the request to the output channel
-	// since it is a blocking write, we should have a select with context,
-	select {
-	case p.output <- request:
-	case <-ctx.Done():
-		// we can persist the message even if the context is done that way we will not rely on
-		// the no-ack functionality of the buffer instead we will completely rely on the pbq to
-		// replay the messages in case of failure.
+	if persist && p.restoredCOB {
+		p.log.Errorw("Failed to write request to pbq, the book was closed before the restart", zap.Any("ID", p.PartitionID), zap.Any("request", request))
+		return COBErr{PartitionID: p.PartitionID}
 	}
 
 	switch request.Operation {
 	case window.Open, window.Append, window.Expand:
+		// the store write and the channel write of a message are not interleaved with the ones of another writer,
+		// otherwise the order of the output channel could diverge from the order of the store which is replayed
+		p.writeMu.Lock()
+		defer p.writeMu.Unlock()
+		// the message is persisted before it is written to the output channel, so that a message which the store
+		// refused, e.g. because the store is full, is never handed to the reducer. We persist the message even if
+		// the context is done, that way we will not rely on the no-ack functionality of the buffer instead we will
+		// completely rely on the pbq to replay the messages in case of failure. A store backed by a remote service
+		// will refuse the write with ctx.Err(), the message is then not acked and will be redelivered.
 		// during replay we do not have to persist
+		var spilling bool
 		if persist {
-			writeErr = p.store.Write(request.ReadMessage)
+			// a live message means there is nothing left to replay
+			p.completeReplay()
+			var err error
+			spilling, err = p.persist(ctx, request.ReadMessage)
+			if errors.Is(err, errMessageDropped) || errors.Is(err, errDuplicateMessage) {
+				// the store is full and the full policy drops the new messages, or the message is redelivered by the
+				// ISB and has already been written
+				return nil
+			} else if err != nil {
+				return err
+			}
+		} else {
+			if p.persistedIDs != nil {
+				// the redeliveries of the replayed messages are duplicates as well
+				p.mu.Lock()
+				p.persistedIDs[request.ReadMessage.ID.String()] = struct{}{}
+				p.mu.Unlock()
+			}
+			if p.replayed.Add(1) == p.replayTotal {
+				p.completeReplay()
+			}
+			pbqReplayMessagesCount.With(p.metricLabels).Inc()
+			pbqReplayBytesCount.With(p.metricLabels).Add(float64(len(request.ReadMessage.Payload)))
+		}
+		p.messagesWritten.Add(1)
+		p.bytesWritten.Add(int64(len(request.ReadMessage.Payload)))
+		p.trackKey(&request.ReadMessage.Message)
+		p.publish(ctx, request.ReadMessage)
+		// the message is delivered from the store after the previously spilled messages
+		if spilling {
+			pbqSpillMessagesCount.With(p.metricLabels).Inc()
+			return nil
 		}
 	case window.Close, window.Merge:
 	// these do not have request.ReadMessage, only metadata fields are used
@@ -89,46 +261,479 @@ func (p *PBQ) Write(ctx context.Context, request *window.TimedWindowRequest, per
 		return fmt.Errorf("unknown request.Operation, %v", request.Operation)
 	}
 
-	pbqChannelSize.With(map[string]string{
-		metrics.LabelVertex:             p.vertexName,
-		metrics.LabelPipeline:           p.pipelineName,
-		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(p.vertexReplica)),
-	}).Set(float64(len(p.output)))
+	// the channel is bypassed only for the persisted messages, since they can be read back from the store
+	var spillC <-chan time.Time
+	if p.spillReader != nil && persist && request.ReadMessage != nil {
+		timer := p.options.clock.NewTimer(p.options.spillTimeout)
+		defer timer.Stop()
+		spillC = timer.C()
+	}
+
+	// a persisted message which can be read back from the store is delivered from the store on close of book, so its
+	// write bails out once the book is closing
+	var closing <-chan struct{}
+	if persist && request.ReadMessage != nil && p.redeliverable() {
+		closing = p.closing
+	}
+	select {
+	case <-closing:
+		// the messages which bailed out before are delivered after the ones in the channel, so the following ones
+		// bail out too to keep the order
+		p.markUndelivered(request)
+		return nil
+	default:
+	}
+
+	// write the request to the output channel
+	// since it is a blocking write, we should have a select with context,
+	for written := false; !written; {
+		written = true
+		select {
+		case p.writeCh() <- request:
+			p.channelWriteCount.Add(1)
+			pbqChannelWriteCount.With(p.metricLabels).Inc()
+		case <-spillC:
+			if !p.startSpilling(ctx, request) {
+				// the write waits for the channel instead
+				spillC, written = nil, false
+			}
+		case <-closing:
+			p.markUndelivered(request)
+		case <-ctx.Done():
+			if persist && request.ReadMessage != nil {
+				// the message is delivered from the store on close of book
+				p.markUndelivered(request)
+			}
+		}
+	}
+
+	pbqChannelSize.With(p.metricLabels).Set(float64(len(p.currentCh())))
+
+	return nil
+}
+
+// startSpilling marks the partition as spilling from the persisted message of the request, which could not be written to
+// the full channel, and starts delivering the spilled messages from the store. It returns false if the partition is
+// being migrated, the message is then buffered by the migration and cannot be read from the store at its offset.
+func (p *PBQ) startSpilling(ctx context.Context, request *window.TimedWindowRequest) bool {
+	p.mu.Lock()
+	if p.migrating {
+		p.mu.Unlock()
+		return false
+	}
+	p.spilling = true
+	// the message of the request is the last one written to the store
+	offset := p.store.Size() - 1
+	p.mu.Unlock()
+
+	pbqSpillMessagesCount.With(p.metricLabels).Inc()
+	p.log.Warnw("PBQ channel is full, spilling to the store", zap.String("ID", p.PartitionID.String()), zap.Int64("offset", offset))
+	p.spillWG.Add(1)
+	go p.deliverSpilled(ctx, offset, request)
+	return true
+}
+
+// deliverSpilled writes the spilled messages from the offset of the store to the output channel, until it catches up
+// with the writes. The requests are rebuilt from the request of the first spilled message, since all the messages of an
+// aligned partition belong to the same window. If the delivery is stopped by the ctx or an error, the messages which
+// are not delivered stay in the store and are replayed after a restart.
+func (p *PBQ) deliverSpilled(ctx context.Context, offset int64, request *window.TimedWindowRequest) {
+	defer p.spillWG.Done()
+	for {
+		p.mu.Lock()
+		if p.store == nil || offset >= p.store.Size() {
+			// caught up, the following messages can be written to the channel
+			p.spilling = false
+			p.mu.Unlock()
+			return
+		}
+		_, span := p.startStoreSpan(ctx, spanStoreRead)
+		msgs, _, err := p.spillReader.ReadAt(offset, p.options.readBatchSize)
+		if span != nil {
+			endStoreSpan(span, len(msgs), err)
+		}
+		if err == nil && len(msgs) == 0 {
+			err = fmt.Errorf("no messages read at offset %d", offset)
+		}
+		if err != nil {
+			p.markSpillUndelivered(offset, request)
+		}
+		p.mu.Unlock()
+		if err != nil {
+			p.log.Errorw("Failed to read the spilled messages", zap.String("ID", p.PartitionID.String()), zap.Int64("offset", offset), zap.Error(err))
+			p.reportReadError(fmt.Errorf("failed to read the spilled messages at offset %d, %w", offset, err))
+			return
+		}
+
+		for _, msg := range msgs {
+			select {
+			case p.currentCh() <- &window.TimedWindowRequest{
+				ReadMessage: msg,
+				Operation:   window.Append,
+				Windows:     request.Windows,
+				ID:          request.ID,
+			}:
+				p.channelWriteCount.Add(1)
+				pbqChannelWriteCount.With(p.metricLabels).Inc()
+			case <-p.closing:
+				// the rest is delivered from the store by the close of book, which waits for this goroutine
+				p.mu.Lock()
+				p.markSpillUndelivered(offset, request)
+				p.mu.Unlock()
+				return
+			case <-ctx.Done():
+				p.mu.Lock()
+				p.markSpillUndelivered(offset, request)
+				p.mu.Unlock()
+				return
+			}
+			offset++
+		}
+	}
+}
+
+// waitForRateLimit blocks until the rate limit allows the message to be written, or returns the error of the ctx.
+func (p *PBQ) waitForRateLimit(ctx context.Context, msg *isb.ReadMessage) error {
+	if p.limiter == nil {
+		return nil
+	}
+	n := 1
+	if p.options.writeRateUnit == BytesPerSecond {
+		// a message larger than the burst takes the whole bucket, WaitN would fail otherwise
+		n = min(len(msg.Payload), p.limiter.Burst())
+	}
+	return p.limiter.WaitN(ctx, n)
+}
+
+// persist writes the message to the store. The boolean is true if the partition is spilling, in which case the message
+// is delivered from the store.
+func (p *PBQ) persist(ctx context.Context, msg *isb.ReadMessage) (spilling bool, err error) {
+	// the lock makes Close wait for the in-flight write
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.persistedIDs != nil {
+		if _, ok := p.persistedIDs[msg.ID.String()]; ok {
+			pbqDuplicateMessagesCount.With(p.metricLabels).Inc()
+			return false, errDuplicateMessage
+		}
+		defer func() {
+			if err == nil {
+				p.persistedIDs[msg.ID.String()] = struct{}{}
+			}
+		}()
+	}
+	if p.migrating {
+		p.migrationBuffer = append(p.migrationBuffer, msg)
+		return p.spilling, nil
+	}
+	if err := p.writeToStore(ctx, msg); err != nil {
+		return false, err
+	}
+	pbqStoreWriteCount.With(p.storeMetricLabels).Inc()
+	return p.spilling, nil
+}
+
+// writeToStore writes the message to the store, a write which finds the store full is handled according to the full
+// policy. errMessageDropped is returned if the policy drops the message. Caller should hold the lock, it is released
+// while the write waits for room in the store.
+func (p *PBQ) writeToStore(ctx context.Context, msg *isb.ReadMessage) (err error) {
+	var span trace.Span
+	if ctx, span = p.startStoreSpan(ctx, spanStoreWrite); span != nil {
+		defer func() { endStoreSpan(span, 1, err) }()
+	}
+	for {
+		err := p.writeWithRetry(ctx, func() error {
+			defer func(t time.Time) {
+				pbqStoreWriteTime.With(p.storeMetricLabels).Observe(float64(time.Since(t).Microseconds()))
+			}(time.Now())
+			return p.store.Write(ctx, msg)
+		})
+		if !errors.Is(err, aligned.ErrWriteStoreFull) {
+			return err
+		}
+		switch p.options.fullPolicy {
+		case FullPolicyDropNewest:
+			pbqStoreFullDropCount.With(p.storeMetricLabels).Inc()
+			p.log.Warnw("PBQ store is full, dropping the message", zap.String("ID", p.PartitionID.String()), zap.String("msgID", msg.ID.String()))
+			return errMessageDropped
+		case FullPolicyDropOldest:
+			evicter, ok := p.store.(wal.Evicter)
+			if !ok || !evicter.EvictOldest() {
+				return err
+			}
+			pbqStoreFullDropCount.With(p.storeMetricLabels).Inc()
+		case FullPolicyBlock:
+			p.mu.Unlock()
+			// the write holds up the close of book, so it gives up once the book is closing, the message is not
+			// persisted and is redelivered by the ISB
+			closing := false
+			select {
+			case <-p.options.clock.After(storeFullRetryInterval):
+			case <-p.closing:
+				closing = true
+			case <-ctx.Done():
+			}
+			p.mu.Lock()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if closing {
+				return COBErr{PartitionID: p.PartitionID}
+			}
+			// the store could have been garbage collected or migrated while the lock was released
+			if p.store == nil {
+				return aligned.ErrWriteStoreClosed
+			}
+			if p.migrating {
+				p.migrationBuffer = append(p.migrationBuffer, msg)
+				return nil
+			}
+		default:
+			return err
+		}
+	}
+}
 
-	return writeErr
+// writeWithRetry invokes the write until it succeeds or fails with an error which is not retryable, as long as the
+// attempts of the write retry are not exhausted. Caller should hold the lock, it is kept while waiting between the
+// attempts.
+func (p *PBQ) writeWithRetry(ctx context.Context, write func() error) error {
+	err := write()
+	if p.options.writeRetryAttempts <= 1 {
+		return err
+	}
+	delay := p.options.writeRetryBackoff.DelayFunc()
+	for attempt := 1; attempt < p.options.writeRetryAttempts && err != nil && p.options.writeRetryClassifier(err); attempt++ {
+		pbqStoreWriteRetryCount.With(p.storeMetricLabels).Inc()
+		p.log.Warnw("Failed to write to the PBQ store, retrying", zap.String("ID", p.PartitionID.String()), zap.Int("attempt", attempt), zap.Error(err))
+		timer := p.options.clock.NewTimer(delay())
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("stopped retrying the write to the store, %w", errors.Join(err, ctx.Err()))
+		}
+		err = write()
+	}
+	return err
 }
 
-// CloseOfBook closes output channel
+// CloseOfBook closes output channel. It is safe to invoke CloseOfBook more than once, since both the shutdown path
+// and the window close path can close the book of the same partition. It waits for the in-flight writes, the writes
+// which start after it are refused with COBErr, and the writes of the persisted messages which are blocked on the full
+// channel bail out. The persisted messages which were never written to the output channel, e.g. because their write was
+// canceled, are delivered from the store before the output channel is closed.
 func (p *PBQ) CloseOfBook() {
-	close(p.output)
-	p.cob = true
+	p.startClosing()
+	p.cobMu.Lock()
+	defer p.cobMu.Unlock()
+	// the spilled messages are delivered before the output channel is closed, no spilling starts once the in-flight
+	// writes are done
+	p.spillWG.Wait()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.State() >= StateClosed {
+		return
+	}
+	closeCh := func() {
+		if buffer := p.buffer.Load(); buffer != nil {
+			// the forwarding goroutine closes the output channel after the buffered requests
+			close(*buffer)
+		} else {
+			close(p.output)
+		}
+	}
+	if len(p.undelivered) > 0 && p.store != nil {
+		// the persisted messages which the reader has never got are delivered from the store before the end of the
+		// channel
+		go p.deliverUndelivered(p.undelivered, p.undeliveredRequest, closeCh)
+		p.undelivered, p.undeliveredRequest = nil, nil
+	} else {
+		closeCh()
+	}
+	p.closeSubscribers()
+	// the writes are blocked by cobMu, so nothing else moves the pbq out of a state before StateClosed
+	_ = p.transition(StateClosed)
+	p.persistBookClosed()
+}
+
+// startClosing makes the blocked writes bail out, see closing.
+func (p *PBQ) startClosing() {
+	if p.closing == nil {
+		return
+	}
+	p.closingOnce.Do(func() { close(p.closing) })
+}
+
+// persistBookClosed records the close of book in the metadata of the partition, if the store can persist it, so that the
+// partition keeps refusing the new messages after a restart. Caller should hold the lock.
+func (p *PBQ) persistBookClosed() {
+	metadataStore, ok := p.store.(wal.MetadataStore)
+	if !ok {
+		return
+	}
+	metadata, err := metadataStore.LoadMetadata()
+	if errors.Is(err, wal.ErrMetadataNotFound) {
+		metadata, err = &wal.PartitionMetadata{PartitionID: p.PartitionID}, nil
+	}
+	if err == nil {
+		metadata.BookClosed = true
+		err = metadataStore.PersistMetadata(*metadata)
+	}
+	if err != nil {
+		p.log.Errorw("Failed to persist the close of book", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+	}
 }
 
 // Close is used by the writer to indicate close of context
 // we should flush pending messages to store
 func (p *PBQ) Close() error {
+	// the lease is kept until it expires, the partition is replayed by this replica if it restarts in time
+	p.stopLease()
+	p.stopDeliveries()
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	// we need a nil check because PBQ.GC could have been invoked before close
 	if p.store != nil {
-		return p.store.Close()
+		return p.closeStore()
 	}
 	return nil
 }
 
+// DrainAndClose is used on shutdown, when the reader of the PBQ is going away. It closes the book, so no more writes are
+// accepted, discards the requests which are left in the output channel, then closes the store. The drained messages are
+// not lost, every message is persisted before it is written to the output channel, and the replayed messages are
+// already in the store, so they are replayed after a restart. DrainAndClose can be invoked after CloseOfBook, in which
+// case it drains what the reader has not read yet, and CloseOfBook is a no-op once DrainAndClose has been invoked. The
+// store is closed even if the ctx is done before the channel is drained, the error of the ctx is returned in that case.
+func (p *PBQ) DrainAndClose(ctx context.Context) error {
+	// CloseOfBook waits for the delivery of the spilled messages to the output channel, which is drained below
+	go p.CloseOfBook()
+
+	var drainErr error
+	drained := 0
+drainLoop:
+	for {
+		if drainErr = ctx.Err(); drainErr != nil {
+			break
+		}
+		select {
+		case _, ok := <-p.output:
+			if !ok {
+				break drainLoop
+			}
+			drained++
+		case <-ctx.Done():
+			drainErr = ctx.Err()
+			break drainLoop
+		}
+	}
+	p.log.Infow("Drained the PBQ", zap.String("ID", p.PartitionID.String()), zap.Int("drained", drained))
+
+	if err := p.Close(); err != nil {
+		return err
+	}
+	return drainErr
+}
+
+// sweepDeadline returns the time after which the partition is evicted by the sweeper of the manager, unless it is
+// written to before.
+func (p *PBQ) sweepDeadline() time.Time {
+	return time.Unix(0, p.lastWriteTime.Load()).Add(p.options.partitionTTL + p.sweepJitter)
+}
+
+// currentStore returns the store of the PBQ and its manager, the store is nil once the PBQ is garbage collected.
+func (p *PBQ) currentStore() (wal.WAL, wal.Manager) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.store, p.storeProvider
+}
+
+// ReplayProgress returns the number of messages replayed to the PBQ so far, and the number of messages which were
+// persisted in the store of the partition when the PBQ was created. Both are zero for a new partition.
+func (p *PBQ) ReplayProgress() (read int64, total int64) {
+	return p.replayed.Load(), p.replayTotal
+}
+
+// completeReplay marks the PBQ live and invokes the replay complete callback, if any, the first time it is called.
+func (p *PBQ) completeReplay() {
+	// the book of the pbq can be closed by the time the replay completes, the pbq then stays closed
+	_ = p.transition(StateLive)
+	if p.options.onReplayComplete == nil {
+		return
+	}
+	p.replayComplete.Do(func() {
+		p.options.onReplayComplete(p.PartitionID, p.replayed.Load())
+	})
+}
+
 // ReadCh exposes read channel to read the window requests from the PBQ
 // close on read channel indicates COB
 func (p *PBQ) ReadCh() <-chan *window.TimedWindowRequest {
 	return p.output
 }
 
+// ReadChWithContext returns a channel of the window requests of the PBQ, like ReadCh, which is closed on close of book
+// or as soon as the ctx is canceled, so that the reader does not have to select on the ctx itself. It consumes the
+// output channel, so a reader should use either ReadCh or ReadChWithContext. A request which is taken from the output
+// channel when the ctx is canceled is not delivered, it is replayed from the store after a restart.
+func (p *PBQ) ReadChWithContext(ctx context.Context) <-chan *window.TimedWindowRequest {
+	requests := make(chan *window.TimedWindowRequest)
+	go func() {
+		defer close(requests)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case request, ok := <-p.output:
+				if !ok {
+					return
+				}
+				select {
+				case requests <- request:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return requests
+}
+
 // GC cleans up the PBQ and also the store associated with it. GC is invoked after the Reader (ProcessAndForward) has
-// finished forwarding the output to ISB.
+// finished forwarding the output to ISB. GC does not close the book, the output channel of a PBQ whose book is still
+// open is left open. GC is idempotent, only the first call deregisters the PBQ, since the PBQ of an idle partition can
+// be evicted by the manager while it is garbage collected.
 func (p *PBQ) GC() error {
+	return p.GCWithContext(context.Background())
+}
+
+var _ ContextGC = (*PBQ)(nil)
+
+// GCWithContext is GC whose garbage collection is traced as a child of the span of the ctx, if the store tracing is
+// enabled. The message count of the span is the number of deleted messages.
+func (p *PBQ) GCWithContext(ctx context.Context) (err error) {
+	var deleted int64
+	if _, span := p.startStoreSpan(ctx, spanStoreGC); span != nil {
+		defer func() { endStoreSpan(span, int(deleted), err) }()
+	}
+	p.stopDeliveries()
 	// we need a lock because Close() and PBQ.GC() can be invoked simultaneously
 	// by shutdown routine(pbq.GC in case of ctx close) and pnf(pbq.Close after forwarding the result)
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if p.store == nil {
+		return nil
+	}
+	if err = p.transition(StateGarbageCollected); err != nil {
+		return err
+	}
+	deleted = p.store.Size()
 	p.store = nil
-	return p.manager.deregister(p.PartitionID)
+	err = p.manager.deregister(p.PartitionID, p.storeProvider)
+	if p.leaseStop != nil {
+		p.stopLease()
+		p.manager.releaseLease(p.PartitionID)
+	}
+	return err
 }
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// BenchmarkPBQ_WriteRead measures the round trip of a message per op, from the write to the PBQ, which persists it in
+// the memory store, to the read from the output channel.
+func BenchmarkPBQ_WriteRead(b *testing.B) {
+	for _, payloadSize := range []int{128, 1024} {
+		b.Run(fmt.Sprintf("payload=%d", payloadSize), func(b *testing.B) {
+			ctx := context.Background()
+			qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(int64(b.N))),
+				window.Aligned, WithChannelBufferSize(100))
+			if err != nil {
+				b.Fatal(err)
+			}
+			pq, err := qManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "bench"})
+			if err != nil {
+				b.Fatal(err)
+			}
+			windowRequests := testutils.BuildTestWindowRequests(100, time.Unix(60, 0), window.Append)
+			for i := range windowRequests {
+				payload := make([]byte, payloadSize)
+				copy(payload, windowRequests[i].ReadMessage.Payload)
+				windowRequests[i].ReadMessage.Payload = payload
+			}
+
+			read := make(chan int)
+			b.ReportAllocs()
+			b.ResetTimer()
+			go func() {
+				count := 0
+				for range pq.ReadCh() {
+					count++
+				}
+				read <- count
+			}()
+			for i := 0; i < b.N; i++ {
+				if err = pq.Write(ctx, &windowRequests[i%len(windowRequests)], true); err != nil {
+					b.Fatal(err)
+				}
+			}
+			pq.CloseOfBook()
+			if count := <-read; count != b.N {
+				b.Fatalf("read %d messages, expected %d", count, b.N)
+			}
+		})
+	}
+}
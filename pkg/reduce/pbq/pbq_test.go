@@ -17,16 +17,28 @@ package pbq
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clocktesting "k8s.io/utils/clock/testing"
 
+	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/metrics"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
 	"github.com/numaproj/numaflow/pkg/window"
 )
 
@@ -160,6 +172,52 @@ func Test_PBQReadWithCanceledContext(t *testing.T) {
 	assert.Len(t, readRequests, 10)
 }
 
+func TestPBQ_ReadChWithContext(t *testing.T) {
+	ctx := context.Background()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	t.Run("context canceled", func(t *testing.T) {
+		pq, err := qManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+		assert.NoError(t, err)
+		windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+
+		readCtx, cancel := context.WithCancel(ctx)
+		readCh := pq.(*PBQ).ReadChWithContext(readCtx)
+		for range windowRequests {
+			<-readCh
+		}
+		// the book is not closed, the channel is closed because of the canceled context
+		cancel()
+		select {
+		case _, ok := <-readCh:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("the read channel is not closed after the context is canceled")
+		}
+	})
+
+	t.Run("close of book", func(t *testing.T) {
+		pq, err := qManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"})
+		assert.NoError(t, err)
+		windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		pq.CloseOfBook()
+
+		var readRequests []*window.TimedWindowRequest
+		for request := range pq.(*PBQ).ReadChWithContext(ctx) {
+			readRequests = append(readRequests, request)
+		}
+		assert.Len(t, readRequests, len(windowRequests))
+	})
+}
+
 func TestPBQ_WriteWithStoreFull(t *testing.T) {
 
 	// create a store of size 100 (it can store max 100 messages)
@@ -195,3 +253,1091 @@ func TestPBQ_WriteWithStoreFull(t *testing.T) {
 
 	assert.Error(t, err, aligned.ErrWriteStoreFull)
 }
+
+func TestPBQ_WriteStoreFullNotEnqueued(t *testing.T) {
+	ctx := context.Background()
+	storeSize := int64(10)
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(storeSize))
+
+	// the channel has room for more messages than the store
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider,
+		window.Aligned, WithChannelBufferSize(2*storeSize), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "slot-1",
+	}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	windowRequests := testutils.BuildTestWindowRequests(storeSize+1, time.Now(), window.Append)
+	for i := int64(0); i < storeSize; i++ {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+	}
+	err = pq.Write(ctx, &windowRequests[storeSize], true)
+	assert.ErrorIs(t, err, aligned.ErrWriteStoreFull)
+
+	// the message which could not be persisted is not handed to the reducer
+	assert.Len(t, pq.ReadCh(), persistedCount(t, storeProvider))
+}
+
+func TestPBQ_CloseOfBookConcurrent(t *testing.T) {
+	ctx := context.Background()
+
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "slot-1",
+	}
+
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// invoke cob from multiple go routines, none of them should panic
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NotPanics(t, pq.CloseOfBook)
+		}()
+	}
+	wg.Wait()
+
+	// the output channel should be closed
+	_, ok := <-pq.ReadCh()
+	assert.False(t, ok)
+}
+
+func TestPBQ_WriteConcurrentWithCloseOfBook(t *testing.T) {
+	ctx := context.Background()
+	writers, perWriter := 8, 200
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(int64(writers*perWriter))),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	delivered := make(map[string]struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for request := range pq.ReadCh() {
+			delivered[request.ReadMessage.ID.String()] = struct{}{}
+		}
+	}()
+
+	// the book is closed while the writers are hammering the pbq
+	windowRequests := testutils.BuildTestWindowRequests(int64(writers*perWriter), time.Now(), window.Append)
+	var (
+		accepted = make(map[string]struct{})
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w * perWriter; i < (w+1)*perWriter; i++ {
+				err := pq.Write(ctx, &windowRequests[i], true)
+				if err != nil {
+					assert.ErrorAs(t, err, &COBErr{})
+					continue
+				}
+				mu.Lock()
+				accepted[windowRequests[i].ReadMessage.ID.String()] = struct{}{}
+				mu.Unlock()
+			}
+		}(w)
+	}
+	assert.Eventually(t, func() bool {
+		return pq.(*PBQ).messagesWritten.Load() >= int64(writers*perWriter/2)
+	}, 5*time.Second, time.Millisecond)
+	assert.NotPanics(t, pq.CloseOfBook)
+	wg.Wait()
+	<-done
+
+	// every accepted message is delivered, and every persisted message is an accepted one
+	assert.Equal(t, accepted, delivered)
+	persisted, _, err := pq.(*PBQ).store.(wal.OffsetReader).ReadAt(0, int64(writers*perWriter))
+	assert.NoError(t, err)
+	assert.Len(t, persisted, len(accepted))
+	for _, msg := range persisted {
+		assert.Contains(t, accepted, msg.ID.String())
+	}
+	assert.Less(t, len(accepted), writers*perWriter)
+}
+
+func TestPBQ_WritePersistsBeforeDelivery(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(100))
+
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned,
+		WithChannelBufferSize(100), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "slot-1",
+	}
+
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// every message the reader gets is already in the store
+	windowRequests := testutils.BuildTestWindowRequests(12, time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		assert.Equal(t, i+1, persistedCount(t, storeProvider))
+		assert.Len(t, pq.ReadCh(), i+1)
+	}
+	assert.NoError(t, pq.Close())
+}
+
+// persistedCount returns the number of messages persisted in the only store of the store provider.
+func persistedCount(t *testing.T, storeProvider wal.Manager) int {
+	stores, err := storeProvider.DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, stores, 1)
+	msgCh, _ := stores[0].Replay()
+	count := 0
+	for msg := range msgCh {
+		if msg != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPBQ_Metrics(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(100))
+
+	labels := map[string]string{
+		metrics.LabelVertex:             "reduce-metrics",
+		metrics.LabelPipeline:           "test-pipeline",
+		metrics.LabelVertexReplicaIndex: "0",
+	}
+	storeLabels := storeMetricLabels(labels, "*memory.memManager")
+	// counters are global, so only the increments made by this test are asserted
+	storeWrites := testutil.ToFloat64(pbqStoreWriteCount.With(storeLabels))
+	writeTime := &dto.Metric{}
+	assert.NoError(t, pbqStoreWriteTime.With(storeLabels).(prometheus.Histogram).Write(writeTime))
+	storeWriteSamples := writeTime.GetHistogram().GetSampleCount()
+	channelWrites := testutil.ToFloat64(pbqChannelWriteCount.With(labels))
+	replayMessages := testutil.ToFloat64(pbqReplayMessagesCount.With(labels))
+	replayBytes := testutil.ToFloat64(pbqReplayBytesCount.With(labels))
+
+	// use a dedicated vertex name so that the counters are not shared with the other tests
+	qManager, err := NewManager(ctx, "reduce-metrics", "test-pipeline", 0, storeProvider, window.Aligned,
+		WithChannelBufferSize(100), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "slot-1",
+	}
+
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+	for _, req := range windowRequests {
+		assert.NoError(t, pq.Write(ctx, &req, true))
+	}
+
+	replayRequests := testutils.BuildTestWindowRequests(3, time.Now(), window.Append)
+	for _, req := range replayRequests {
+		replayBytes += float64(len(req.ReadMessage.Payload))
+		assert.NoError(t, pq.Write(ctx, &req, false))
+	}
+
+	assert.Equal(t, storeWrites+5, testutil.ToFloat64(pbqStoreWriteCount.With(storeLabels)))
+	assert.NoError(t, pbqStoreWriteTime.With(storeLabels).(prometheus.Histogram).Write(writeTime))
+	assert.Equal(t, storeWriteSamples+5, writeTime.GetHistogram().GetSampleCount())
+	assert.Equal(t, channelWrites+8, testutil.ToFloat64(pbqChannelWriteCount.With(labels)))
+	assert.Equal(t, replayMessages+3, testutil.ToFloat64(pbqReplayMessagesCount.With(labels)))
+	assert.Equal(t, replayBytes, testutil.ToFloat64(pbqReplayBytesCount.With(labels)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(activePartitionCount.With(labels)))
+	assert.Equal(t, float64(8), testutil.ToFloat64(pbqChannelSize.With(labels)))
+
+	assert.NoError(t, pq.Close())
+	assert.NoError(t, pq.GC())
+}
+
+// blockingWAL is a WAL whose writes block until the context is canceled, like a slow remote store.
+type blockingWAL struct {
+	wal.WAL
+}
+
+func (b *blockingWAL) Write(ctx context.Context, _ *isb.ReadMessage) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// blockingManager creates blockingWALs on top of the given manager.
+type blockingManager struct {
+	wal.Manager
+}
+
+func (m *blockingManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	w, err := m.Manager.CreateWAL(ctx, partitionID)
+	if err != nil {
+		return nil, err
+	}
+	return &blockingWAL{WAL: w}, nil
+}
+
+func TestPBQ_WriteCanceledByContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, &blockingManager{Manager: memory.NewMemManager(memory.WithStoreSize(10))},
+		window.Aligned, WithChannelBufferSize(10), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+
+	pq, err := qManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+	assert.NoError(t, err)
+
+	writeRequests := testutils.BuildTestWindowRequests(1, time.Now(), window.Append)
+	errCh := make(chan error)
+	go func() {
+		errCh <- pq.Write(ctx, &writeRequests[0], true)
+	}()
+
+	select {
+	case err = <-errCh:
+		t.Fatalf("write should block until the context is canceled, got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case err = <-errCh:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("write did not unblock after the context was canceled")
+	}
+	pq.CloseOfBook()
+}
+
+func TestPBQ_WriteRateLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	limit := 200.0
+	count := 100
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(int64(2*count))),
+		window.Aligned, WithChannelBufferSize(int64(2*count)), WithReadTimeout(1*time.Second), WithWriteRateLimit(limit, 1, MessagesPerSecond))
+	assert.NoError(t, err)
+
+	pq, err := qManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+	assert.NoError(t, err)
+
+	// the replayed messages are not throttled
+	writeRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+	start := time.Now()
+	for i := range writeRequests {
+		assert.NoError(t, pq.Write(ctx, &writeRequests[i], false))
+	}
+	assert.Less(t, time.Since(start), time.Duration(float64(count)/limit*float64(time.Second))/2)
+
+	// the writes from the ISB are pushed as fast as possible, the observed rate stays within the tolerance of the limit
+	start = time.Now()
+	for i := range writeRequests {
+		assert.NoError(t, pq.Write(ctx, &writeRequests[i], true))
+	}
+	observedRate := float64(count) / time.Since(start).Seconds()
+	assert.InDelta(t, limit, observedRate, limit*0.2)
+
+	// a throttled write gives up once the ctx is done
+	canceledCtx, cancelWrite := context.WithCancel(ctx)
+	cancelWrite()
+	assert.Error(t, pq.Write(canceledCtx, &writeRequests[0], true))
+	pq.CloseOfBook()
+}
+
+func TestWithWriteRateLimit(t *testing.T) {
+	opts := DefaultOptions()
+	assert.NoError(t, WithWriteRateLimit(1024, 512, BytesPerSecond)(opts))
+	assert.Equal(t, 1024.0, opts.writeRateLimit)
+	assert.Equal(t, 512, opts.writeRateBurst)
+	assert.Equal(t, BytesPerSecond, opts.writeRateUnit)
+
+	assert.Error(t, WithWriteRateLimit(0, 1, MessagesPerSecond)(opts))
+	assert.Error(t, WithWriteRateLimit(10, 0, MessagesPerSecond)(opts))
+}
+
+func TestPBQ_ReplayProgress(t *testing.T) {
+	ctx := context.Background()
+	count := 10
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+
+	// the messages persisted before the restart
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(int64(2 * count)))
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+	for i := range writeRequests {
+		assert.NoError(t, store.Write(ctx, writeRequests[i].ReadMessage))
+	}
+
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider,
+		window.Aligned, WithChannelBufferSize(int64(2*count)), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q := pq.(*PBQ)
+
+	read, total := q.ReplayProgress()
+	assert.Equal(t, int64(0), read)
+	assert.Equal(t, int64(count), total)
+
+	// replay half of the persisted messages
+	for i := range writeRequests[:count/2] {
+		assert.NoError(t, pq.Write(ctx, &writeRequests[i], false))
+	}
+	read, total = q.ReplayProgress()
+	assert.Equal(t, int64(count/2), read)
+	assert.Equal(t, int64(count), total)
+
+	// the writes from the ISB are not part of the replay
+	assert.NoError(t, pq.Write(ctx, &writeRequests[0], true))
+	read, total = q.ReplayProgress()
+	assert.Equal(t, int64(count/2), read)
+	assert.Equal(t, int64(count), total)
+	pq.CloseOfBook()
+}
+
+func TestPBQ_ReplayCompleteCallback(t *testing.T) {
+	ctx := context.Background()
+	count := 10
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+
+	// the messages persisted before the restart
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(int64(2 * count)))
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+	for i := range writeRequests {
+		assert.NoError(t, store.Write(ctx, writeRequests[i].ReadMessage))
+	}
+
+	var calls int
+	var gotPartitionID partition.ID
+	var gotReplayed int64
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider,
+		window.Aligned, WithChannelBufferSize(int64(2*count)), WithReadTimeout(1*time.Second),
+		WithReplayCompleteCallback(func(partitionID partition.ID, replayed int64) {
+			calls++
+			gotPartitionID = partitionID
+			gotReplayed = replayed
+		}))
+	assert.NoError(t, err)
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// drain the store
+	for i := range writeRequests {
+		assert.NoError(t, pq.Write(ctx, &writeRequests[i], false))
+		if i < count-1 {
+			assert.Equal(t, 0, calls)
+		}
+	}
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, partitionID, gotPartitionID)
+	assert.Equal(t, int64(count), gotReplayed)
+
+	// the live writes do not fire the callback again
+	assert.NoError(t, pq.Write(ctx, &writeRequests[0], true))
+	assert.Equal(t, 1, calls)
+	pq.CloseOfBook()
+
+	// a partition without persisted messages completes its replay on the first live write
+	newPartitionID := partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"}
+	pq, err = qManager.CreateNewPBQ(ctx, newPartitionID)
+	assert.NoError(t, err)
+	assert.NoError(t, pq.Write(ctx, &writeRequests[0], true))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, newPartitionID, gotPartitionID)
+	assert.Equal(t, int64(0), gotReplayed)
+	pq.CloseOfBook()
+}
+
+func TestPBQ_SpillOnBackpressure(t *testing.T) {
+	ctx := context.Background()
+	count := 20
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(int64(count)))
+	qManager, err := NewManager(ctx, "reduce-spill", "test-pipeline", 0, storeProvider, window.Aligned,
+		WithChannelBufferSize(2), WithReadTimeout(1*time.Second), WithReadBatchSize(3), WithSpillOnBackpressure(5*time.Millisecond))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// a slow reader keeps the channel full
+	var readMessages []*isb.ReadMessage
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for request := range pq.ReadCh() {
+			readMessages = append(readMessages, request.ReadMessage)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	windowRequests := testutils.BuildTestWindowRequests(int64(count), time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+	}
+	pq.CloseOfBook()
+	<-done
+
+	// no message is lost and the order is preserved
+	assert.Len(t, readMessages, count)
+	for i, msg := range readMessages {
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, msg.Header.ID)
+	}
+	labels := map[string]string{
+		metrics.LabelVertex:             "reduce-spill",
+		metrics.LabelPipeline:           "test-pipeline",
+		metrics.LabelVertexReplicaIndex: "0",
+	}
+	assert.Greater(t, testutil.ToFloat64(pbqSpillMessagesCount.With(labels)), float64(0))
+
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithSpillOnBackpressure(0))
+	assert.Error(t, err)
+}
+
+func TestPBQ_SpillTimeoutWithFakeClock(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+		WithChannelBufferSize(2), WithSpillOnBackpressure(time.Hour), WithClock(fakeClock))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	windowRequests := testutils.BuildTestWindowRequests(3, time.Now(), window.Append)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+	}
+
+	// the write to the full channel waits on the spill timer until the clock is advanced past the timeout
+	written := make(chan error)
+	go func() {
+		written <- pq.Write(ctx, &windowRequests[2], true)
+	}()
+	assert.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	select {
+	case <-written:
+		t.Fatal("the write returned before the spill timeout")
+	default:
+	}
+	fakeClock.Step(time.Hour)
+	assert.NoError(t, <-written)
+
+	// the spilled message is delivered from the store after the buffered ones
+	for i := range windowRequests {
+		request := <-pq.ReadCh()
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, request.ReadMessage.Header.ID)
+	}
+	pq.CloseOfBook()
+
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithClock(nil))
+	assert.Error(t, err)
+}
+
+func TestPBQ_ElasticChannelBuffer(t *testing.T) {
+	ctx := context.Background()
+	idleTimeout := 50 * time.Millisecond
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned,
+		WithChannelBufferSize(2), WithElasticChannelBuffer(16, idleTimeout))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q := pq.(*PBQ)
+
+	// a burst while nothing is read grows the buffer instead of stalling the writer
+	count := 12
+	windowRequests := testutils.BuildTestWindowRequests(int64(count+1), time.Now(), window.Append)
+	writeCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	for i := 0; i < count; i++ {
+		assert.NoError(t, pq.Write(writeCtx, &windowRequests[i], true))
+	}
+	assert.Equal(t, 16, cap(q.currentCh()))
+
+	// the requests are not reordered by the resizes
+	for i := 0; i < count; i++ {
+		request := <-pq.ReadCh()
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, request.ReadMessage.Header.ID)
+	}
+
+	// the idle buffer shrinks back
+	time.Sleep(2 * idleTimeout)
+	assert.NoError(t, pq.Write(writeCtx, &windowRequests[count], true))
+	assert.Equal(t, 2, cap(q.currentCh()))
+	request := <-pq.ReadCh()
+	assert.Equal(t, windowRequests[count].ReadMessage.Header.ID, request.ReadMessage.Header.ID)
+
+	pq.CloseOfBook()
+	_, ok := <-pq.ReadCh()
+	assert.False(t, ok)
+
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithElasticChannelBuffer(16, 0))
+	assert.Error(t, err)
+}
+
+func TestPBQ_Peek(t *testing.T) {
+	ctx := context.Background()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned,
+		WithChannelBufferSize(10), WithPeek())
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q := pq.(*PBQ)
+
+	windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+	}
+
+	peeked, err := q.Peek(3)
+	assert.NoError(t, err)
+	assert.Len(t, peeked, 3)
+	// peeking again returns the same requests
+	again, err := q.Peek(2)
+	assert.NoError(t, err)
+	assert.Equal(t, peeked[:2], again)
+
+	// the peeked requests are read in order, followed by the rest
+	for i := range windowRequests {
+		request := <-pq.ReadCh()
+		if i < len(peeked) {
+			assert.Same(t, peeked[i], request)
+		}
+		assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, request.ReadMessage.Header.ID)
+	}
+
+	// there is nothing buffered to peek at
+	peeked, err = q.Peek(3)
+	assert.NoError(t, err)
+	assert.Len(t, peeked, 0)
+
+	pq.CloseOfBook()
+	_, ok := <-pq.ReadCh()
+	assert.False(t, ok)
+	peeked, err = q.Peek(3)
+	assert.NoError(t, err)
+	assert.Len(t, peeked, 0)
+
+	// peeking is not enabled by default
+	qManager, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned)
+	assert.NoError(t, err)
+	pq, err = qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	_, err = pq.(*PBQ).Peek(1)
+	assert.ErrorIs(t, err, ErrPeekDisabled)
+}
+
+func TestPBQ_GCIdempotent(t *testing.T) {
+	ctx := context.Background()
+	walManager := fake.NewManager()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, walManager, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.NoError(t, pq.GC())
+	assert.NotPanics(t, func() { assert.NoError(t, pq.GC()) })
+	assert.Equal(t, []partition.ID{partitionID}, walManager.Deleted())
+
+	// concurrent GCs, e.g. the pnf and the eviction of the idle partition, deregister the pbq once
+	partitionID = partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"}
+	pq, err = qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NotPanics(t, func() { assert.NoError(t, pq.GC()) })
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, walManager.Deleted(), 2)
+	assert.Len(t, qManager.ListPartitions(), 0)
+}
+
+func TestPBQ_DrainAndClose(t *testing.T) {
+	ctx := context.Background()
+	walManager := fake.NewManager()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, walManager, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	t.Run("buffered messages", func(t *testing.T) {
+		partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		store, _ := walManager.GetWAL(partitionID)
+		assert.Len(t, pq.ReadCh(), len(windowRequests))
+
+		assert.NoError(t, pq.(*PBQ).DrainAndClose(ctx))
+		// the drained messages are persisted, the channel is closed and the writes are refused
+		assert.Len(t, store.Messages(), len(windowRequests))
+		assert.True(t, store.IsClosed())
+		_, ok := <-pq.ReadCh()
+		assert.False(t, ok)
+		assert.Error(t, pq.Write(ctx, &windowRequests[0], true))
+		// the book is already closed
+		assert.NotPanics(t, pq.CloseOfBook)
+	})
+
+	t.Run("after close of book", func(t *testing.T) {
+		partitionID := partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"}
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		pq.CloseOfBook()
+		// the reader reads some of the messages before it goes away
+		<-pq.ReadCh()
+		<-pq.ReadCh()
+
+		assert.NoError(t, pq.(*PBQ).DrainAndClose(ctx))
+		store, _ := walManager.GetWAL(partitionID)
+		assert.Len(t, store.Messages(), len(windowRequests))
+		assert.True(t, store.IsClosed())
+	})
+
+	t.Run("ctx done", func(t *testing.T) {
+		partitionID := partition.ID{Start: time.Unix(180, 0), End: time.Unix(240, 0), Slot: "slot-1"}
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+		windowRequests := testutils.BuildTestWindowRequests(1, time.Now(), window.Append)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		// the store is closed even if the channel could not be drained
+		err = pq.(*PBQ).DrainAndClose(cctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		store, _ := walManager.GetWAL(partitionID)
+		assert.Len(t, store.Messages(), 1)
+		assert.True(t, store.IsClosed())
+	})
+}
+
+func TestPBQ_FullPolicy(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	// the store has room for 2 messages
+	storeSize := int64(2)
+	windowRequests := testutils.BuildTestWindowRequests(storeSize+1, time.Now(), window.Append)
+
+	newPBQ := func(t *testing.T, storeProvider wal.Manager, policy FullPolicy) ReadWriteCloser {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned,
+			WithChannelBufferSize(10), WithFullPolicy(policy))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+		return pq
+	}
+	persistedIDs := func(storeProvider wal.Manager) []string {
+		var ids []string
+		stores, _ := storeProvider.DiscoverWALs(ctx)
+		msgCh, _ := stores[0].Replay()
+		for msg := range msgCh {
+			ids = append(ids, msg.ID.String())
+		}
+		return ids
+	}
+
+	t.Run("error", func(t *testing.T) {
+		storeProvider := memory.NewMemManager(memory.WithStoreSize(storeSize))
+		pq := newPBQ(t, storeProvider, FullPolicyError)
+		for i := int64(0); i < storeSize; i++ {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		assert.ErrorIs(t, pq.Write(ctx, &windowRequests[storeSize], true), aligned.ErrWriteStoreFull)
+		assert.Len(t, pq.ReadCh(), int(storeSize))
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		storeProvider := memory.NewMemManager(memory.WithStoreSize(storeSize))
+		pq := newPBQ(t, storeProvider, FullPolicyDropNewest)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		// the dropped message is neither persisted nor handed to the reducer
+		assert.Equal(t, []string{windowRequests[0].ReadMessage.ID.String(), windowRequests[1].ReadMessage.ID.String()}, persistedIDs(storeProvider))
+		assert.Len(t, pq.ReadCh(), int(storeSize))
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		storeProvider := memory.NewMemManager(memory.WithStoreSize(storeSize))
+		pq := newPBQ(t, storeProvider, FullPolicyDropOldest)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		// the oldest message is evicted from the store, the new one is handed to the reducer
+		assert.Equal(t, []string{windowRequests[1].ReadMessage.ID.String(), windowRequests[2].ReadMessage.ID.String()}, persistedIDs(storeProvider))
+		assert.Len(t, pq.ReadCh(), len(windowRequests))
+	})
+
+	t.Run("drop oldest without eviction", func(t *testing.T) {
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, aligned.ErrWriteStoreFull))
+		pq := newPBQ(t, storeProvider, FullPolicyDropOldest)
+		// the fake store cannot evict, the write fails
+		assert.ErrorIs(t, pq.Write(ctx, &windowRequests[0], true), aligned.ErrWriteStoreFull)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		// the store frees up room after the first two attempts
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, aligned.ErrWriteStoreFull), fake.WithWriteErr(2, aligned.ErrWriteStoreFull))
+		pq := newPBQ(t, storeProvider, FullPolicyBlock)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		assert.Equal(t, []string{windowRequests[0].ReadMessage.ID.String()}, persistedIDs(storeProvider))
+		assert.Len(t, pq.ReadCh(), 1)
+
+		// the write gives up once the context is done
+		pq = newPBQ(t, memory.NewMemManager(memory.WithStoreSize(storeSize)), FullPolicyBlock)
+		for i := int64(0); i < storeSize; i++ {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, pq.Write(cctx, &windowRequests[storeSize], true), context.DeadlineExceeded)
+		assert.Len(t, pq.ReadCh(), int(storeSize))
+		// the lock is not held by the blocked write
+		assert.NoError(t, pq.Close())
+	})
+
+	t.Run("block until cob", func(t *testing.T) {
+		pq := newPBQ(t, memory.NewMemManager(memory.WithStoreSize(storeSize)), FullPolicyBlock)
+		for i := int64(0); i < storeSize; i++ {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		written := make(chan error)
+		go func() { written <- pq.Write(ctx, &windowRequests[storeSize], true) }()
+		// the write blocked on the full store gives up on cob, it does not hold up the cob
+		cobDone := make(chan struct{})
+		go func() {
+			pq.CloseOfBook()
+			close(cobDone)
+		}()
+		select {
+		case err := <-written:
+			assert.ErrorAs(t, err, &COBErr{})
+		case <-time.After(time.Second):
+			assert.Fail(t, "the blocked write did not give up on cob")
+		}
+		<-cobDone
+		assert.Len(t, pq.ReadCh(), int(storeSize))
+		assert.NoError(t, pq.Close())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithFullPolicy(FullPolicy(10)))
+		assert.Error(t, err)
+	})
+}
+
+func TestPBQ_Dedup(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	windowRequests := testutils.BuildTestWindowRequests(2, time.Now(), window.Append)
+
+	t.Run("enabled", func(t *testing.T) {
+		storeProvider := fake.NewManager()
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned,
+			WithChannelBufferSize(10), WithDedup())
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		// the same message is written twice
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Len(t, store.Messages(), 1)
+		assert.Len(t, pq.ReadCh(), 1)
+
+		// a replayed message is not persisted again when it is redelivered
+		assert.NoError(t, pq.Write(ctx, &windowRequests[1], false))
+		assert.NoError(t, pq.Write(ctx, &windowRequests[1], true))
+		assert.Len(t, store.Messages(), 1)
+		assert.Len(t, pq.ReadCh(), 2)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		storeProvider := fake.NewManager()
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Len(t, store.Messages(), 2)
+	})
+}
+
+func TestPBQ_WriteRetry(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	windowRequests := testutils.BuildTestWindowRequests(1, time.Now(), window.Append)
+	transientErr := errors.New("connection reset by peer")
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 2}
+
+	newPBQ := func(t *testing.T, storeProvider *fake.Manager, opts ...PBQOption) ReadWriteCloser {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned,
+			append([]PBQOption{WithChannelBufferSize(10)}, opts...)...)
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+		return pq
+	}
+
+	t.Run("transient failures", func(t *testing.T) {
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, transientErr), fake.WithWriteErr(2, transientErr))
+		pq := newPBQ(t, storeProvider, WithWriteRetry(3, backoff))
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Equal(t, 3, store.Writes())
+		assert.Len(t, store.Messages(), 1)
+		assert.Len(t, pq.ReadCh(), 1)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, transientErr), fake.WithWriteErr(2, transientErr))
+		pq := newPBQ(t, storeProvider, WithWriteRetry(2, backoff))
+		assert.ErrorIs(t, pq.Write(ctx, &windowRequests[0], true), transientErr)
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Equal(t, 2, store.Writes())
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, aligned.ErrWriteStoreClosed))
+		pq := newPBQ(t, storeProvider, WithWriteRetry(3, backoff))
+		assert.ErrorIs(t, pq.Write(ctx, &windowRequests[0], true), aligned.ErrWriteStoreClosed)
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Equal(t, 1, store.Writes())
+	})
+
+	t.Run("classifier", func(t *testing.T) {
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, transientErr))
+		pq := newPBQ(t, storeProvider, WithWriteRetry(3, backoff), WithWriteRetryClassifier(func(err error) bool {
+			return !errors.Is(err, transientErr)
+		}))
+		assert.ErrorIs(t, pq.Write(ctx, &windowRequests[0], true), transientErr)
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Equal(t, 1, store.Writes())
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		storeProvider := fake.NewManager(fake.WithWriteErr(1, transientErr))
+		pq := newPBQ(t, storeProvider, WithWriteRetry(3, wait.Backoff{Duration: time.Minute}))
+		err := pq.Write(cctx, &windowRequests[0], true)
+		assert.ErrorIs(t, err, transientErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		store, _ := storeProvider.GetWAL(partitionID)
+		assert.Equal(t, 1, store.Writes())
+	})
+}
+
+func TestIsRetryableWriteError(t *testing.T) {
+	assert.False(t, IsRetryableWriteError(nil))
+	assert.False(t, IsRetryableWriteError(aligned.ErrWriteStoreFull))
+	assert.False(t, IsRetryableWriteError(fmt.Errorf("write failed, %w", aligned.ErrWriteStoreClosed)))
+	assert.False(t, IsRetryableWriteError(context.Canceled))
+	assert.True(t, IsRetryableWriteError(errors.New("connection refused")))
+	assert.Error(t, WithWriteRetry(0, wait.Backoff{})(DefaultOptions()))
+}
+
+func TestPBQ_RestoreCOB(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	windowRequests := testutils.BuildTestWindowRequests(3, time.Now(), window.Append)
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(10))
+
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	for i := range windowRequests[:2] {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+	}
+	pq.CloseOfBook()
+	assert.ErrorAs(t, pq.Write(ctx, &windowRequests[2], true), &COBErr{})
+	// the pod crashes before the partition is garbage collected
+	assert.NoError(t, pq.Close())
+
+	// a new manager with the same stores simulates the restart
+	restarted, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	pq, err = restarted.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// the persisted messages are replayed, the new ones are refused
+	for i := range windowRequests[:2] {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], false))
+	}
+	assert.ErrorAs(t, pq.Write(ctx, &windowRequests[2], true), &COBErr{})
+	assert.Len(t, pq.ReadCh(), 2)
+
+	// the book of a partition which was not closed stays open after a restart
+	openID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-2"}
+	_, err = qManager.CreateNewPBQ(ctx, openID)
+	assert.NoError(t, err)
+	pq, err = restarted.CreateNewPBQ(ctx, openID)
+	assert.NoError(t, err)
+	assert.NoError(t, pq.Write(ctx, &windowRequests[2], true))
+}
+
+func TestPBQ_WriteNilMessage(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(10))
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	var nilErr NilMessageErr
+	assert.ErrorAs(t, pq.Write(ctx, nil, true), &nilErr)
+	assert.Equal(t, partitionID, nilErr.PartitionID)
+	for _, op := range []window.Operation{window.Open, window.Append, window.Expand} {
+		assert.ErrorAs(t, pq.Write(ctx, &window.TimedWindowRequest{Operation: op, ID: &partitionID}, true), &nilErr)
+		assert.Equal(t, op.String(), nilErr.Operation)
+		assert.ErrorAs(t, pq.Write(ctx, &window.TimedWindowRequest{Operation: op, ID: &partitionID}, false), &nilErr)
+	}
+	// the rejected writes neither reach the store nor the output channel, nor move the pbq out of created
+	assert.Len(t, pq.ReadCh(), 0)
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), store.Size())
+	assert.Equal(t, StateCreated, pq.(*PBQ).State())
+
+	// a close does not carry a message
+	assert.NoError(t, pq.Write(ctx, &window.TimedWindowRequest{Operation: window.Close, ID: &partitionID}, true))
+}
+
+func TestPBQ_Stats(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	windowRequests := testutils.BuildTestWindowRequests(10, time.Now(), window.Append)
+	var bytes int64
+	for _, request := range windowRequests {
+		bytes += int64(len(request.ReadMessage.Payload))
+	}
+
+	for name, opts := range map[string][]PBQOption{
+		"channel": nil,
+		"buffer":  {WithPeek()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned,
+				append(opts, WithChannelBufferSize(10))...)
+			assert.NoError(t, err)
+			pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+			assert.NoError(t, err)
+			q := pq.(*PBQ)
+			assert.Equal(t, PBQStats{}, q.Stats())
+
+			// the replayed messages are counted along with the live ones
+			for i := range windowRequests[:2] {
+				assert.NoError(t, pq.Write(ctx, &windowRequests[i], false))
+			}
+			for i := range windowRequests[2:] {
+				assert.NoError(t, pq.Write(ctx, &windowRequests[2+i], true))
+			}
+			for i := 0; i < 4; i++ {
+				<-pq.ReadCh()
+			}
+
+			assert.Eventually(t, func() bool {
+				return q.Stats().MessagesRead == 4
+			}, time.Second, 10*time.Millisecond)
+			stats := q.Stats()
+			assert.Equal(t, int64(len(windowRequests)), stats.MessagesWritten)
+			assert.Equal(t, bytes, stats.BytesWritten)
+			pq.CloseOfBook()
+		})
+	}
+}
+
+func TestPBQ_ConcurrentWritesOrder(t *testing.T) {
+	// the writers have to run in parallel to interleave, even on a single CPU
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	storeProvider := fake.NewManager()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(100))
+	assert.NoError(t, err)
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	writers, perWriter := 8, 250
+	windowRequests := testutils.BuildTestWindowRequests(int64(writers*perWriter), time.Now(), window.Append)
+	var read []*isb.ReadMessage
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for request := range pq.ReadCh() {
+			read = append(read, request.ReadMessage)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w * perWriter; i < (w+1)*perWriter; i++ {
+				assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+			}
+		}(w)
+	}
+	wg.Wait()
+	pq.CloseOfBook()
+	<-readDone
+
+	// the output channel has a single total order, which is the order of the store
+	store, _ := storeProvider.GetWAL(partitionID)
+	persisted := store.Messages()
+	assert.Len(t, read, writers*perWriter)
+	assert.Len(t, persisted, writers*perWriter)
+	for i := range read {
+		assert.Equal(t, persisted[i].Header.ID, read[i].Header.ID)
+	}
+}
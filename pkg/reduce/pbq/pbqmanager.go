@@ -18,33 +18,56 @@ package pbq
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"math/rand"
 	"strconv"
 	"sync"
 	"time"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/numaproj/numaflow/pkg/metrics"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/tiered"
 	"github.com/numaproj/numaflow/pkg/window"
 
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 )
 
+// sweepsPerJitterWindow is the number of sweeps within the jitter window of the partition TTL.
+const sweepsPerJitterWindow = 8
+
 // Manager helps in managing the lifecycle of PBQ instances
 type Manager struct {
 	vertexName    string
 	pipelineName  string
 	vertexReplica int32
 	storeProvider wal.Manager
-	pbqOptions    *options
-	pbqMap        map[string]*PBQ
-	log           *zap.SugaredLogger
-	windowType    window.Type
+	// storeType is the type of the store provider, it labels the metrics of the store writes
+	storeType  string
+	pbqOptions *options
+	pbqMap     map[string]*PBQ
+	// reserved holds the partitions whose pbq is being created, the channel is closed once the pbq is registered or
+	// could not be created, so that a concurrent creation of the same partition never creates a second pbq.
+	reserved map[string]chan struct{}
+	// lateStores holds the partitions whose store creation timed out while the store is still being created, the
+	// channel is closed once the late store is released and closed
+	lateStores map[string]chan struct{}
+	log        *zap.SugaredLogger
+	windowType window.Type
+	// partitionSlots holds a token for every registered or in-flight partition, nil if the number of partitions is not
+	// limited
+	partitionSlots chan struct{}
+	// leaser leases the partitions in the store shared by the replicas, nil if the partitions are not leased
+	leaser wal.Leaser
 	// we need lock to access pbqMap, since deregister will be called inside pbq
 	// and each pbq will be inside a go routine, and also entire PBQ could be managed
 	// through a go routine (depends on the orchestrator)
@@ -63,25 +86,265 @@ func NewManager(ctx context.Context, vertexName string, pipelineName string, vr
 		}
 	}
 
+	if pbqOpts.storeType != "" {
+		var err error
+		storeProvider, err = wal.NewStoreManager(ctx, pbqOpts.storeType, wal.ManagerOptions{PipelineName: pipelineName, VertexName: vertexName, Replica: vr})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the partitions are leased in the store shared by the replicas, not in the memory tier
+	var leaser wal.Leaser
+	if pbqOpts.leaseTTL > 0 {
+		if pbqOpts.leaseOwner == "" {
+			var err error
+			if pbqOpts.leaseOwner, err = podLeaseOwner(); err != nil {
+				return nil, err
+			}
+		}
+		var ok bool
+		if leaser, ok = storeProvider.(wal.Leaser); !ok {
+			return nil, fmt.Errorf("leases are not supported by the store provider %T", storeProvider)
+		}
+	}
+	if pbqOpts.memoryTier != nil {
+		storeProvider = tiered.NewManager(pbqOpts.memoryTier, storeProvider)
+	}
+	storeType := pbqOpts.storeType
+	if storeType == "" || pbqOpts.memoryTier != nil {
+		storeType = storeTypeName(storeProvider)
+	}
+
 	pbqManager := &Manager{
 		vertexName:    vertexName,
 		pipelineName:  pipelineName,
 		vertexReplica: vr,
 		storeProvider: storeProvider,
+		storeType:     storeType,
 		pbqMap:        make(map[string]*PBQ),
+		reserved:      make(map[string]chan struct{}),
+		lateStores:    make(map[string]chan struct{}),
 		pbqOptions:    pbqOpts,
 		log:           logging.FromContext(ctx),
 		windowType:    windowType,
+		leaser:        leaser,
+	}
+
+	if pbqOpts.maxPartitions > 0 {
+		pbqManager.partitionSlots = make(chan struct{}, pbqOpts.maxPartitions)
+	}
+
+	if pbqOpts.partitionTTL > 0 {
+		go pbqManager.sweepIdlePartitions(ctx)
 	}
 
 	return pbqManager, nil
 }
 
-// CreateNewPBQ creates new pbq for a partition
+// CreateNewPBQ creates new pbq for a partition, it returns PartitionExistsErr if a pbq is already registered for the
+// partition. If the number of partitions is limited and the limit is hit, it returns MaxPartitionsExceededErr or waits
+// for a partition to be deregistered, see WithMaxPartitions.
 func (m *Manager) CreateNewPBQ(ctx context.Context, partitionID partition.ID) (ReadWriteCloser, error) {
-	persistentStore, err := m.storeProvider.CreateWAL(ctx, partitionID)
+	return m.createNewPBQ(ctx, partitionID, nil)
+}
+
+// createNewPBQ creates new pbq for a partition, the keys are persisted with the metadata of the partition.
+func (m *Manager) createNewPBQ(ctx context.Context, partitionID partition.ID, keys []string) (ReadWriteCloser, error) {
+	if _, reserved := m.reserveOrGet(partitionID); !reserved {
+		return nil, PartitionExistsErr{PartitionID: partitionID}
+	}
+	return m.createReserved(ctx, partitionID, keys)
+}
+
+// createReserved creates and registers the pbq of the reserved partition, the reservation is released either way.
+func (m *Manager) createReserved(ctx context.Context, partitionID partition.ID, keys []string) (*PBQ, error) {
+	if err := m.acquirePartitionSlot(ctx); err != nil {
+		m.unreserve(partitionID)
+		return nil, err
+	}
+	p, err := m.newPBQ(ctx, partitionID, keys)
+	if err != nil {
+		m.releasePartitionSlot()
+		m.unreserve(partitionID)
+		return nil, err
+	}
+	if !m.register(partitionID, p) {
+		m.discard(p)
+		return nil, PartitionExistsErr{PartitionID: partitionID}
+	}
+	m.keepLease(p)
+	return p, nil
+}
+
+// CreatePBQIfAbsent returns the pbq registered for the partition, or creates a new one if there is none. The boolean
+// is true if the pbq was newly created. If the pbq of the partition is being created concurrently, it waits for that
+// pbq instead of creating a second one.
+func (m *Manager) CreatePBQIfAbsent(ctx context.Context, partitionID partition.ID) (ReadWriteCloser, bool, error) {
+	for {
+		existing, reserved := m.reserveOrGet(partitionID)
+		if existing != nil {
+			return existing, false, nil
+		}
+		if reserved {
+			break
+		}
+		if err := m.waitForReservation(ctx, partitionID); err != nil {
+			return nil, false, err
+		}
+	}
+	p, err := m.createReserved(ctx, partitionID, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	return p, true, nil
+}
+
+// CreateNewPBQs creates the pbqs of the partitions, up to concurrency stores are created at a time, e.g. to warm up the
+// partitions to be replayed on startup. The created pbqs are registered at once after all the stores are created, and
+// returned by their partition IDs. A partition which fails, e.g. because it already has a pbq, does not fail the others,
+// the errors of all the partitions which could not be created are returned along with the created pbqs. If the number
+// of partitions is limited, the partitions beyond the limit wait for a slot or fail as for CreateNewPBQ, a waiting
+// partition is only unblocked by the pbqs outside the batch, or by the ctx, since the batch is registered at the end.
+func (m *Manager) CreateNewPBQs(ctx context.Context, partitionIDs []partition.ID, concurrency int) (map[string]ReadWriteCloser, error) {
+	if concurrency <= 0 {
+		return nil, fmt.Errorf("create concurrency should be positive, got %d", concurrency)
+	}
+
+	var (
+		createErr error
+		created   []*PBQ
+		mu        sync.Mutex
+	)
+	eg := errgroup.Group{}
+	eg.SetLimit(concurrency)
+	appendErr := func(err error) {
+		mu.Lock()
+		createErr = multierr.Append(createErr, err)
+		mu.Unlock()
+	}
+	for _, partitionID := range partitionIDs {
+		// the partitions are reserved until the batch is registered, which also catches the duplicates of the batch
+		if _, reserved := m.reserveOrGet(partitionID); !reserved {
+			appendErr(PartitionExistsErr{PartitionID: partitionID})
+			continue
+		}
+		partitionID := partitionID
+		eg.Go(func() error {
+			err := m.acquirePartitionSlot(ctx)
+			if err == nil {
+				var p *PBQ
+				if p, err = m.newPBQ(ctx, partitionID, nil); err == nil {
+					mu.Lock()
+					created = append(created, p)
+					mu.Unlock()
+					return nil
+				}
+				m.releasePartitionSlot()
+			}
+			m.unreserve(partitionID)
+			appendErr(fmt.Errorf("failed to create pbq %s, %w", partitionID.String(), err))
+			// the other partitions are created regardless
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	registered := m.registerAll(created)
+	pbqs := make(map[string]ReadWriteCloser, len(registered))
+	for _, p := range registered {
+		m.keepLease(p)
+		pbqs[p.PartitionID.String()] = p
+	}
+	// the partitions are reserved, so none of them can get a pbq while its store is being created, a pbq which is not
+	// registered anyway is discarded rather than leaked
+	for _, p := range created {
+		if _, ok := pbqs[p.PartitionID.String()]; !ok {
+			m.discard(p)
+			createErr = multierr.Append(createErr, PartitionExistsErr{PartitionID: p.PartitionID})
+		}
+	}
+	return pbqs, createErr
+}
+
+// CreateNewPBQForKey creates new pbq for the partition which the key resolves to in the window, it returns
+// PartitionExistsErr if a pbq is already registered for the partition.
+func (m *Manager) CreateNewPBQForKey(ctx context.Context, start time.Time, end time.Time, key string) (ReadWriteCloser, error) {
+	return m.createNewPBQ(ctx, m.pbqOptions.partitionResolver.Resolve(start, end, key), []string{key})
+}
+
+// Register adopts a pbq which is constructed by the caller, e.g. with a bespoke store, so that the lookups, the GC and
+// the shutdown of the manager apply to it like to a pbq created by CreateNewPBQ. The partition ID has to be the one of
+// the pbq, and PartitionExistsErr is returned if a pbq is already registered for it. The GC of the pbq deletes its store
+// with the store provider of the pbq, the store is left to the caller if the pbq has no store provider.
+func (m *Manager) Register(partitionID string, pq ReadWriteCloser) error {
+	p, ok := pq.(*PBQ)
+	if !ok {
+		return fmt.Errorf("only a *PBQ can be registered, got %T", pq)
+	}
+	if p.PartitionID.String() != partitionID {
+		return fmt.Errorf("partition ID %s does not match the partition %s of the pbq", partitionID, p.PartitionID.String())
+	}
+	if p.manager != nil && p.manager != m {
+		return fmt.Errorf("pbq for partition %s is managed by another manager", partitionID)
+	}
+	// a partition whose pbq is being created is taken as well
+	if _, reserved := m.reserveOrGet(p.PartitionID); !reserved {
+		return PartitionExistsErr{PartitionID: p.PartitionID}
+	}
+	// the caller owns the pbq, so waiting for a slot is left to the caller as well
+	if m.partitionSlots != nil {
+		select {
+		case m.partitionSlots <- struct{}{}:
+		default:
+			m.unreserve(p.PartitionID)
+			return MaxPartitionsExceededErr{Limit: cap(m.partitionSlots)}
+		}
+	}
+	p.manager = m
+	if p.metricLabels == nil {
+		p.metricLabels = m.metricLabels()
+	}
+	if p.storeMetricLabels == nil {
+		p.storeMetricLabels = storeMetricLabels(p.metricLabels, storeTypeName(p.storeProvider))
+	}
+	if !m.register(p.PartitionID, p) {
+		m.releasePartitionSlot()
+		return PartitionExistsErr{PartitionID: p.PartitionID}
+	}
+	return nil
+}
+
+// PartitionResolver returns the resolver which maps the message keys to the partitions, the reducers use it to compute
+// the same partition ID as the manager.
+func (m *Manager) PartitionResolver() partition.Resolver {
+	return m.pbqOptions.partitionResolver
+}
+
+// newPBQ creates a pbq for the partition without registering it. If the store can persist the metadata of the partition,
+// the metadata is persisted unless the store already has it, e.g. when the store was discovered during the replay. The
+// lease of the partition is acquired first if the partitions are leased.
+func (m *Manager) newPBQ(ctx context.Context, partitionID partition.ID, keys []string) (_ *PBQ, err error) {
+	if err := m.validatePartitionID(partitionID); err != nil {
+		return nil, err
+	}
+	if m.leaser != nil {
+		if err := m.leaser.AcquireLease(ctx, partitionID, m.leaseOwner(), m.pbqOptions.leaseTTL); err != nil {
+			return nil, PartitionCreateErr{PartitionID: partitionID, StoreType: m.storeType, Err: err}
+		}
+		defer func() {
+			if err != nil {
+				m.releaseLease(partitionID)
+			}
+		}()
+	}
+	persistentStore, err := m.createWAL(ctx, partitionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create a PBQ store, %w", err)
+		return nil, PartitionCreateErr{PartitionID: partitionID, StoreType: m.storeType, Err: err}
+	}
+	metadata, err := persistMetadataIfAbsent(persistentStore, wal.PartitionMetadata{PartitionID: partitionID, Keys: keys})
+	if err != nil {
+		return nil, PartitionCreateErr{PartitionID: partitionID, StoreType: m.storeType, Err: err}
 	}
 
 	// output channel is buffered to support bulk reads
@@ -90,18 +353,86 @@ func (m *Manager) CreateNewPBQ(ctx context.Context, partitionID partition.ID) (R
 		pipelineName:  m.pipelineName,
 		vertexReplica: m.vertexReplica,
 		store:         persistentStore,
+		storeProvider: m.storeProvider,
 		output:        make(chan *window.TimedWindowRequest, m.pbqOptions.channelBufferSize),
-		cob:           false,
+		readErrs:      make(chan error, readErrBufferSize),
+		stopDelivery:  make(chan struct{}),
+		closing:       make(chan struct{}),
+		restoredCOB:   metadata != nil && metadata.BookClosed,
 		PartitionID:   partitionID,
 		options:       m.pbqOptions,
 		manager:       m,
 		windowType:    m.windowType, // FIXME(session): this is can be removed when we have unaligned window replay
 		log:           logging.FromContext(ctx).With("PBQ", partitionID),
+		metricLabels:  m.metricLabels(),
+	}
+	p.storeMetricLabels = storeMetricLabels(p.metricLabels, m.storeType)
+	if m.pbqOptions.dedup {
+		p.persistedIDs = make(map[string]struct{})
+	}
+	if m.pbqOptions.ackTracking {
+		p.acks = newAckState()
+	}
+	if m.pbqOptions.maxTrackedKeys > 0 {
+		p.keys.Store(&trackedKeys{seen: make(map[string]struct{})})
+	}
+	if m.pbqOptions.writeRateLimit > 0 {
+		// every partition gets its own bucket, so that a hot partition does not starve the others
+		p.limiter = rate.NewLimiter(rate.Limit(m.pbqOptions.writeRateLimit), m.pbqOptions.writeRateBurst)
+	}
+	if m.pbqOptions.maxChannelBufferSize > m.pbqOptions.channelBufferSize || m.pbqOptions.peek {
+		// the reader holds on to the output channel, so the resizable and peekable buffer sits in front of it
+		p.output = make(chan *window.TimedWindowRequest)
+		buffer := make(chan *window.TimedWindowRequest, m.pbqOptions.channelBufferSize)
+		p.buffer.Store(&buffer)
+		p.forwarded = make(chan struct{})
+		if m.pbqOptions.peek {
+			p.peekCh = make(chan peekRequest)
+		}
+		go p.forwardBuffer()
+	}
+	if m.pbqOptions.spillTimeout > 0 {
+		if reader, ok := persistentStore.(wal.OffsetReader); ok && m.windowType == window.Aligned {
+			p.spillReader = reader
+		} else {
+			p.log.Warnw("Spilling on backpressure is not supported by the partition, the writes block on the full channel", zap.String("store", fmt.Sprintf("%T", persistentStore)))
+		}
+	}
+	// the store of a partition which is being recovered is discovered before the pbq is created, so its size is the
+	// number of messages to be replayed.
+	p.replayTotal = persistentStore.Size()
+	p.lastWriteTime.Store(m.pbqOptions.clock.Now().UnixNano())
+	if m.pbqOptions.partitionTTL > 0 && m.pbqOptions.sweepJitter > 0 {
+		p.sweepJitter = time.Duration(rand.Float64() * m.pbqOptions.sweepJitter * float64(m.pbqOptions.partitionTTL))
 	}
-	m.register(partitionID, p)
 	return p, nil
 }
 
+// validatePartitionID applies the default checks and then the configured validator to the partition ID.
+func (m *Manager) validatePartitionID(partitionID partition.ID) error {
+	if err := wal.ValidatePartitionID(partitionID); err != nil {
+		return err
+	}
+	if m.pbqOptions.partitionIDValidator != nil {
+		return m.pbqOptions.partitionIDValidator(partitionID)
+	}
+	return nil
+}
+
+// persistMetadataIfAbsent persists the metadata of the partition if the store supports it and has none yet. It returns
+// the metadata of the store, nil if the store does not support it.
+func persistMetadataIfAbsent(store wal.WAL, metadata wal.PartitionMetadata) (*wal.PartitionMetadata, error) {
+	metadataStore, ok := store.(wal.MetadataStore)
+	if !ok {
+		return nil, nil
+	}
+	persisted, err := metadataStore.LoadMetadata()
+	if !errors.Is(err, wal.ErrMetadataNotFound) {
+		return persisted, err
+	}
+	return &metadata, metadataStore.PersistMetadata(metadata)
+}
+
 // ListPartitions returns all the pbq instances
 func (m *Manager) ListPartitions() []*PBQ {
 	m.RLock()
@@ -117,23 +448,47 @@ func (m *Manager) ListPartitions() []*PBQ {
 	return pbqList
 }
 
-// GetPBQ returns pbq for the given ID
-func (m *Manager) GetPBQ(partitionID partition.ID) ReadWriteCloser {
+// ListPartitionIDs returns the IDs of all the registered partitions
+func (m *Manager) ListPartitionIDs() []partition.ID {
+	m.RLock()
+	defer m.RUnlock()
+
+	partitionIDs := make([]partition.ID, 0, len(m.pbqMap))
+	for _, val := range m.pbqMap {
+		partitionIDs = append(partitionIDs, val.PartitionID)
+	}
+
+	return partitionIDs
+}
+
+// PartitionCount returns the number of registered partitions.
+func (m *Manager) PartitionCount() int {
+	m.RLock()
+	defer m.RUnlock()
+	return len(m.pbqMap)
+}
+
+// GetPBQ returns pbq for the given ID, the boolean is false if there is no pbq registered for the partition.
+func (m *Manager) GetPBQ(partitionID partition.ID) (ReadWriteCloser, bool) {
 	m.RLock()
 	defer m.RUnlock()
 
 	if pbqInstance, ok := m.pbqMap[partitionID.String()]; ok {
-		return pbqInstance
+		return pbqInstance, true
 	}
 
-	return nil
+	return nil, false
 }
 
-// ShutDown for clean shut down, flushes pending messages to store and closes the store
-func (m *Manager) ShutDown(ctx context.Context) {
+// ShutDown for clean shut down, flushes pending messages to store and closes the store. Every pbq is closed at least
+// once, after the in-flight write to its store is done, even if the ctx is already done. The failed closes are retried
+// until the ctx is done, and the errors of the pbqs which could not be closed are returned.
+func (m *Manager) ShutDown(ctx context.Context) error {
 	// iterate through the map of pbq
 	// close all the pbq
 	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var shutdownErr error
 	var PBQCloseBackOff = wait.Backoff{
 		Steps:    math.MaxInt,
 		Duration: 100 * time.Millisecond,
@@ -145,10 +500,10 @@ func (m *Manager) ShutDown(ctx context.Context) {
 		wg.Add(1)
 		go func(q *PBQ) {
 			defer wg.Done()
-			var ctxClosedErr error
+			var ctxClosedErr, closeErr error
 			var attempt int
 			ctxClosedErr = wait.ExponentialBackoff(PBQCloseBackOff, func() (done bool, err error) {
-				closeErr := q.Close()
+				closeErr = q.Close()
 				if closeErr != nil {
 					attempt += 1
 					m.log.Errorw("Failed to close pbq, retrying", zap.Any("attempt", attempt), zap.Any("ID", q.PartitionID), zap.Error(closeErr))
@@ -164,44 +519,277 @@ func (m *Manager) ShutDown(ctx context.Context) {
 			})
 			if ctxClosedErr != nil {
 				m.log.Errorw("Context closed while closing pbq", zap.Any("ID", q.PartitionID), zap.Error(ctxClosedErr))
+				errMu.Lock()
+				shutdownErr = multierr.Append(shutdownErr, fmt.Errorf("failed to close pbq %s, %w", q.PartitionID.String(), closeErr))
+				errMu.Unlock()
 			}
 		}(v)
 	}
 
 	wg.Wait()
+	return shutdownErr
+}
+
+// DeregisterAll closes and garbage collects every registered pbq, deleting their stores, so that the manager can be
+// reused with an empty registry. It is safe to call concurrently with the creation of pbqs, a pbq which is registered
+// while DeregisterAll is in progress may be kept. The errors of all the pbqs which could not be removed are returned.
+func (m *Manager) DeregisterAll() error {
+	var deregisterErr error
+	for _, q := range m.getPBQs() {
+		q.CloseOfBook()
+		if err := q.Close(); err != nil {
+			deregisterErr = multierr.Append(deregisterErr, fmt.Errorf("failed to close pbq %s, %w", q.PartitionID.String(), err))
+		}
+		if err := q.GC(); err != nil {
+			deregisterErr = multierr.Append(deregisterErr, fmt.Errorf("failed to gc pbq %s, %w", q.PartitionID.String(), err))
+		}
+	}
+	return deregisterErr
+}
+
+// HealthCheck pings the stores of a sample of the registered pbqs, one pbq per store provider since the partitions of a
+// provider share its backend. The errors of all the stores which could not be pinged are returned, it returns nil if
+// no pbq is registered.
+func (m *Manager) HealthCheck(ctx context.Context) error {
+	var healthErr error
+	sampled := make(map[wal.Manager]bool)
+	for _, q := range m.getPBQs() {
+		store, storeProvider := q.currentStore()
+		if store == nil || sampled[storeProvider] {
+			continue
+		}
+		sampled[storeProvider] = true
+		if err := store.Ping(ctx); err != nil {
+			healthErr = multierr.Append(healthErr, fmt.Errorf("failed to ping the store of pbq %s, %w", q.PartitionID.String(), err))
+		}
+	}
+	return healthErr
+}
+
+// reserveOrGet reserves the partition for the pbq which is about to be created. It returns the pbq registered for the
+// partition, or false if the partition has a pbq or is already reserved.
+func (m *Manager) reserveOrGet(partitionID partition.ID) (*PBQ, bool) {
+	m.Lock()
+	defer m.Unlock()
+	if registered, ok := m.pbqMap[partitionID.String()]; ok {
+		return registered, false
+	}
+	if _, ok := m.reserved[partitionID.String()]; ok {
+		return nil, false
+	}
+	m.reserved[partitionID.String()] = make(chan struct{})
+	return nil, true
+}
+
+// waitForReservation waits until the pbq of the reserved partition is registered or could not be created, it returns
+// right away if the partition is not reserved.
+func (m *Manager) waitForReservation(ctx context.Context, partitionID partition.ID) error {
+	m.RLock()
+	done, ok := m.reserved[partitionID.String()]
+	m.RUnlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// unreserve releases the reservation of a partition whose pbq could not be created.
+func (m *Manager) unreserve(partitionID partition.ID) {
+	m.Lock()
+	defer m.Unlock()
+	m.unreserveLocked(partitionID)
+}
+
+// unreserveLocked releases the reservation of the partition and wakes up its waiters, caller should hold the lock.
+func (m *Manager) unreserveLocked(partitionID partition.ID) {
+	if done, ok := m.reserved[partitionID.String()]; ok {
+		close(done)
+		delete(m.reserved, partitionID.String())
+	}
+}
+
+// register registers the pbq of the reserved partition and releases the reservation. A pbq which is already registered
+// for the partition is never replaced, it returns whether the given pbq was registered.
+func (m *Manager) register(partitionID partition.ID, p *PBQ) bool {
+	m.Lock()
+	defer m.Unlock()
+	defer m.unreserveLocked(partitionID)
+	_, ok := m.registerLocked(partitionID, p)
+	return ok
 }
 
-// register is intended to be used by PBQ to register itself with the manager.
-func (m *Manager) register(partitionID partition.ID, p *PBQ) {
+// registerAll registers the pbqs of the reserved partitions at once, so that a lookup never finds only a part of them,
+// and releases the reservations. It returns the pbqs which were registered, a pbq is skipped if its partition already
+// has one.
+func (m *Manager) registerAll(pbqs []*PBQ) []*PBQ {
 	m.Lock()
 	defer m.Unlock()
+	registered := make([]*PBQ, 0, len(pbqs))
+	for _, p := range pbqs {
+		if _, ok := m.registerLocked(p.PartitionID, p); ok {
+			registered = append(registered, p)
+		}
+		m.unreserveLocked(p.PartitionID)
+	}
+	return registered
+}
 
-	if _, ok := m.pbqMap[partitionID.String()]; !ok {
-		m.pbqMap[partitionID.String()] = p
+// discard drops a pbq which was created but could not be registered. Its slot and the lease of its partition are
+// released and its store is closed, the store is not deleted since it holds the messages of the partition.
+func (m *Manager) discard(p *PBQ) {
+	m.releasePartitionSlot()
+	if m.leaser != nil {
+		m.releaseLease(p.PartitionID)
+	}
+	if buffer := p.buffer.Load(); buffer != nil {
+		// stops the forwarding goroutine
+		close(*buffer)
 	}
-	activePartitionCount.With(map[string]string{
+	if err := p.Close(); err != nil {
+		m.log.Warnw("Failed to close the store of a discarded pbq", zap.String("ID", p.PartitionID.String()), zap.Error(err))
+	}
+}
+
+// registerLocked registers the pbq unless the partition already has one, caller should hold the lock.
+func (m *Manager) registerLocked(partitionID partition.ID, p *PBQ) (*PBQ, bool) {
+	if registered, ok := m.pbqMap[partitionID.String()]; ok {
+		return registered, false
+	}
+	m.pbqMap[partitionID.String()] = p
+	p.registeredAt = m.pbqOptions.clock.Now()
+	labels := m.metricLabels()
+	activePartitionCount.With(labels).Inc()
+	partitionCreatedCount.With(labels).Inc()
+	return p, true
+}
+
+// metricLabels returns the labels of the metrics emitted by the manager.
+func (m *Manager) metricLabels() map[string]string {
+	return map[string]string{
 		metrics.LabelVertex:             m.vertexName,
 		metrics.LabelPipeline:           m.pipelineName,
 		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(m.vertexReplica)),
-	}).Inc()
+	}
+}
 
+// storeMetricLabels returns the labels of the metrics of the store writes of a PBQ, which are its metric labels along
+// with the type of its store.
+func storeMetricLabels(labels map[string]string, storeType string) map[string]string {
+	storeLabels := maps.Clone(labels)
+	storeLabels[labelStoreType] = storeType
+	return storeLabels
+}
+
+// storeTypeName returns the name of the type of the store provider, for the store providers which are not created from
+// a registered store type.
+func storeTypeName(storeProvider wal.Manager) string {
+	if storeProvider == nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%T", storeProvider)
 }
 
 // deregister is intended to be used by PBQ to deregister itself after GC is called.
-// it will also delete the store using the store provider
-func (m *Manager) deregister(partitionID partition.ID) error {
+// it will also delete the store using the store provider of the PBQ
+func (m *Manager) deregister(partitionID partition.ID, storeProvider wal.Manager) error {
 
+	labels := m.metricLabels()
 	m.Lock()
-	delete(m.pbqMap, partitionID.String())
+	if q, ok := m.pbqMap[partitionID.String()]; ok {
+		delete(m.pbqMap, partitionID.String())
+		m.releasePartitionSlot()
+		partitionDeregisteredCount.With(labels).Inc()
+		partitionLifetime.With(labels).Observe(m.pbqOptions.clock.Since(q.registeredAt).Seconds())
+	}
 	m.Unlock()
 
-	activePartitionCount.With(map[string]string{
-		metrics.LabelVertex:             m.vertexName,
-		metrics.LabelPipeline:           m.pipelineName,
-		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(m.vertexReplica)),
-	}).Dec()
+	activePartitionCount.With(labels).Dec()
+
+	// the store of a pbq adopted with Register without a store provider is owned by the caller
+	if storeProvider == nil {
+		return nil
+	}
+	return storeProvider.DeleteWAL(partitionID)
+}
+
+// sweepIdlePartitions periodically evicts the closed book partitions which have not been written to for longer than the
+// partition TTL, it returns when the context is done.
+func (m *Manager) sweepIdlePartitions(ctx context.Context) {
+	ticker := m.pbqOptions.clock.NewTicker(m.sweepInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			m.evictIdlePartitions(m.pbqOptions.clock.Now())
+		}
+	}
+}
+
+// sweepInterval returns the period of the sweeper. With jitter, the sweeper runs several times per jitter window so that
+// the evictions are spread across the window.
+func (m *Manager) sweepInterval() time.Duration {
+	interval := m.pbqOptions.partitionTTL / 2
+	jitterWindow := time.Duration(m.pbqOptions.sweepJitter * float64(m.pbqOptions.partitionTTL))
+	if jitterInterval := jitterWindow / sweepsPerJitterWindow; jitterInterval > 0 {
+		interval = min(interval, jitterInterval)
+	}
+	return interval
+}
+
+// evictIdlePartitions closes and garbage collects the closed book PBQs which have been idle beyond the partition TTL.
+// The PBQs of the open windows are skipped, evicting them would delete the WALs of the messages which are not yet
+// reduced.
+func (m *Manager) evictIdlePartitions(now time.Time) {
+	for _, q := range m.getPBQs() {
+		if q.State() < StateClosed || now.Before(q.sweepDeadline()) {
+			continue
+		}
+		idle := now.Sub(time.Unix(0, q.lastWriteTime.Load()))
+		m.log.Infow("Evicting idle partition", zap.String("ID", q.PartitionID.String()), zap.Duration("idle", idle))
+		if err := q.Close(); err != nil {
+			m.log.Errorw("Failed to close idle pbq, will retry on the next sweep", zap.String("ID", q.PartitionID.String()), zap.Error(err))
+			continue
+		}
+		if err := q.GC(); err != nil {
+			m.log.Errorw("Failed to GC idle pbq", zap.String("ID", q.PartitionID.String()), zap.Error(err))
+		}
+	}
+}
 
-	return m.storeProvider.DeleteWAL(partitionID)
+// acquirePartitionSlot reserves a slot for a new partition, it either fails with MaxPartitionsExceededErr or waits for
+// a slot when all of them are taken, depending on the options.
+func (m *Manager) acquirePartitionSlot(ctx context.Context) error {
+	if m.partitionSlots == nil {
+		return nil
+	}
+	if !m.pbqOptions.waitForPartitionSlot {
+		select {
+		case m.partitionSlots <- struct{}{}:
+			return nil
+		default:
+			return MaxPartitionsExceededErr{Limit: cap(m.partitionSlots)}
+		}
+	}
+	select {
+	case m.partitionSlots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return MaxPartitionsExceededErr{Limit: cap(m.partitionSlots), Err: ctx.Err()}
+	}
+}
+
+// releasePartitionSlot frees the slot of a partition which is deregistered or which could not be registered.
+func (m *Manager) releasePartitionSlot() {
+	if m.partitionSlots != nil {
+		<-m.partitionSlots
+	}
 }
 
 func (m *Manager) getPBQs() []*PBQ {
@@ -17,16 +17,29 @@ package pbq
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+	clocktesting "k8s.io/utils/clock/testing"
 
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/metrics"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/noop"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/window"
 )
 
@@ -88,9 +101,61 @@ func TestManager_GetPBQ(t *testing.T) {
 	assert.NoError(t, err)
 
 	// get the created pbq
-	pb2 = pbqManager.GetPBQ(testPartition)
-
+	pb2, ok := pbqManager.GetPBQ(testPartition)
+	assert.True(t, ok)
 	assert.Equal(t, pb1, pb2)
+
+	// lookup of a partition which is not registered
+	_, ok = pbqManager.GetPBQ(partition.ID{
+		Start: time.Unix(120, 0),
+		End:   time.Unix(180, 0),
+		Slot:  "slot-1",
+	})
+	assert.False(t, ok)
+}
+
+func TestManager_ListPartitionIDs(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	partitionIDs := make([]partition.ID, 20)
+	for i := range partitionIDs {
+		partitionIDs[i] = partition.ID{
+			Start: time.Unix(int64(60*i), 0),
+			End:   time.Unix(int64(60*(i+1)), 0),
+			Slot:  "slot-1",
+		}
+		wg.Add(1)
+		go func(id partition.ID) {
+			defer wg.Done()
+			_, err := pbqManager.CreateNewPBQ(ctx, id)
+			assert.NoError(t, err)
+			// lookups and listing should be safe with concurrent registration
+			_, ok := pbqManager.GetPBQ(id)
+			assert.True(t, ok)
+			_ = pbqManager.ListPartitionIDs()
+		}(partitionIDs[i])
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, partitionIDs, pbqManager.ListPartitionIDs())
+
+	// deregister half of the partitions concurrently
+	for _, id := range partitionIDs[:10] {
+		wg.Add(1)
+		go func(id partition.ID) {
+			defer wg.Done()
+			q, ok := pbqManager.GetPBQ(id)
+			assert.True(t, ok)
+			assert.NoError(t, q.GC())
+		}(id)
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, partitionIDs[10:], pbqManager.ListPartitionIDs())
 }
 
 // manager -> pbq -> store
@@ -208,3 +273,817 @@ func TestPBQFlowWithNoOpStore(t *testing.T) {
 	// no of windowRequests will be equal to no of produced messages
 	assert.Len(t, windowRequests, msgsCount)
 }
+
+func TestManager_PartitionTTL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ttl := 100 * time.Millisecond
+	fakeClock := clocktesting.NewFakeClock(time.Unix(1000, 0))
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(100))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned,
+		WithChannelBufferSize(10), WithPartitionTTL(ttl), WithClock(fakeClock))
+	assert.NoError(t, err)
+
+	openID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-open"}
+	closedID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-closed"}
+	openPQ, err := pbqManager.CreateNewPBQ(ctx, openID)
+	assert.NoError(t, err)
+	closedPQ, err := pbqManager.CreateNewPBQ(ctx, closedID)
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+	for _, msg := range writeMessages {
+		assert.NoError(t, openPQ.Write(ctx, &msg, true))
+		assert.NoError(t, closedPQ.Write(ctx, &msg, true))
+	}
+	closedPQ.CloseOfBook()
+
+	// the sweeper ticks on the injected clock
+	assert.Eventually(t, fakeClock.HasWaiters, 2*time.Second, 10*time.Millisecond)
+	fakeClock.Step(2 * ttl)
+
+	// the idle closed book partition is evicted and its store is deleted
+	assert.Eventually(t, func() bool {
+		_, ok := pbqManager.GetPBQ(closedID)
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+	var count int
+	for range closedPQ.ReadCh() {
+		count++
+	}
+	assert.Equal(t, 5, count)
+
+	// the idle open partition keeps its messages
+	_, ok := pbqManager.GetPBQ(openID)
+	assert.True(t, ok)
+	assert.Less(t, openPQ.(*PBQ).State(), StateClosed)
+	wals, err := storeProvider.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, wals, 1)
+	assert.Equal(t, openID, *wals[0].PartitionID())
+}
+
+func TestManager_SweepJitter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ttl := time.Hour
+	fakeClock := clocktesting.NewFakeClock(time.Unix(1000, 0))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned,
+		WithChannelBufferSize(10), WithPartitionTTL(ttl), WithSweepJitter(0.5), WithClock(fakeClock))
+	assert.NoError(t, err)
+	// the sweeper runs several times per jitter window
+	assert.Equal(t, ttl/2/sweepsPerJitterWindow, pbqManager.sweepInterval())
+
+	// the partitions which went idle at the same time are swept across the jitter window
+	partitions := 100
+	deadlines := make(map[time.Time]struct{})
+	var earliest, latest time.Time
+	for i := 0; i < partitions; i++ {
+		pq, err := pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("slot-%d", i)})
+		assert.NoError(t, err)
+		pq.CloseOfBook()
+		deadline := pq.(*PBQ).sweepDeadline()
+		assert.False(t, deadline.Before(fakeClock.Now().Add(ttl)))
+		assert.True(t, deadline.Before(fakeClock.Now().Add(ttl+ttl/2)))
+		if earliest.IsZero() || deadline.Before(earliest) {
+			earliest = deadline
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+		deadlines[deadline] = struct{}{}
+	}
+	assert.Greater(t, len(deadlines), partitions*9/10)
+	assert.Greater(t, latest.Sub(earliest), ttl/4)
+
+	// a sweep in the middle of the jitter window evicts only a part of the partitions
+	pbqManager.evictIdlePartitions(fakeClock.Now().Add(ttl + ttl/4))
+	assert.Greater(t, pbqManager.PartitionCount(), 0)
+	assert.Less(t, pbqManager.PartitionCount(), partitions)
+	pbqManager.evictIdlePartitions(fakeClock.Now().Add(ttl + ttl/2))
+	assert.Equal(t, 0, pbqManager.PartitionCount())
+}
+
+// failingManager is a WAL manager which fails to create any WAL.
+type failingManager struct {
+	wal.Manager
+	err error
+}
+
+func (f *failingManager) CreateWAL(context.Context, partition.ID) (wal.WAL, error) {
+	return nil, f.err
+}
+
+func TestManager_CreateNewPBQError(t *testing.T) {
+	ctx := context.Background()
+	createErr := errors.New("disk is full")
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, &failingManager{Manager: noop.NewNoopStores(), err: createErr},
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.ErrorIs(t, err, createErr)
+
+	var createPartitionErr PartitionCreateErr
+	assert.ErrorAs(t, err, &createPartitionErr)
+	assert.Equal(t, partitionID, createPartitionErr.PartitionID)
+	assert.Equal(t, "*pbq.failingManager", createPartitionErr.StoreType)
+
+	// the partition is not registered
+	_, ok := pbqManager.GetPBQ(partitionID)
+	assert.False(t, ok)
+}
+
+func TestManager_CreateNewPBQExists(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	created, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	var existsErr PartitionExistsErr
+	assert.ErrorAs(t, err, &existsErr)
+	assert.Equal(t, partitionID, existsErr.PartitionID)
+
+	// the registered pbq is not replaced
+	registered, ok := pbqManager.GetPBQ(partitionID)
+	assert.True(t, ok)
+	assert.Same(t, created, registered)
+}
+
+func TestManager_CreatePBQIfAbsent(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	created, isNew, err := pbqManager.CreatePBQIfAbsent(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.True(t, isNew)
+
+	existing, isNew, err := pbqManager.CreatePBQIfAbsent(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Same(t, created, existing)
+	assert.Len(t, pbqManager.ListPartitions(), 1)
+}
+
+// gatedManager counts the stores created on top of the given manager, the creations block until the gate is closed.
+type gatedManager struct {
+	wal.Manager
+	gate    chan struct{}
+	created atomic.Int32
+}
+
+func (m *gatedManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	m.created.Add(1)
+	<-m.gate
+	return m.Manager.CreateWAL(ctx, partitionID)
+}
+
+func TestManager_CreatePBQIfAbsentConcurrent(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := &gatedManager{Manager: memory.NewMemManager(memory.WithStoreSize(100)), gate: make(chan struct{})}
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	type result struct {
+		pq    ReadWriteCloser
+		isNew bool
+	}
+	results := make(chan result, 10)
+	create := func() {
+		pq, isNew, err := pbqManager.CreatePBQIfAbsent(ctx, partitionID)
+		assert.NoError(t, err)
+		results <- result{pq: pq, isNew: isNew}
+	}
+	go create()
+	assert.Eventually(t, func() bool { return storeProvider.created.Load() == 1 }, time.Second, time.Millisecond)
+
+	// the partition is taken while its pbq is being created
+	var existsErr PartitionExistsErr
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.ErrorAs(t, err, &existsErr)
+	assert.ErrorAs(t, pbqManager.Register(partitionID.String(), &PBQ{PartitionID: partitionID}), &existsErr)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = pbqManager.CreatePBQIfAbsent(cancelled, partitionID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// the concurrent creations wait for the pbq instead of creating their own
+	for i := 0; i < 9; i++ {
+		go create()
+	}
+	close(storeProvider.gate)
+	var created ReadWriteCloser
+	var newCount int
+	for i := 0; i < 10; i++ {
+		r := <-results
+		if r.isNew {
+			newCount++
+		}
+		if created == nil {
+			created = r.pq
+		}
+		assert.Same(t, created, r.pq)
+	}
+	assert.Equal(t, 1, newCount)
+	assert.Equal(t, int32(1), storeProvider.created.Load())
+	assert.Equal(t, 1, pbqManager.PartitionCount())
+	assert.Empty(t, pbqManager.reserved)
+}
+
+func TestManager_Register(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := fake.NewManager()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	// the pbq is constructed by the caller with a store that the store provider did not create
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	store := fake.NewWAL(partitionID)
+	pq := &PBQ{
+		vertexName:    "reduce",
+		pipelineName:  "test-pipeline",
+		store:         store,
+		storeProvider: storeProvider,
+		output:        make(chan *window.TimedWindowRequest, 10),
+		readErrs:      make(chan error, readErrBufferSize),
+		PartitionID:   partitionID,
+		options:       DefaultOptions(),
+		windowType:    window.Aligned,
+		log:           logging.FromContext(ctx),
+		metricLabels: map[string]string{
+			metrics.LabelVertex:             "reduce",
+			metrics.LabelPipeline:           "test-pipeline",
+			metrics.LabelVertexReplicaIndex: "0",
+		},
+	}
+
+	assert.Error(t, pbqManager.Register("another-partition", pq))
+	assert.NoError(t, pbqManager.Register(partitionID.String(), pq))
+	registered, ok := pbqManager.GetPBQ(partitionID)
+	assert.True(t, ok)
+	assert.Same(t, pq, registered)
+
+	var existsErr PartitionExistsErr
+	assert.ErrorAs(t, pbqManager.Register(partitionID.String(), pq), &existsErr)
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.ErrorAs(t, err, &existsErr)
+
+	// the normal flows apply to the adopted pbq
+	windowRequests := testutils.BuildTestWindowRequests(1, time.Now(), window.Append)
+	assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+	assert.Len(t, store.Messages(), 1)
+	assert.NoError(t, pq.GC())
+	_, ok = pbqManager.GetPBQ(partitionID)
+	assert.False(t, ok)
+	assert.Equal(t, []partition.ID{partitionID}, storeProvider.Deleted())
+}
+
+func TestManager_CreateNewPBQs(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+		WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionIDs := make([]partition.ID, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		partitionIDs = append(partitionIDs, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("slot-%d", i)})
+	}
+	pbqs, err := pbqManager.CreateNewPBQs(ctx, partitionIDs, 16)
+	assert.NoError(t, err)
+	assert.Len(t, pbqs, len(partitionIDs))
+	assert.Equal(t, len(partitionIDs), pbqManager.PartitionCount())
+	for _, partitionID := range partitionIDs {
+		registered, ok := pbqManager.GetPBQ(partitionID)
+		assert.True(t, ok)
+		assert.Same(t, pbqs[partitionID.String()], registered)
+	}
+
+	// the failed partitions do not fail the others
+	created := partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-0"}
+	invalid := partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot/1"}
+	pbqs, err = pbqManager.CreateNewPBQs(ctx, []partition.ID{partitionIDs[0], created, invalid, created}, 2)
+	assert.Len(t, pbqs, 1)
+	assert.Contains(t, pbqs, created.String())
+	assert.Len(t, multierr.Errors(err), 3)
+	var existsErr PartitionExistsErr
+	assert.ErrorAs(t, err, &existsErr)
+	var invalidErr wal.InvalidPartitionIDErr
+	assert.ErrorAs(t, err, &invalidErr)
+	assert.Equal(t, len(partitionIDs)+1, pbqManager.PartitionCount())
+
+	_, err = pbqManager.CreateNewPBQs(ctx, partitionIDs, 0)
+	assert.Error(t, err)
+}
+
+func TestManager_CreateNewPBQsReserved(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := &gatedManager{Manager: memory.NewMemManager(memory.WithStoreSize(10)), gate: make(chan struct{})}
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	batch := make(chan map[string]ReadWriteCloser)
+	go func() {
+		pbqs, err := pbqManager.CreateNewPBQs(ctx, []partition.ID{partitionID}, 1)
+		assert.NoError(t, err)
+		batch <- pbqs
+	}()
+	assert.Eventually(t, func() bool { return storeProvider.created.Load() == 1 }, time.Second, time.Millisecond)
+
+	// the partitions of the batch are taken until the batch is registered
+	var existsErr PartitionExistsErr
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.ErrorAs(t, err, &existsErr)
+	absent := make(chan ReadWriteCloser)
+	go func() {
+		pq, isNew, err := pbqManager.CreatePBQIfAbsent(ctx, partitionID)
+		assert.NoError(t, err)
+		assert.False(t, isNew)
+		absent <- pq
+	}()
+	close(storeProvider.gate)
+	pbqs := <-batch
+	assert.Same(t, pbqs[partitionID.String()], <-absent)
+	assert.Equal(t, int32(1), storeProvider.created.Load())
+	assert.Empty(t, pbqManager.reserved)
+}
+
+func TestManager_Discard(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := fake.NewManager()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10),
+		WithLease(time.Minute, 10*time.Millisecond), WithMaxPartitions(1, false), WithPeek())
+	assert.NoError(t, err)
+
+	// a pbq which could not be registered gives its slot and the lease of its partition back
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	assert.NoError(t, pbqManager.acquirePartitionSlot(ctx))
+	p, err := pbqManager.newPBQ(ctx, partitionID, nil)
+	assert.NoError(t, err)
+	_, ok := storeProvider.LeaseHolder(partitionID)
+	assert.True(t, ok)
+	pbqManager.discard(p)
+	_, ok = storeProvider.LeaseHolder(partitionID)
+	assert.False(t, ok)
+	<-p.forwarded
+	assert.Empty(t, pbqManager.partitionSlots)
+
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+}
+
+func TestManager_CreateNewPBQs_MaxPartitions(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+		WithChannelBufferSize(10), WithMaxPartitions(2, false))
+	assert.NoError(t, err)
+
+	partitionIDs := make([]partition.ID, 0, 3)
+	for i := 0; i < 3; i++ {
+		partitionIDs = append(partitionIDs, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("slot-%d", i)})
+	}
+	pbqs, err := pbqManager.CreateNewPBQs(ctx, partitionIDs, 3)
+	assert.Len(t, pbqs, 2)
+	var maxErr MaxPartitionsExceededErr
+	assert.ErrorAs(t, err, &maxErr)
+	assert.Equal(t, 2, pbqManager.PartitionCount())
+}
+
+func TestManager_Lease(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	windowRequests := testutils.BuildTestWindowRequests(3, time.Now(), window.Append)
+	// the stale pod of the replica and the pod which replaces it share the store
+	storeProvider := fake.NewManager()
+	stalePod, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10),
+		WithLease(time.Minute, 10*time.Millisecond), WithLeaseOwner("reduce-0-stale"))
+	assert.NoError(t, err)
+	newPod, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10),
+		WithLease(time.Minute, 10*time.Millisecond), WithLeaseOwner("reduce-0-new"))
+	assert.NoError(t, err)
+
+	pq, err := stalePod.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	holder, ok := storeProvider.LeaseHolder(partitionID)
+	assert.True(t, ok)
+	assert.Equal(t, "reduce-0-stale", holder)
+	assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+
+	// the new pod cannot operate the partition while the lease is held
+	var heldErr wal.LeaseHeldErr
+	_, err = newPod.CreateNewPBQ(ctx, partitionID)
+	assert.ErrorAs(t, err, &heldErr)
+	assert.Equal(t, "reduce-0-stale", heldErr.Holder)
+
+	// the lease is lost, e.g. it expired while the pod was partitioned from the store, and taken over
+	storeProvider.RevokeLease(partitionID)
+	pq1, err := newPod.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	var lostErr wal.LeaseLostErr
+	assert.Eventually(t, func() bool {
+		return errors.As(pq.Write(ctx, &windowRequests[1], true), &lostErr)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, partitionID, lostErr.PartitionID)
+	assert.Equal(t, "reduce-0-stale", lostErr.Owner)
+	// nothing is written once the loss is detected
+	w, ok := storeProvider.GetWAL(partitionID)
+	assert.True(t, ok)
+	written := len(w.Messages())
+	assert.ErrorAs(t, pq.Write(ctx, &windowRequests[1], true), &lostErr)
+	assert.Len(t, w.Messages(), written)
+	assert.NoError(t, pq1.Write(ctx, &windowRequests[2], true))
+
+	// the GC releases the lease only if the pod holds it
+	assert.NoError(t, pq.(*PBQ).GC())
+	holder, ok = storeProvider.LeaseHolder(partitionID)
+	assert.True(t, ok)
+	assert.Equal(t, "reduce-0-new", holder)
+	assert.NoError(t, pq1.(*PBQ).GC())
+	_, ok = storeProvider.LeaseHolder(partitionID)
+	assert.False(t, ok)
+
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithLease(time.Minute, time.Second))
+	assert.Error(t, err)
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithLease(time.Second, time.Minute))
+	assert.Error(t, err)
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithLeaseOwner(""))
+	assert.Error(t, err)
+
+	// the pod owns the leases by default
+	t.Setenv(dfv1.EnvPod, "reduce-0-pod")
+	podManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithLease(time.Minute, time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, "reduce-0-pod", podManager.leaseOwner())
+}
+
+func TestManager_TieredStore(t *testing.T) {
+	ctx := context.Background()
+	diskTier := fake.NewManager()
+	memoryTier := memory.NewMemManager(memory.WithStoreSize(100))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, diskTier, window.Aligned, WithChannelBufferSize(10),
+		WithTieredStore(memoryTier))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	windowRequests := testutils.BuildTestWindowRequests(5, time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+	}
+
+	// the writes land in both tiers
+	diskStore, ok := diskTier.GetWAL(partitionID)
+	assert.True(t, ok)
+	assert.Len(t, diskStore.Messages(), len(windowRequests))
+	memoryStores, err := memoryTier.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, memoryStores, 1)
+	assert.Equal(t, int64(len(windowRequests)), memoryStores[0].Size())
+
+	// the GC deletes the stores of both tiers
+	assert.NoError(t, pq.GC())
+	assert.Equal(t, []partition.ID{partitionID}, diskTier.Deleted())
+	memoryStores, err = memoryTier.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, memoryStores, 0)
+}
+
+func TestManager_CreateNewPBQForKey(t *testing.T) {
+	ctx := context.Background()
+	resolver, err := partition.NewHashResolver(4)
+	assert.NoError(t, err)
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(100))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider,
+		window.Aligned, WithChannelBufferSize(10), WithPartitionResolver(resolver))
+	assert.NoError(t, err)
+
+	start, end := time.Unix(60, 0), time.Unix(120, 0)
+	_, err = pbqManager.CreateNewPBQForKey(ctx, start, end, "customer-1")
+	assert.NoError(t, err)
+
+	// the reducers compute the same partition from the exposed resolver
+	partitionID := pbqManager.PartitionResolver().Resolve(start, end, "customer-1")
+	_, ok := pbqManager.GetPBQ(partitionID)
+	assert.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("slot-%d", resolver.Shard("customer-1")), partitionID.Slot)
+
+	// the key is persisted with the metadata of the partition
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	metadata, err := store.(wal.MetadataStore).LoadMetadata()
+	assert.NoError(t, err)
+	assert.Equal(t, partitionID, metadata.PartitionID)
+	assert.Equal(t, []string{"customer-1"}, metadata.Keys)
+
+	_, err = pbqManager.CreateNewPBQForKey(ctx, start, end, "customer-1")
+	assert.ErrorAs(t, err, &PartitionExistsErr{})
+
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithPartitionResolver(nil))
+	assert.Error(t, err)
+}
+
+func TestManager_ShutDown(t *testing.T) {
+	ctx := context.Background()
+	walManager := fake.NewManager()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, walManager, window.Aligned,
+		WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionIDs := []partition.ID{
+		{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"},
+		{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"},
+		{Start: time.Unix(180, 0), End: time.Unix(240, 0), Slot: "slot-1"},
+	}
+	requests := testutils.BuildTestWindowRequests(5, time.Unix(60, 0), window.Append)
+	for _, partitionID := range partitionIDs {
+		q, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+		for i := range requests {
+			assert.NoError(t, q.Write(ctx, &requests[i], true))
+		}
+	}
+
+	assert.NoError(t, pbqManager.ShutDown(ctx))
+
+	// every store is closed with all the messages
+	for _, partitionID := range partitionIDs {
+		w, _ := walManager.GetWAL(partitionID)
+		assert.Len(t, w.Messages(), len(requests))
+		assert.True(t, w.IsClosed())
+	}
+}
+
+func TestManager_ShutDownError(t *testing.T) {
+	closeErr := errors.New("close failed")
+	walManager := fake.NewManager(fake.WithCloseErr(closeErr))
+	pbqManager, err := NewManager(context.Background(), "reduce", "test-pipeline", 0, walManager, window.Aligned,
+		WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	q, err := pbqManager.CreateNewPBQ(context.Background(), partitionID)
+	assert.NoError(t, err)
+	requests := testutils.BuildTestWindowRequests(1, time.Unix(60, 0), window.Append)
+	assert.NoError(t, q.Write(context.Background(), &requests[0], true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = pbqManager.ShutDown(ctx)
+	assert.ErrorIs(t, err, closeErr)
+	assert.ErrorContains(t, err, partitionID.String())
+}
+
+func TestManager_DeregisterAll(t *testing.T) {
+	ctx := context.Background()
+	storeProvider := memory.NewMemManager(memory.WithStoreSize(100))
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("partition-%d", i)}
+		_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+	}
+	assert.Len(t, pbqManager.ListPartitions(), 5)
+
+	assert.NoError(t, pbqManager.DeregisterAll())
+	assert.Len(t, pbqManager.ListPartitions(), 0)
+	stores, err := storeProvider.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stores, 0)
+
+	// the same partitions can be created again after the reset, also while another reset is in progress
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("partition-%d", i)}
+			_, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+			assert.NoError(t, err)
+		}(i)
+	}
+	assert.NoError(t, pbqManager.DeregisterAll())
+	wg.Wait()
+	assert.NoError(t, pbqManager.DeregisterAll())
+	assert.Len(t, pbqManager.ListPartitions(), 0)
+}
+
+func TestManager_CreateNewPBQInvalidPartitionID(t *testing.T) {
+	ctx := context.Background()
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	for _, slot := range []string{"slot-1", "", "slot 1", "slot.1", "..", "slot-é"} {
+		_, err = pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: slot})
+		assert.NoError(t, err, slot)
+	}
+
+	for _, slot := range []string{"../../etc/passwd", "slot/1", `slot\\1`, "slot\n1", "slot\x001", "slot\u0085"} {
+		partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: slot}
+		_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+		var invalidErr wal.InvalidPartitionIDErr
+		assert.ErrorAs(t, err, &invalidErr, slot)
+		assert.Equal(t, partitionID, invalidErr.PartitionID)
+		_, ok := pbqManager.GetPBQ(partitionID)
+		assert.False(t, ok)
+	}
+
+	// a stricter validator is applied after the default checks
+	strictErr := errors.New("slot should not be empty")
+	pbqManager, err = NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10), WithPartitionIDValidator(func(id partition.ID) error {
+			if id.Slot == "" {
+				return strictErr
+			}
+			return nil
+		}))
+	assert.NoError(t, err)
+	_, err = pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: ""})
+	assert.ErrorIs(t, err, strictErr)
+	_, err = pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot/1"})
+	assert.ErrorAs(t, err, &wal.InvalidPartitionIDErr{})
+	_, err = pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+	assert.NoError(t, err)
+}
+
+func TestManager_HealthCheck(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "partition-1"}
+
+	// no pbq is registered
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	assert.NoError(t, pbqManager.HealthCheck(ctx))
+
+	_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.NoError(t, pbqManager.HealthCheck(ctx))
+
+	// the store of the sampled partition cannot be reached
+	pingErr := errors.New("connection refused")
+	pbqManager, err = NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(fake.WithPingErr(pingErr)),
+		window.Aligned, WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("partition-%d", i)})
+		assert.NoError(t, err)
+	}
+	err = pbqManager.HealthCheck(ctx)
+	assert.ErrorIs(t, err, pingErr)
+	// a single partition is sampled per store provider
+	assert.Len(t, multierr.Errors(err), 1)
+
+	// the garbage collected pbqs are not sampled
+	assert.NoError(t, pbqManager.DeregisterAll())
+	assert.NoError(t, pbqManager.HealthCheck(ctx))
+}
+
+func TestManager_MaxPartitions(t *testing.T) {
+	ctx := context.Background()
+	partitionIDs := make([]partition.ID, 3)
+	for i := range partitionIDs {
+		partitionIDs[i] = partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("slot-%d", i)}
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+			WithMaxPartitions(2, false))
+		assert.NoError(t, err)
+
+		_, err = qManager.CreateNewPBQ(ctx, partitionIDs[0])
+		assert.NoError(t, err)
+		_, _, err = qManager.CreatePBQIfAbsent(ctx, partitionIDs[1])
+		assert.NoError(t, err)
+		assert.Equal(t, 2, qManager.PartitionCount())
+
+		_, err = qManager.CreateNewPBQ(ctx, partitionIDs[2])
+		assert.ErrorAs(t, err, &MaxPartitionsExceededErr{})
+		// an existing partition does not take another slot
+		_, created, err := qManager.CreatePBQIfAbsent(ctx, partitionIDs[0])
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, 2, qManager.PartitionCount())
+
+		// the slot of a deregistered partition is reused, even if it is garbage collected twice
+		q, _ := qManager.GetPBQ(partitionIDs[0])
+		q.CloseOfBook()
+		assert.NoError(t, q.Close())
+		assert.NoError(t, q.GC())
+		assert.NoError(t, q.GC())
+		assert.Equal(t, 1, qManager.PartitionCount())
+		_, err = qManager.CreateNewPBQ(ctx, partitionIDs[2])
+		assert.NoError(t, err)
+		_, err = qManager.CreateNewPBQ(ctx, partitionIDs[0])
+		assert.ErrorAs(t, err, &MaxPartitionsExceededErr{})
+	})
+
+	t.Run("block", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+			WithMaxPartitions(1, true))
+		assert.NoError(t, err)
+		q, err := qManager.CreateNewPBQ(ctx, partitionIDs[0])
+		assert.NoError(t, err)
+
+		// the creation gives up when the ctx is done
+		cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = qManager.CreateNewPBQ(cctx, partitionIDs[1])
+		assert.ErrorAs(t, err, &MaxPartitionsExceededErr{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// the creation waits for the partition to be deregistered
+		created := make(chan error, 1)
+		go func() {
+			_, err := qManager.CreateNewPBQ(ctx, partitionIDs[1])
+			created <- err
+		}()
+		select {
+		case <-created:
+			t.Fatal("the pbq should not be created before a partition is deregistered")
+		case <-time.After(50 * time.Millisecond):
+		}
+		q.CloseOfBook()
+		assert.NoError(t, q.Close())
+		assert.NoError(t, q.GC())
+		assert.NoError(t, <-created)
+		assert.Equal(t, 1, qManager.PartitionCount())
+	})
+
+	_, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(), window.Aligned, WithMaxPartitions(0, false))
+	assert.Error(t, err)
+}
+
+func TestManager_PartitionChurnMetrics(t *testing.T) {
+	ctx := context.Background()
+	fakeClock := clocktesting.NewFakeClock(time.Unix(1000, 0))
+	pbqManager, err := NewManager(ctx, "reduce", "test-churn", 0, fake.NewManager(), window.Aligned, WithClock(fakeClock))
+	assert.NoError(t, err)
+	labels := map[string]string{
+		metrics.LabelVertex:             "reduce",
+		metrics.LabelPipeline:           "test-churn",
+		metrics.LabelVertexReplicaIndex: "0",
+	}
+
+	short, err := pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+	assert.NoError(t, err)
+	long, err := pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "slot-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), testutil.ToFloat64(partitionCreatedCount.With(labels)))
+
+	fakeClock.Step(2 * time.Second)
+	assert.NoError(t, short.GC())
+	fakeClock.Step(3 * time.Second)
+	assert.NoError(t, long.GC())
+	// a repeated GC does not deregister the partition again
+	assert.NoError(t, long.GC())
+	assert.Equal(t, float64(2), testutil.ToFloat64(partitionDeregisteredCount.With(labels)))
+
+	lifetime := &dto.Metric{}
+	assert.NoError(t, partitionLifetime.With(labels).(prometheus.Histogram).Write(lifetime))
+	assert.Equal(t, uint64(2), lifetime.GetHistogram().GetSampleCount())
+	assert.InDelta(t, 7.0, lifetime.GetHistogram().GetSampleSum(), 0.001)
+}
+
+func TestManager_StoreType(t *testing.T) {
+	ctx := context.Background()
+	// the registry is global, the names are unique across the runs of the test
+	storeType := fmt.Sprintf("test-noop-%d", time.Now().UnixNano())
+	assert.NoError(t, wal.RegisterStoreType(storeType, func(_ context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+		assert.Equal(t, wal.ManagerOptions{PipelineName: "test-pipeline", VertexName: "reduce", Replica: 2}, opts)
+		return noop.NewNoopStores(), nil
+	}))
+
+	// the registered store type replaces the store provider
+	pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 2, nil, window.Aligned, WithStoreType(storeType))
+	assert.NoError(t, err)
+	q, err := pbqManager.CreateNewPBQ(ctx, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+	assert.NoError(t, err)
+	writeRequests := testutils.BuildTestWindowRequests(1, time.Now(), window.Append)
+	assert.NoError(t, q.Write(ctx, &writeRequests[0], true))
+	assert.Equal(t, writeRequests[0].ReadMessage.Header.ID, (<-q.ReadCh()).ReadMessage.Header.ID)
+	store, storeProvider := q.(*PBQ).currentStore()
+	assert.Equal(t, "*noop.noopWAL", fmt.Sprintf("%T", store))
+	assert.Equal(t, "*noop.noopManager", fmt.Sprintf("%T", storeProvider))
+	q.CloseOfBook()
+	assert.NoError(t, q.GC())
+
+	_, err = NewManager(ctx, "reduce", "test-pipeline", 2, nil, window.Aligned, WithStoreType(storeType+"-unknown"))
+	assert.Error(t, err)
+}
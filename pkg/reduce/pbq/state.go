@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import "fmt"
+
+// State is the state of a PBQ in its lifecycle. A PBQ moves forward through the states, it never goes back.
+//
+//	Created -> Replaying -> Live -> Closed -> GarbageCollected
+//
+// A PBQ with nothing to replay goes from Created to Live on the first live write. The book of a PBQ can be closed in
+// any state before Closed, and a PBQ can be garbage collected in any state, GC does not close the book.
+type State int32
+
+const (
+	// StateCreated is the state of a new PBQ, nothing has been written to it yet.
+	StateCreated State = iota
+	// StateReplaying is the state of a PBQ whose persisted messages are being replayed.
+	StateReplaying
+	// StateLive is the state of a PBQ whose replay is complete, the messages written to it are the live messages from
+	// the ISB.
+	StateLive
+	// StateClosed is the state of a PBQ whose book is closed, the writes are refused with COBErr.
+	StateClosed
+	// StateGarbageCollected is the state of a PBQ whose store is deleted, it is no longer registered with the manager.
+	StateGarbageCollected
+)
+
+// validTransitions are the states a PBQ can move to from every state.
+var validTransitions = map[State][]State{
+	StateCreated:   {StateReplaying, StateLive, StateClosed, StateGarbageCollected},
+	StateReplaying: {StateLive, StateClosed, StateGarbageCollected},
+	StateLive:      {StateClosed, StateGarbageCollected},
+	StateClosed:    {StateGarbageCollected},
+}
+
+func (s State) String() string {
+	switch s {
+	case StateCreated:
+		return "created"
+	case StateReplaying:
+		return "replaying"
+	case StateLive:
+		return "live"
+	case StateClosed:
+		return "closed"
+	case StateGarbageCollected:
+		return "garbage-collected"
+	default:
+		return fmt.Sprintf("unknown(%d)", int32(s))
+	}
+}
+
+// canTransition returns true if a PBQ can move from the state to the given state.
+func (s State) canTransition(to State) bool {
+	for _, valid := range validTransitions[s] {
+		if valid == to {
+			return true
+		}
+	}
+	return false
+}
+
+// State returns the current state of the PBQ.
+func (p *PBQ) State() State {
+	return State(p.state.Load())
+}
+
+// transition moves the PBQ to the given state, it is a no-op if the PBQ is already in that state. StateTransitionErr is
+// returned if the PBQ cannot move from its current state to the given state.
+func (p *PBQ) transition(to State) error {
+	for {
+		from := p.State()
+		if from == to {
+			return nil
+		}
+		if !from.canTransition(to) {
+			return StateTransitionErr{PartitionID: p.PartitionID, From: from, To: to}
+		}
+		if p.state.CompareAndSwap(int32(from), int32(to)) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_StateLifecycle(t *testing.T) {
+	ctx := context.Background()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned,
+		WithChannelBufferSize(10))
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q := pq.(*PBQ)
+	assert.Equal(t, StateCreated, q.State())
+
+	windowRequests := testutils.BuildTestWindowRequests(4, time.Now(), window.Append)
+	// a replayed message moves the PBQ to replaying
+	assert.NoError(t, q.Write(ctx, &windowRequests[0], false))
+	assert.Equal(t, StateReplaying, q.State())
+
+	// a live message moves the PBQ to live, it cannot go back to replaying
+	assert.NoError(t, q.Write(ctx, &windowRequests[1], true))
+	assert.Equal(t, StateLive, q.State())
+	var stateErr StateTransitionErr
+	assert.ErrorAs(t, q.transition(StateReplaying), &stateErr)
+	assert.Equal(t, StateLive, stateErr.From)
+	assert.Equal(t, StateReplaying, stateErr.To)
+
+	q.CloseOfBook()
+	assert.Equal(t, StateClosed, q.State())
+	assert.ErrorAs(t, q.Write(ctx, &windowRequests[2], true), &COBErr{})
+
+	assert.NoError(t, q.GC())
+	assert.Equal(t, StateGarbageCollected, q.State())
+	assert.ErrorAs(t, q.Write(ctx, &windowRequests[3], true), &stateErr)
+	assert.Equal(t, StateGarbageCollected, stateErr.From)
+	assert.Equal(t, "garbage-collected", StateGarbageCollected.String())
+}
+
+func TestPBQ_GCOpenBook(t *testing.T) {
+	ctx := context.Background()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned)
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	q := pq.(*PBQ)
+
+	// GC does not close the book
+	assert.NoError(t, q.GC())
+	assert.Equal(t, StateGarbageCollected, q.State())
+	select {
+	case <-q.ReadCh():
+		assert.Fail(t, "the output channel of an open book is closed by GC")
+	default:
+	}
+	_, ok := qManager.GetPBQ(partitionID)
+	assert.False(t, ok)
+	// GC is idempotent
+	assert.NoError(t, q.GC())
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+// PBQStats are the cumulative counts of a PBQ over its lifetime, unlike the metrics they are not aggregated across the
+// partitions.
+type PBQStats struct {
+	// MessagesWritten is the number of messages accepted by the PBQ, the live and the replayed ones.
+	MessagesWritten int64
+	// BytesWritten is the payload size of the messages accepted by the PBQ.
+	BytesWritten int64
+	// MessagesRead is the number of requests taken from the read channel by the reader, it includes the requests of
+	// the window operations which carry no message.
+	MessagesRead int64
+}
+
+// Stats returns the cumulative counts of the PBQ. The counts are updated without a lock, so they are not consistent with
+// each other while the PBQ is written or read.
+func (p *PBQ) Stats() PBQStats {
+	var read int64
+	if p.forwarded != nil {
+		// the output channel is unbuffered, every request the forwarding goroutine wrote to it has been read
+		read = p.forwardedCount.Load()
+	} else {
+		// the requests which are still buffered in the output channel have not been read
+		read = max(p.channelWriteCount.Load()-int64(len(p.output)), 0)
+	}
+	return PBQStats{
+		MessagesWritten: p.messagesWritten.Load(),
+		BytesWritten:    p.bytesWritten.Load(),
+		MessagesRead:    read,
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// Subscribe registers an additional reader of the PBQ, e.g. a debug tap next to the reducer, and returns its channel.
+// Every message written to the PBQ after the subscription, including the replayed ones, is sent to all the subscribers
+// before it is written to the output channel, the requests without a message are not. The channel is buffered as set by
+// WithSubscriberBufferSize, and a subscriber which falls behind is handled as set by WithSlowSubscriberPolicy. The
+// channel is closed on close of book. The messages are shared with the reducer, so a subscriber should not modify them.
+func (p *PBQ) Subscribe() <-chan *isb.Message {
+	ch := make(chan *isb.Message, p.options.subscriberBufferSize)
+	// the lock waits for the in-flight writes, the book cannot be closed meanwhile
+	p.cobMu.Lock()
+	defer p.cobMu.Unlock()
+	if p.State() >= StateClosed {
+		close(ch)
+		return ch
+	}
+	p.subscribers = append(p.subscribers, ch)
+	return ch
+}
+
+// publish sends the message to all the subscribers. With SlowSubscriberBlock it returns early if the ctx is done or the
+// book is closing, the message is then not sent to the remaining subscribers. Caller should hold cobMu.
+func (p *PBQ) publish(ctx context.Context, msg *isb.ReadMessage) {
+	for _, ch := range p.subscribers {
+		if p.options.slowSubscriberPolicy == SlowSubscriberBlock {
+			select {
+			case ch <- &msg.Message:
+			case <-p.closing:
+				return
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		select {
+		case ch <- &msg.Message:
+		default:
+			pbqSubscriberDropCount.With(p.metricLabels).Inc()
+		}
+	}
+}
+
+// closeSubscribers closes the channels of the subscribers. Caller should hold cobMu, so there is no publish in flight.
+func (p *PBQ) closeSubscribers() {
+	for _, ch := range p.subscribers {
+		close(ch)
+	}
+	p.subscribers = nil
+}
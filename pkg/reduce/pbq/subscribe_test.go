@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	msgCount := 10
+	windowRequests := testutils.BuildTestWindowRequests(int64(msgCount), time.Now(), window.Append)
+
+	collect := func(ch <-chan *isb.Message) []*isb.Message {
+		msgs := make([]*isb.Message, 0)
+		for msg := range ch {
+			msgs = append(msgs, msg)
+		}
+		return msgs
+	}
+
+	t.Run("all the subscribers receive all the messages", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned,
+			WithChannelBufferSize(int64(msgCount)), WithSubscriberBufferSize(0), WithSlowSubscriberPolicy(SlowSubscriberBlock))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		results := make([]chan []*isb.Message, 2)
+		for i := range results {
+			results[i] = make(chan []*isb.Message, 1)
+			ch := pq.(*PBQ).Subscribe()
+			go func(i int) {
+				results[i] <- collect(ch)
+			}(i)
+		}
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		// the reader of the output channel receives the messages as well
+		assert.Len(t, pq.ReadCh(), msgCount)
+		pq.CloseOfBook()
+
+		for _, result := range results {
+			msgs := <-result
+			assert.Len(t, msgs, msgCount)
+			for i, msg := range msgs {
+				assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, msg.Header.ID)
+			}
+		}
+	})
+
+	t.Run("a slow subscriber misses the messages", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned,
+			WithChannelBufferSize(int64(msgCount)), WithSubscriberBufferSize(2))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		slow, fast := pq.(*PBQ).Subscribe(), pq.(*PBQ).Subscribe()
+		received := make(chan []*isb.Message, 1)
+		for i := range windowRequests {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+			// the fast subscriber keeps up, the slow one does not read until the end
+			assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, (<-fast).Header.ID)
+		}
+		go func() {
+			received <- collect(slow)
+		}()
+		pq.CloseOfBook()
+		assert.Len(t, <-received, 2)
+		_, ok := <-fast
+		assert.False(t, ok)
+		assert.Len(t, pq.ReadCh(), msgCount)
+	})
+
+	t.Run("subscribe after close of book", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned)
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+		pq.CloseOfBook()
+		_, ok := <-pq.(*PBQ).Subscribe()
+		assert.False(t, ok)
+	})
+}
+
+func TestWithSlowSubscriberPolicy(t *testing.T) {
+	o := DefaultOptions()
+	assert.Equal(t, SlowSubscriberDrop, o.slowSubscriberPolicy)
+	assert.NoError(t, WithSlowSubscriberPolicy(SlowSubscriberBlock)(o))
+	assert.Equal(t, SlowSubscriberBlock, o.slowSubscriberPolicy)
+	assert.Error(t, WithSlowSubscriberPolicy(SlowSubscriberPolicy(5))(o))
+	assert.Error(t, WithSubscriberBufferSize(-1)(o))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// createWAL creates the store of the partition, the creation fails with wal.TimeoutErr if it takes longer than the store
+// open timeout. A store which is created after the timeout is closed and released by the store provider, so that the
+// next creation of the partition does not get the closed store, the next creation waits until then.
+func (m *Manager) createWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	timeout := m.pbqOptions.storeOpenTimeout
+	if timeout <= 0 {
+		return m.storeProvider.CreateWAL(ctx, partitionID)
+	}
+
+	type created struct {
+		store wal.WAL
+		err   error
+	}
+	openCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	m.RLock()
+	late := m.lateStores[partitionID.String()]
+	m.RUnlock()
+	if late != nil {
+		select {
+		case <-late:
+		case <-openCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, wal.TimeoutErr{PartitionID: partitionID, Operation: "open", Timeout: timeout}
+		}
+	}
+	result := make(chan created, 1)
+	go func() {
+		store, err := m.storeProvider.CreateWAL(openCtx, partitionID)
+		result <- created{store: store, err: err}
+	}()
+
+	select {
+	case r := <-result:
+		return r.store, r.err
+	case <-openCtx.Done():
+	}
+	select {
+	case r := <-result:
+		// the store was created just in time
+		return r.store, r.err
+	default:
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	late = make(chan struct{})
+	m.Lock()
+	m.lateStores[partitionID.String()] = late
+	m.Unlock()
+	go func() {
+		defer func() {
+			m.Lock()
+			delete(m.lateStores, partitionID.String())
+			m.Unlock()
+			close(late)
+		}()
+		r := <-result
+		if r.store == nil {
+			return
+		}
+		if releaser, ok := m.storeProvider.(wal.Releaser); ok {
+			releaser.ReleaseWAL(partitionID, r.store)
+		}
+		if err := r.store.Close(); err != nil {
+			m.log.Warnw("Failed to close the store created after the timeout", zap.String("ID", partitionID.String()), zap.Error(err))
+		}
+	}()
+	return nil, wal.TimeoutErr{PartitionID: partitionID, Operation: "open", Timeout: timeout}
+}
+
+// storeClose is a close of the store of the PBQ which is in flight or done, err is set once done is closed.
+type storeClose struct {
+	store wal.WAL
+	done  chan struct{}
+	err   error
+}
+
+// closeStore closes the store of the PBQ, it returns wal.TimeoutErr if the close takes longer than the store close
+// timeout, the close carries on in the background. Only one close of the store is in flight, a retry waits for it.
+// Caller should hold the lock.
+func (p *PBQ) closeStore() error {
+	timeout := p.options.storeCloseTimeout
+	if timeout <= 0 {
+		return p.store.Close()
+	}
+
+	if p.storeClose == nil || p.storeClose.store != p.store {
+		closing := &storeClose{store: p.store, done: make(chan struct{})}
+		p.storeClose = closing
+		go func() {
+			closing.err = closing.store.Close()
+			close(closing.done)
+		}()
+	}
+	closing := p.storeClose
+	timer := p.options.clock.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-closing.done:
+		return closing.err
+	case <-timer.C():
+		p.log.Warnw("The store is not closed within the timeout", zap.String("ID", p.PartitionID.String()), zap.Duration("timeout", timeout))
+		return wal.TimeoutErr{PartitionID: p.PartitionID, Operation: "close", Timeout: timeout}
+	}
+}
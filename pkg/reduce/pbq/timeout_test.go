@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/fake"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// hangingManager is a store provider of a remote store whose WALs hang on open and close until they are released.
+type hangingManager struct {
+	*fake.Manager
+	release chan struct{}
+	// hangOnOpen makes CreateWAL hang, otherwise only the close of the WALs hangs
+	hangOnOpen bool
+	wals       []*hangingWAL
+	released   []wal.WAL
+	mu         sync.Mutex
+}
+
+type hangingWAL struct {
+	*fake.WAL
+	release chan struct{}
+	closes  atomic.Int32
+}
+
+func (w *hangingWAL) Close() error {
+	w.closes.Add(1)
+	<-w.release
+	return w.WAL.Close()
+}
+
+func (m *hangingManager) ReleaseWAL(_ partition.ID, w wal.WAL) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.released = append(m.released, w)
+}
+
+func (m *hangingManager) releasedWALs() []wal.WAL {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]wal.WAL(nil), m.released...)
+}
+
+func (m *hangingManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	if m.hangOnOpen {
+		// the store ignores the ctx, like a client without a deadline
+		<-m.release
+	}
+	store, err := m.Manager.CreateWAL(ctx, partitionID)
+	if err != nil {
+		return nil, err
+	}
+	w := &hangingWAL{WAL: store.(*fake.WAL), release: m.release}
+	m.mu.Lock()
+	m.wals = append(m.wals, w)
+	m.mu.Unlock()
+	return w, nil
+}
+
+func TestManager_StoreTimeout(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+
+	t.Run("open", func(t *testing.T) {
+		storeProvider := &hangingManager{Manager: fake.NewManager(), release: make(chan struct{}), hangOnOpen: true}
+		pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned, WithStoreOpenTimeout(50*time.Millisecond))
+		assert.NoError(t, err)
+
+		start := time.Now()
+		_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+		var timeoutErr wal.TimeoutErr
+		assert.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "open", timeoutErr.Operation)
+		assert.Less(t, time.Since(start), time.Second)
+		_, ok := pbqManager.GetPBQ(partitionID)
+		assert.False(t, ok)
+		// the next creation waits for the late store, it does not open the partition a second time
+		_, err = pbqManager.CreateNewPBQ(ctx, partitionID)
+		assert.ErrorAs(t, err, &timeoutErr)
+
+		// the store created after the timeout is released by the store provider and closed
+		close(storeProvider.release)
+		assert.Eventually(t, func() bool {
+			store, ok := storeProvider.GetWAL(partitionID)
+			return ok && store.IsClosed()
+		}, time.Second, 10*time.Millisecond)
+		storeProvider.mu.Lock()
+		late := storeProvider.wals[0]
+		storeProvider.mu.Unlock()
+		assert.Eventually(t, func() bool {
+			released := storeProvider.releasedWALs()
+			return len(released) == 1 && released[0] == late
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		storeProvider := &hangingManager{Manager: fake.NewManager(), release: make(chan struct{})}
+		pbqManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, storeProvider, window.Aligned,
+			WithStoreOpenTimeout(time.Second), WithStoreCloseTimeout(50*time.Millisecond))
+		assert.NoError(t, err)
+		q, err := pbqManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		assert.ErrorAs(t, q.Close(), &wal.TimeoutErr{})
+		// a retry waits for the close in flight
+		assert.ErrorAs(t, q.Close(), &wal.TimeoutErr{})
+		assert.Equal(t, int32(1), storeProvider.wals[0].closes.Load())
+		close(storeProvider.release)
+		assert.Eventually(t, func() bool {
+			return storeProvider.wals[0].IsClosed()
+		}, time.Second, 10*time.Millisecond)
+		assert.NoError(t, q.Close())
+		assert.Equal(t, int32(1), storeProvider.wals[0].closes.Load())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned, WithStoreOpenTimeout(0))
+		assert.Error(t, err)
+		_, err = NewManager(ctx, "reduce", "test-pipeline", 0, fake.NewManager(), window.Aligned, WithStoreCloseTimeout(-time.Second))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// TraceOperation is the operation of a trace line.
+type TraceOperation string
+
+const (
+	TraceWrite       TraceOperation = "write"
+	TraceRead        TraceOperation = "read"
+	TraceCloseOfBook TraceOperation = "cob"
+	TraceClose       TraceOperation = "close"
+	TraceGC          TraceOperation = "gc"
+)
+
+// TraceLine is a line of the access log of a traced PBQ, it is written to the sink as a JSON object per line.
+type TraceLine struct {
+	Time        time.Time      `json:"time"`
+	PartitionID string         `json:"partitionID"`
+	Operation   TraceOperation `json:"operation"`
+	// Offset is the number of messages written, for a write, cob or close, or read, for a read or gc, before the
+	// operation.
+	Offset int64 `json:"offset"`
+	// Count is the number of messages of the operation.
+	Count int64  `json:"count"`
+	Error string `json:"error,omitempty"`
+}
+
+// Tracer is a ReadWriteCloser which delegates to a PBQ while appending a TraceLine per operation to a sink, it is meant
+// for debugging the reducers. The reads are traced as they are received from the read channel.
+type Tracer struct {
+	ReadWriteCloser
+	partitionID partition.ID
+	sink        io.Writer
+	written     int64
+	read        int64
+	readCh      chan *window.TimedWindowRequest
+	readOnce    sync.Once
+	mu          sync.Mutex
+}
+
+var _ ReadWriteCloser = (*Tracer)(nil)
+
+// NewTracer returns a ReadWriteCloser which traces the operations on the PBQ of the partition to the sink.
+func NewTracer(q ReadWriteCloser, partitionID partition.ID, sink io.Writer) *Tracer {
+	return &Tracer{
+		ReadWriteCloser: q,
+		partitionID:     partitionID,
+		sink:            sink,
+	}
+}
+
+// Write writes the request to the PBQ and traces the write.
+func (t *Tracer) Write(ctx context.Context, request *window.TimedWindowRequest, persist bool) error {
+	err := t.ReadWriteCloser.Write(ctx, request, persist)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace(TraceWrite, t.written, 1, err)
+	if err == nil {
+		t.written++
+	}
+	return err
+}
+
+// ReadCh exposes the read channel of the PBQ, every request received from it is traced.
+func (t *Tracer) ReadCh() <-chan *window.TimedWindowRequest {
+	t.readOnce.Do(func() {
+		t.readCh = make(chan *window.TimedWindowRequest)
+		go func() {
+			defer close(t.readCh)
+			for request := range t.ReadWriteCloser.ReadCh() {
+				t.mu.Lock()
+				t.trace(TraceRead, t.read, 1, nil)
+				t.read++
+				t.mu.Unlock()
+				t.readCh <- request
+			}
+		}()
+	})
+	return t.readCh
+}
+
+// CloseOfBook closes the PBQ and traces the cob.
+func (t *Tracer) CloseOfBook() {
+	t.ReadWriteCloser.CloseOfBook()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace(TraceCloseOfBook, t.written, 0, nil)
+}
+
+// Close closes the PBQ and traces the close.
+func (t *Tracer) Close() error {
+	err := t.ReadWriteCloser.Close()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace(TraceClose, t.written, 0, err)
+	return err
+}
+
+// GC garbage collects the PBQ and traces the gc.
+func (t *Tracer) GC() error {
+	err := t.ReadWriteCloser.GC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace(TraceGC, t.read, 0, err)
+	return err
+}
+
+// trace writes a trace line to the sink, the errors of the sink are ignored since tracing must not affect the PBQ.
+// Caller should hold the lock.
+func (t *Tracer) trace(operation TraceOperation, offset int64, count int64, err error) {
+	line := TraceLine{
+		Time:        time.Now(),
+		PartitionID: t.partitionID.String(),
+		Operation:   operation,
+		Offset:      offset,
+		Count:       count,
+	}
+	if err != nil {
+		line.Error = err.Error()
+	}
+	body, _ := json.Marshal(line)
+	_, _ = t.sink.Write(append(body, '\n'))
+}
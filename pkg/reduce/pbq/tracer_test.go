@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestTracer(t *testing.T) {
+	ctx := context.Background()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)),
+		window.Aligned, WithChannelBufferSize(10), WithReadTimeout(1*time.Second))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	q, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+	var sink bytes.Buffer
+	tracer := NewTracer(q, partitionID, &sink)
+
+	windowRequests := testutils.BuildTestWindowRequests(2, time.Now(), window.Append)
+	for i := range windowRequests {
+		assert.NoError(t, tracer.Write(ctx, &windowRequests[i], true))
+	}
+	tracer.CloseOfBook()
+	assert.Error(t, tracer.Write(ctx, &windowRequests[0], true))
+	for range tracer.ReadCh() {
+	}
+	assert.NoError(t, tracer.Close())
+	assert.NoError(t, tracer.GC())
+
+	expected := []TraceLine{
+		{Operation: TraceWrite, Offset: 0, Count: 1},
+		{Operation: TraceWrite, Offset: 1, Count: 1},
+		{Operation: TraceCloseOfBook, Offset: 2},
+		{Operation: TraceWrite, Offset: 2, Count: 1, Error: COBErr{PartitionID: partitionID}.Error()},
+		{Operation: TraceRead, Offset: 0, Count: 1},
+		{Operation: TraceRead, Offset: 1, Count: 1},
+		{Operation: TraceClose, Offset: 2},
+		{Operation: TraceGC, Offset: 2},
+	}
+	scanner := bufio.NewScanner(&sink)
+	var lines []TraceLine
+	for scanner.Scan() {
+		var line TraceLine
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		assert.Equal(t, partitionID.String(), line.PartitionID)
+		assert.False(t, line.Time.IsZero())
+		line.PartitionID = ""
+		line.Time = time.Time{}
+		lines = append(lines, line)
+	}
+	assert.Equal(t, expected, lines)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// A persisted message which is not written to the output channel, because its write is canceled or the delivery of
+// the spilled messages stops, would only be read again after a restart. Such messages are tracked by their offsets in
+// the store, and delivered from the store on close of book before the output channel is closed, so that the reader
+// does not find the end of the channel while the store still holds messages it has never read. They are delivered
+// after the requests which are already in the channel. The messages are not tracked if the store cannot read at the
+// offsets, or if the full policy evicts the oldest messages, since the offsets shift then.
+
+// offsetRange is the range [from, to) of the offsets of the undelivered messages, to is -1 if the range extends to the
+// end of the store.
+type offsetRange struct {
+	from int64
+	to   int64
+}
+
+// markUndelivered tracks the message of the request, which is the last one persisted, as undelivered. The writes of the
+// messages are serialized by writeMu, so the offset of the message is known.
+func (p *PBQ) markUndelivered(request *window.TimedWindowRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.tracksUndelivered() {
+		return
+	}
+	offset := p.store.Size() - 1
+	if p.migrating {
+		// the message is the last one buffered, and the buffered messages are written after the ones of the store
+		offset = p.store.Size() + int64(len(p.migrationBuffer)) - 1
+	}
+	p.addUndelivered(offsetRange{from: offset, to: offset + 1}, request)
+}
+
+// markSpillUndelivered tracks the messages from the offset on as undelivered, since the delivery of the spilled
+// messages stopped there and the following messages are only persisted. Caller should hold the lock.
+func (p *PBQ) markSpillUndelivered(offset int64, request *window.TimedWindowRequest) {
+	if !p.tracksUndelivered() {
+		return
+	}
+	p.addUndelivered(offsetRange{from: offset, to: -1}, request)
+}
+
+// redeliverable returns whether a persisted message which is not written to the output channel can be delivered from
+// the store on close of book.
+func (p *PBQ) redeliverable() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.tracksUndelivered()
+}
+
+// tracksUndelivered returns whether the undelivered messages can be delivered from the store. Caller should hold the
+// lock.
+func (p *PBQ) tracksUndelivered() bool {
+	if p.store == nil || p.options.fullPolicy == FullPolicyDropOldest {
+		return false
+	}
+	_, ok := p.store.(wal.OffsetReader)
+	return ok
+}
+
+// addUndelivered adds the range to the undelivered messages, the request of the first undelivered message is kept to
+// rebuild the requests of the messages read from the store. Caller should hold the lock.
+func (p *PBQ) addUndelivered(r offsetRange, request *window.TimedWindowRequest) {
+	p.undelivered = append(p.undelivered, r)
+	if p.undeliveredRequest == nil {
+		p.undeliveredRequest = request
+	}
+}
+
+// deliverUndelivered writes the undelivered messages from the store to the channel, and then closes the channel with
+// closeCh. The requests are rebuilt from the request of the first undelivered message, since all the messages of an
+// aligned partition belong to the same window. The delivery stops if the PBQ is closed or garbage collected, or if the
+// store fails to read, the messages which are not delivered are replayed after a restart.
+func (p *PBQ) deliverUndelivered(ranges []offsetRange, request *window.TimedWindowRequest, closeCh func()) {
+	defer closeCh()
+	for _, r := range ranges {
+		for offset := r.from; ; {
+			select {
+			case <-p.stopDelivery:
+				return
+			default:
+			}
+			p.mu.Lock()
+			if p.store == nil {
+				p.mu.Unlock()
+				return
+			}
+			end := r.to
+			if end < 0 {
+				end = p.store.Size()
+			}
+			if offset >= end {
+				p.mu.Unlock()
+				break
+			}
+			msgs, _, err := p.store.(wal.OffsetReader).ReadAt(offset, min(p.options.readBatchSize, end-offset))
+			p.mu.Unlock()
+			if err == nil && len(msgs) == 0 {
+				err = fmt.Errorf("no messages read at offset %d", offset)
+			}
+			if err != nil {
+				p.log.Errorw("Failed to read the undelivered messages", zap.String("ID", p.PartitionID.String()), zap.Int64("offset", offset), zap.Error(err))
+				p.reportReadError(fmt.Errorf("failed to read the undelivered messages at offset %d, %w", offset, err))
+				return
+			}
+
+			for _, msg := range msgs {
+				select {
+				case p.currentCh() <- &window.TimedWindowRequest{
+					ReadMessage: msg,
+					Operation:   window.Append,
+					Windows:     request.Windows,
+					ID:          request.ID,
+				}:
+					p.channelWriteCount.Add(1)
+					pbqChannelWriteCount.With(p.metricLabels).Inc()
+				case <-p.stopDelivery:
+					return
+				}
+			}
+			offset += int64(len(msgs))
+		}
+	}
+}
+
+// stopDeliveries stops the delivery of the undelivered messages, the output channel is closed without them.
+func (p *PBQ) stopDeliveries() {
+	if p.stopDelivery == nil {
+		return
+	}
+	p.stopDeliveryOnce.Do(func() { close(p.stopDelivery) })
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_DeliverUndeliveredOnCOB(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	readAll := func(pq ReadWriteCloser) []*isb.ReadMessage {
+		var read []*isb.ReadMessage
+		for request := range pq.ReadCh() {
+			read = append(read, request.ReadMessage)
+		}
+		return read
+	}
+
+	t.Run("canceled writes", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+			WithChannelBufferSize(1))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		windowRequests := testutils.BuildTestWindowRequests(4, time.Now(), window.Append)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		// the channel is full and nobody reads it, the writes are canceled after the messages are persisted
+		for i := 1; i < 3; i++ {
+			writeCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			assert.NoError(t, pq.Write(writeCtx, &windowRequests[i], true))
+			cancel()
+		}
+		done := make(chan []*isb.ReadMessage)
+		go func() { done <- readAll(pq) }()
+		assert.NoError(t, pq.Write(ctx, &windowRequests[3], true))
+		pq.CloseOfBook()
+
+		// the canceled messages are read from the store after the delivered ones
+		read := <-done
+		assert.Len(t, read, 4)
+		for i, id := range []int{0, 3, 1, 2} {
+			assert.Equal(t, windowRequests[id].ReadMessage.Header.ID, read[i].Header.ID)
+		}
+		assert.NoError(t, pq.GC())
+	})
+
+	t.Run("stopped spill", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+			WithChannelBufferSize(1), WithSpillOnBackpressure(5*time.Millisecond))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		windowRequests := testutils.BuildTestWindowRequests(4, time.Now(), window.Append)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		// the message is spilled, and its delivery stops when the ctx of the write is canceled
+		spillCtx, cancel := context.WithCancel(ctx)
+		assert.NoError(t, pq.Write(spillCtx, &windowRequests[1], true))
+		cancel()
+		// the partition is still spilling, the messages are only persisted
+		for i := 2; i < 4; i++ {
+			assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		}
+		pq.CloseOfBook()
+
+		read := readAll(pq)
+		assert.Len(t, read, 4)
+		for i, msg := range read {
+			assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, msg.Header.ID)
+		}
+		assert.NoError(t, pq.GC())
+	})
+
+	t.Run("blocked writes bail out on cob", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+			WithChannelBufferSize(1))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		windowRequests := testutils.BuildTestWindowRequests(2, time.Now(), window.Append)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		// the channel is full and nobody reads it, the write blocks until the book is closing
+		written := make(chan error)
+		go func() { written <- pq.Write(ctx, &windowRequests[1], true) }()
+		assert.Eventually(t, func() bool { return pq.(*PBQ).store.Size() == 2 }, time.Second, time.Millisecond)
+		pq.CloseOfBook()
+		assert.NoError(t, <-written)
+
+		read := readAll(pq)
+		assert.Len(t, read, 2)
+		for i, msg := range read {
+			assert.Equal(t, windowRequests[i].ReadMessage.Header.ID, msg.Header.ID)
+		}
+		assert.NoError(t, pq.GC())
+	})
+
+	t.Run("stopped by close", func(t *testing.T) {
+		qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(10)), window.Aligned,
+			WithChannelBufferSize(1))
+		assert.NoError(t, err)
+		pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+		assert.NoError(t, err)
+
+		windowRequests := testutils.BuildTestWindowRequests(2, time.Now(), window.Append)
+		assert.NoError(t, pq.Write(ctx, &windowRequests[0], true))
+		writeCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		assert.NoError(t, pq.Write(writeCtx, &windowRequests[1], true))
+		cancel()
+		pq.CloseOfBook()
+
+		// the reader is gone, the delivery does not outlive the pbq
+		assert.NoError(t, pq.Close())
+		assert.Len(t, readAll(pq), 1)
+	})
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boltdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+const (
+	// dbFileName is the name of the BoltDB file inside the store path.
+	dbFileName = "pbq.db"
+)
+
+func init() {
+	if err := wal.RegisterStoreType(string(dfv1.BoltDBType), newRegisteredManager); err != nil {
+		panic(err)
+	}
+}
+
+type boltManager struct {
+	storePath       string
+	openTimeout     time.Duration
+	replayBatchSize int
+	db              *bolt.DB
+	activeWals      map[string]wal.WAL
+	mu              sync.RWMutex
+}
+
+// NewBoltManager is a BoltDB WAL Manager. All the partitions share a single BoltDB file in the store path, the returned
+// manager implements io.Closer to close the file.
+func NewBoltManager(opts ...Option) (wal.Manager, error) {
+	s := &boltManager{
+		storePath:       dfv1.DefaultBoltDBWALPath,
+		openTimeout:     time.Second,
+		replayBatchSize: int(dfv1.DefaultPBQReadBatchSize),
+		activeWals:      make(map[string]wal.WAL),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	// Create the dir if not exist
+	if err := os.MkdirAll(s.storePath, 0755); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(filepath.Join(s.storePath, dbFileName), 0644, &bolt.Options{Timeout: s.openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open boltdb file, %w", err)
+	}
+	s.db = db
+	return s, nil
+}
+
+// newRegisteredManager creates the manager of the boltdb store type, the BoltDB file is kept on the volume of the PBQs if
+// the vertex has one, apart from the segments of the fs store type.
+func newRegisteredManager(_ context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+	return NewBoltManager(WithStorePath(registeredStorePath(opts)))
+}
+
+// registeredStorePath is the directory of the BoltDB file of the vertex replica, see newRegisteredManager.
+func registeredStorePath(opts wal.ManagerOptions) string {
+	return filepath.Join(dfv1.DefaultBoltDBWALPath, fmt.Sprintf("%s-%s-%d", opts.PipelineName, opts.VertexName, opts.Replica))
+}
+
+// CreateWAL creates the bucket for the partition if it does not exist and returns the WAL.
+func (bm *boltManager) CreateWAL(_ context.Context, partitionID partition.ID) (wal.WAL, error) {
+	// during crash recovery, we might have already created the WAL while replaying
+	bm.mu.RLock()
+	w, ok := bm.activeWals[partitionID.String()]
+	bm.mu.RUnlock()
+	if ok {
+		return w, nil
+	}
+
+	bucket := []byte(partitionID.String())
+	var size int64
+	err := bm.db.Update(func(tx *bolt.Tx) error {
+		pBucket, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return err
+		}
+		msgBucket, err := pBucket.CreateBucketIfNotExists(messagesBucket)
+		if err != nil {
+			return err
+		}
+		size = int64(msgBucket.Stats().KeyN)
+		return pBucket.Put(partitionKey, aligned.EncodePartitionID(partitionID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	w = bm.newWAL(bucket, &partitionID, size)
+	bm.mu.Lock()
+	bm.activeWals[partitionID.String()] = w
+	bm.mu.Unlock()
+	return w, nil
+}
+
+// DiscoverWALs returns a WAL for every partition bucket present in the BoltDB file.
+func (bm *boltManager) DiscoverWALs(_ context.Context) ([]wal.WAL, error) {
+	partitions := make([]wal.WAL, 0)
+	err := bm.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			id, err := aligned.DecodePartitionID(b.Get(partitionKey))
+			if err != nil {
+				return err
+			}
+			var size int64
+			if msgBucket := b.Bucket(messagesBucket); msgBucket != nil {
+				size = int64(msgBucket.Stats().KeyN)
+			}
+			// bucket names are only valid for the life of the transaction
+			partitions = append(partitions, bm.newWAL(append([]byte(nil), name...), id, size))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bm.mu.Lock()
+	for _, w := range partitions {
+		bm.activeWals[w.PartitionID().String()] = w
+	}
+	bm.mu.Unlock()
+	return partitions, nil
+}
+
+// DeleteWAL deletes the bucket of the given partitionID.
+func (bm *boltManager) DeleteWAL(partitionID partition.ID) error {
+	err := bm.db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket([]byte(partitionID.String()))
+	})
+	bm.mu.Lock()
+	delete(bm.activeWals, partitionID.String())
+	bm.mu.Unlock()
+	return err
+}
+
+// ReleaseWAL forgets the WAL of the partition if it is w, its messages are kept.
+func (bm *boltManager) ReleaseWAL(partitionID partition.ID, w wal.WAL) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	if bm.activeWals[partitionID.String()] == w {
+		delete(bm.activeWals, partitionID.String())
+	}
+}
+
+// Close closes the BoltDB file.
+func (bm *boltManager) Close() error {
+	return bm.db.Close()
+}
+
+// newWAL returns the WAL of the partition bucket, size is the number of entries the bucket already holds.
+func (bm *boltManager) newWAL(bucket []byte, id *partition.ID, size int64) *boltWAL {
+	w := &boltWAL{
+		db:              bm.db,
+		bucket:          bucket,
+		partitionID:     id,
+		replayBatchSize: bm.replayBatchSize,
+	}
+	w.size.Store(size)
+	return w
+}
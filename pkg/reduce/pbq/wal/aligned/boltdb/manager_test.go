@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boltdb
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+func TestBoltManager_Registered(t *testing.T) {
+	assert.Contains(t, wal.StoreTypes(), string(dfv1.BoltDBType))
+	// the BoltDB file is kept apart from the segments of the fs store type
+	assert.Equal(t, dfv1.DefaultBoltDBWALPath+"/p-v-1", registeredStorePath(wal.ManagerOptions{PipelineName: "p", VertexName: "v", Replica: 1}))
+}
+
+func TestBoltManager_Restart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	partitionIds := []partition.ID{
+		{
+			Start: time.Unix(60, 0),
+			End:   time.Unix(120, 0),
+			Slot:  "test-1",
+		},
+		{
+			Start: time.Unix(120, 0),
+			End:   time.Unix(180, 0),
+			Slot:  "test-2",
+		},
+		{
+			Start: time.Unix(180, 0),
+			End:   time.Unix(240, 0),
+			Slot:  "test-3",
+		},
+	}
+
+	tmp := t.TempDir()
+	storeProvider, err := NewBoltManager(WithStorePath(tmp))
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessages(5, time.Unix(60, 0), nil)
+	for _, partitionID := range partitionIds {
+		store, err := storeProvider.CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		for _, msg := range writeMessages {
+			assert.NoError(t, store.Write(ctx, &msg))
+		}
+	}
+
+	// GC the first partition, only its bucket should be removed
+	assert.NoError(t, storeProvider.DeleteWAL(partitionIds[0]))
+
+	// simulate a restart by closing and reopening the BoltDB file
+	assert.NoError(t, storeProvider.(io.Closer).Close())
+	storeProvider, err = NewBoltManager(WithStorePath(tmp))
+	assert.NoError(t, err)
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+
+	discoveredStores, err := storeProvider.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 2)
+
+	for _, store := range discoveredStores {
+		assert.Contains(t, []string{partitionIds[1].String(), partitionIds[2].String()}, store.PartitionID().String())
+		assert.Len(t, readAll(t, store), len(writeMessages))
+		// the size is counted from the bucket once it is discovered
+		assert.Equal(t, int64(len(writeMessages)), store.Size())
+		assert.NoError(t, store.Write(ctx, &writeMessages[0]))
+		assert.Equal(t, int64(len(writeMessages)+1), store.Size())
+	}
+
+	for _, partitionID := range partitionIds[1:] {
+		assert.NoError(t, storeProvider.DeleteWAL(partitionID))
+	}
+	discoveredStores, err = storeProvider.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 0)
+}
+
+func TestBoltManager_ReleaseWAL(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "test-release"}
+	storeProvider, err := NewBoltManager(WithStorePath(t.TempDir()))
+	assert.NoError(t, err)
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+
+	writeMessages := testutils.BuildTestReadMessages(2, time.Unix(60, 0), nil)
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Write(ctx, &writeMessages[0]))
+	assert.NoError(t, store.Close())
+
+	// another WAL is not released
+	storeProvider.(wal.Releaser).ReleaseWAL(partitionID, nil)
+	sameStore, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.Same(t, store, sameStore)
+
+	// the released WAL is replaced by a new one over the persisted messages
+	storeProvider.(wal.Releaser).ReleaseWAL(partitionID, store)
+	newStore, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.NotSame(t, store, newStore)
+	assert.NoError(t, newStore.Write(ctx, &writeMessages[1]))
+	assert.Len(t, readAll(t, newStore), len(writeMessages))
+}
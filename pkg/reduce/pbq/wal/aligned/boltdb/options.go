@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boltdb
+
+import (
+	"time"
+)
+
+type Option func(stores *boltManager)
+
+// WithStorePath sets the directory in which the BoltDB file is created
+func WithStorePath(path string) Option {
+	return func(stores *boltManager) {
+		stores.storePath = path
+	}
+}
+
+// WithOpenTimeout sets how long to wait for the file lock while opening the BoltDB file
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(stores *boltManager) {
+		stores.openTimeout = timeout
+	}
+}
+
+// WithReplayBatchSize sets the number of entries read in a single read transaction during replay
+func WithReplayBatchSize(size int) Option {
+	return func(stores *boltManager) {
+		stores.replayBatchSize = size
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+var (
+	// partitionKey holds the encoded partition ID inside the partition bucket.
+	partitionKey = []byte("partition")
+	// messagesBucket is the nested bucket which holds the entries of the partition keyed by sequence.
+	messagesBucket = []byte("messages")
+)
+
+// boltWAL implements wal.WAL on top of a bucket in a BoltDB file.
+type boltWAL struct {
+	db              *bolt.DB
+	bucket          []byte
+	partitionID     *partition.ID
+	replayBatchSize int
+	// size is the number of entries in the partition bucket, it is counted by the writes so that Size does not walk the
+	// bucket on every call
+	size atomic.Int64
+	// closed is set by Close, which can race with the writes of the PBQ
+	closed atomic.Bool
+}
+
+var _ wal.WAL = (*boltWAL)(nil)
+
+// Replay replays all the entries persisted in the partition bucket in the order they were written. Entries are read in
+// batches so that a read transaction is never held open while the caller is consuming the messages.
+func (b *boltWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+
+		var lastKey []byte
+		for {
+			batch, next, err := b.readBatch(lastKey)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for _, msg := range batch {
+				messages <- msg
+			}
+			if next == nil {
+				return
+			}
+			lastKey = next
+		}
+	}()
+	return messages, errs
+}
+
+// readBatch reads up to replayBatchSize entries which come after the given key. It returns the key of the last entry
+// read, or nil if there are no more entries.
+func (b *boltWAL) readBatch(after []byte) ([]*isb.ReadMessage, []byte, error) {
+	var batch = make([]*isb.ReadMessage, 0, b.replayBatchSize)
+	var last []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		msgBucket, err := b.messages(tx)
+		if err != nil {
+			return err
+		}
+		c := msgBucket.Cursor()
+		k, v := c.First()
+		if after != nil {
+			k, v = c.Seek(after)
+			if k != nil && bytes.Equal(k, after) {
+				k, v = c.Next()
+			}
+		}
+		for ; k != nil && len(batch) < b.replayBatchSize; k, v = c.Next() {
+			msg, err := aligned.DecodeEntry(v)
+			if err != nil {
+				return err
+			}
+			batch = append(batch, msg)
+			// keys are only valid for the life of the transaction
+			last = append(last[:0], k...)
+		}
+		if k == nil {
+			last = nil
+		}
+		return nil
+	})
+	return batch, last, err
+}
+
+// Write writes the message to the partition bucket, every write is a single BoltDB transaction. The context is
+// ignored since BoltDB is a local file.
+func (b *boltWAL) Write(_ context.Context, msg *isb.ReadMessage) error {
+	if b.closed.Load() {
+		return aligned.ErrWriteStoreClosed
+	}
+	entry, err := aligned.EncodeEntry(msg)
+	if err != nil {
+		return err
+	}
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		msgBucket, err := b.messages(tx)
+		if err != nil {
+			return err
+		}
+		seq, err := msgBucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		return msgBucket.Put(sequenceKey(seq), entry)
+	})
+	if err != nil {
+		return err
+	}
+	b.size.Add(1)
+	return nil
+}
+
+// WriteBatch writes all the messages to the partition bucket in a single BoltDB transaction, either all the messages
+// are written or none of them are.
+func (b *boltWAL) WriteBatch(_ context.Context, msgs []*isb.ReadMessage) error {
+	if b.closed.Load() {
+		return aligned.ErrWriteStoreClosed
+	}
+	entries := make([][]byte, 0, len(msgs))
+	for _, msg := range msgs {
+		entry, err := aligned.EncodeEntry(msg)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		msgBucket, err := b.messages(tx)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			seq, err := msgBucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err = msgBucket.Put(sequenceKey(seq), entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// the transaction is atomic, either all the entries are written or none of them are
+	b.size.Add(int64(len(entries)))
+	return nil
+}
+
+// PartitionID returns the partition ID of the WAL.
+func (b *boltWAL) PartitionID() *partition.ID {
+	return b.partitionID
+}
+
+// Size returns the number of entries in the partition bucket.
+func (b *boltWAL) Size() int64 {
+	return b.size.Load()
+}
+
+// Flush is a no-op, every BoltDB transaction is synced to the file when it commits.
+func (b *boltWAL) Flush() error {
+	return nil
+}
+
+// Ping opens a read transaction, it fails if the BoltDB file has been closed.
+func (b *boltWAL) Ping(_ context.Context) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return nil
+	})
+}
+
+// Close closes the WAL, no more writes will be accepted. The underlying BoltDB file is shared across partitions and is
+// owned by the manager.
+func (b *boltWAL) Close() error {
+	b.closed.Store(true)
+	return nil
+}
+
+func (b *boltWAL) messages(tx *bolt.Tx) (*bolt.Bucket, error) {
+	pBucket := tx.Bucket(b.bucket)
+	if pBucket == nil {
+		return nil, bolt.ErrBucketNotFound
+	}
+	msgBucket := pBucket.Bucket(messagesBucket)
+	if msgBucket == nil {
+		return nil, bolt.ErrBucketNotFound
+	}
+	return msgBucket, nil
+}
+
+// sequenceKey encodes the sequence as big endian so that the keys are sorted in the write order.
+func sequenceKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boltdb
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+func readAll(t *testing.T, w wal.WAL) []*isb.ReadMessage {
+	msgCh, errCh := w.Replay()
+	readMessages := make([]*isb.ReadMessage, 0)
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				return readMessages
+			}
+			readMessages = append(readMessages, msg)
+		case err, ok := <-errCh:
+			if ok {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestBoltWAL_WriteReplay(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	// a small replay batch size makes sure the replay spans multiple read transactions
+	storeProvider, err := NewBoltManager(WithStorePath(t.TempDir()), WithReplayBatchSize(3))
+	assert.NoError(t, err)
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.Equal(t, partitionID, *store.PartitionID())
+
+	msgCount := 10
+	writeMessages := testutils.BuildTestReadMessages(int64(msgCount), time.Unix(60, 0), nil)
+	for _, msg := range writeMessages {
+		err := store.Write(ctx, &msg)
+		assert.NoError(t, err)
+	}
+
+	readMessages := readAll(t, store)
+	assert.Len(t, readMessages, msgCount)
+	// replay does not remove the entries from the bucket
+	assert.Equal(t, int64(msgCount), store.Size())
+	assert.NoError(t, store.WriteBatch(ctx, []*isb.ReadMessage{&writeMessages[0], &writeMessages[1]}))
+	assert.Equal(t, int64(msgCount+2), store.Size())
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+		assert.Equal(t, writeMessages[i].Body.Payload, msg.Body.Payload)
+		assert.Equal(t, writeMessages[i].Watermark.UnixMilli(), msg.Watermark.UnixMilli())
+		wantOffset, _ := writeMessages[i].ReadOffset.Sequence()
+		gotOffset, _ := msg.ReadOffset.Sequence()
+		assert.Equal(t, wantOffset, gotOffset)
+	}
+
+	// no writes are accepted after close
+	assert.NoError(t, store.Close())
+	err = store.Write(ctx, &writeMessages[0])
+	assert.ErrorIs(t, err, aligned.ErrWriteStoreClosed)
+	assert.Equal(t, int64(msgCount+2), store.Size())
+}
+
+func TestBoltWAL_EmptyReplay(t *testing.T) {
+	storeProvider, err := NewBoltManager(WithStorePath(t.TempDir()))
+	assert.NoError(t, err)
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+
+	store, err := storeProvider.CreateWAL(context.Background(), partition.ID{Slot: "empty"})
+	assert.NoError(t, err)
+	assert.Len(t, readAll(t, store), 0)
+	assert.Equal(t, int64(0), store.Size())
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aligned
+
+import (
+	"bytes"
+	"sync"
+)
+
+// DefaultMaxPooledBufferSize is the capacity above which a buffer is not returned to the DefaultBufferPool, so that a
+// single large message does not pin a large buffer for the lifetime of the process.
+const DefaultMaxPooledBufferSize = 1 << 20
+
+// DefaultBufferPool is the buffer pool used by the WALs unless configured otherwise.
+var DefaultBufferPool = NewBufferPool(DefaultMaxPooledBufferSize)
+
+// BufferPool pools the byte buffers which the messages are encoded into before they are written, to reduce the garbage
+// generated per write. A buffer taken with Get must be returned with Put once the write is done, and its bytes must not
+// be retained after that. A nil BufferPool is valid and allocates a new buffer on every Get.
+type BufferPool struct {
+	pool        sync.Pool
+	maxPoolSize int
+}
+
+// NewBufferPool creates a BufferPool which drops the buffers whose capacity grew beyond maxPoolSize instead of pooling
+// them, a non-positive maxPoolSize pools the buffers of any size.
+func NewBufferPool(maxPoolSize int) *BufferPool {
+	return &BufferPool{
+		pool:        sync.Pool{New: func() any { return new(bytes.Buffer) }},
+		maxPoolSize: maxPoolSize,
+	}
+}
+
+// Get returns an empty buffer.
+func (p *BufferPool) Get() *bytes.Buffer {
+	if p == nil {
+		return new(bytes.Buffer)
+	}
+	return p.pool.Get().(*bytes.Buffer)
+}
+
+// Put resets the buffer and returns it to the pool.
+func (p *BufferPool) Put(buf *bytes.Buffer) {
+	if p == nil || buf == nil {
+		return
+	}
+	if p.maxPoolSize > 0 && buf.Cap() > p.maxPoolSize {
+		return
+	}
+	buf.Reset()
+	p.pool.Put(buf)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aligned
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// Codec encodes and decodes the isb messages persisted by a WAL. The same codec has to be used to replay a WAL that
+// was used to write it.
+type Codec interface {
+	// Encode encodes the message to bytes
+	Encode(msg *isb.Message) ([]byte, error)
+	// Decode decodes the message from the bytes which are encoded by Encode
+	Decode(data []byte) (*isb.Message, error)
+}
+
+// BufferEncoder is an optional interface of a Codec, which encodes the message into a caller-provided buffer instead of
+// allocating the bytes on every call.
+type BufferEncoder interface {
+	// EncodeTo appends the encoded message to the buf, the bytes are decoded by Decode like the ones of Encode.
+	EncodeTo(buf *bytes.Buffer, msg *isb.Message) error
+}
+
+// EncodeTo appends the message encoded by the codec to the buf, it uses the BufferEncoder of the codec if implemented.
+func EncodeTo(codec Codec, buf *bytes.Buffer, msg *isb.Message) error {
+	if encoder, ok := codec.(BufferEncoder); ok {
+		return encoder.EncodeTo(buf, msg)
+	}
+	data, err := codec.Encode(msg)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+var (
+	// ProtoCodec encodes the message using its protobuf representation, it is the default codec.
+	ProtoCodec Codec = protoCodec{}
+	// JSONCodec encodes the message as JSON, it is larger and slower than ProtoCodec but human-readable.
+	JSONCodec Codec = jsonCodec{}
+)
+
+type protoCodec struct{}
+
+func (protoCodec) Encode(msg *isb.Message) ([]byte, error) {
+	return msg.MarshalBinary()
+}
+
+func (protoCodec) EncodeTo(buf *bytes.Buffer, msg *isb.Message) error {
+	data, err := msg.MarshalAppend(buf.AvailableBuffer())
+	if err != nil {
+		return err
+	}
+	// data is backed by the spare capacity of the buf when it fits, the write is then a copy onto itself
+	buf.Write(data)
+	return nil
+}
+
+func (protoCodec) Decode(data []byte) (*isb.Message, error) {
+	var msg = new(isb.Message)
+	if err := msg.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(msg *isb.Message) ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+func (jsonCodec) EncodeTo(buf *bytes.Buffer, msg *isb.Message) error {
+	if err := json.NewEncoder(buf).Encode(msg); err != nil {
+		return err
+	}
+	// drop the newline which the json.Encoder adds, json.Marshal does not add it
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+func (jsonCodec) Decode(data []byte) (*isb.Message, error) {
+	var msg = new(isb.Message)
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aligned
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+func TestCodec_RoundTrip(t *testing.T) {
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(1, time.Unix(1665109020, 0).UTC(), []string{"key-1"})
+	tests := []struct {
+		name    string
+		codec   Codec
+		message *isb.Message
+	}{
+		{
+			name:    "proto_good",
+			codec:   ProtoCodec,
+			message: &writeMessages[0].Message,
+		},
+		{
+			name:    "json_good",
+			codec:   JSONCodec,
+			message: &writeMessages[0].Message,
+		},
+		{
+			name:  "json_nodata",
+			codec: JSONCodec,
+			message: &isb.Message{
+				Header: isb.Header{},
+				Body:   isb.Body{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.codec.Encode(tt.message)
+			assert.NoError(t, err)
+			result, err := tt.codec.Decode(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.message, result)
+
+			// encoding into a buffer appends the message after the existing content
+			buf := bytes.NewBufferString("prefix")
+			assert.NoError(t, EncodeTo(tt.codec, buf, tt.message))
+			assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("prefix")))
+			result, err = tt.codec.Decode(buf.Bytes()[len("prefix"):])
+			assert.NoError(t, err)
+			assert.Equal(t, tt.message, result)
+		})
+	}
+}
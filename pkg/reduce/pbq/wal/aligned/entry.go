@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aligned
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// entryHeaderPreamble is the fixed size header of every entry.
+type entryHeaderPreamble struct {
+	WaterMark int64
+	Offset    int64
+}
+
+// EncodeEntry encodes the read message as a fixed header followed by the binary of the isb message. It is used by the
+// WALs which are backed by a key-value or list store, where every message is persisted as a separate value.
+//
+//	+-------------------+----------------+----------------+
+//	| watermark (int64) | offset (int64) | message []byte |
+//	+-------------------+----------------+----------------+
+func EncodeEntry(msg *isb.ReadMessage) ([]byte, error) {
+	offset, err := msg.ReadOffset.Sequence()
+	if err != nil {
+		return nil, err
+	}
+	body, err := msg.Message.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err = binary.Write(buf, binary.LittleEndian, entryHeaderPreamble{
+		WaterMark: msg.Watermark.UnixMilli(),
+		Offset:    offset,
+	}); err != nil {
+		return nil, err
+	}
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
+
+// DecodeEntry decodes the entry which is encoded by EncodeEntry. The entry is not retained.
+func DecodeEntry(entry []byte) (*isb.ReadMessage, error) {
+	reader := bytes.NewReader(entry)
+	var header entryHeaderPreamble
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	var message isb.Message
+	if err := message.UnmarshalBinary(append([]byte(nil), entry[binary.Size(header):]...)); err != nil {
+		return nil, err
+	}
+	return &isb.ReadMessage{
+		Message:    message,
+		Watermark:  time.UnixMilli(header.WaterMark),
+		ReadOffset: isb.SimpleIntOffset(func() int64 { return header.Offset }),
+	}, nil
+}
+
+// EncodePartitionID encodes the partition ID as start and end in milliseconds followed by the slot.
+func EncodePartitionID(id partition.ID) []byte {
+	buf := make([]byte, 16, 16+len(id.Slot))
+	binary.LittleEndian.PutUint64(buf[0:8], uint64(id.Start.UnixMilli()))
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(id.End.UnixMilli()))
+	return append(buf, id.Slot...)
+}
+
+// DecodePartitionID decodes the partition ID which is encoded by EncodePartitionID.
+func DecodePartitionID(value []byte) (*partition.ID, error) {
+	if len(value) < 16 {
+		return nil, fmt.Errorf("invalid partition entry, expected at least 16 bytes but got %d", len(value))
+	}
+	return &partition.ID{
+		Start: time.UnixMilli(int64(binary.LittleEndian.Uint64(value[0:8]))),
+		End:   time.UnixMilli(int64(binary.LittleEndian.Uint64(value[8:16]))),
+		Slot:  string(value[16:]),
+	}, nil
+}
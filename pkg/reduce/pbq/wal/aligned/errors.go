@@ -21,3 +21,4 @@ import "errors"
 var ErrWriteStoreFull error = errors.New("error writing, store is full")
 var ErrWriteStoreClosed error = errors.New("error writing, store is closed")
 var ErrReadStoreEmpty error = errors.New("error reading, store is empty")
+var ErrCorruptRecord error = errors.New("error reading, record is corrupted")
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// blockReader reads a file in blocks aligned to the block size, and serves the reads from the block in memory, so that
+// decoding the entries of a segment does not cost a read of the file for every entry header and body. The reads of the
+// file are positioned, so the offset of the file is not moved.
+type blockReader struct {
+	r         io.ReaderAt
+	blockSize int64
+	block     []byte
+	// start is the offset in the file of the first byte of the block
+	start int64
+	// offset is the offset in the file of the next byte to be read
+	offset int64
+}
+
+var _ io.ReadSeeker = (*blockReader)(nil)
+
+// newBlockReader returns a blockReader which reads the file from the offset.
+func newBlockReader(r io.ReaderAt, offset int64, blockSize int64) *blockReader {
+	return &blockReader{r: r, blockSize: blockSize, offset: offset}
+}
+
+// segmentReader returns a reader of the segment from the offset, the segment is read in blocks if the block size is
+// positive, otherwise the reads go straight to the file, which has to be at the offset.
+func segmentReader(fp io.ReadSeeker, offset int64, blockSize int64) io.ReadSeeker {
+	if r, ok := fp.(io.ReaderAt); ok && blockSize > 0 {
+		return newBlockReader(r, offset, blockSize)
+	}
+	return fp
+}
+
+// Read reads from the block, the block which holds the offset is read from the file first if it is not in memory.
+func (b *blockReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if b.offset < b.start || b.offset >= b.start+int64(len(b.block)) {
+		if err := b.fill(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, b.block[b.offset-b.start:])
+	b.offset += int64(n)
+	return n, nil
+}
+
+// fill reads the block which holds the offset, a short block is kept as long as it holds the offset.
+func (b *blockReader) fill() error {
+	if b.block == nil {
+		b.block = make([]byte, b.blockSize)
+	}
+	start := b.offset - b.offset%b.blockSize
+	n, err := b.r.ReadAt(b.block[:b.blockSize], start)
+	b.block, b.start = b.block[:n], start
+	if b.offset < start+int64(n) {
+		return nil
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	return err
+}
+
+// Seek moves the offset of the next read, the block is read again only if the offset leaves it.
+func (b *blockReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += b.offset
+	default:
+		return 0, fmt.Errorf("unsupported whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+	b.offset = offset
+	return offset, nil
+}
@@ -17,14 +17,18 @@ limitations under the License.
 package fs
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 )
 
 var location *time.Location
@@ -47,7 +51,7 @@ func (w *alignedWAL) readWALHeader() (*partition.ID, error) {
 		return nil, fmt.Errorf("header has already been read, current readoffset is at %d", w.rOffset)
 	}
 
-	id, err := decodeWALHeader(w.fp)
+	id, _, err := decodeWALHeader(w.fp)
 	if err != nil {
 		return nil, err
 	}
@@ -68,31 +72,53 @@ func (w *alignedWAL) isEnd() bool {
 	return w.rOffset >= w.readUpTo
 }
 
-// decodeWALHeader decodes the header which is encoded by encodeWALHeader.
-func decodeWALHeader(buf io.Reader) (*partition.ID, error) {
+// decodeWALHeader decodes the header which is encoded by encodeWALHeader, and returns the partition ID and the
+// compression of the segment.
+func decodeWALHeader(buf io.Reader) (*partition.ID, Compression, error) {
 	var err error
+	// read the magic, the header of a segment written before the header was versioned starts with the preamble
+	var magic [8]byte
+	_, err = io.ReadFull(buf, magic[:])
+	if err != nil {
+		return nil, CompressionNone, err
+	}
+	var hv walHeaderVersioned
+	if binary.LittleEndian.Uint64(magic[:]) == walHeaderMagic {
+		err = binary.Read(buf, binary.LittleEndian, &hv)
+		if err != nil {
+			return nil, CompressionNone, err
+		}
+		if hv.Version > walHeaderVersion {
+			return nil, CompressionNone, fmt.Errorf("unsupported segment header version %d", hv.Version)
+		}
+	} else {
+		buf = io.MultiReader(bytes.NewReader(magic[:]), buf)
+	}
+
 	// read the fixed values
 	var hp = new(walHeaderPreamble)
 	err = binary.Read(buf, binary.LittleEndian, hp)
 	if err != nil {
-		return nil, err
+		return nil, CompressionNone, err
 	}
 	// read the variadic slot
 	var slot = make([]rune, hp.SLen)
 	err = binary.Read(buf, binary.LittleEndian, slot)
 	if err != nil {
-		return nil, err
+		return nil, CompressionNone, err
 	}
 
 	return &partition.ID{
 		Start: time.UnixMilli(hp.S).In(location),
 		End:   time.UnixMilli(hp.E).In(location),
 		Slot:  string(slot),
-	}, nil
+	}, hv.Compression, nil
 }
 
 // Replay replays the alignedWAL messages, returns a channel to read messages and a channel to read errors.
-// channel will be closed after all the messages are read from the alignedWAL.
+// channel will be closed after all the messages are read from the alignedWAL. A corrupted entry is reported as
+// aligned.ErrCorruptRecord, except for a torn last entry which is left behind by a crash in the middle of a write,
+// it is treated as the end of the alignedWAL and is truncated so that the next write overwrites it.
 func (w *alignedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 	messages := make(chan *isb.ReadMessage)
 	errs := make(chan error)
@@ -101,10 +127,30 @@ func (w *alignedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 		defer close(messages)
 		defer func() { errs = nil }()
 
+		// replay the older segments first, they are no longer written to.
+		for i, segment := range w.segments[:len(w.segments)-1] {
+			count, err := w.replaySegment(segment, messages)
+			if err != nil {
+				errs <- err
+				return
+			}
+			w.segmentEntries[i] = count
+		}
+
+		var count int64
 		// decode read message and send it to the channel
 		// dont use Read method
+		reader := segmentReader(w.fp, w.rOffset, w.readBlockSize)
 		for !w.isEnd() {
-			message, sizeRead, err := decodeReadMessage(w.fp)
+			message, sizeRead, err := decodeReadMessage(reader, w.codec, w.readUpTo-w.rOffset)
+			if isTornEntry(err, w.rOffset+sizeRead >= w.readUpTo) {
+				if err = w.fp.Truncate(w.rOffset); err != nil {
+					errs <- err
+					return
+				}
+				w.readUpTo = w.rOffset
+				break
+			}
 			if err != nil {
 				if errors.Is(err, errChecksumMismatch) {
 					w.corrupted = true
@@ -114,8 +160,10 @@ func (w *alignedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 			}
 
 			w.rOffset += sizeRead
+			count++
 			messages <- message
 		}
+		w.segmentEntries[len(w.segmentEntries)-1] = count
 		w.wOffset = w.rOffset
 		w.prevSyncedWOffset = w.wOffset
 		w.prevSyncedTime = time.Now()
@@ -124,18 +172,294 @@ func (w *alignedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 	return messages, errs
 }
 
-// decodeReadMessage decodes the WALMessage which is encoded by encodeWALMessage.
-func decodeReadMessage(buf io.Reader) (*isb.ReadMessage, int64, error) {
+// replaySegment replays all the messages of a segment which is no longer written to, and returns the number of
+// messages replayed. If mmapReplay is set, the entries are decoded from a memory mapping of the segment which is
+// unmapped before it returns, so that a closed or garbage collected alignedWAL never holds a mapping.
+func (w *alignedWAL) replaySegment(filePath string, messages chan<- *isb.ReadMessage) (count int64, err error) {
+	segment, err := openSegment(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = segment.Close() }()
+	size, offset := segment.size, segment.offset
+
+	buf := io.Reader(segment.reader(w.readBlockSize))
+	// the entries of a compressed segment are already in memory
+	if w.mmapReplay && segment.fp != nil {
+		data, unmap, mapErr := mapSegment(segment.fp, size)
+		switch {
+		case mapErr == nil:
+			defer func() {
+				if unmapErr := unmap(); err == nil {
+					err = unmapErr
+				}
+			}()
+			buf = bytes.NewReader(data[offset:])
+		case !errors.Is(mapErr, errMmapUnsupported):
+			return 0, fmt.Errorf("failed to map segment %s, %w", filePath, mapErr)
+		}
+	}
+
+	for offset < size {
+		message, sizeRead, err := decodeReadMessage(buf, w.codec, size-offset)
+		if err != nil {
+			if errors.Is(err, errChecksumMismatch) {
+				w.corrupted = true
+			}
+			return count, err
+		}
+		offset += sizeRead
+		count++
+		messages <- message
+	}
+	return count, nil
+}
+
+var _ wal.OffsetReader = (*alignedWAL)(nil)
+
+// ReadAt reads up to size messages starting at offset across the segments, without affecting Replay. It should not be
+// called while the alignedWAL is being replayed.
+func (w *alignedWAL) ReadAt(offset int64, size int64) ([]*isb.ReadMessage, bool, error) {
+	w.mu.Lock()
+	segments := append([]string(nil), w.segments...)
+	segmentEntries := append([]int64(nil), w.segmentEntries...)
+	// the active segment of a discovered alignedWAL is valid up to readUpTo until the writes resume after the replay
+	activeEnd := max(w.wOffset, w.readUpTo)
+	w.mu.Unlock()
+
+	var total int64
+	for _, count := range segmentEntries {
+		total += count
+	}
+	if offset < 0 || offset > total {
+		return nil, false, wal.OffsetOutOfRangeErr{Offset: offset, Size: total}
+	}
+
+	messages := make([]*isb.ReadMessage, 0, min(max(size, 0), total-offset))
+	skip := offset
+	for i, segment := range segments {
+		if int64(len(messages)) >= size {
+			break
+		}
+		if skip >= segmentEntries[i] {
+			skip -= segmentEntries[i]
+			continue
+		}
+		end := int64(-1)
+		if i == len(segments)-1 {
+			end = activeEnd
+		}
+		read, err := w.readSegmentAt(segment, skip, size-int64(len(messages)), end)
+		if err != nil {
+			return nil, false, err
+		}
+		messages = append(messages, read...)
+		skip = 0
+	}
+	return messages, offset+int64(len(messages)) >= total, nil
+}
+
+var _ wal.Snapshotter = (*alignedWAL)(nil)
+
+// Snapshot reads the messages of the segments up to the end of the writes at the time of the call, the segments are
+// read without holding the lock, so the writes are not blocked. It should not be called while the alignedWAL is being
+// replayed.
+func (w *alignedWAL) Snapshot() ([]*isb.Message, error) {
+	// the messages written after the size is taken are not read
+	w.mu.Lock()
+	size := w.Size()
+	w.mu.Unlock()
+	readMessages, _, err := w.ReadAt(0, size)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*isb.Message, len(readMessages))
+	for i, readMessage := range readMessages {
+		messages[i] = &readMessage.Message
+	}
+	return messages, nil
+}
+
+// readSegmentAt skips the first skip entries of the segment and reads up to size entries. end is the offset up to
+// which the segment is valid, the whole segment is read if it is negative.
+func (w *alignedWAL) readSegmentAt(filePath string, skip int64, size int64, end int64) ([]*isb.ReadMessage, error) {
+	segment, err := openSegment(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = segment.Close() }()
+	offset := segment.offset
+	// the active segment is never compressed, a compressed segment was sealed and is read as a whole
+	if end < 0 || segment.compression != CompressionNone {
+		end = segment.size
+	}
+
+	reader := segment.reader(w.readBlockSize)
+	for ; skip > 0 && offset < end; skip-- {
+		entryHeader, err := decodeWALMessageHeader(reader)
+		if err != nil {
+			return nil, err
+		}
+		if offset, err = reader.Seek(entryHeader.MessageLen, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := make([]*isb.ReadMessage, 0, size)
+	for int64(len(messages)) < size && offset < end {
+		message, sizeRead, err := decodeReadMessage(reader, w.codec, end-offset)
+		if err != nil {
+			return nil, err
+		}
+		offset += sizeRead
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// countEntries counts the entries of a segment by reading only the entry headers, and returns the offset at which the
+// entries end. It stops at a torn last entry.
+func countEntries(filePath string) (int64, int64, error) {
+	segment, err := openSegment(filePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = segment.Close() }()
+	size, offset := segment.size, segment.offset
+
+	reader := segment.reader(0)
+	var count int64
+	for offset < size {
+		entryHeader, err := decodeWALMessageHeader(reader)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return 0, 0, err
+		}
+		if entryHeader.MessageLen < 0 || offset+EntryHeaderSize+entryHeader.MessageLen > size {
+			break
+		}
+		if offset, err = reader.Seek(entryHeader.MessageLen, io.SeekCurrent); err != nil {
+			return 0, 0, err
+		}
+		count++
+	}
+	return count, offset, nil
+}
+
+// openedSegment is a segment opened for read. The entries of a compressed segment are decompressed into memory behind
+// room for the header, so the offsets of its entries are the same as if the segment was not compressed.
+type openedSegment struct {
+	id          *partition.ID
+	compression Compression
+	// fp is the segment file, nil if the segment is compressed.
+	fp *os.File
+	// data are the decompressed entries of a compressed segment from the offset.
+	data []byte
+	// size is the offset at which the entries end.
+	size int64
+	// offset is the offset of the first entry.
+	offset int64
+}
+
+// openSegment opens the segment for read and skips the header.
+func openSegment(filePath string) (*openedSegment, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := fp.Stat()
+	if err != nil {
+		_ = fp.Close()
+		return nil, err
+	}
+	id, compression, err := decodeWALHeader(fp)
+	if err != nil {
+		_ = fp.Close()
+		return nil, err
+	}
+	offset, err := fp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		_ = fp.Close()
+		return nil, err
+	}
+	if compression == CompressionNone {
+		return &openedSegment{id: id, compression: compression, fp: fp, size: stat.Size(), offset: offset}, nil
+	}
+
+	compressed, err := io.ReadAll(fp)
+	_ = fp.Close()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := compression.decompress(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress segment %s, %w", filePath, err)
+	}
+	data := make([]byte, offset+int64(len(entries)))
+	copy(data[offset:], entries)
+	return &openedSegment{id: id, compression: compression, data: data, size: int64(len(data)), offset: offset}, nil
+}
+
+// reader returns a reader of the entries from the first entry, the segment file is read in blocks if the block size is
+// positive.
+func (s *openedSegment) reader(blockSize int64) io.ReadSeeker {
+	if s.fp == nil {
+		reader := bytes.NewReader(s.data)
+		_, _ = reader.Seek(s.offset, io.SeekStart)
+		return reader
+	}
+	return segmentReader(s.fp, s.offset, blockSize)
+}
+
+// readEntries reads all the entries of the segment.
+func (s *openedSegment) readEntries() ([]byte, error) {
+	if s.fp == nil {
+		return s.data[s.offset:], nil
+	}
+	return io.ReadAll(io.NewSectionReader(s.fp, s.offset, s.size-s.offset))
+}
+
+// Close closes the segment file.
+func (s *openedSegment) Close() error {
+	if s.fp == nil {
+		return nil
+	}
+	return s.fp.Close()
+}
+
+// isTornEntry returns true if the entry failed to decode because it was only partially written, which can only be the
+// case for the last entry.
+func isTornEntry(err error, isLast bool) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	return isLast && errors.Is(err, errChecksumMismatch)
+}
+
+// decodeReadMessage decodes the WALMessage which is encoded by encodeWALMessage. remaining is the number of bytes left
+// to be read, an entry longer than remaining is reported as io.ErrUnexpectedEOF. The size of the entry is returned
+// along with errChecksumMismatch, so the caller can tell where the corrupted entry ends.
+func decodeReadMessage(buf io.Reader, codec aligned.Codec, remaining int64) (*isb.ReadMessage, int64, error) {
 	entryHeader, err := decodeWALMessageHeader(buf)
 	if err != nil {
 		return nil, 0, err
 	}
+	if entryHeader.MessageLen < 0 {
+		return nil, EntryHeaderSize, errChecksumMismatch
+	}
+	size := EntryHeaderSize + entryHeader.MessageLen
+	if size > remaining {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
 
-	entryBody, err := decodeWALBody(buf, entryHeader)
+	entryBody, err := decodeWALBody(buf, entryHeader, codec)
 	if err != nil {
+		if errors.Is(err, errChecksumMismatch) {
+			return nil, size, err
+		}
 		return nil, 0, err
 	}
-	size := EntryHeaderSize + entryHeader.MessageLen
 
 	return &isb.ReadMessage{
 		Message:    *entryBody,
@@ -157,17 +481,14 @@ func decodeWALMessageHeader(buf io.Reader) (*readMessageHeaderPreamble, error) {
 
 // decodeWALBody decodes the WALMessage body which is encoded by encodeWALMessageBody.
 // Returns errChecksumMismatch to indicate if corrupted entry is found.
-func decodeWALBody(buf io.Reader, entryHeader *readMessageHeaderPreamble) (*isb.Message, error) {
+func decodeWALBody(buf io.Reader, entryHeader *readMessageHeaderPreamble, codec aligned.Codec) (*isb.Message, error) {
 	var err error
 
 	body := make([]byte, entryHeader.MessageLen)
-	size, err := buf.Read(body)
+	_, err = io.ReadFull(buf, body)
 	if err != nil {
 		return nil, err
 	}
-	if int64(size) != entryHeader.MessageLen {
-		return nil, fmt.Errorf("expected to read length of %d, but wrote only %d", entryHeader.MessageLen, size)
-	}
 
 	// verify the checksum
 	checksum := calculateChecksum(body)
@@ -175,10 +496,5 @@ func decodeWALBody(buf io.Reader, entryHeader *readMessageHeaderPreamble) (*isb.
 		return nil, errChecksumMismatch
 	}
 
-	var message = new(isb.Message)
-	err = message.UnmarshalBinary(body)
-	if err != nil {
-		return nil, err
-	}
-	return message, nil
+	return codec.Decode(body)
 }
@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the algorithm used to compress the entries of a sealed segment, they are compressed as a whole so that
+// the repetition across the messages is compressed too. It is recorded in the segment header, so a segment is always
+// replayed with the compression it was written with.
+type Compression uint8
+
+const (
+	// CompressionNone stores the entries as they are, it is the default.
+	CompressionNone Compression = iota
+	// CompressionGzip compresses the entries with gzip.
+	CompressionGzip
+	// CompressionSnappy compresses the message bodies with snappy, it is faster than gzip but compresses less.
+	CompressionSnappy
+	// CompressionZstd compresses the entries with zstd.
+	CompressionZstd
+)
+
+var (
+	// gzipWriters pools the gzip writers, a gzip writer allocates its compression state up front.
+	gzipWriters = sync.Pool{New: func() any { return gzip.NewWriter(nil) }}
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// ParseCompression parses the name of the compression, one of none, gzip, snappy or zstd.
+func ParseCompression(name string) (Compression, error) {
+	switch name {
+	case "", "none":
+		return CompressionNone, nil
+	case "gzip":
+		return CompressionGzip, nil
+	case "snappy":
+		return CompressionSnappy, nil
+	case "zstd":
+		return CompressionZstd, nil
+	default:
+		return CompressionNone, fmt.Errorf("unsupported compression %q", name)
+	}
+}
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionGzip:
+		return "gzip"
+	case CompressionSnappy:
+		return "snappy"
+	case CompressionZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(c))
+	}
+}
+
+// compress compresses the data with the compression.
+func (c Compression) compress(data []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		zw := gzipWriters.Get().(*gzip.Writer)
+		defer gzipWriters.Put(zw)
+		zw.Reset(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, data), nil
+	case CompressionZstd:
+		if err := initZstd(); err != nil {
+			return nil, err
+		}
+		return zstdEncoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", c)
+	}
+}
+
+// decompress decompresses the data which is compressed by compress.
+func (c Compression) decompress(data []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = zr.Close() }()
+		return io.ReadAll(zr)
+	case CompressionSnappy:
+		return snappy.Decode(nil, data)
+	case CompressionZstd:
+		if err := initZstd(); err != nil {
+			return nil, err
+		}
+		return zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", c)
+	}
+}
+
+// initZstd creates the zstd encoder and decoder which are shared by all the segments, both are safe for concurrent
+// use with EncodeAll and DecodeAll.
+func initZstd() error {
+	zstdOnce.Do(func() {
+		if zstdEncoder, zstdErr = zstd.NewWriter(nil); zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+	})
+	return zstdErr
+}
+
+// rewriteSegment rewrites the sealed segment with its entries compressed with the compression, it is a no-op if the
+// segment already has the compression. A compressed segment is kept as it is if it does not shrink. The segment is
+// written to a temporary file without the SegmentPrefix which replaces the segment, so a crash leaves either of them
+// complete and the temporary file is never discovered as a segment.
+func (w *alignedWAL) rewriteSegment(filePath string, compression Compression) error {
+	segment, err := openSegment(filePath)
+	if err != nil {
+		return err
+	}
+	if segment.compression == compression {
+		return segment.Close()
+	}
+	entries, err := segment.readEntries()
+	if closeErr := segment.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	compressed, err := compression.compress(entries)
+	if err != nil {
+		return err
+	}
+	if compression != CompressionNone && len(compressed) >= len(entries) {
+		return nil
+	}
+	header, err := w.encodeWALHeader(segment.id, compression)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(filepath.Dir(filePath), ".rewrite-"+filepath.Base(filePath))
+	fp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = fp.Write(header.Bytes()); err == nil {
+		_, err = fp.Write(compressed)
+	}
+	if err == nil {
+		err = fp.Sync()
+	}
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
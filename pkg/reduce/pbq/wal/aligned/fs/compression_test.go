@@ -0,0 +1,238 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+func replayAll(t *testing.T, w wal.WAL) []*isb.ReadMessage {
+	msgCh, errCh := w.Replay()
+	actualMessages := make([]*isb.ReadMessage, 0)
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				return actualMessages
+			}
+			actualMessages = append(actualMessages, msg)
+		case err, ok := <-errCh:
+			if ok {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestCompression_RoundTrip(t *testing.T) {
+	data := []byte(`{"event":"page_view","region":"us-west-2","event":"page_view","region":"us-west-2"}`)
+	for _, c := range []Compression{CompressionNone, CompressionGzip, CompressionSnappy, CompressionZstd} {
+		t.Run(c.String(), func(t *testing.T) {
+			compressed, err := c.compress(data)
+			assert.NoError(t, err)
+			decompressed, err := c.decompress(compressed)
+			assert.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+
+			parsed, err := ParseCompression(c.String())
+			assert.NoError(t, err)
+			assert.Equal(t, c, parsed)
+		})
+	}
+	_, err := ParseCompression("lz4")
+	assert.Error(t, err)
+}
+
+// buildCompressibleMessages builds messages with small and repetitive JSON payloads.
+func buildCompressibleMessages(count int64) []isb.ReadMessage {
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(count, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages {
+		writeMessages[i].Payload = []byte(fmt.Sprintf(`{"customer_id":"customer-%d","event":"page_view","region":"us-west-2",`+
+			`"attributes":{"browser":"chrome","os":"linux","campaign":"fall-sale","page":"/products/%d"}}`, i%10, i%5))
+	}
+	return writeMessages
+}
+
+func Test_sealedSegmentsAreCompressed(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	for _, c := range []Compression{CompressionGzip, CompressionSnappy, CompressionZstd} {
+		t.Run(c.String(), func(t *testing.T) {
+			tmp := t.TempDir()
+			w, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(4096), WithCompression(c)).CreateWAL(context.Background(), id)
+			assert.NoError(t, err)
+			writeMessages := buildCompressibleMessages(100)
+			for i := range writeMessages[:80] {
+				assert.NoError(t, w.Write(context.Background(), &writeMessages[i]))
+			}
+			assert.NoError(t, w.Close())
+
+			// the sealed segments are compressed as a whole, the segment being written to is not
+			segments := w.(*alignedWAL).segments
+			assert.Greater(t, len(segments), 2)
+			for i, segment := range segments {
+				opened, err := openSegment(segment)
+				assert.NoError(t, err)
+				assert.NoError(t, opened.Close())
+				stat, err := os.Stat(segment)
+				assert.NoError(t, err)
+				if i < len(segments)-1 {
+					assert.Equal(t, c, opened.compression)
+					assert.Less(t, stat.Size(), opened.size/2)
+				} else {
+					assert.Equal(t, CompressionNone, opened.compression)
+				}
+			}
+
+			// the segments are replayed with the compression in their header even though the config has changed
+			discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(4096)).DiscoverWALs(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, discoveredStores, 1)
+			newWal := discoveredStores[0].(*alignedWAL)
+			assert.Equal(t, id, *newWal.PartitionID())
+			readMessages := replayAll(t, newWal)
+			assert.Len(t, readMessages, 80)
+			for i, msg := range readMessages {
+				assert.Equal(t, writeMessages[i].Message, msg.Message)
+			}
+			read, eof, err := newWal.ReadAt(10, 5)
+			assert.NoError(t, err)
+			assert.False(t, eof)
+			for i, msg := range read {
+				assert.Equal(t, writeMessages[10+i].Message, msg.Message)
+			}
+
+			// the truncation into a compressed segment decompresses it, so that the writes resume in it
+			assert.NoError(t, newWal.Truncate(10))
+			assert.Equal(t, CompressionNone, segmentCompression(t, newWal.segments[len(newWal.segments)-1]))
+			for i := range writeMessages[10:] {
+				assert.NoError(t, newWal.Write(context.Background(), &writeMessages[10+i]))
+			}
+			assert.NoError(t, newWal.Close())
+
+			discoveredStores, err = NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+			assert.NoError(t, err)
+			readMessages = replayAll(t, discoveredStores[0])
+			assert.Len(t, readMessages, len(writeMessages))
+			for i, msg := range readMessages {
+				assert.Equal(t, writeMessages[i].Message, msg.Message)
+			}
+			assert.NoError(t, discoveredStores[0].Close())
+
+			// no temporary file is left behind
+			files, err := os.ReadDir(tmp)
+			assert.NoError(t, err)
+			for _, f := range files {
+				assert.False(t, strings.HasPrefix(f.Name(), "."), f.Name())
+			}
+		})
+	}
+}
+
+func Test_compressedLastSegmentIsDecompressed(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	w, err := NewFSManager(vi, WithStorePath(tmp), WithCompression(CompressionZstd)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	writeMessages := buildCompressibleMessages(20)
+	for i := range writeMessages[:10] {
+		assert.NoError(t, w.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.NoError(t, w.Close())
+	// a crash right after the rotation which did not create the next segment leaves the last segment compressed
+	segment := w.(*alignedWAL).segments[0]
+	assert.NoError(t, w.(*alignedWAL).rewriteSegment(segment, CompressionZstd))
+	assert.Equal(t, CompressionZstd, segmentCompression(t, segment))
+
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, CompressionNone, segmentCompression(t, segment))
+	assert.Len(t, replayAll(t, discoveredStores[0]), 10)
+	for i := range writeMessages[10:] {
+		assert.NoError(t, discoveredStores[0].Write(context.Background(), &writeMessages[10+i]))
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+
+	discoveredStores, err = NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	readMessages := replayAll(t, discoveredStores[0])
+	assert.Len(t, readMessages, len(writeMessages))
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Message, msg.Message)
+	}
+}
+
+func Test_decodeUnversionedWALHeader(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	// the header of a segment written before the header was versioned
+	buf := new(bytes.Buffer)
+	assert.NoError(t, binary.Write(buf, binary.LittleEndian, walHeaderPreamble{S: id.Start.UnixMilli(), E: id.End.UnixMilli(), SLen: int16(len(id.Slot))}))
+	assert.NoError(t, binary.Write(buf, binary.LittleEndian, []rune(id.Slot)))
+	decoded, compression, err := decodeWALHeader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, id, *decoded)
+	assert.Equal(t, CompressionNone, compression)
+
+	header, err := (&alignedWAL{}).encodeWALHeader(&id, CompressionSnappy)
+	assert.NoError(t, err)
+	decoded, compression, err = decodeWALHeader(header)
+	assert.NoError(t, err)
+	assert.Equal(t, id, *decoded)
+	assert.Equal(t, CompressionSnappy, compression)
+
+	// a newer version of the header is not decoded
+	header, err = (&alignedWAL{}).encodeWALHeader(&id, CompressionNone)
+	assert.NoError(t, err)
+	header.Bytes()[8] = walHeaderVersion + 1
+	_, _, err = decodeWALHeader(header)
+	assert.Error(t, err)
+}
+
+func segmentCompression(t *testing.T, filePath string) Compression {
+	opened, err := openSegment(filePath)
+	assert.NoError(t, err)
+	assert.NoError(t, opened.Close())
+	return opened.compression
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+var _ wal.Dumper = (*fsManager)(nil)
+
+// Dump reads the segments of the partition from the disk, they are opened read-only and the alignedWAL of the partition
+// is not created, so the manager can be created only to dump a partition. If the partition is active the segments are
+// read up to their size at the time they are opened, an entry which is being written at the end is not included.
+func (ws *fsManager) Dump(ctx context.Context, partitionID partition.ID) ([]*isb.ReadMessage, error) {
+	segments, err := ws.partitionSegments(partitionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, wal.ErrPartitionNotFound
+	}
+	stat, err := os.Stat(segments[len(segments)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	it := &segmentIterator{codec: ws.codec, blockSize: ws.readBlockSize, segments: segments, activeEnd: stat.Size()}
+	defer func() { _ = it.Close() }()
+	var messages []*isb.ReadMessage
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		msg, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		} else if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+}
+
+// partitionSegments returns the paths of the segments of the partition on the disk, in the order of their indexes.
+func (ws *fsManager) partitionSegments(partitionID partition.ID) ([]string, error) {
+	dir := ws.partitionDir(partitionID)
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var segments []segmentFile
+	for _, f := range files {
+		if f.IsDir() || !strings.HasPrefix(f.Name(), SegmentPrefix) {
+			continue
+		}
+		filePath := filepath.Join(dir, f.Name())
+		// the segments of the other partitions share the storePath
+		index, err := parseSegmentIndex(&partitionID, filePath)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segmentFile{path: filePath, index: index})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].index < segments[j].index
+	})
+	paths := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		paths = append(paths, segment.path)
+	}
+	return paths, nil
+}
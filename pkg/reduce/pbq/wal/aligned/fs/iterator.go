@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+var _ wal.Iterable = (*alignedWAL)(nil)
+
+// Iterator returns an iterator over the messages written to the segments at the time of the call. The segments are read
+// one entry at a time without holding the lock, so the writes are not blocked. It should not be called while the
+// alignedWAL is being replayed.
+func (w *alignedWAL) Iterator() (wal.StoreIterator, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return &segmentIterator{
+		codec:     w.codec,
+		blockSize: w.readBlockSize,
+		segments:  append([]string(nil), w.segments...),
+		// the active segment of a discovered alignedWAL is valid up to readUpTo until the writes resume
+		activeEnd: max(w.wOffset, w.readUpTo),
+	}, nil
+}
+
+// segmentIterator reads the entries of the segments in order, only the segment being read is open.
+type segmentIterator struct {
+	codec aligned.Codec
+	// blockSize is the size of the blocks the segments are read in, 0 disables the block reads.
+	blockSize int64
+	// segments are the segments which are not opened yet.
+	segments  []string
+	activeEnd int64
+	// segment is the segment being read, nil if the next segment has to be opened.
+	segment *openedSegment
+	// reader reads the segment being read
+	reader   io.Reader
+	filePath string
+	offset   int64
+	end      int64
+}
+
+// Next decodes the next entry, the segment is closed once all its entries are read and the next one is opened.
+func (it *segmentIterator) Next() (*isb.ReadMessage, error) {
+	for {
+		if it.segment == nil {
+			if len(it.segments) == 0 {
+				return nil, io.EOF
+			}
+			if err := it.openNext(); err != nil {
+				return nil, err
+			}
+		}
+		if it.offset < it.end {
+			message, sizeRead, err := decodeReadMessage(it.reader, it.codec, it.end-it.offset)
+			if err == nil {
+				it.offset += sizeRead
+				return message, nil
+			}
+			// only the last entry of the active segment can be torn, it is truncated by Replay
+			if len(it.segments) > 0 || !isTornEntry(err, it.offset+sizeRead >= it.end) {
+				return nil, fmt.Errorf("failed to read segment %s at offset %d, %w", it.filePath, it.offset, err)
+			}
+		}
+		if err := it.closeSegment(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// openNext opens the next segment and skips its header, the last segment is read up to activeEnd unless it is a
+// compressed segment, which is sealed.
+func (it *segmentIterator) openNext() error {
+	filePath := it.segments[0]
+	segment, err := openSegment(filePath)
+	if err != nil {
+		return err
+	}
+	it.segments = it.segments[1:]
+	it.segment, it.filePath, it.offset, it.end = segment, filePath, segment.offset, segment.size
+	it.reader = segment.reader(it.blockSize)
+	if len(it.segments) == 0 && segment.compression == CompressionNone {
+		it.end = it.activeEnd
+	}
+	return nil
+}
+
+// Close closes the segment being read, the segments which are not opened yet are not read.
+func (it *segmentIterator) Close() error {
+	it.segments = nil
+	return it.closeSegment()
+}
+
+// closeSegment closes the segment being read.
+func (it *segmentIterator) closeSegment() error {
+	if it.segment == nil {
+		return nil
+	}
+	err := it.segment.Close()
+	it.segment = nil
+	return err
+}
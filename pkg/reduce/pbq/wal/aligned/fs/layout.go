@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// dirPerm is the permission of the directories created for the WALs.
+const dirPerm = 0755
+
+// StoreDirNotFoundErr is returned by the creation of a WAL in the strict store dir mode if the base directory of the WALs
+// does not exist.
+type StoreDirNotFoundErr struct {
+	Dir string
+}
+
+func (e StoreDirNotFoundErr) Error() string {
+	return fmt.Sprintf("store directory %s does not exist", e.Dir)
+}
+
+func (e StoreDirNotFoundErr) Unwrap() error {
+	return os.ErrNotExist
+}
+
+// baseDir returns the directory which has to exist in the strict store dir mode, the directories of the WALs are under
+// it.
+func (ws *fsManager) baseDir() string {
+	if ws.storeDir != "" {
+		return ws.storeDir
+	}
+	return ws.storePath
+}
+
+// checkStoreDir returns StoreDirNotFoundErr if the dir does not exist or is not a directory.
+func checkStoreDir(dir string) error {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) || (err == nil && !info.IsDir()) {
+		return StoreDirNotFoundErr{Dir: dir}
+	}
+	return err
+}
+
+// partitionDir returns the directory of the segments and the metadata of the partition. All the partitions share the
+// storePath, unless a storeDir is set, in which case every partition gets its own directory under
+// storeDir/vertex/replica, so that the vertices and the replicas sharing the storeDir on a node do not clash.
+func (ws *fsManager) partitionDir(partitionID partition.ID) string {
+	if ws.storeDir == "" {
+		return ws.storePath
+	}
+	return filepath.Join(ws.replicaDir(), sanitizePathElement(partitionID.String()))
+}
+
+// replicaDir returns the directory of the partition directories of the vertex replica.
+func (ws *fsManager) replicaDir() string {
+	return filepath.Join(ws.storeDir, sanitizePathElement(ws.vertexName), strconv.Itoa(int(ws.replicaIndex)))
+}
+
+// partitionDirs returns the directories which hold the WALs of the vertex replica.
+func (ws *fsManager) partitionDirs() ([]string, error) {
+	if ws.storeDir == "" {
+		return []string{ws.storePath}, nil
+	}
+	entries, err := os.ReadDir(ws.replicaDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(ws.replicaDir(), entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// sanitizePathElement escapes the bytes of the name which are not safe in a path element, including the escape
+// character itself, so that distinct names never map to the same path element.
+func sanitizePathElement(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_':
+			b.WriteByte(c)
+		case c == '.' && i > 0:
+			// a leading dot would hide the directory, or make it refer to the parent
+			b.WriteByte(c)
+		default:
+			_, _ = fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
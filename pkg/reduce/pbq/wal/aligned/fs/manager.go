@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,10 +30,20 @@ import (
 	"github.com/numaproj/numaflow/pkg/metrics"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 )
 
+func init() {
+	if err := wal.RegisterStoreType(string(dfv1.FSType), newRegisteredManager); err != nil {
+		panic(err)
+	}
+}
+
 type fsManager struct {
 	storePath string
+	// storeDir is the base directory of the per partition directories, the WALs are created in storePath if it is not
+	// set
+	storeDir string
 	// maxBufferSize max size of batch before it's flushed to store
 	maxBatchSize int64
 	// syncDuration timeout to sync to store
@@ -40,11 +51,32 @@ type fsManager struct {
 	pipelineName string
 	vertexName   string
 	replicaIndex int32
-	activeWals   map[string]wal.WAL
-	mu           sync.RWMutex
+	// codec encodes and decodes the isb messages
+	codec aligned.Codec
+	// bufferPool pools the buffers which the messages are encoded into, nil disables the pooling
+	bufferPool *aligned.BufferPool
+	// segmentSize is the size after which a WAL rotates to a new segment, 0 disables rotation
+	segmentSize int64
+	// compression is used to compress the segments once they are sealed
+	compression Compression
+	// syncPolicy decides when the written entries are synced to the disk
+	syncPolicy SyncPolicy
+	// syncJitter is the max fraction of the sync duration the background flusher of a WAL delays its first sync by
+	syncJitter float64
+	// readBlockSize is the size of the blocks the segments are read in, 0 disables the block reads
+	readBlockSize int64
+	// mmapReplay replays the sealed segments of the discovered WALs from a read-only memory mapping
+	mmapReplay bool
+	// sharedFile persists all the partitions in a single shared log
+	sharedFile bool
+	// strictStoreDir refuses to create the WALs if the base directory does not exist, instead of creating it
+	strictStoreDir bool
+	activeWals     map[string]wal.WAL
+	mu             sync.RWMutex
 }
 
-// NewFSManager is a FileSystem WAL Manager.
+// NewFSManager is a FileSystem WAL Manager. Every partition has its own segments, unless WithSharedFile is set, in which
+// case all the partitions share a single log.
 func NewFSManager(vertexInstance *dfv1.VertexInstance, opts ...Option) wal.Manager {
 	s := &fsManager{
 		storePath:    dfv1.DefaultSegmentWALPath,
@@ -53,14 +85,31 @@ func NewFSManager(vertexInstance *dfv1.VertexInstance, opts ...Option) wal.Manag
 		pipelineName: vertexInstance.Vertex.Spec.PipelineName,
 		vertexName:   vertexInstance.Vertex.Spec.AbstractVertex.Name,
 		replicaIndex: vertexInstance.Replica,
+		codec:        aligned.ProtoCodec,
+		bufferPool:   aligned.DefaultBufferPool,
 		activeWals:   make(map[string]wal.WAL),
 	}
 	for _, o := range opts {
 		o(s)
 	}
+	if s.sharedFile {
+		return newSharedManager(s)
+	}
 	return s
 }
 
+// newRegisteredManager creates the manager of the fs store type, the segments are kept in the WAL path, i.e. on the
+// volume of the PBQs if the vertex has one.
+func newRegisteredManager(_ context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+	return NewFSManager(&dfv1.VertexInstance{
+		Vertex: &dfv1.Vertex{Spec: dfv1.VertexSpec{
+			PipelineName:   opts.PipelineName,
+			AbstractVertex: dfv1.AbstractVertex{Name: opts.VertexName},
+		}},
+		Replica: opts.Replica,
+	}), nil
+}
+
 // CreateWAL creates the FS alignedWAL.
 func (ws *fsManager) CreateWAL(_ context.Context, partitionID partition.ID) (wal.WAL, error) {
 	// check if the store is already present
@@ -71,16 +120,18 @@ func (ws *fsManager) CreateWAL(_ context.Context, partitionID partition.ID) (wal
 	if ok {
 		return store, nil
 	}
-	// Create fs dir if not exist
-	var err error
-	if _, err = os.Stat(ws.storePath); os.IsNotExist(err) {
-		err = os.Mkdir(ws.storePath, 0755)
-		if err != nil {
+	if ws.strictStoreDir {
+		if err := checkStoreDir(ws.baseDir()); err != nil {
 			return nil, err
 		}
 	}
+	// Create fs dir if not exist
+	dir := ws.partitionDir(partitionID)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
 
-	filePath := getSegmentFilePath(&partitionID, ws.storePath)
+	filePath := getSegmentFilePath(&partitionID, dir)
 	// we are interested only in the number of new files created
 	filesCount.With(map[string]string{
 		metrics.LabelPipeline:           ws.pipelineName,
@@ -93,7 +144,7 @@ func (ws *fsManager) CreateWAL(_ context.Context, partitionID partition.ID) (wal
 		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(ws.replicaIndex)),
 	}).Inc()
 
-	w, err := NewAlignedWriteOnlyWAL(&partitionID, filePath, ws.maxBatchSize, ws.syncDuration, ws.pipelineName, ws.vertexName, ws.replicaIndex)
+	w, err := NewAlignedWriteOnlyWAL(&partitionID, filePath, ws.maxBatchSize, ws.syncDuration, ws.pipelineName, ws.vertexName, ws.replicaIndex, ws.codec, ws.bufferPool, ws.segmentSize, ws.compression, ws.syncPolicy, ws.syncJitter, ws.readBlockSize)
 	if err != nil {
 		return nil, err
 	}
@@ -103,31 +154,79 @@ func (ws *fsManager) CreateWAL(_ context.Context, partitionID partition.ID) (wal
 	return w, nil
 }
 
-// DiscoverWALs returns all the WALs present in the storePath
+// DiscoverWALs returns all the WALs present in the storePath, or in the partition directories under the storeDir
 func (ws *fsManager) DiscoverWALs(_ context.Context) ([]wal.WAL, error) {
-	files, err := os.ReadDir(ws.storePath)
-	if os.IsNotExist(err) {
-		return []wal.WAL{}, nil
-	} else if err != nil {
+	dirs, err := ws.partitionDirs()
+	if err != nil {
 		return nil, err
 	}
 	partitions := make([]wal.WAL, 0)
 
-	for _, f := range files {
-		if strings.HasPrefix(f.Name(), SegmentPrefix) && !f.IsDir() {
-			filePath := filepath.Join(ws.storePath, f.Name())
-			wl, err := NewAlignedReadWriteWAL(filePath, ws.maxBatchSize, ws.syncDuration, ws.pipelineName, ws.vertexName, ws.replicaIndex)
-			if err != nil {
-				return nil, err
+	// a partition can have more than one segment if the WAL was rotated, group the segments by partition.
+	var partitionKeys []string
+	segments := make(map[string][]segmentFile)
+	for _, dir := range dirs {
+		files, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if strings.HasPrefix(f.Name(), SegmentPrefix) && !f.IsDir() {
+				filePath := filepath.Join(dir, f.Name())
+				id, err := readSegmentPartitionID(filePath)
+				if err != nil {
+					return nil, err
+				}
+				index, err := parseSegmentIndex(id, filePath)
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := segments[id.String()]; !ok {
+					partitionKeys = append(partitionKeys, id.String())
+				}
+				segments[id.String()] = append(segments[id.String()], segmentFile{path: filePath, index: index})
 			}
-			partitions = append(partitions, wl)
-			ws.activeWals[wl.PartitionID().String()] = wl
 		}
 	}
 
+	for _, key := range partitionKeys {
+		sort.Slice(segments[key], func(i, j int) bool {
+			return segments[key][i].index < segments[key][j].index
+		})
+		segmentPaths := make([]string, 0, len(segments[key]))
+		for _, segment := range segments[key] {
+			segmentPaths = append(segmentPaths, segment.path)
+		}
+		wl, err := NewAlignedReadWriteWAL(segmentPaths, ws.maxBatchSize, ws.syncDuration, ws.pipelineName, ws.vertexName, ws.replicaIndex, ws.codec, ws.bufferPool, ws.segmentSize, ws.compression, ws.syncPolicy, ws.syncJitter, ws.readBlockSize, ws.mmapReplay)
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, wl)
+		ws.activeWals[wl.PartitionID().String()] = wl
+	}
+
 	return partitions, nil
 }
 
+// segmentFile is a segment of a partition discovered from the storePath.
+type segmentFile struct {
+	path  string
+	index int
+}
+
+// readSegmentPartitionID reads the partition ID from the header of the segment.
+func readSegmentPartitionID(filePath string) (*partition.ID, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = fp.Close() }()
+	id, _, err := decodeWALHeader(fp)
+	return id, err
+}
+
 // DeleteWAL deletes the wal for the given partitionID
 func (ws *fsManager) DeleteWAL(partitionID partition.ID) error {
 	var err error
@@ -142,16 +241,38 @@ func (ws *fsManager) DeleteWAL(partitionID partition.ID) error {
 		}
 	}()
 
-	filePath := getSegmentFilePath(&partitionID, ws.storePath)
-	_, err = os.Stat(filePath)
+	// delete all the segments of the partition if the WAL was rotated
+	dir := ws.partitionDir(partitionID)
+	filePaths := []string{getSegmentFilePath(&partitionID, dir)}
+	ws.mu.RLock()
+	if w, ok := ws.activeWals[partitionID.String()].(*alignedWAL); ok {
+		filePaths = w.segments
+	}
+	ws.mu.RUnlock()
 
-	if err != nil {
-		return err
+	for _, filePath := range filePaths {
+		if _, err = os.Stat(filePath); err != nil {
+			return err
+		}
 	}
 
 	start := time.Now()
-	// an open file can also be deleted
-	err = os.Remove(filePath)
+	for _, filePath := range filePaths {
+		// an open file can also be deleted
+		if err = os.Remove(filePath); err != nil {
+			break
+		}
+	}
+	// the metadata is persisted only if the partition was created with it
+	if err == nil {
+		if err = os.Remove(getMetadataFilePath(&partitionID, dir)); os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	// the directory of the partition is not shared with the other partitions
+	if err == nil && ws.storeDir != "" {
+		err = os.RemoveAll(dir)
+	}
 
 	if err == nil {
 		garbageCollectingTime.With(map[string]string{
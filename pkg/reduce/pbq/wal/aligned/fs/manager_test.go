@@ -18,11 +18,15 @@ package fs
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -82,3 +86,262 @@ func TestWalStores(t *testing.T) {
 
 	assert.Len(t, discoverStores, 0)
 }
+
+func TestWalStores_Metadata(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "test-1",
+	}
+
+	tmp := t.TempDir()
+	storeProvider := NewFSManager(vi, WithStorePath(tmp))
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	_, err = store.(wal.MetadataStore).LoadMetadata()
+	assert.ErrorIs(t, err, wal.ErrMetadataNotFound)
+
+	metadata := wal.PartitionMetadata{PartitionID: partitionID, Keys: []string{"key-1", "key-2"}}
+	assert.NoError(t, store.(wal.MetadataStore).PersistMetadata(metadata))
+	assert.NoError(t, store.Write(ctx, &testutils.BuildTestReadMessages(1, time.Unix(60, 0), nil)[0]))
+	assert.NoError(t, store.Close())
+
+	// a new manager simulates a restart of the pod, the metadata file is not discovered as a WAL
+	restarted := NewFSManager(vi, WithStorePath(tmp))
+	discoveredStores, err := restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	loaded, err := discoveredStores[0].(wal.MetadataStore).LoadMetadata()
+	assert.NoError(t, err)
+	assert.Equal(t, metadata.Keys, loaded.Keys)
+	assert.True(t, partitionID.Start.Equal(loaded.PartitionID.Start))
+	assert.True(t, partitionID.End.Equal(loaded.PartitionID.End))
+	assert.Equal(t, partitionID.Slot, loaded.PartitionID.Slot)
+	assert.NoError(t, discoveredStores[0].Close())
+
+	// the metadata is deleted with the WAL
+	assert.NoError(t, restarted.DeleteWAL(partitionID))
+	entries, err := os.ReadDir(tmp)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 0)
+}
+
+func TestWalStores_Ping(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "test-1",
+	}
+
+	t.Run("writable", func(t *testing.T) {
+		tmp := t.TempDir()
+		store, err := NewFSManager(vi, WithStorePath(tmp)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		defer func() { _ = store.Close() }()
+		assert.NoError(t, store.Ping(ctx))
+
+		// the ping does not leave any file behind
+		entries, err := os.ReadDir(tmp)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("the permissions of the directory are not enforced for root")
+		}
+		tmp := t.TempDir()
+		store, err := NewFSManager(vi, WithStorePath(tmp)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		defer func() { _ = store.Close() }()
+		assert.NoError(t, os.Chmod(tmp, 0555))
+		defer func() { _ = os.Chmod(tmp, 0755) }()
+		assert.ErrorIs(t, store.Ping(ctx), os.ErrPermission)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		tmp := filepath.Join(t.TempDir(), "store")
+		store, err := NewFSManager(vi, WithStorePath(tmp)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		defer func() { _ = store.Close() }()
+		assert.NoError(t, os.RemoveAll(tmp))
+		assert.ErrorIs(t, store.Ping(ctx), os.ErrNotExist)
+	})
+}
+
+func TestWalStores_StoreDir(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	partitionIDs := []partition.ID{
+		{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"},
+		{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot 1"},
+	}
+	// another replica of the vertex on the same node
+	otherReplica := vi.DeepCopy()
+	otherReplica.Replica = 1
+
+	storeProvider := NewFSManager(vi, WithStoreDir(tmp))
+	otherProvider := NewFSManager(otherReplica, WithStoreDir(tmp))
+	for _, partitionID := range partitionIDs {
+		for _, provider := range []wal.Manager{storeProvider, otherProvider} {
+			store, err := provider.CreateWAL(ctx, partitionID)
+			assert.NoError(t, err)
+			assert.NoError(t, store.(wal.MetadataStore).PersistMetadata(wal.PartitionMetadata{PartitionID: partitionID}))
+			assert.NoError(t, store.Write(ctx, &testutils.BuildTestReadMessages(1, time.Unix(60, 0), nil)[0]))
+			assert.NoError(t, store.Close())
+		}
+	}
+
+	// every partition gets its own directory under storeDir/vertex/replica
+	dirs := []string{
+		filepath.Join(tmp, "testVertex", "0", "60000-120000-slot-1"),
+		filepath.Join(tmp, "testVertex", "0", "60000-120000-slot%201"),
+		filepath.Join(tmp, "testVertex", "1", "60000-120000-slot-1"),
+		filepath.Join(tmp, "testVertex", "1", "60000-120000-slot%201"),
+	}
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		assert.NoError(t, err)
+		assert.True(t, info.IsDir())
+		// the directories are never more permissive than dirPerm
+		assert.Zero(t, info.Mode().Perm()&^dirPerm)
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		// a segment and the metadata
+		assert.Len(t, entries, 2)
+	}
+
+	// the WALs are discovered from the directories of the replica
+	restarted := NewFSManager(vi, WithStoreDir(tmp))
+	discoveredStores, err := restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 2)
+	for _, store := range discoveredStores {
+		assert.Equal(t, int64(1), store.Size())
+		assert.NoError(t, store.Close())
+	}
+
+	// the GC of a partition removes its directory, the sibling partitions are kept
+	assert.NoError(t, restarted.DeleteWAL(partitionIDs[0]))
+	_, err = os.Stat(dirs[0])
+	assert.True(t, os.IsNotExist(err))
+	for _, dir := range dirs[1:] {
+		_, err = os.Stat(dir)
+		assert.NoError(t, err)
+	}
+	discoveredStores, err = NewFSManager(vi, WithStoreDir(tmp)).DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	assert.Equal(t, partitionIDs[1].Slot, discoveredStores[0].PartitionID().Slot)
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func TestWalStores_StrictStoreDir(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+
+	t.Run("strict", func(t *testing.T) {
+		for name, opts := range map[string][]Option{
+			"store path":  {WithStorePath(filepath.Join(t.TempDir(), "missing"))},
+			"store dir":   {WithStoreDir(filepath.Join(t.TempDir(), "missing"))},
+			"shared file": {WithStorePath(filepath.Join(t.TempDir(), "missing")), WithSharedFile()},
+		} {
+			_, err := NewFSManager(vi, append(opts, WithStrictStoreDir())...).CreateWAL(ctx, partitionID)
+			assert.ErrorAs(t, err, &StoreDirNotFoundErr{}, name)
+			assert.ErrorIs(t, err, os.ErrNotExist, name)
+		}
+
+		// the directories under an existing base directory are created
+		tmp := t.TempDir()
+		store, err := NewFSManager(vi, WithStoreDir(tmp), WithStrictStoreDir()).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Close())
+		_, err = os.Stat(filepath.Join(tmp, "testVertex", "0"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "missing")
+		store, err := NewFSManager(vi, WithStorePath(dir)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Close())
+		info, err := os.Stat(dir)
+		assert.NoError(t, err)
+		assert.True(t, info.IsDir())
+	})
+}
+
+func TestWalStores_Dump(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	partitionIds := []partition.ID{
+		{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "test-1"},
+		{Start: time.Unix(120, 0), End: time.Unix(180, 0), Slot: "test-2"},
+	}
+
+	tmp := t.TempDir()
+	// a small segment size makes the dump span multiple segments
+	storeProvider := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320))
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(60, 0).In(location), nil)
+	var stores []wal.WAL
+	for _, partitionID := range partitionIds {
+		store, err := storeProvider.CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		for i := range writeMessages {
+			assert.NoError(t, store.Write(ctx, &writeMessages[i]))
+		}
+		stores = append(stores, store)
+	}
+
+	// a separate manager dumps the active partition from the disk
+	dumped, err := wal.Dump(ctx, NewFSManager(vi, WithStorePath(tmp)), partitionIds[0])
+	assert.NoError(t, err)
+	assert.Len(t, dumped, len(writeMessages))
+	for i, msg := range dumped {
+		assert.Equal(t, writeMessages[i].Message, msg.Message)
+	}
+	for _, store := range stores {
+		assert.NoError(t, store.Close())
+	}
+
+	// the dump does not disturb the replay of a discovered WAL
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, len(partitionIds))
+	for _, store := range discoveredStores {
+		dumped, err = wal.Dump(ctx, NewFSManager(vi, WithStorePath(tmp)), *store.PartitionID())
+		assert.NoError(t, err)
+		assert.Len(t, dumped, len(writeMessages))
+		assert.Len(t, replayAll(t, store), len(writeMessages))
+		assert.NoError(t, store.Close())
+	}
+
+	_, err = wal.Dump(ctx, NewFSManager(vi, WithStorePath(tmp)), partition.ID{Start: time.Unix(180, 0), End: time.Unix(240, 0), Slot: "test-3"})
+	assert.ErrorIs(t, err, wal.ErrPartitionNotFound)
+	_, err = wal.Dump(ctx, NewFSManager(vi, WithStorePath(tmp), WithSharedFile()), partitionIds[0])
+	assert.Error(t, err)
+}
+
+func Test_sanitizePathElement(t *testing.T) {
+	assert.Equal(t, "60000-120000-slot-1", sanitizePathElement("60000-120000-slot-1"))
+	assert.Equal(t, "slot%201", sanitizePathElement("slot 1"))
+	assert.Equal(t, "slot%251", sanitizePathElement("slot%1"))
+	assert.Equal(t, "%2E.", sanitizePathElement(".."))
+	assert.Equal(t, "a%2Fb", sanitizePathElement("a/b"))
+	assert.Equal(t, "slot.1", sanitizePathElement("slot.1"))
+	assert.Equal(t, "slot-%C3%A9", sanitizePathElement("slot-é"))
+}
+
+func TestFSManager_Registered(t *testing.T) {
+	assert.Contains(t, wal.StoreTypes(), string(dfv1.FSType))
+	manager, err := wal.NewStoreManager(context.Background(), string(dfv1.FSType), wal.ManagerOptions{PipelineName: "p", VertexName: "v", Replica: 1})
+	assert.NoError(t, err)
+	fm := manager.(*fsManager)
+	assert.Equal(t, dfv1.DefaultSegmentWALPath, fm.storePath)
+	assert.Equal(t, "p", fm.pipelineName)
+	assert.Equal(t, "v", fm.vertexName)
+	assert.Equal(t, int32(1), fm.replicaIndex)
+}
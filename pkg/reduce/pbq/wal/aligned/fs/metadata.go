@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// MetadataPrefix is the prefix of the metadata files, it differs from SegmentPrefix so that the metadata files are not
+// discovered as segments.
+const MetadataPrefix = "metadata"
+
+var _ wal.MetadataStore = (*alignedWAL)(nil)
+
+// PersistMetadata writes the metadata of the partition to a small file next to the first segment. The file is replaced
+// atomically, so a crash leaves either the previous or the new metadata behind.
+func (w *alignedWAL) PersistMetadata(metadata wal.PartitionMetadata) error {
+	body, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	filePath := w.metadataFilePath()
+	tmpPath := filePath + ".tmp"
+	fp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = fp.Write(body); err == nil {
+		err = fp.Sync()
+	}
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// LoadMetadata reads the metadata of the partition, it returns wal.ErrMetadataNotFound if the metadata file does not
+// exist.
+func (w *alignedWAL) LoadMetadata() (*wal.PartitionMetadata, error) {
+	body, err := os.ReadFile(w.metadataFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, wal.ErrMetadataNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	metadata := &wal.PartitionMetadata{}
+	if err = json.Unmarshal(body, metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode the metadata of partition %s, %w", w.partitionID.String(), err)
+	}
+	return metadata, nil
+}
+
+// metadataFilePath returns the path of the metadata file of the WAL, the segments of a WAL are all in the same
+// directory.
+func (w *alignedWAL) metadataFilePath() string {
+	return getMetadataFilePath(w.partitionID, filepath.Dir(w.segments[len(w.segments)-1]))
+}
+
+// getMetadataFilePath returns the file path of the metadata of the partition.
+func getMetadataFilePath(id *partition.ID, dir string) string {
+	filename := fmt.Sprintf("%s_%d.%d.%s", MetadataPrefix, id.Start.Unix(), id.End.Unix(), id.Slot)
+	return filepath.Join(dir, filename)
+}
@@ -0,0 +1,28 @@
+//go:build !unix
+
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"os"
+)
+
+// mapSegment is not supported on this platform, the segments are replayed with buffered reads.
+func mapSegment(_ *os.File, _ int64) ([]byte, func() error, error) {
+	return nil, nil, errMmapUnsupported
+}
@@ -18,10 +18,25 @@ package fs
 
 import (
 	"time"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 )
 
 type Option func(stores *fsManager)
 
+// SyncPolicy decides when the written entries are synced to the disk.
+type SyncPolicy int
+
+const (
+	// SyncInterval syncs once the unsynced bytes exceed the max buffer size or the sync duration has elapsed since the
+	// last sync, and a background flusher syncs the entries of an idle alignedWAL every sync duration. It is the default.
+	SyncInterval SyncPolicy = iota
+	// SyncAlways syncs after every write, it is the most durable and the slowest.
+	SyncAlways
+	// SyncNever only syncs on Flush, Close and segment rotation.
+	SyncNever
+)
+
 // WithStorePath sets the alignedWAL store path
 func WithStorePath(path string) Option {
 	return func(stores *fsManager) {
@@ -29,6 +44,14 @@ func WithStorePath(path string) Option {
 	}
 }
 
+// WithStoreDir sets the base directory of the WALs and gives every partition its own directory, the segments and the
+// metadata of a partition are kept in storeDir/vertex/replica/partition. It takes precedence over WithStorePath.
+func WithStoreDir(dir string) Option {
+	return func(stores *fsManager) {
+		stores.storeDir = dir
+	}
+}
+
 // WithMaxBufferSize sets the alignedWAL buffer max size option
 func WithMaxBufferSize(size int64) Option {
 	return func(stores *fsManager) {
@@ -42,3 +65,87 @@ func WithSyncDuration(maxDuration time.Duration) Option {
 		stores.syncDuration = maxDuration
 	}
 }
+
+// WithCodec sets the codec used to encode and decode the isb messages, the same codec has to be used across restarts
+func WithCodec(codec aligned.Codec) Option {
+	return func(stores *fsManager) {
+		stores.codec = codec
+	}
+}
+
+// WithBufferPool sets the pool of the buffers which the messages are encoded into, it defaults to the
+// aligned.DefaultBufferPool. A nil pool allocates a new buffer for every write.
+func WithBufferPool(pool *aligned.BufferPool) Option {
+	return func(stores *fsManager) {
+		stores.bufferPool = pool
+	}
+}
+
+// WithSegmentSize sets the size after which the alignedWAL rotates to a new segment, 0 disables the rotation
+func WithSegmentSize(size int64) Option {
+	return func(stores *fsManager) {
+		stores.segmentSize = size
+	}
+}
+
+// WithSyncPolicy sets when the written entries are synced to the disk
+func WithSyncPolicy(policy SyncPolicy) Option {
+	return func(stores *fsManager) {
+		stores.syncPolicy = policy
+	}
+}
+
+// WithSyncJitter delays the first sync of the background flusher of every WAL by a random duration of up to the given
+// fraction of the sync duration, so that the flushers of the WALs created at the same time sync at different times
+// instead of all at once. The fraction is clamped to [0, 1], zero disables the jitter.
+func WithSyncJitter(fraction float64) Option {
+	return func(stores *fsManager) {
+		stores.syncJitter = min(max(fraction, 0), 1)
+	}
+}
+
+// WithReadBlockSize reads the segments in blocks of the given size, aligned to the block size, and decodes the entries
+// from the block in memory, instead of reading the file for every entry header and body. It saves the small reads
+// during the replays and the reads at an offset, e.g. on the network backed volumes. Zero disables the block reads.
+func WithReadBlockSize(size int64) Option {
+	return func(stores *fsManager) {
+		stores.readBlockSize = max(size, 0)
+	}
+}
+
+// WithMmapReplay replays the sealed segments of the discovered WALs from a read-only memory mapping instead of buffered
+// reads, which saves a read syscall per entry on large segments. The segment being written to is always read with
+// buffered reads, and the platforms without mmap fall back to buffered reads.
+func WithMmapReplay() Option {
+	return func(stores *fsManager) {
+		stores.mmapReplay = true
+	}
+}
+
+// WithSharedFile persists all the partitions of the vertex replica in a single segmented log instead of a file per
+// partition, so that the number of open files does not grow with the number of partitions. The records are tagged with
+// their partition, a replay reads the whole log, and the records of a deleted partition are dropped by a compaction.
+// The segment size applies to the shared log, the other options of the segments do not.
+func WithSharedFile() Option {
+	return func(stores *fsManager) {
+		stores.sharedFile = true
+	}
+}
+
+// WithStrictStoreDir makes the creation of a WAL fail with StoreDirNotFoundErr if the base directory, the store dir if
+// set or else the store path, does not exist, e.g. because a volume which should have been mounted is missing. The
+// directories under the base directory are still created. By default the base directory is created on demand.
+func WithStrictStoreDir() Option {
+	return func(stores *fsManager) {
+		stores.strictStoreDir = true
+	}
+}
+
+// WithCompression sets the compression of the sealed segments, the entries of a segment are compressed as a whole once
+// the writes rotate to the next segment, so it only takes effect with WithSegmentSize. The compression is recorded in the
+// segment header, so the segments written with a different compression are still replayed after a config change.
+func WithCompression(compression Compression) Option {
+	return func(stores *fsManager) {
+		stores.compression = compression
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// sharedCompactionMinDead is the min number of dead records before the deletion of a partition triggers a compaction of
+// the shared log.
+const sharedCompactionMinDead = 1000
+
+// sharedManager is the WAL Manager of the shared file mode, all the partitions of the vertex replica are persisted in a
+// single sharedLog. The log is opened on first use.
+type sharedManager struct {
+	dir string
+	// strictDir is the base directory which has to exist before the shared log is opened, empty if it is created on
+	// demand
+	strictDir   string
+	segmentSize int64
+	log         *sharedLog
+	activeWals  map[string]wal.WAL
+	mu          sync.Mutex
+	// compacting is set while a compaction started by DeleteWAL runs in the background, compactions wg tracks it
+	compacting  atomic.Bool
+	compactions sync.WaitGroup
+	logger      *zap.SugaredLogger
+}
+
+func newSharedManager(ws *fsManager) *sharedManager {
+	dir := ws.storePath
+	if ws.storeDir != "" {
+		dir = ws.replicaDir()
+	}
+	var strictDir string
+	if ws.strictStoreDir {
+		strictDir = ws.baseDir()
+	}
+	return &sharedManager{
+		dir:         dir,
+		strictDir:   strictDir,
+		segmentSize: ws.segmentSize,
+		activeWals:  make(map[string]wal.WAL),
+		logger:      logging.NewLogger(),
+	}
+}
+
+// openLog opens the shared log if it is not open yet. Caller should hold the lock.
+func (sm *sharedManager) openLog() error {
+	if sm.log != nil {
+		return nil
+	}
+	if sm.strictDir != "" {
+		if err := checkStoreDir(sm.strictDir); err != nil {
+			return err
+		}
+	}
+	log, err := openSharedLog(sm.dir, sm.segmentSize)
+	if err != nil {
+		return err
+	}
+	sm.log = log
+	return nil
+}
+
+// CreateWAL returns the WAL of the partition in the shared log.
+func (sm *sharedManager) CreateWAL(_ context.Context, partitionID partition.ID) (wal.WAL, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if err := sm.openLog(); err != nil {
+		return nil, err
+	}
+	// during crash recovery, we might have already created the WAL while replaying
+	if w, ok := sm.activeWals[partitionID.String()]; ok {
+		return w, nil
+	}
+	w := sm.newWAL(partitionID)
+	sm.activeWals[partitionID.String()] = w
+	return w, nil
+}
+
+// DiscoverWALs returns a WAL for every partition which has live messages in the shared log.
+func (sm *sharedManager) DiscoverWALs(_ context.Context) ([]wal.WAL, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if err := sm.openLog(); err != nil {
+		return nil, err
+	}
+	ids, err := sm.log.partitions()
+	if err != nil {
+		return nil, err
+	}
+	partitions := make([]wal.WAL, 0, len(ids))
+	for _, id := range ids {
+		w := sm.newWAL(*id)
+		sm.activeWals[id.String()] = w
+		partitions = append(partitions, w)
+	}
+	return partitions, nil
+}
+
+// DeleteWAL writes a tombstone for the partition, its messages are dropped by a later compaction. The shared log is
+// compacted in the background once at least half of its records are dead.
+func (sm *sharedManager) DeleteWAL(partitionID partition.ID) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if err := sm.openLog(); err != nil {
+		return err
+	}
+	if err := sm.log.delete(string(aligned.EncodePartitionID(partitionID))); err != nil {
+		return err
+	}
+	delete(sm.activeWals, partitionID.String())
+	if sm.log.shouldCompact(sharedCompactionMinDead) && sm.compacting.CompareAndSwap(false, true) {
+		sm.compactions.Add(1)
+		go sm.compactInBackground(sm.log)
+	}
+	return nil
+}
+
+// compactInBackground compacts the shared log without holding the lock of the manager, a failed compaction is retried
+// by a later deletion.
+func (sm *sharedManager) compactInBackground(log *sharedLog) {
+	defer sm.compactions.Done()
+	defer sm.compacting.Store(false)
+	if err := log.compact(); err != nil {
+		sm.logger.Errorw("Failed to compact the shared log", zap.String("dir", sm.dir), zap.Error(err))
+	}
+}
+
+// Compact drops the records of the deleted partitions from the shared log.
+func (sm *sharedManager) Compact() error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.log == nil {
+		return nil
+	}
+	return sm.log.compact()
+}
+
+// Close waits for the background compaction, and syncs and closes the shared log.
+func (sm *sharedManager) Close() error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.compactions.Wait()
+	if sm.log == nil {
+		return nil
+	}
+	err := sm.log.close()
+	sm.log = nil
+	return err
+}
+
+func (sm *sharedManager) newWAL(partitionID partition.ID) *sharedWAL {
+	return &sharedWAL{
+		log:         sm.log,
+		key:         string(aligned.EncodePartitionID(partitionID)),
+		partitionID: &partitionID,
+	}
+}
+
+// sharedWAL implements wal.WAL on top of the records of a partition in the shared log.
+type sharedWAL struct {
+	log *sharedLog
+	// key is the encoded partition ID which tags the records of the partition.
+	key         string
+	partitionID *partition.ID
+	closed      bool
+}
+
+var _ wal.WAL = (*sharedWAL)(nil)
+
+// Replay replays the live messages of the partition in the order they were written, the records of the other
+// partitions are skipped.
+func (s *sharedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+		msgs, err := s.log.messages(s.key)
+		if err != nil {
+			errs <- err
+			return
+		}
+		for _, msg := range msgs {
+			messages <- msg
+		}
+	}()
+	return messages, errs
+}
+
+// Write appends the message to the shared log.
+func (s *sharedWAL) Write(_ context.Context, msg *isb.ReadMessage) error {
+	if s.closed {
+		return aligned.ErrWriteStoreClosed
+	}
+	return s.log.write(s.key, msg)
+}
+
+// WriteBatch appends the messages to the shared log one after the other, it stops at the first failed write and
+// returns wal.BatchWriteErr with the index of the failed message.
+func (s *sharedWAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	for i, msg := range msgs {
+		if err := s.Write(ctx, msg); err != nil {
+			return wal.BatchWriteErr{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// PartitionID returns the partition ID of the WAL.
+func (s *sharedWAL) PartitionID() *partition.ID {
+	return s.partitionID
+}
+
+// Size returns the number of live messages of the partition.
+func (s *sharedWAL) Size() int64 {
+	return s.log.count(s.key)
+}
+
+// Flush syncs the shared log, which makes the messages of all the partitions durable.
+func (s *sharedWAL) Flush() error {
+	return s.log.flush()
+}
+
+// Ping checks that the files can be written to the directory of the shared log.
+func (s *sharedWAL) Ping(_ context.Context) error {
+	return pingDir(s.log.dir)
+}
+
+// Close closes the WAL, no more writes will be accepted. The shared log is owned by the manager.
+func (s *sharedWAL) Close() error {
+	s.closed = true
+	return nil
+}
@@ -0,0 +1,546 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+const (
+	// SharedPrefix is the prefix of the segments of the shared log, it differs from SegmentPrefix so that the shared
+	// segments are never discovered as the segments of a partition.
+	SharedPrefix = "shared"
+	// compactingFile is the file the compaction writes to before it is renamed to a shared segment.
+	compactingFile = ".compacting"
+)
+
+// kinds of the records of the shared log.
+const (
+	// recordMessage holds a message of the partition.
+	recordMessage uint8 = iota
+	// recordTombstone deletes the messages of the partition which were written before it.
+	recordTombstone
+	// recordCheckpoint is the first record of a compacted segment, the segments before it are superseded.
+	recordCheckpoint
+)
+
+// sharedRecordHeader is the fixed size header of every record of the shared log. The checksum covers the partition and
+// the body.
+//
+//	+-------------+----------------------+------------------+-------------------+-----------------+-------------+
+//	| kind uint8  | partition len uint16 | body len uint32  | checksum uint32   | partition []byte | body []byte |
+//	+-------------+----------------------+------------------+-------------------+-----------------+-------------+
+type sharedRecordHeader struct {
+	Kind         uint8
+	PartitionLen uint16
+	BodyLen      uint32
+	Checksum     uint32
+}
+
+// sharedRecordHeaderSize is the encoded size of sharedRecordHeader.
+var sharedRecordHeaderSize = int64(binary.Size(sharedRecordHeader{}))
+
+// recordPosition locates a record in the shared log.
+type recordPosition struct {
+	// segment is the index of the shared segment which holds the record.
+	segment int
+	// offset is the offset of the record in the segment.
+	offset int64
+	// size is the encoded size of the record, including the header.
+	size int64
+}
+
+// sharedLog is a segmented log which holds the messages of all the partitions of a vertex replica, so that the number
+// of open files does not grow with the number of partitions. Every record is tagged with the encoded partition ID. The
+// records of a deleted partition are only dropped by a compaction, which rewrites the live records into a new segment.
+type sharedLog struct {
+	dir         string
+	segmentSize int64
+	// segments are the file paths of the segments, oldest first, the last one is being written to.
+	segments  []string
+	nextIndex int
+	fp        *os.File
+	writer    *bufio.Writer
+	// activeSize is the size of the segment being written to.
+	activeSize int64
+	// records is the number of records of the log, it is the sequence of the next record.
+	records int64
+	// positions are the positions of the live messages of every partition in the write order, keyed by the encoded
+	// partition ID, so that a partition is replayed without reading the records of the other partitions.
+	positions map[string][]recordPosition
+	// tombstones is the sequence of the last tombstone of every deleted partition.
+	tombstones map[string]int64
+	// dead is the number of records which would be dropped by a compaction.
+	dead int64
+	mu   sync.Mutex
+}
+
+// openSharedLog opens the shared log in the dir and indexes its records. The segments superseded by a compaction which
+// did not finish cleaning up are removed, and a torn last record is truncated.
+func openSharedLog(dir string, segmentSize int64) (*sharedLog, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+	// the compaction did not get to rename the compacted segment, the old segments are still complete
+	if err := os.Remove(filepath.Join(dir, compactingFile)); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l := &sharedLog{
+		dir:         dir,
+		segmentSize: segmentSize,
+		positions:   make(map[string][]recordPosition),
+		tombstones:  make(map[string]int64),
+	}
+	segments, err := l.listSegments()
+	if err != nil {
+		return nil, err
+	}
+	if segments, err = removeSupersededSegments(segments); err != nil {
+		return nil, err
+	}
+
+	// the records of all the partitions are indexed in a single pass
+	var validEnd int64
+	for i, segment := range segments {
+		segmentIndex := parseSharedSegmentIndex(segment)
+		validEnd, err = readSharedSegment(segment, i == len(segments)-1, func(offset int64, header sharedRecordHeader, key []byte, body []byte) error {
+			l.index(header.Kind, string(key), recordPosition{segment: segmentIndex, offset: offset, size: recordSize(key, body)})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(segments) == 0 {
+		if err = l.openSegment(); err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
+	l.segments = segments
+	last := segments[len(segments)-1]
+	l.nextIndex = parseSharedSegmentIndex(last) + 1
+	if err = os.Truncate(last, validEnd); err != nil {
+		return nil, err
+	}
+	if l.fp, err = os.OpenFile(last, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return nil, err
+	}
+	l.writer = bufio.NewWriter(l.fp)
+	l.activeSize = validEnd
+	return l, nil
+}
+
+// listSegments returns the segments of the shared log in the dir, oldest first.
+func (l *sharedLog) listSegments() ([]string, error) {
+	files, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]string, 0)
+	for _, f := range files {
+		if !f.IsDir() && strings.HasPrefix(f.Name(), SharedPrefix+"-") {
+			segments = append(segments, filepath.Join(l.dir, f.Name()))
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return parseSharedSegmentIndex(segments[i]) < parseSharedSegmentIndex(segments[j])
+	})
+	return segments, nil
+}
+
+// removeSupersededSegments removes the segments which come before the last segment that starts with a checkpoint.
+func removeSupersededSegments(segments []string) ([]string, error) {
+	for i := len(segments) - 1; i > 0; i-- {
+		fp, err := os.Open(segments[i])
+		if err != nil {
+			return nil, err
+		}
+		var header sharedRecordHeader
+		err = binary.Read(fp, binary.LittleEndian, &header)
+		_ = fp.Close()
+		if err != nil || header.Kind != recordCheckpoint {
+			continue
+		}
+		for _, segment := range segments[:i] {
+			if err = os.Remove(segment); err != nil {
+				return nil, err
+			}
+		}
+		return segments[i:], nil
+	}
+	return segments, nil
+}
+
+// sharedSegmentPath returns the path of the shared segment with the given index.
+func (l *sharedLog) sharedSegmentPath(index int) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%s-%d", SharedPrefix, index))
+}
+
+// parseSharedSegmentIndex returns the index of the shared segment, -1 if the name is not a shared segment.
+func parseSharedSegmentIndex(filePath string) int {
+	index, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filePath), SharedPrefix+"-"))
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+// openSegment creates the next segment and makes it the one being written to. Caller should hold the lock.
+func (l *sharedLog) openSegment() error {
+	path := l.sharedSegmentPath(l.nextIndex)
+	fp, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	l.fp = fp
+	l.writer = bufio.NewWriter(fp)
+	l.activeSize = 0
+	l.segments = append(l.segments, path)
+	l.nextIndex++
+	return nil
+}
+
+// rotate syncs and closes the segment being written to, and opens the next one. Caller should hold the lock.
+func (l *sharedLog) rotate() error {
+	if err := l.sync(); err != nil {
+		return err
+	}
+	if err := l.fp.Close(); err != nil {
+		return err
+	}
+	return l.openSegment()
+}
+
+// index accounts for a record of the partition with the given key at the position. Caller should hold the lock.
+func (l *sharedLog) index(kind uint8, key string, pos recordPosition) {
+	switch kind {
+	case recordMessage:
+		l.positions[key] = append(l.positions[key], pos)
+	case recordTombstone:
+		// the deleted messages and the tombstone itself are dropped by the compaction
+		l.dead += int64(len(l.positions[key])) + 1
+		delete(l.positions, key)
+		l.tombstones[key] = l.records
+	}
+	l.records++
+}
+
+// append writes a record of the partition with the given key. Caller should hold the lock.
+func (l *sharedLog) append(kind uint8, key string, body []byte) error {
+	if l.segmentSize > 0 && l.activeSize >= l.segmentSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := writeSharedRecord(l.writer, kind, []byte(key), body)
+	if err != nil {
+		return err
+	}
+	l.index(kind, key, recordPosition{segment: l.nextIndex - 1, offset: l.activeSize, size: n})
+	l.activeSize += n
+	return nil
+}
+
+// recordSize returns the encoded size of a record with the given key and body.
+func recordSize(key []byte, body []byte) int64 {
+	return sharedRecordHeaderSize + int64(len(key)) + int64(len(body))
+}
+
+// writeSharedRecord encodes a record to the writer and returns the number of bytes written.
+func writeSharedRecord(w io.Writer, kind uint8, key []byte, body []byte) (int64, error) {
+	checksum := crc32.NewIEEE()
+	_, _ = checksum.Write(key)
+	_, _ = checksum.Write(body)
+	header := sharedRecordHeader{Kind: kind, PartitionLen: uint16(len(key)), BodyLen: uint32(len(body)), Checksum: checksum.Sum32()}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(key); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(body); err != nil {
+		return 0, err
+	}
+	return recordSize(key, body), nil
+}
+
+// readSharedRecord reads the record at the position of the segment, it returns the body of the record.
+func readSharedRecord(fp *os.File, pos recordPosition) ([]byte, error) {
+	data := make([]byte, pos.size)
+	if _, err := fp.ReadAt(data, pos.offset); err != nil {
+		return nil, err
+	}
+	var header sharedRecordHeader
+	if err := binary.Read(bytes.NewReader(data[:sharedRecordHeaderSize]), binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	data = data[sharedRecordHeaderSize:]
+	if crc32.ChecksumIEEE(data) != header.Checksum {
+		return nil, errChecksumMismatch
+	}
+	return data[header.PartitionLen:], nil
+}
+
+// readSharedSegment reads the records of the segment in order, and returns the offset at which the valid records end.
+// The fn is called with the offset of every record.
+// A torn record at the end of the last segment is left behind by a crash in the middle of a write, it ends the segment
+// instead of failing the read.
+func readSharedSegment(filePath string, isLast bool, fn func(offset int64, header sharedRecordHeader, key []byte, body []byte) error) (int64, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = fp.Close() }()
+	stat, err := fp.Stat()
+	if err != nil {
+		return 0, err
+	}
+	reader := bufio.NewReader(fp)
+
+	var offset int64
+	for offset < stat.Size() {
+		var header sharedRecordHeader
+		var data []byte
+		if err = binary.Read(reader, binary.LittleEndian, &header); err == nil {
+			data = make([]byte, int(header.PartitionLen)+int(header.BodyLen))
+			_, err = io.ReadFull(reader, data)
+		}
+		if err == nil && crc32.ChecksumIEEE(data) != header.Checksum {
+			err = errChecksumMismatch
+		}
+		if err != nil {
+			isLastRecord := offset+sharedRecordHeaderSize+int64(header.PartitionLen)+int64(header.BodyLen) >= stat.Size()
+			if isLast && isTornEntry(err, isLastRecord) {
+				return offset, nil
+			}
+			return offset, fmt.Errorf("failed to read segment %s at offset %d, %w", filePath, offset, err)
+		}
+		if err = fn(offset, header, data[:header.PartitionLen], data[header.PartitionLen:]); err != nil {
+			return offset, err
+		}
+		offset += sharedRecordHeaderSize + int64(len(data))
+	}
+	return offset, nil
+}
+
+// write writes a message of the partition.
+func (l *sharedLog) write(key string, msg *isb.ReadMessage) error {
+	body, err := aligned.EncodeEntry(msg)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.append(recordMessage, key, body)
+}
+
+// delete writes a tombstone of the partition, its messages are dropped by the next compaction.
+func (l *sharedLog) delete(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.append(recordTombstone, key, nil)
+}
+
+// count returns the number of live messages of the partition.
+func (l *sharedLog) count(key string) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int64(len(l.positions[key]))
+}
+
+// partitions returns the partitions which have live messages.
+func (l *sharedLog) partitions() ([]*partition.ID, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ids := make([]*partition.ID, 0, len(l.positions))
+	for key := range l.positions {
+		id, err := aligned.DecodePartitionID([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// messages returns the live messages of the partition in the write order. Only the indexed records of the partition are
+// read, the records of the other partitions are not.
+func (l *sharedLog) messages(key string) ([]*isb.ReadMessage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.writer.Flush(); err != nil {
+		return nil, err
+	}
+	positions := l.positions[key]
+	messages := make([]*isb.ReadMessage, 0, len(positions))
+	files := make(map[int]*os.File)
+	defer func() {
+		for _, fp := range files {
+			_ = fp.Close()
+		}
+	}()
+	for _, pos := range positions {
+		fp, ok := files[pos.segment]
+		if !ok {
+			var err error
+			if fp, err = os.Open(l.sharedSegmentPath(pos.segment)); err != nil {
+				return nil, err
+			}
+			files[pos.segment] = fp
+		}
+		body, err := readSharedRecord(fp, pos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read segment %s at offset %d, %w", fp.Name(), pos.offset, err)
+		}
+		msg, err := aligned.DecodeEntry(body)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// shouldCompact returns true once the dead records are at least half of the records of the log and there are at least
+// minDead of them.
+func (l *sharedLog) shouldCompact(minDead int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dead >= minDead && l.dead*2 >= l.records
+}
+
+// compact rewrites the live records into a new segment which starts with a checkpoint, and removes the old segments. The
+// new segment is written to a temporary file which is renamed once it is synced, and a crash before the old segments
+// are removed is recovered by removeSupersededSegments, so the live records are never lost or duplicated.
+func (l *sharedLog) compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.sync(); err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(l.dir, compactingFile)
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(tmp)
+	size, err := writeSharedRecord(writer, recordCheckpoint, nil, nil)
+	records := int64(1)
+	compactedIndex := l.nextIndex
+	positions := make(map[string][]recordPosition, len(l.positions))
+	var seq int64
+	for _, segment := range l.segments {
+		if err != nil {
+			break
+		}
+		_, err = readSharedSegment(segment, false, func(_ int64, header sharedRecordHeader, key []byte, body []byte) error {
+			defer func() { seq++ }()
+			if header.Kind != recordMessage {
+				return nil
+			}
+			if deletedUpTo, ok := l.tombstones[string(key)]; ok && seq < deletedUpTo {
+				return nil
+			}
+			n, err := writeSharedRecord(writer, recordMessage, key, body)
+			positions[string(key)] = append(positions[string(key)], recordPosition{segment: compactedIndex, offset: size, size: n})
+			size += n
+			records++
+			return err
+		})
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to compact the shared log, %w", err)
+	}
+
+	compacted := l.sharedSegmentPath(compactedIndex)
+	if err = os.Rename(tmpPath, compacted); err != nil {
+		return err
+	}
+	if err = l.fp.Close(); err != nil {
+		return err
+	}
+	for _, segment := range l.segments {
+		if err = os.Remove(segment); err != nil {
+			return err
+		}
+	}
+	if l.fp, err = os.OpenFile(compacted, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return err
+	}
+	l.writer = bufio.NewWriter(l.fp)
+	l.segments = []string{compacted}
+	l.nextIndex++
+	l.activeSize = size
+	l.records = records
+	l.positions = positions
+	l.tombstones = make(map[string]int64)
+	l.dead = 0
+	return nil
+}
+
+// sync flushes the buffered records and syncs the segment being written to. Caller should hold the lock.
+func (l *sharedLog) sync() error {
+	if err := l.writer.Flush(); err != nil {
+		return err
+	}
+	return l.fp.Sync()
+}
+
+// flush makes the records written so far durable.
+func (l *sharedLog) flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sync()
+}
+
+// close syncs and closes the segment being written to.
+func (l *sharedLog) close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.sync(); err != nil {
+		return err
+	}
+	return l.fp.Close()
+}
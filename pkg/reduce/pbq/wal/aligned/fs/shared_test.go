@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+func TestSharedFile(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	partitionCount, msgCount := 200, 5
+
+	partitionIDs := make([]partition.ID, partitionCount)
+	for i := range partitionIDs {
+		partitionIDs[i] = partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: fmt.Sprintf("slot-%d", i)}
+	}
+	// every partition has its own messages, the writes of the partitions are interleaved
+	messages := make(map[string][]isb.ReadMessage, partitionCount)
+	for _, id := range partitionIDs {
+		messages[id.String()] = testutils.BuildTestReadMessagesIntOffset(int64(msgCount), time.Unix(60, 0), nil)
+		for i := range messages[id.String()] {
+			messages[id.String()][i].Payload = []byte(id.Slot + "-" + fmt.Sprint(i))
+		}
+	}
+
+	stores := NewFSManager(vi, WithStorePath(tmp), WithSharedFile(), WithSegmentSize(4096))
+	wals := make([]wal.WAL, partitionCount)
+	for i, id := range partitionIDs {
+		w, err := stores.CreateWAL(ctx, id)
+		assert.NoError(t, err)
+		wals[i] = w
+	}
+	for j := 0; j < msgCount; j++ {
+		for i, id := range partitionIDs {
+			assert.NoError(t, wals[i].Write(ctx, &messages[id.String()][j]))
+		}
+	}
+	assert.NoError(t, stores.(io.Closer).Close())
+
+	// the partitions share the segments of a single log instead of a file each
+	files, err := os.ReadDir(tmp)
+	assert.NoError(t, err)
+	assert.Less(t, len(files), partitionCount)
+
+	verify := func(stores wal.Manager, deleted map[string]bool) {
+		discovered, err := stores.DiscoverWALs(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, discovered, partitionCount-len(deleted))
+		for _, w := range discovered {
+			key := w.PartitionID().String()
+			assert.False(t, deleted[key])
+			assert.Equal(t, int64(msgCount), w.Size())
+			// the replay of a partition returns only the messages of that partition
+			readMessages := replayAll(t, w)
+			assert.Len(t, readMessages, msgCount)
+			for i, msg := range readMessages {
+				assert.Equal(t, messages[key][i].Payload, msg.Payload)
+			}
+		}
+	}
+
+	restarted := NewFSManager(vi, WithStorePath(tmp), WithSharedFile(), WithSegmentSize(4096))
+	verify(restarted, nil)
+
+	// the deleted partitions are tombstoned, and dropped from the segments by the compaction
+	deleted := make(map[string]bool)
+	for _, id := range partitionIDs[:partitionCount/2] {
+		assert.NoError(t, restarted.DeleteWAL(id))
+		deleted[id.String()] = true
+	}
+	verify(restarted, deleted)
+	assert.NoError(t, restarted.(*sharedManager).Compact())
+	verify(restarted, deleted)
+	assert.NoError(t, restarted.(io.Closer).Close())
+
+	files, err = os.ReadDir(tmp)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	verify(NewFSManager(vi, WithStorePath(tmp), WithSharedFile(), WithSegmentSize(4096)), deleted)
+}
+
+func TestSharedFile_TornRecord(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	id := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+
+	stores := NewFSManager(vi, WithStorePath(tmp), WithSharedFile())
+	w, err := stores.CreateWAL(ctx, id)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(3, time.Unix(60, 0), nil)
+	for i := range writeMessages {
+		assert.NoError(t, w.Write(ctx, &writeMessages[i]))
+	}
+	assert.NoError(t, stores.(io.Closer).Close())
+
+	// a crash in the middle of the last write leaves a torn record behind
+	segment := filepath.Join(tmp, SharedPrefix+"-0")
+	stat, err := os.Stat(segment)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(segment, stat.Size()-3))
+
+	restarted := NewFSManager(vi, WithStorePath(tmp), WithSharedFile())
+	discovered, err := restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 1)
+	assert.Len(t, replayAll(t, discovered[0]), 2)
+
+	// the torn record is truncated, so the next write is readable
+	assert.NoError(t, discovered[0].Write(ctx, &writeMessages[2]))
+	assert.Len(t, replayAll(t, discovered[0]), 3)
+}
+
+func TestSharedFile_BackgroundCompaction(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	deletedID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-deleted"}
+	liveID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-live"}
+
+	stores := NewFSManager(vi, WithStorePath(tmp), WithSharedFile(), WithSegmentSize(4096))
+	deletedWAL, err := stores.CreateWAL(ctx, deletedID)
+	assert.NoError(t, err)
+	liveWAL, err := stores.CreateWAL(ctx, liveID)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(sharedCompactionMinDead, time.Unix(60, 0), nil)
+	for i := range writeMessages {
+		assert.NoError(t, deletedWAL.Write(ctx, &writeMessages[i]))
+	}
+	assert.NoError(t, liveWAL.Write(ctx, &writeMessages[0]))
+
+	// the deletion starts the compaction and returns, the compaction finishes before the log is closed
+	sm := stores.(*sharedManager)
+	assert.NoError(t, stores.DeleteWAL(deletedID))
+	assert.NoError(t, liveWAL.Write(ctx, &writeMessages[1]))
+	assert.NoError(t, sm.Close())
+	assert.False(t, sm.compacting.Load())
+
+	files, err := os.ReadDir(tmp)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	discovered, err := NewFSManager(vi, WithStorePath(tmp), WithSharedFile()).DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 1)
+	assert.Equal(t, liveID, *discovered[0].PartitionID())
+	assert.Len(t, replayAll(t, discovered[0]), 2)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/numaproj/numaflow/pkg/metrics"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+var _ wal.Truncater = (*alignedWAL)(nil)
+
+// Truncate drops the entries at and after offset. The segment which holds the entry at offset is truncated before the
+// entry and becomes the segment being written to, the segments after it are deleted. It should not be called while the
+// alignedWAL is being replayed.
+func (w *alignedWAL) Truncate(offset int64) (err error) {
+	defer func() {
+		if err != nil {
+			walErrors.With(map[string]string{
+				metrics.LabelPipeline:           w.pipelineName,
+				metrics.LabelVertex:             w.vertexName,
+				metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+				labelErrorKind:                  "truncate",
+			}).Inc()
+		}
+	}()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if size := w.Size(); offset < 0 || offset > size {
+		return wal.OffsetOutOfRangeErr{Offset: offset, Size: size}
+	}
+
+	// find the segment which holds the entry at offset, and the number of entries of that segment which are kept
+	last, kept := 0, offset
+	for last < len(w.segments)-1 && kept >= w.segmentEntries[last] {
+		kept -= w.segmentEntries[last]
+		last++
+	}
+	end, err := skipEntries(w.segments[last], kept)
+	if err != nil {
+		return err
+	}
+
+	// the newest segments are deleted first, so that a crash never leaves a gap between the segments
+	for i := len(w.segments) - 1; i > last; i-- {
+		if err = os.Remove(w.segments[i]); err != nil && !os.IsNotExist(err) {
+			if i < len(w.segments)-1 {
+				// the segments after the one which could not be deleted are gone
+				return errors.Join(err, w.resumeSegment(i))
+			}
+			return err
+		}
+	}
+	if last < len(w.segments)-1 {
+		if err = w.resumeSegment(last); err != nil {
+			return err
+		}
+	}
+
+	if err = w.fp.Truncate(end); err != nil {
+		return err
+	}
+	w.segmentEntries[last] = kept
+	// the writes resume at the new end, the truncated entries are never replayed
+	w.readUpTo = end
+	w.wOffset = end
+	return w.sync()
+}
+
+// resumeSegment makes the segment at index i the one being written to, the segments after it should have been deleted.
+// The writer is positioned at the end of the entries of the segment, a compressed segment is decompressed first. Caller
+// should hold the lock.
+func (w *alignedWAL) resumeSegment(i int) error {
+	if err := w.rewriteSegment(w.segments[i], CompressionNone); err != nil {
+		return err
+	}
+	fp, err := os.OpenFile(w.segments[i], os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	_, _, err = decodeWALHeader(fp)
+	if err != nil {
+		_ = fp.Close()
+		return err
+	}
+	segmentIndex, err := parseSegmentIndex(w.partitionID, w.segments[i])
+	if err != nil {
+		_ = fp.Close()
+		return err
+	}
+	_ = w.fp.Close()
+	w.fp = fp
+	w.segmentIndex = segmentIndex
+	w.segments, w.segmentEntries = w.segments[:i+1], w.segmentEntries[:i+1]
+	return w.resumeWrites()
+}
+
+// skipEntries returns the offset of the segment at which the entries after the first n entries start.
+func skipEntries(filePath string, n int64) (int64, error) {
+	segment, err := openSegment(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = segment.Close() }()
+	offset := segment.offset
+	reader := segment.reader(0)
+	for ; n > 0; n-- {
+		entryHeader, err := decodeWALMessageHeader(reader)
+		if err != nil {
+			return 0, err
+		}
+		if offset, err = reader.Seek(entryHeader.MessageLen, io.SeekCurrent); err != nil {
+			return 0, err
+		}
+	}
+	return offset, nil
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+const benchmarkBatchSize = 100
+
+func BenchmarkAlignedWAL_Write(b *testing.B) {
+	wal, err := NewFSManager(vi, WithStorePath(b.TempDir())).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = wal.Close() }()
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(benchmarkBatchSize, time.Unix(1665109020, 0), nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range writeMessages {
+			if err = wal.Write(context.Background(), &writeMessages[j]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkAlignedWAL_WriteBatch(b *testing.B) {
+	wal, err := NewFSManager(vi, WithStorePath(b.TempDir())).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = wal.Close() }()
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(benchmarkBatchSize, time.Unix(1665109020, 0), nil)
+	batch := make([]*isb.ReadMessage, len(writeMessages))
+	for j := range writeMessages {
+		batch[j] = &writeMessages[j]
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err = wal.WriteBatch(context.Background(), batch); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAlignedWAL_BufferPool compares the allocations of the writes with and without pooling the encode buffers.
+func BenchmarkAlignedWAL_BufferPool(b *testing.B) {
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(benchmarkBatchSize, time.Unix(1665109020, 0), nil)
+	for _, mode := range []struct {
+		name string
+		pool *aligned.BufferPool
+	}{
+		{name: "unpooled", pool: nil},
+		{name: "pooled", pool: aligned.NewBufferPool(aligned.DefaultMaxPooledBufferSize)},
+	} {
+		b.Run(mode.name, func(b *testing.B) {
+			w, err := NewFSManager(vi, WithStorePath(b.TempDir()), WithBufferPool(mode.pool)).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer func() { _ = w.Close() }()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := range writeMessages {
+					if err = w.Write(context.Background(), &writeMessages[j]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkAlignedWAL_Compression reports the on-disk bytes per message for every compression, on a dataset of small
+// and repetitive JSON messages. The segments are rotated, so that the sealed segments are compressed.
+func BenchmarkAlignedWAL_Compression(b *testing.B) {
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(benchmarkBatchSize, time.Unix(1665109020, 0), nil)
+	for j := range writeMessages {
+		writeMessages[j].Payload = []byte(fmt.Sprintf(`{"customer_id":"customer-%d","event":"page_view","region":"us-west-2",`+
+			`"attributes":{"browser":"chrome","os":"linux","campaign":"fall-sale","page":"/products/%d"}}`, j%10, j%5))
+	}
+	batch := make([]*isb.ReadMessage, len(writeMessages))
+	for j := range writeMessages {
+		batch[j] = &writeMessages[j]
+	}
+
+	for _, c := range []Compression{CompressionNone, CompressionGzip, CompressionSnappy, CompressionZstd} {
+		b.Run(c.String(), func(b *testing.B) {
+			w, err := NewFSManager(vi, WithStorePath(b.TempDir()), WithSegmentSize(1<<20), WithCompression(c)).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err = w.WriteBatch(context.Background(), batch); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			if err = w.Close(); err != nil {
+				b.Fatal(err)
+			}
+			var size int64
+			for _, segment := range w.(*alignedWAL).segments {
+				stat, err := os.Stat(segment)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size += stat.Size()
+			}
+			b.ReportMetric(float64(size)/float64(b.N*len(batch)), "disk-bytes/msg")
+		})
+	}
+}
+
+// BenchmarkAlignedWAL_ReplaySegment compares the replay of a large sealed segment with buffered reads and from a memory
+// mapping.
+func BenchmarkAlignedWAL_ReplaySegment(b *testing.B) {
+	w, err := NewFSManager(vi, WithStorePath(b.TempDir())).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(benchmarkBatchSize, time.Unix(1665109020, 0), nil)
+	batch := make([]*isb.ReadMessage, len(writeMessages))
+	for j := range writeMessages {
+		writeMessages[j].Payload = make([]byte, 1024)
+		batch[j] = &writeMessages[j]
+	}
+	// 100k messages of 1KiB
+	for i := 0; i < 1000; i++ {
+		if err = w.WriteBatch(context.Background(), batch); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		b.Fatal(err)
+	}
+	segment := w.(*alignedWAL).segments[0]
+
+	for _, mode := range []struct {
+		name          string
+		mmapReplay    bool
+		readBlockSize int64
+	}{{"buffered", false, 0}, {"mmap", true, 0}, {"block", false, 64 * 1024}} {
+		b.Run(mode.name, func(b *testing.B) {
+			reader := &alignedWAL{codec: aligned.ProtoCodec, mmapReplay: mode.mmapReplay, readBlockSize: mode.readBlockSize}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				messages := make(chan *isb.ReadMessage, benchmarkBatchSize)
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					for range messages {
+					}
+				}()
+				_, err := reader.replaySegment(segment, messages)
+				close(messages)
+				<-done
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// slowFile simulates a segment on a network backed volume, every read of the file costs a round trip.
+type slowFile struct {
+	*os.File
+	latency time.Duration
+	reads   int
+}
+
+func (f *slowFile) Read(p []byte) (int, error) {
+	f.reads++
+	time.Sleep(f.latency)
+	return f.File.Read(p)
+}
+
+func (f *slowFile) ReadAt(p []byte, off int64) (int, error) {
+	f.reads++
+	time.Sleep(f.latency)
+	return f.File.ReadAt(p, off)
+}
+
+func BenchmarkAlignedWAL_ReadBlockSize(b *testing.B) {
+	w, err := NewFSManager(vi, WithStorePath(b.TempDir())).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(benchmarkBatchSize, time.Unix(1665109020, 0), nil)
+	batch := make([]*isb.ReadMessage, len(writeMessages))
+	for j := range writeMessages {
+		writeMessages[j].Payload = make([]byte, 1024)
+		batch[j] = &writeMessages[j]
+	}
+	// 1k messages of 1KiB
+	for i := 0; i < 10; i++ {
+		if err = w.WriteBatch(context.Background(), batch); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		b.Fatal(err)
+	}
+	segment := w.(*alignedWAL).segments[0]
+
+	for _, blockSize := range []int64{0, 4 * 1024, 64 * 1024} {
+		b.Run(fmt.Sprintf("block-%d", blockSize), func(b *testing.B) {
+			var reads int
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				opened, err := openSegment(segment)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size, offset := opened.size, opened.offset
+				file := &slowFile{File: opened.fp, latency: 20 * time.Microsecond}
+				reader := segmentReader(file, offset, blockSize)
+				for offset < size {
+					_, sizeRead, err := decodeReadMessage(reader, aligned.ProtoCodec, size-offset)
+					if err != nil {
+						b.Fatal(err)
+					}
+					offset += sizeRead
+				}
+				reads += file.reads
+				_ = opened.Close()
+			}
+			b.ReportMetric(float64(reads)/float64(b.N), "reads/op")
+		})
+	}
+}
@@ -18,18 +18,24 @@ package fs
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash/crc32"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/metrics"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 )
 
 const (
@@ -40,12 +46,14 @@ const (
 
 // Various errors contained in DNSError.
 var (
-	errChecksumMismatch = fmt.Errorf("data checksum not match")
+	errChecksumMismatch = fmt.Errorf("data checksum not match, %w", aligned.ErrCorruptRecord)
+	// errMmapUnsupported is returned by mapSegment on the platforms without mmap.
+	errMmapUnsupported = errors.New("mmap is not supported on this platform")
 )
 
 // alignedWAL implements a write-ahead-log. It represents both reader and writer. This alignedWAL is write heavy and read is
-// infrequent, meaning a read will only happen during a boot up. alignedWAL will only have one segment since these are
-// relatively short-lived.
+// infrequent, meaning a read will only happen during a boot up. alignedWAL has only one segment unless a segment size is
+// configured, in which case the writer rotates to a new segment once the current one exceeds the segment size.
 type alignedWAL struct {
 	pipelineName      string
 	vertexName        string
@@ -62,6 +70,19 @@ type alignedWAL struct {
 	prevSyncedWOffset int64         // prevSyncedWOffset is the write offset that is already synced as tracked by the writer
 	prevSyncedTime    time.Time     // prevSyncedTime is the time when the last sync was made
 	numOfUnsyncedMsgs int64
+	codec             aligned.Codec       // codec is used to encode and decode the isb messages
+	bufferPool        *aligned.BufferPool // bufferPool pools the buffers which the messages are encoded into, nil disables the pooling.
+	segmentSize       int64               // segmentSize is the size after which the writer rotates to a new segment, 0 disables rotation.
+	segmentIndex      int                 // segmentIndex is the index of the segment that is being written to.
+	segments          []string            // segments are the file paths of the segments, oldest first, the last one is being written to.
+	segmentEntries    []int64             // segmentEntries is the number of entries in each of the segments.
+	compression       Compression         // compression is used to compress the segments once they are sealed.
+	syncPolicy        SyncPolicy          // syncPolicy decides when the written entries are synced to the disk.
+	syncJitter        float64             // syncJitter is the max fraction of the sync duration the first background sync is delayed by.
+	stopFlusher       context.CancelFunc  // stopFlusher stops the background flusher, nil if it is not running.
+	readBlockSize     int64               // readBlockSize is the size of the blocks the segments are read in, 0 disables the block reads.
+	mmapReplay        bool                // mmapReplay replays the sealed segments from a read-only memory mapping.
+	mu                sync.Mutex          // mu serializes the writes and the syncs of the background flusher.
 }
 
 // NewAlignedWriteOnlyWAL creates a new alignedWAL instance for write-only. This will be used in happy path where we are only
@@ -72,7 +93,14 @@ func NewAlignedWriteOnlyWAL(id *partition.ID,
 	syncDuration time.Duration,
 	pipelineName string,
 	vertexName string,
-	replica int32) (wal.WAL, error) {
+	replica int32,
+	codec aligned.Codec,
+	bufferPool *aligned.BufferPool,
+	segmentSize int64,
+	compression Compression,
+	syncPolicy SyncPolicy,
+	syncJitter float64,
+	readBlockSize int64) (wal.WAL, error) {
 
 	w := &alignedWAL{
 		pipelineName:      pipelineName,
@@ -88,6 +116,16 @@ func NewAlignedWriteOnlyWAL(id *partition.ID,
 		numOfUnsyncedMsgs: 0,
 		maxBatchSize:      maxBufferSize,
 		syncDuration:      syncDuration,
+		codec:             codec,
+		bufferPool:        bufferPool,
+		segmentSize:       segmentSize,
+		segmentIndex:      0,
+		segments:          []string{filePath},
+		segmentEntries:    []int64{0},
+		compression:       compression,
+		syncPolicy:        syncPolicy,
+		syncJitter:        syncJitter,
+		readBlockSize:     readBlockSize,
 	}
 
 	// here we are explicitly giving O_WRONLY because we will not be using this to read. Our read is only during
@@ -106,13 +144,22 @@ func NewAlignedWriteOnlyWAL(id *partition.ID,
 }
 
 // NewAlignedReadWriteWAL creates a new alignedWAL instance for read-write. This will be used during boot up where we will be replaying
-// the messages from the alignedWAL and then writing to it.
-func NewAlignedReadWriteWAL(filePath string,
+// the messages from the alignedWAL and then writing to it. segmentPaths are the segments of the same partition, oldest
+// first, the last segment is the one which will be written to after the replay.
+func NewAlignedReadWriteWAL(segmentPaths []string,
 	maxBufferSize int64,
 	syncDuration time.Duration,
 	pipelineName string,
 	vertexName string,
-	replica int32) (wal.WAL, error) {
+	replica int32,
+	codec aligned.Codec,
+	bufferPool *aligned.BufferPool,
+	segmentSize int64,
+	compression Compression,
+	syncPolicy SyncPolicy,
+	syncJitter float64,
+	readBlockSize int64,
+	mmapReplay bool) (wal.WAL, error) {
 	w := &alignedWAL{
 		pipelineName:      pipelineName,
 		vertexName:        vertexName,
@@ -126,6 +173,32 @@ func NewAlignedReadWriteWAL(filePath string,
 		numOfUnsyncedMsgs: 0,
 		maxBatchSize:      maxBufferSize,
 		syncDuration:      syncDuration,
+		codec:             codec,
+		bufferPool:        bufferPool,
+		segmentSize:       segmentSize,
+		segments:          segmentPaths,
+		segmentEntries:    make([]int64, 0, len(segmentPaths)),
+		compression:       compression,
+		syncPolicy:        syncPolicy,
+		syncJitter:        syncJitter,
+		readBlockSize:     readBlockSize,
+		mmapReplay:        mmapReplay,
+	}
+
+	filePath := segmentPaths[len(segmentPaths)-1]
+	// the last segment is written to after the replay, it is left compressed only by a crash right after a rotation
+	// which did not create the next segment.
+	if err := w.rewriteSegment(filePath, CompressionNone); err != nil {
+		return nil, err
+	}
+
+	// count the entries without decoding them, so that the size is known before the replay.
+	for _, segmentPath := range segmentPaths {
+		count, _, err := countEntries(segmentPath)
+		if err != nil {
+			return nil, err
+		}
+		w.segmentEntries = append(w.segmentEntries, count)
 	}
 
 	// here we are explicitly giving O_RDWR because we will be using this to read too. Our read is only during
@@ -136,12 +209,16 @@ func NewAlignedReadWriteWAL(filePath string,
 	}
 	w.fp = fp
 
-	// read the partition ID from the alignedWAL header and set it in the alignedWAL.
+	// read the partition ID from the alignedWAL header and set it in the alignedWAL
 	readPartition, err := w.readWALHeader()
 	if err != nil {
 		return nil, err
 	}
 	w.partitionID = readPartition
+	w.segmentIndex, err = parseSegmentIndex(readPartition, filePath)
+	if err != nil {
+		return nil, err
+	}
 
 	// set the read up to the end of the file.
 	stat, err := os.Stat(filePath)
@@ -170,7 +247,8 @@ func (w *alignedWAL) writeWALHeader() (err error) {
 			}).Inc()
 		}
 	}()
-	header, err := w.encodeWALHeader(w.partitionID)
+	// the segment being written to is never compressed, it is compressed as a whole once it is sealed
+	header, err := w.encodeWALHeader(w.partitionID, CompressionNone)
 	if err != nil {
 		return err
 	}
@@ -184,6 +262,19 @@ func (w *alignedWAL) writeWALHeader() (err error) {
 	return err
 }
 
+// walHeaderMagic starts the versioned header of a segment. The header of the segments written before the header was
+// versioned starts with the start time of the partition instead, which can never be the magic as a Unix milli time.
+const walHeaderMagic uint64 = 0xfe57414c4e554d41
+
+// walHeaderVersion is the version of the segment header which is written.
+const walHeaderVersion uint8 = 1
+
+// walHeaderVersioned follows the magic of a versioned header.
+type walHeaderVersioned struct {
+	Version     uint8
+	Compression Compression
+}
+
 // walHeaderPreamble is the header preamble (excludes variadic key)
 type walHeaderPreamble struct {
 	S    int64
@@ -202,12 +293,13 @@ type readMessageHeaderPreamble struct {
 // encodeWALHeader builds the alignedWAL header. alignedWAL header is per alignedWAL and has information to build the alignedWAL partition.
 // The header is of the following format.
 //
-//	+--------------------+------------------+------------------+-------------+
-//	| start time (int64) | end time (int64) | slot-len (int16) | slot []rune |
-//	+--------------------+------------------+------------------+-------------+
+//	+----------------+-----------------+---------------------+--------------------+------------------+------------------+-------------+
+//	| magic (uint64) | version (uint8) | compression (uint8) | start time (int64) | end time (int64) | slot-len (int16) | slot []rune |
+//	+----------------+-----------------+---------------------+--------------------+------------------+------------------+-------------+
 //
-// We require the slot-len because slot is variadic.
-func (w *alignedWAL) encodeWALHeader(id *partition.ID) (buf *bytes.Buffer, err error) {
+// We require the slot-len because slot is variadic. The compression is the compression of the entries which follow the
+// header, they are compressed as a whole.
+func (w *alignedWAL) encodeWALHeader(id *partition.ID, compression Compression) (buf *bytes.Buffer, err error) {
 	defer func() {
 		if err != nil {
 			walErrors.With(map[string]string{
@@ -226,6 +318,14 @@ func (w *alignedWAL) encodeWALHeader(id *partition.ID) (buf *bytes.Buffer, err e
 	}
 
 	// write the fixed values
+	err = binary.Write(buf, binary.LittleEndian, walHeaderMagic)
+	if err != nil {
+		return nil, err
+	}
+	err = binary.Write(buf, binary.LittleEndian, walHeaderVersioned{Version: walHeaderVersion, Compression: compression})
+	if err != nil {
+		return nil, err
+	}
 	err = binary.Write(buf, binary.LittleEndian, hp)
 	if err != nil {
 		return nil, err
@@ -242,8 +342,19 @@ func calculateChecksum(data []byte) uint32 {
 	return crc32.Checksum(data, crc32q)
 }
 
-// encodeWALMessage builds the alignedWAL message.
-func (w *alignedWAL) encodeWALMessage(message *isb.ReadMessage) (buf *bytes.Buffer, err error) {
+// encodeWALMessage builds the alignedWAL message into a buffer of the buffer pool, the caller should return the buffer to
+// the pool once it is written.
+func (w *alignedWAL) encodeWALMessage(message *isb.ReadMessage) (*bytes.Buffer, error) {
+	buf := w.bufferPool.Get()
+	if err := w.appendWALMessage(buf, message); err != nil {
+		w.bufferPool.Put(buf)
+		return nil, err
+	}
+	return buf, nil
+}
+
+// appendWALMessage appends the alignedWAL message to the buf.
+func (w *alignedWAL) appendWALMessage(buf *bytes.Buffer, message *isb.ReadMessage) (err error) {
 	defer func() {
 		if err != nil {
 			walErrors.With(map[string]string{
@@ -254,57 +365,38 @@ func (w *alignedWAL) encodeWALMessage(message *isb.ReadMessage) (buf *bytes.Buff
 			}).Inc()
 		}
 	}()
-	buf = new(bytes.Buffer)
-	body, err := w.encodeWALMessageBody(message)
+	scratch := w.bufferPool.Get()
+	defer w.bufferPool.Put(scratch)
+	// body may be backed by the scratch buffer, it is copied to the buf before the scratch buffer is returned
+	body, err := w.encodeWALMessageBody(scratch, message)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	checksum := calculateChecksum(body)
 
 	// Writes the message header
-	header, err := w.encodeWALMessageHeader(message, int64(len(body)), checksum)
-	if err != nil {
-		return nil, err
-	}
-	wrote, err := buf.Write(header.Bytes())
-	if err != nil {
-		return nil, err
-	}
-	if wrote != header.Len() {
-		return nil, fmt.Errorf("expected to write %d, but wrote only %d, %w", header.Len(), wrote, err)
+	if err = w.encodeWALMessageHeader(buf, message, int64(len(body)), checksum); err != nil {
+		return err
 	}
 
 	// Writes the message body
-	wrote, err = buf.Write(body)
+	wrote, err := buf.Write(body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if wrote != len(body) {
-		return nil, fmt.Errorf("expected to write %d, but wrote only %d, %w", header.Len(), wrote, err)
+		return fmt.Errorf("expected to write %d, but wrote only %d, %w", len(body), wrote, err)
 	}
-	return buf, nil
+	return nil
 }
 
-// encodeWALMessageHeader creates the header of the alignedWAL message. The header is mainly for checksum, verifying the
-// correctness of the WALMessage.
-func (w *alignedWAL) encodeWALMessageHeader(message *isb.ReadMessage, messageLen int64, checksum uint32) (*bytes.Buffer, error) {
+// encodeWALMessageHeader appends the header of the alignedWAL message to the buf. The header is mainly for checksum,
+// verifying the correctness of the WALMessage. The fields are appended one by one in the layout of
+// readMessageHeaderPreamble, which avoids the allocations of binary.Write.
+func (w *alignedWAL) encodeWALMessageHeader(buf *bytes.Buffer, message *isb.ReadMessage, messageLen int64, checksum uint32) error {
 	watermark := message.Watermark.UnixMilli()
 
 	offset, err := message.ReadOffset.Sequence()
-	if err != nil {
-		return nil, err
-	}
-
-	msgHeader := readMessageHeaderPreamble{
-		WaterMark:  watermark,
-		Offset:     offset,
-		MessageLen: messageLen,
-		Checksum:   checksum,
-	}
-
-	// write the fixed values
-	buf := new(bytes.Buffer)
-	err = binary.Write(buf, binary.LittleEndian, msgHeader)
 	if err != nil {
 		walErrors.With(map[string]string{
 			metrics.LabelPipeline:           w.pipelineName,
@@ -312,15 +404,24 @@ func (w *alignedWAL) encodeWALMessageHeader(message *isb.ReadMessage, messageLen
 			metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
 			labelErrorKind:                  "encodeWALMessageHeader",
 		}).Inc()
-		return nil, err
+		return err
 	}
-	return buf, nil
+
+	// write the fixed values
+	header := buf.AvailableBuffer()
+	header = binary.LittleEndian.AppendUint64(header, uint64(watermark))
+	header = binary.LittleEndian.AppendUint64(header, uint64(offset))
+	header = binary.LittleEndian.AppendUint64(header, uint64(messageLen))
+	header = binary.LittleEndian.AppendUint32(header, checksum)
+	_, err = buf.Write(header)
+	return err
 }
 
 // encodeWALMessageBody uses ReadMessage.Message field as the body of the alignedWAL message, encodes the
-// ReadMessage.Message, and returns.
-func (w *alignedWAL) encodeWALMessageBody(readMsg *isb.ReadMessage) ([]byte, error) {
-	msgBinary, err := readMsg.Message.MarshalBinary()
+// ReadMessage.Message into the scratch buffer using the codec of the alignedWAL, and returns. The returned bytes are
+// only valid until the scratch buffer is reused.
+func (w *alignedWAL) encodeWALMessageBody(scratch *bytes.Buffer, readMsg *isb.ReadMessage) ([]byte, error) {
+	err := aligned.EncodeTo(w.codec, scratch, &readMsg.Message)
 	if err != nil {
 		walErrors.With(map[string]string{
 			metrics.LabelPipeline:           w.pipelineName,
@@ -330,7 +431,7 @@ func (w *alignedWAL) encodeWALMessageBody(readMsg *isb.ReadMessage) ([]byte, err
 		}).Inc()
 		return nil, fmt.Errorf("encodeWALMessageBody encountered encode err: %w", err)
 	}
-	return msgBinary, nil
+	return scratch.Bytes(), nil
 }
 
 // Write writes the message to the alignedWAL. The format as follow is
@@ -339,8 +440,8 @@ func (w *alignedWAL) encodeWALMessageBody(readMsg *isb.ReadMessage) ([]byte, err
 //	| watermark (int64) | offset (int64) | msg-len (int64) | CRC (unit32) | message []byte |
 //	+-------------------+----------------+-----------------+--------------+----------------+
 //
-// CRC will be used for detecting ReadMessage corruptions.
-func (w *alignedWAL) Write(message *isb.ReadMessage) (err error) {
+// msg-len is the length prefix of the message and CRC will be used for detecting ReadMessage corruptions.
+func (w *alignedWAL) Write(_ context.Context, message *isb.ReadMessage) (err error) {
 	defer func() {
 		if err != nil {
 			walErrors.With(map[string]string{
@@ -349,10 +450,15 @@ func (w *alignedWAL) Write(message *isb.ReadMessage) (err error) {
 				metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
 				labelErrorKind:                  "write",
 			}).Inc()
+			aligned.RecordWriteError("fs", err)
 		}
 	}()
 	encodeStart := time.Now()
 	entry, err := w.encodeWALMessage(message)
+	if err == nil {
+		// the entry is copied to the file by the write, so the buffer is not retained past it
+		defer w.bufferPool.Put(entry)
+	}
 	entryEncodeLatency.With(map[string]string{
 		metrics.LabelPipeline:           w.pipelineName,
 		metrics.LabelVertex:             w.vertexName,
@@ -362,21 +468,77 @@ func (w *alignedWAL) Write(message *isb.ReadMessage) (err error) {
 		return err
 	}
 
+	return w.writeEntries(entry, 1)
+}
+
+// WriteBatch writes the messages to the alignedWAL. All the messages are encoded into a single buffer, and the buffer
+// is written using a single write call. The format of every message is the same as Write. Either all the messages
+// are written or none of them are.
+func (w *alignedWAL) WriteBatch(_ context.Context, messages []*isb.ReadMessage) (err error) {
+	defer func() {
+		if err != nil {
+			walErrors.With(map[string]string{
+				metrics.LabelPipeline:           w.pipelineName,
+				metrics.LabelVertex:             w.vertexName,
+				metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+				labelErrorKind:                  "writeBatch",
+			}).Inc()
+			aligned.RecordWriteError("fs", err)
+		}
+	}()
+	if len(messages) == 0 {
+		return nil
+	}
+	encodeStart := time.Now()
+	batch := w.bufferPool.Get()
+	defer w.bufferPool.Put(batch)
+	for _, message := range messages {
+		if err = w.appendWALMessage(batch, message); err != nil {
+			return err
+		}
+	}
+	entryEncodeLatency.With(map[string]string{
+		metrics.LabelPipeline:           w.pipelineName,
+		metrics.LabelVertex:             w.vertexName,
+		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+	}).Observe(float64(time.Since(encodeStart).Milliseconds()))
+
+	return w.writeEntries(batch, int64(len(messages)))
+}
+
+// writeEntries writes the encoded entries to the file at the current write offset, and syncs the file as per the
+// sync policy.
+func (w *alignedWAL) writeEntries(entries *bytes.Buffer, count int64) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// a discovered alignedWAL which was read with ReadAt instead of Replay has not positioned its writer yet
+	if w.wOffset == 0 {
+		if err = w.resumeWrites(); err != nil {
+			return err
+		}
+	}
+	// the flusher is started on the first write, so that it never runs alongside the replay
+	if w.syncPolicy == SyncInterval && w.syncDuration > 0 && w.stopFlusher == nil {
+		var ctx context.Context
+		ctx, w.stopFlusher = context.WithCancel(context.Background())
+		go w.runFlusher(ctx)
+	}
 	writeStart := time.Now()
-	wrote, err := w.fp.WriteAt(entry.Bytes(), w.wOffset)
+	wrote, err := w.fp.WriteAt(entries.Bytes(), w.wOffset)
 	entryWriteLatency.With(map[string]string{
 		metrics.LabelPipeline:           w.pipelineName,
 		metrics.LabelVertex:             w.vertexName,
 		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
 	}).Observe(float64(time.Since(writeStart).Milliseconds()))
-	if wrote != entry.Len() {
-		return fmt.Errorf("expected to write %d, but wrote only %d, %w", entry.Len(), wrote, err)
+	if wrote != entries.Len() {
+		return fmt.Errorf("expected to write %d, but wrote only %d, %w", entries.Len(), wrote, err)
 	}
 	if err != nil {
 		return err
 	}
 
-	w.numOfUnsyncedMsgs = w.numOfUnsyncedMsgs + 1
+	w.numOfUnsyncedMsgs = w.numOfUnsyncedMsgs + count
+	w.segmentEntries[len(w.segmentEntries)-1] += count
 	// Only increase the write offset when we successfully write for atomicity.
 	w.wOffset += int64(wrote)
 	entriesBytesCount.With(map[string]string{
@@ -388,25 +550,202 @@ func (w *alignedWAL) Write(message *isb.ReadMessage) (err error) {
 		metrics.LabelPipeline:           w.pipelineName,
 		metrics.LabelVertex:             w.vertexName,
 		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
-	}).Inc()
+	}).Add(float64(count))
 	currentTime := time.Now()
 
-	if w.wOffset-w.prevSyncedWOffset > w.maxBatchSize || currentTime.Sub(w.prevSyncedTime) > w.syncDuration {
-		w.prevSyncedWOffset = w.wOffset
-		w.prevSyncedTime = currentTime
-		fSyncStart := time.Now()
-		err = w.fp.Sync()
-		fileSyncWaitTime.With(map[string]string{
+	var needSync bool
+	switch w.syncPolicy {
+	case SyncAlways:
+		needSync = true
+	case SyncInterval:
+		needSync = w.wOffset-w.prevSyncedWOffset > w.maxBatchSize || currentTime.Sub(w.prevSyncedTime) > w.syncDuration
+	}
+	if needSync {
+		if err = w.sync(); err != nil {
+			return err
+		}
+	}
+
+	if w.segmentSize > 0 && w.wOffset >= w.segmentSize {
+		return w.rotate()
+	}
+	return nil
+}
+
+// resumeWrites positions the writer at the end of the entries of the segment being written to, which is otherwise done
+// by Replay. A torn last entry is truncated, so that the next write overwrites it. Caller should hold the lock.
+func (w *alignedWAL) resumeWrites() error {
+	_, end, err := countEntries(w.segments[len(w.segments)-1])
+	if err != nil {
+		return err
+	}
+	if err = w.fp.Truncate(end); err != nil {
+		return err
+	}
+	w.readUpTo = end
+	w.wOffset = end
+	w.prevSyncedWOffset = end
+	w.prevSyncedTime = time.Now()
+	return nil
+}
+
+// rotate closes the current segment and starts writing to a new segment. The closed segment is compressed once the
+// new segment is created, so that the segment being written to is always the last one.
+func (w *alignedWAL) rotate() (err error) {
+	defer func() {
+		if err != nil {
+			walErrors.With(map[string]string{
+				metrics.LabelPipeline:           w.pipelineName,
+				metrics.LabelVertex:             w.vertexName,
+				metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+				labelErrorKind:                  "rotate",
+			}).Inc()
+		}
+	}()
+	if err = w.fp.Sync(); err != nil {
+		return err
+	}
+	_ = w.fp.Close()
+
+	filePath := getRotatedSegmentFilePath(w.partitionID, filepath.Dir(w.segments[len(w.segments)-1]), w.segmentIndex+1)
+	fp, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	filesCount.With(map[string]string{
+		metrics.LabelPipeline:           w.pipelineName,
+		metrics.LabelVertex:             w.vertexName,
+		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+	}).Inc()
+
+	sealed := w.segments[len(w.segments)-1]
+	w.fp = fp
+	w.segmentIndex++
+	w.segments = append(w.segments, filePath)
+	w.segmentEntries = append(w.segmentEntries, 0)
+	w.wOffset = 0
+	w.prevSyncedWOffset = 0
+	w.prevSyncedTime = time.Now()
+	w.numOfUnsyncedMsgs = 0
+	if err = w.writeWALHeader(); err != nil {
+		return err
+	}
+
+	// the sealed segment is still valid if it cannot be compressed, so the rotation does not fail
+	if compressErr := w.rewriteSegment(sealed, w.compression); compressErr != nil {
+		walErrors.With(map[string]string{
 			metrics.LabelPipeline:           w.pipelineName,
 			metrics.LabelVertex:             w.vertexName,
 			metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
-		}).Observe(float64(time.Since(fSyncStart).Milliseconds()))
-		w.numOfUnsyncedMsgs = 0
+			labelErrorKind:                  "compressSegment",
+		}).Inc()
+	}
+	return nil
+}
+
+// sync syncs the file to the disk. Caller should hold the lock.
+func (w *alignedWAL) sync() error {
+	w.prevSyncedWOffset = w.wOffset
+	w.prevSyncedTime = time.Now()
+	fSyncStart := time.Now()
+	err := w.fp.Sync()
+	fileSyncWaitTime.With(map[string]string{
+		metrics.LabelPipeline:           w.pipelineName,
+		metrics.LabelVertex:             w.vertexName,
+		metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+	}).Observe(float64(time.Since(fSyncStart).Milliseconds()))
+	w.numOfUnsyncedMsgs = 0
+	return err
+}
+
+// Flush syncs the entries written so far to the disk, regardless of the sync policy.
+func (w *alignedWAL) Flush() (err error) {
+	defer func() {
+		if err != nil {
+			walErrors.With(map[string]string{
+				metrics.LabelPipeline:           w.pipelineName,
+				metrics.LabelVertex:             w.vertexName,
+				metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+				labelErrorKind:                  "flush",
+			}).Inc()
+		}
+	}()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.sync()
+}
+
+// Ping creates, syncs and removes a temporary file next to the segments, it fails if the directory is not writable.
+func (w *alignedWAL) Ping(_ context.Context) error {
+	w.mu.Lock()
+	dir := filepath.Dir(w.segments[len(w.segments)-1])
+	w.mu.Unlock()
+	return pingDir(dir)
+}
+
+// pingDir checks that the files can be written to the dir. The temporary file does not have the SegmentPrefix, so it
+// is never discovered as a segment even if it is left behind by a crash.
+func pingDir(dir string) error {
+	fp, err := os.CreateTemp(dir, ".ping-*")
+	if err != nil {
 		return err
 	}
+	defer func() { _ = os.Remove(fp.Name()) }()
+	if _, err = fp.Write([]byte{0}); err == nil {
+		err = fp.Sync()
+	}
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
 	return err
 }
 
+// runFlusher syncs the unsynced entries every syncDuration until the ctx is done, so that the tail of an idle
+// alignedWAL does not stay unsynced until the next write.
+func (w *alignedWAL) runFlusher(ctx context.Context) {
+	// the flushers of the WALs created at the same time would otherwise sync at the same time on every tick
+	if w.syncJitter > 0 {
+		delay := time.NewTimer(time.Duration(rand.Float64() * w.syncJitter * float64(w.syncDuration)))
+		select {
+		case <-ctx.Done():
+			delay.Stop()
+			return
+		case <-delay.C:
+		}
+	}
+	ticker := time.NewTicker(w.syncDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.wOffset > w.prevSyncedWOffset {
+				if err := w.sync(); err != nil {
+					walErrors.With(map[string]string{
+						metrics.LabelPipeline:           w.pipelineName,
+						metrics.LabelVertex:             w.vertexName,
+						metrics.LabelVertexReplicaIndex: strconv.Itoa(int(w.replicaIndex)),
+						labelErrorKind:                  "backgroundFlush",
+					}).Inc()
+				}
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// Size returns the number of entries in all the segments of the alignedWAL. The entries of a discovered alignedWAL are
+// counted from the entry headers, the count is corrected by the replay if the last entry turns out to be torn.
+func (w *alignedWAL) Size() int64 {
+	var size int64
+	for _, count := range w.segmentEntries {
+		size += count
+	}
+	return size
+}
+
 // Close closes the alignedWAL Segment.
 func (w *alignedWAL) Close() (err error) {
 	defer func() {
@@ -419,6 +758,11 @@ func (w *alignedWAL) Close() (err error) {
 			}).Inc()
 		}
 	}()
+	if w.stopFlusher != nil {
+		w.stopFlusher()
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	start := time.Now()
 	err = w.fp.Sync()
 	fileSyncWaitTime.With(map[string]string{
@@ -436,7 +780,33 @@ func (w *alignedWAL) Close() (err error) {
 	return nil
 }
 
+// getSegmentFilePath returns the file path of the first segment of the partition.
 func getSegmentFilePath(id *partition.ID, dir string) string {
 	filename := fmt.Sprintf("%s_%d.%d.%s", SegmentPrefix, id.Start.Unix(), id.End.Unix(), id.Slot)
 	return filepath.Join(dir, filename)
 }
+
+// getRotatedSegmentFilePath returns the file path of the segment at the given index of the partition. The first segment
+// keeps the name returned by getSegmentFilePath, the index is separated by "_" from the end time so that it cannot be
+// mistaken for a part of the slot.
+func getRotatedSegmentFilePath(id *partition.ID, dir string, index int) string {
+	if index == 0 {
+		return getSegmentFilePath(id, dir)
+	}
+	filename := fmt.Sprintf("%s_%d.%d_%d.%s", SegmentPrefix, id.Start.Unix(), id.End.Unix(), index, id.Slot)
+	return filepath.Join(dir, filename)
+}
+
+// parseSegmentIndex returns the index of the segment of the partition from its file path.
+func parseSegmentIndex(id *partition.ID, filePath string) (int, error) {
+	filename := filepath.Base(filePath)
+	if filename == filepath.Base(getSegmentFilePath(id, "")) {
+		return 0, nil
+	}
+	prefix := fmt.Sprintf("%s_%d.%d_", SegmentPrefix, id.Start.Unix(), id.End.Unix())
+	suffix := "." + id.Slot
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, suffix) || len(filename) < len(prefix)+len(suffix) {
+		return 0, fmt.Errorf("segment %s does not belong to partition %s", filePath, id.String())
+	}
+	return strconv.Atoi(filename[len(prefix) : len(filename)-len(suffix)])
+}
@@ -20,6 +20,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/numaproj/numaflow/pkg/isb/testutils"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 )
 
 var vi = &dfv1.VertexInstance{
@@ -64,7 +68,7 @@ func Test_writeReadHeader(t *testing.T) {
 	fmt.Println(fName)
 	assert.NoError(t, err)
 
-	openWAL, err := NewAlignedWriteOnlyWAL(&id, fName, dfv1.DefaultWALMaxSyncSize, dfv1.DefaultWALSyncDuration, "testPipeline", "testVertex", 0)
+	openWAL, err := NewAlignedWriteOnlyWAL(&id, fName, dfv1.DefaultWALMaxSyncSize, dfv1.DefaultWALSyncDuration, "testPipeline", "testVertex", 0, aligned.ProtoCodec, aligned.DefaultBufferPool, 0, CompressionNone, SyncInterval, 0, 0)
 	assert.NoError(t, err)
 	// we have already read the header in OpenWAL
 	_, err = openWAL.(*alignedWAL).readWALHeader()
@@ -110,11 +114,11 @@ func Test_encodeDecodeHeader(t *testing.T) {
 			wal, err := stores.CreateWAL(context.Background(), *tt.id)
 			assert.NoError(t, err)
 			newWal := wal.(*alignedWAL)
-			got, err := newWal.encodeWALHeader(tt.id)
+			got, err := newWal.encodeWALHeader(tt.id, CompressionNone)
 			if !tt.wantErr(t, err, fmt.Sprintf("encodeWALHeader(%v)", tt.id)) {
 				return
 			}
-			result, err := decodeWALHeader(got)
+			result, _, err := decodeWALHeader(got)
 			assert.NoError(t, err)
 			assert.Equalf(t, tt.id, result, "encodeWALHeader(%v)", tt.id)
 			err = newWal.Close()
@@ -139,7 +143,7 @@ func Test_writeReadEntry(t *testing.T) {
 	msgCount := 2
 	writeMessages := testutils.BuildTestReadMessagesIntOffset(int64(msgCount), startTime, nil)
 	message := writeMessages[0]
-	err = wal.Write(&message)
+	err = wal.Write(context.Background(), &message)
 	assert.NoError(t, err)
 	err = wal.Close()
 	assert.NoError(t, err)
@@ -188,7 +192,7 @@ outerLoop:
 	assert.Equalf(t, message.Watermark, actualMessage.Watermark, "encodeWALMessage(%v)", message.Watermark)
 
 	// Start to write an entry again
-	err = newWal.Write(&message)
+	err = newWal.Write(context.Background(), &message)
 	assert.NoError(t, err)
 	err = newWal.Close()
 	assert.NoError(t, err)
@@ -235,7 +239,7 @@ func Test_encodeDecodeEntry(t *testing.T) {
 				return
 			}
 
-			result, _, err := decodeReadMessage(bytes.NewReader(got.Bytes()))
+			result, _, err := decodeReadMessage(bytes.NewReader(got.Bytes()), aligned.ProtoCodec, int64(got.Len()))
 			assert.NoError(t, err)
 			assert.Equalf(t, tt.message.Message, result.Message, "encodeWALMessage(%v)", tt.message.Message)
 			expectedOffset, err := tt.message.ReadOffset.Sequence()
@@ -270,7 +274,7 @@ func Test_batchSyncWithMaxBatchSize(t *testing.T) {
 	msgCount := 2
 	writeMessages := testutils.BuildTestReadMessagesIntOffset(int64(msgCount), startTime, nil)
 	message := writeMessages[0]
-	err = wal.Write(&message)
+	err = wal.Write(context.Background(), &message)
 	assert.NoError(t, err)
 
 	assert.Equal(t, int64(0), tempWAL.prevSyncedWOffset)
@@ -278,9 +282,9 @@ func Test_batchSyncWithMaxBatchSize(t *testing.T) {
 
 	tempWAL.maxBatchSize = 10
 	assert.NoError(t, err)
-	err = wal.Write(&message)
+	err = wal.Write(context.Background(), &message)
 	assert.NoError(t, err)
-	assert.Equal(t, int64(288), tempWAL.prevSyncedWOffset)
+	assert.Equal(t, int64(298), tempWAL.prevSyncedWOffset)
 
 	err = wal.Close()
 	assert.NoError(t, err)
@@ -329,7 +333,7 @@ outerLoop:
 	assert.Equalf(t, message.Watermark, actualMessage.Watermark, "encodeWALMessage(%v)", message.Watermark)
 
 	// Start to write an entry again
-	err = newWal.Write(&message)
+	err = newWal.Write(context.Background(), &message)
 	assert.NoError(t, err)
 	err = newWal.Close()
 	assert.NoError(t, err)
@@ -350,6 +354,7 @@ func Test_batchSyncWithSyncDuration(t *testing.T) {
 		pipelineName: vi.Vertex.Spec.PipelineName,
 		vertexName:   vi.Vertex.Spec.AbstractVertex.Name,
 		replicaIndex: vi.Replica,
+		codec:        aligned.ProtoCodec,
 		activeWals:   make(map[string]wal.WAL),
 	}
 
@@ -363,15 +368,15 @@ func Test_batchSyncWithSyncDuration(t *testing.T) {
 	writeMessages := testutils.BuildTestReadMessagesIntOffset(int64(msgCount), startTime, nil)
 	message := writeMessages[0]
 	storePrevSyncedTime := tempWAL.prevSyncedTime
-	err = wal.Write(&message)
-	assert.Equal(t, int64(163), tempWAL.prevSyncedWOffset)
+	err = wal.Write(context.Background(), &message)
+	assert.Equal(t, int64(173), tempWAL.prevSyncedWOffset)
 	assert.NotEqual(t, storePrevSyncedTime, tempWAL.prevSyncedTime)
 	assert.NoError(t, err)
 
 	storePrevSyncedTime = tempWAL.prevSyncedTime
 	tempWAL.syncDuration = 10 * time.Second
 	assert.NoError(t, err)
-	err = wal.Write(&message)
+	err = wal.Write(context.Background(), &message)
 	assert.NoError(t, err)
 	assert.Equal(t, tempWAL.prevSyncedTime, storePrevSyncedTime)
 
@@ -421,8 +426,816 @@ outerLoop:
 	assert.Equalf(t, message.Watermark, actualMessage.Watermark, "encodeWALMessage(%v)", message.Watermark)
 
 	// Start to write an entry again
-	err = newWal.Write(&message)
+	err = newWal.Write(context.Background(), &message)
 	assert.NoError(t, err)
 	err = newWal.Close()
 	assert.NoError(t, err)
 }
+
+func Test_writeReadEntryWithJSONCodec(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	stores := NewFSManager(vi, WithStorePath(tmp), WithCodec(aligned.JSONCodec))
+	wal, err := stores.CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+
+	startTime := time.Unix(1665109020, 0).In(location)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(3, startTime, nil)
+	for _, message := range writeMessages {
+		err = wal.Write(context.Background(), &message)
+		assert.NoError(t, err)
+	}
+	err = wal.Close()
+	assert.NoError(t, err)
+
+	// Reopen the alignedWAL with the same codec and replay.
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithCodec(aligned.JSONCodec)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+
+	msgCh, errCh := discoveredStores[0].Replay()
+	actualMessages := make([]*isb.ReadMessage, 0)
+outerLoop:
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if msg != nil {
+				actualMessages = append(actualMessages, msg)
+			}
+			if !ok {
+				break outerLoop
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				break outerLoop
+			}
+			assert.NoError(t, err)
+			break outerLoop
+		}
+	}
+
+	assert.Len(t, actualMessages, len(writeMessages))
+	for i, actualMessage := range actualMessages {
+		assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_writeBatchReplay(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	wal, err := NewFSManager(vi, WithStorePath(tmp)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	batch := make([]*isb.ReadMessage, len(writeMessages))
+	for i := range writeMessages {
+		batch[i] = &writeMessages[i]
+	}
+	assert.NoError(t, wal.WriteBatch(context.Background(), batch[:4]))
+	assert.NoError(t, wal.WriteBatch(context.Background(), batch[4:]))
+	assert.NoError(t, wal.Close())
+
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+
+	msgCh, errCh := discoveredStores[0].Replay()
+	actualMessages := make([]*isb.ReadMessage, 0)
+outerLoop:
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if msg != nil {
+				actualMessages = append(actualMessages, msg)
+			}
+			if !ok {
+				break outerLoop
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				break outerLoop
+			}
+			assert.NoError(t, err)
+			break outerLoop
+		}
+	}
+
+	assert.Len(t, actualMessages, len(writeMessages))
+	for i, actualMessage := range actualMessages {
+		assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_segmentRotationReplay(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	wal, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages {
+		assert.NoError(t, wal.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.NoError(t, wal.Close())
+
+	files, err := os.ReadDir(tmp)
+	assert.NoError(t, err)
+	assert.Greater(t, len(files), 2)
+
+	stores := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320))
+	discoveredStores, err := stores.DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	newWal := discoveredStores[0].(*alignedWAL)
+	assert.Len(t, newWal.segments, len(files))
+
+	msgCh, errCh := newWal.Replay()
+	actualMessages := make([]*isb.ReadMessage, 0)
+outerLoop:
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if msg != nil {
+				actualMessages = append(actualMessages, msg)
+			}
+			if !ok {
+				break outerLoop
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				break outerLoop
+			}
+			assert.NoError(t, err)
+			break outerLoop
+		}
+	}
+
+	// all the messages across the segments are replayed in order
+	assert.Len(t, actualMessages, len(writeMessages))
+	for i, actualMessage := range actualMessages {
+		assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+	}
+
+	// writes after the replay keep rotating from the last segment index
+	for i := range writeMessages {
+		assert.NoError(t, newWal.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.NoError(t, newWal.Close())
+	assert.Greater(t, len(newWal.segments), len(files))
+
+	assert.NoError(t, stores.DeleteWAL(id))
+	files, err = os.ReadDir(tmp)
+	assert.NoError(t, err)
+	assert.Len(t, files, 0)
+}
+
+func Test_mmapReplay(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	wal, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages {
+		assert.NoError(t, wal.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.NoError(t, wal.Close())
+
+	// the sealed segments are replayed from the mapping, the segment being written to with buffered reads
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320), WithMmapReplay()).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	assert.Greater(t, len(discoveredStores[0].(*alignedWAL).segments), 1)
+
+	msgCh, errCh := discoveredStores[0].Replay()
+	actualMessages := make([]*isb.ReadMessage, 0)
+	for msgCh != nil {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				msgCh = nil
+				continue
+			}
+			actualMessages = append(actualMessages, msg)
+		case err := <-errCh:
+			assert.NoError(t, err)
+		}
+	}
+	assert.Len(t, actualMessages, len(writeMessages))
+	for i, actualMessage := range actualMessages {
+		assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_parseSegmentIndex(t *testing.T) {
+	id := &partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "slot_1.2",
+	}
+	for _, index := range []int{0, 1, 12} {
+		got, err := parseSegmentIndex(id, getRotatedSegmentFilePath(id, "/tmp", index))
+		assert.NoError(t, err)
+		assert.Equal(t, index, got)
+	}
+	_, err := parseSegmentIndex(id, getSegmentFilePath(&partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "other"}, "/tmp"))
+	assert.Error(t, err)
+}
+
+func Test_replayCorruptedEntry(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(3, time.Unix(1665109020, 0).In(location), nil)
+
+	// writeWAL writes the messages and returns the path of the segment and the offset at which every entry ends.
+	writeWAL := func(t *testing.T, dir string) (string, []int64) {
+		wal, err := NewFSManager(vi, WithStorePath(dir)).CreateWAL(context.Background(), id)
+		assert.NoError(t, err)
+		entryEnds := make([]int64, 0, len(writeMessages))
+		for i := range writeMessages {
+			assert.NoError(t, wal.Write(context.Background(), &writeMessages[i]))
+			entryEnds = append(entryEnds, wal.(*alignedWAL).wOffset)
+		}
+		assert.NoError(t, wal.Close())
+		return getSegmentFilePath(&id, dir), entryEnds
+	}
+
+	// replayWAL replays the discovered WAL and returns the replayed messages and the first error.
+	replayWAL := func(t *testing.T, dir string) (*alignedWAL, []*isb.ReadMessage, error) {
+		discoveredStores, err := NewFSManager(vi, WithStorePath(dir)).DiscoverWALs(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, discoveredStores, 1)
+		msgCh, errCh := discoveredStores[0].Replay()
+		actualMessages := make([]*isb.ReadMessage, 0)
+		for {
+			select {
+			case msg, ok := <-msgCh:
+				if !ok {
+					return discoveredStores[0].(*alignedWAL), actualMessages, nil
+				}
+				actualMessages = append(actualMessages, msg)
+			case err := <-errCh:
+				return discoveredStores[0].(*alignedWAL), actualMessages, err
+			}
+		}
+	}
+
+	t.Run("corrupted entry in the middle", func(t *testing.T) {
+		tmp := t.TempDir()
+		filePath, entryEnds := writeWAL(t, tmp)
+
+		// flip the last byte of the second entry
+		data, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		data[entryEnds[1]-1] ^= 0xff
+		assert.NoError(t, os.WriteFile(filePath, data, 0644))
+
+		newWal, actualMessages, err := replayWAL(t, tmp)
+		assert.ErrorIs(t, err, aligned.ErrCorruptRecord)
+		assert.True(t, newWal.IsCorrupted())
+		assert.Len(t, actualMessages, 1)
+		assert.NoError(t, newWal.Close())
+	})
+
+	t.Run("corrupted entry at the tail", func(t *testing.T) {
+		tmp := t.TempDir()
+		filePath, entryEnds := writeWAL(t, tmp)
+
+		// flip the last byte of the last entry
+		data, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		data[entryEnds[2]-1] ^= 0xff
+		assert.NoError(t, os.WriteFile(filePath, data, 0644))
+
+		newWal, actualMessages, err := replayWAL(t, tmp)
+		assert.NoError(t, err)
+		assert.False(t, newWal.IsCorrupted())
+		assert.Len(t, actualMessages, 2)
+		assert.Equal(t, entryEnds[1], newWal.wOffset)
+		assert.NoError(t, newWal.Close())
+	})
+
+	t.Run("torn entry at the tail", func(t *testing.T) {
+		tmp := t.TempDir()
+		filePath, entryEnds := writeWAL(t, tmp)
+
+		// drop the last few bytes of the last entry as if the write was interrupted
+		assert.NoError(t, os.Truncate(filePath, entryEnds[2]-5))
+
+		newWal, actualMessages, err := replayWAL(t, tmp)
+		assert.NoError(t, err)
+		assert.Len(t, actualMessages, 2)
+
+		// the torn entry is overwritten by the next write
+		assert.NoError(t, newWal.Write(context.Background(), &writeMessages[2]))
+		assert.NoError(t, newWal.Close())
+
+		newWal, actualMessages, err = replayWAL(t, tmp)
+		assert.NoError(t, err)
+		assert.Len(t, actualMessages, len(writeMessages))
+		for i, actualMessage := range actualMessages {
+			assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+		}
+		assert.NoError(t, newWal.Close())
+	})
+}
+
+func Test_size(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	wal, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), wal.Size())
+
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages {
+		assert.NoError(t, wal.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.Equal(t, int64(10), wal.Size())
+	assert.NoError(t, wal.Close())
+
+	// the size of a discovered WAL is known before the replay
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	newWal := discoveredStores[0].(*alignedWAL)
+	assert.Equal(t, int64(10), newWal.Size())
+
+	// replaying does not change the size
+	msgCh, errCh := newWal.Replay()
+	replayed := 0
+outerLoop:
+	for {
+		select {
+		case _, ok := <-msgCh:
+			if !ok {
+				break outerLoop
+			}
+			replayed++
+		case err := <-errCh:
+			assert.NoError(t, err)
+			break outerLoop
+		}
+	}
+	assert.Equal(t, 10, replayed)
+	assert.Equal(t, int64(10), newWal.Size())
+
+	assert.NoError(t, newWal.Write(context.Background(), &writeMessages[0]))
+	assert.Equal(t, int64(11), newWal.Size())
+	assert.NoError(t, newWal.Close())
+}
+
+func Test_syncPolicy(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+
+	t.Run("never", func(t *testing.T) {
+		tmp := t.TempDir()
+		store, err := NewFSManager(vi, WithStorePath(tmp), WithSyncPolicy(SyncNever)).CreateWAL(context.Background(), id)
+		assert.NoError(t, err)
+		w := store.(*alignedWAL)
+		for i := range writeMessages {
+			assert.NoError(t, w.Write(context.Background(), &writeMessages[i]))
+		}
+		// nothing is synced until the explicit flush
+		assert.Equal(t, int64(10), w.numOfUnsyncedMsgs)
+		assert.NoError(t, w.Flush())
+		assert.Equal(t, int64(0), w.numOfUnsyncedMsgs)
+		assert.Equal(t, w.wOffset, w.prevSyncedWOffset)
+		assert.NoError(t, w.Close())
+
+		discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSyncPolicy(SyncNever)).DiscoverWALs(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, discoveredStores, 1)
+		actualMessages := replayAll(t, discoveredStores[0])
+		assert.Len(t, actualMessages, len(writeMessages))
+		for i, actualMessage := range actualMessages {
+			assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+		}
+		assert.NoError(t, discoveredStores[0].Close())
+	})
+
+	t.Run("always", func(t *testing.T) {
+		store, err := NewFSManager(vi, WithStorePath(t.TempDir()), WithSyncPolicy(SyncAlways), WithMaxBufferSize(1<<20), WithSyncDuration(time.Hour)).CreateWAL(context.Background(), id)
+		assert.NoError(t, err)
+		w := store.(*alignedWAL)
+		for i := range writeMessages {
+			assert.NoError(t, w.Write(context.Background(), &writeMessages[i]))
+			assert.Equal(t, int64(0), w.numOfUnsyncedMsgs)
+		}
+		assert.NoError(t, w.Close())
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		store, err := NewFSManager(vi, WithStorePath(t.TempDir()), WithMaxBufferSize(1<<20), WithSyncDuration(50*time.Millisecond)).CreateWAL(context.Background(), id)
+		assert.NoError(t, err)
+		w := store.(*alignedWAL)
+		// the first write syncs since nothing has been synced yet
+		for i := range writeMessages {
+			assert.NoError(t, w.Write(context.Background(), &writeMessages[i]))
+		}
+		// the background flusher syncs the idle alignedWAL
+		assert.Eventually(t, func() bool {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			return w.numOfUnsyncedMsgs == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.NoError(t, w.Close())
+	})
+
+	t.Run("interval with jitter", func(t *testing.T) {
+		store, err := NewFSManager(vi, WithStorePath(t.TempDir()), WithMaxBufferSize(1<<20), WithSyncDuration(50*time.Millisecond), WithSyncJitter(1)).CreateWAL(context.Background(), id)
+		assert.NoError(t, err)
+		w := store.(*alignedWAL)
+		for i := range writeMessages {
+			assert.NoError(t, w.Write(context.Background(), &writeMessages[i]))
+		}
+		// the background flusher still syncs the idle alignedWAL after the delayed start
+		assert.Eventually(t, func() bool {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			return w.numOfUnsyncedMsgs == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.NoError(t, w.Close())
+	})
+}
+
+func Test_readAt(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	// a small segment size makes the reads span multiple segments
+	store, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.Greater(t, len(store.(*alignedWAL).segments), 2)
+
+	check := func(reader wal.OffsetReader) {
+		// offset 0
+		msgs, eof, err := reader.ReadAt(0, 4)
+		assert.NoError(t, err)
+		assert.False(t, eof)
+		assert.Len(t, msgs, 4)
+		for i, msg := range msgs {
+			assert.Equal(t, writeMessages[i].Message, msg.Message)
+		}
+
+		// mid-store across the segments, the size is capped at the end
+		msgs, eof, err = reader.ReadAt(3, 100)
+		assert.NoError(t, err)
+		assert.True(t, eof)
+		assert.Len(t, msgs, 7)
+		for i, msg := range msgs {
+			assert.Equal(t, writeMessages[3+i].Message, msg.Message)
+		}
+
+		// at the end
+		msgs, eof, err = reader.ReadAt(10, 1)
+		assert.NoError(t, err)
+		assert.True(t, eof)
+		assert.Len(t, msgs, 0)
+
+		// past the end
+		_, _, err = reader.ReadAt(11, 1)
+		assert.ErrorAs(t, err, &wal.OffsetOutOfRangeErr{})
+	}
+
+	// the segments being written to
+	check(store.(wal.OffsetReader))
+	assert.NoError(t, store.Close())
+
+	// a discovered alignedWAL before and after the replay
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	check(discoveredStores[0].(wal.OffsetReader))
+	assert.Len(t, replayAll(t, discoveredStores[0]), len(writeMessages))
+	check(discoveredStores[0].(wal.OffsetReader))
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_readBlockSize(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+
+	// the block sizes smaller than an entry and not aligned to the entries make the entries span the blocks
+	for _, blockSize := range []int64{1, 7, 64, 4096} {
+		t.Run(fmt.Sprintf("block-%d", blockSize), func(t *testing.T) {
+			tmp := t.TempDir()
+			newManager := func() wal.Manager {
+				return NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320), WithReadBlockSize(blockSize))
+			}
+			store, err := newManager().CreateWAL(context.Background(), id)
+			assert.NoError(t, err)
+			for i := range writeMessages {
+				assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+			}
+			segments := store.(*alignedWAL).segments
+			assert.Greater(t, len(segments), 2)
+
+			msgs, eof, err := store.(wal.OffsetReader).ReadAt(3, 4)
+			assert.NoError(t, err)
+			assert.False(t, eof)
+			assert.Len(t, msgs, 4)
+			for i, msg := range msgs {
+				assert.Equal(t, writeMessages[3+i].Message, msg.Message)
+			}
+			assert.NoError(t, store.Close())
+
+			// the torn last entry of a discovered alignedWAL ends the replay
+			stat, err := os.Stat(segments[len(segments)-1])
+			assert.NoError(t, err)
+			assert.NoError(t, os.Truncate(segments[len(segments)-1], stat.Size()-5))
+			discoveredStores, err := newManager().DiscoverWALs(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, discoveredStores, 1)
+			readMessages := replayAll(t, discoveredStores[0])
+			assert.Len(t, readMessages, len(writeMessages)-1)
+			for i, msg := range readMessages {
+				assert.Equal(t, writeMessages[i].Message, msg.Message)
+			}
+			// the writes resume at the end of the replay
+			assert.NoError(t, discoveredStores[0].Write(context.Background(), &writeMessages[9]))
+			dumped, err := wal.Dump(context.Background(), newManager(), id)
+			assert.NoError(t, err)
+			assert.Len(t, dumped, len(writeMessages))
+			for i, msg := range dumped {
+				assert.Equal(t, writeMessages[i].Message, msg.Message)
+			}
+			assert.NoError(t, discoveredStores[0].Close())
+		})
+	}
+}
+
+func Test_writeAfterReadAt(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	store, err := NewFSManager(vi, WithStorePath(tmp)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages[:5] {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+	}
+	assert.NoError(t, store.Close())
+
+	// the discovered alignedWAL is read with ReadAt instead of Replay before the writes resume
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	msgs, eof, err := discoveredStores[0].(wal.OffsetReader).ReadAt(0, 10)
+	assert.NoError(t, err)
+	assert.True(t, eof)
+	assert.Len(t, msgs, 5)
+	for i := range writeMessages[5:] {
+		assert.NoError(t, discoveredStores[0].Write(context.Background(), &writeMessages[5+i]))
+	}
+	assert.Equal(t, int64(10), discoveredStores[0].Size())
+	assert.NoError(t, discoveredStores[0].Close())
+
+	discoveredStores, err = NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	readMessages := replayAll(t, discoveredStores[0])
+	assert.Len(t, readMessages, len(writeMessages))
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Message, msg.Message)
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_iterator(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	// a small segment size makes the iterator span multiple segments
+	store, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+	}
+	segments := store.(*alignedWAL).segments
+	assert.Greater(t, len(segments), 2)
+
+	iterateAll := func(iterable wal.Iterable) []*isb.ReadMessage {
+		it, err := iterable.Iterator()
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, it.Close()) }()
+		messages := make([]*isb.ReadMessage, 0)
+		for {
+			msg, err := it.Next()
+			if err == io.EOF {
+				return messages
+			}
+			assert.NoError(t, err)
+			messages = append(messages, msg)
+		}
+	}
+
+	// the segments being written to
+	readMessages := iterateAll(store.(wal.Iterable))
+	assert.Len(t, readMessages, len(writeMessages))
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Message, msg.Message)
+	}
+	assert.NoError(t, store.Close())
+
+	// the torn last entry of a discovered alignedWAL ends the iteration
+	stat, err := os.Stat(segments[len(segments)-1])
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(segments[len(segments)-1], stat.Size()-5))
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	readMessages = iterateAll(discoveredStores[0].(wal.Iterable))
+	assert.Len(t, readMessages, len(writeMessages)-1)
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Message, msg.Message)
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_truncate(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	// a small segment size makes the truncation delete the newest segments
+	store, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(11, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages[:10] {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+	}
+	segments := len(store.(*alignedWAL).segments)
+	assert.Greater(t, segments, 2)
+
+	truncater := store.(wal.Truncater)
+	assert.ErrorAs(t, truncater.Truncate(11), &wal.OffsetOutOfRangeErr{})
+	assert.NoError(t, truncater.Truncate(5))
+	assert.Equal(t, int64(5), store.Size())
+	assert.Less(t, len(store.(*alignedWAL).segments), segments)
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(store.(*alignedWAL).segments[0]), SegmentPrefix+"*"))
+	assert.NoError(t, err)
+	assert.Len(t, files, len(store.(*alignedWAL).segments))
+
+	// the reads beyond the new end find the end of the alignedWAL
+	msgs, eof, err := store.(wal.OffsetReader).ReadAt(5, 1)
+	assert.NoError(t, err)
+	assert.True(t, eof)
+	assert.Len(t, msgs, 0)
+	assert.NoError(t, store.Close())
+
+	// only the kept messages are replayed after a restart
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	readMessages := replayAll(t, discoveredStores[0])
+	assert.Len(t, readMessages, 5)
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Message, msg.Message)
+	}
+
+	// the next write follows the kept messages
+	assert.NoError(t, discoveredStores[0].Write(context.Background(), &writeMessages[10]))
+	assert.NoError(t, discoveredStores[0].Close())
+	discoveredStores, err = NewFSManager(vi, WithStorePath(tmp), WithSegmentSize(320)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	readMessages = replayAll(t, discoveredStores[0])
+	assert.Len(t, readMessages, 6)
+	assert.Equal(t, writeMessages[10].Message, readMessages[5].Message)
+	assert.NoError(t, discoveredStores[0].Close())
+}
+
+func Test_snapshot(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	// a small segment size makes the snapshot span the sealed segments and the active one
+	store, err := NewFSManager(vi, WithStorePath(t.TempDir()), WithSegmentSize(320)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+	defer func() { _ = store.Close() }()
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(20, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages[:10] {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+	}
+
+	snapshot, err := store.(wal.Snapshotter).Snapshot()
+	assert.NoError(t, err)
+	for i := range writeMessages[10:] {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[10+i]))
+	}
+
+	// the writes after the snapshot do not change it
+	assert.Len(t, snapshot, 10)
+	for i, msg := range snapshot {
+		assert.Equal(t, writeMessages[i].Message, *msg)
+	}
+	snapshot, err = store.(wal.Snapshotter).Snapshot()
+	assert.NoError(t, err)
+	assert.Len(t, snapshot, len(writeMessages))
+}
+
+func Test_bufferPool(t *testing.T) {
+	id := partition.ID{
+		Start: time.Unix(1665109020, 0).In(location),
+		End:   time.Unix(1665109020, 0).Add(time.Minute).In(location),
+		Slot:  "test1",
+	}
+
+	tmp := t.TempDir()
+	pool := aligned.NewBufferPool(0)
+	store, err := NewFSManager(vi, WithStorePath(tmp), WithBufferPool(pool)).CreateWAL(context.Background(), id)
+	assert.NoError(t, err)
+
+	// scribbling over the pooled buffers after every write must not change what was written
+	scribble := func() {
+		for i := 0; i < 3; i++ {
+			buf := pool.Get()
+			buf.Write(bytes.Repeat([]byte{0xff}, buf.Cap()+1))
+			defer pool.Put(buf)
+		}
+	}
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(1665109020, 0).In(location), nil)
+	for i := range writeMessages[:5] {
+		assert.NoError(t, store.Write(context.Background(), &writeMessages[i]))
+		scribble()
+	}
+	batch := make([]*isb.ReadMessage, 0, 5)
+	for i := range writeMessages[5:] {
+		batch = append(batch, &writeMessages[5+i])
+	}
+	assert.NoError(t, store.WriteBatch(context.Background(), batch))
+	scribble()
+	assert.NoError(t, store.Close())
+
+	discoveredStores, err := NewFSManager(vi, WithStorePath(tmp)).DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 1)
+	actualMessages := replayAll(t, discoveredStores[0])
+	assert.Len(t, actualMessages, len(writeMessages))
+	for i, actualMessage := range actualMessages {
+		assert.Equal(t, writeMessages[i].Message, actualMessage.Message)
+	}
+	assert.NoError(t, discoveredStores[0].Close())
+}
@@ -0,0 +1,20 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jetstream implements write-ahead-log on a NATS JetStream stream, so that the persisted messages of a
+// partition survive the rescheduling of the pod. Every partition is persisted on its own subject of the stream, replay
+// reads the subject with an ordered consumer, and deleting the WAL of a partition purges its subject.
+package jetstream
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	natslib "github.com/nats-io/nats.go"
+	jetstreamlib "github.com/nats-io/nats.go/jetstream"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+const (
+	// defaultStreamName is the default name of the stream which holds the partitions.
+	defaultStreamName = "numaflow-pbq"
+	// defaultSubjectPrefix is the default prefix of the partition subjects.
+	defaultSubjectPrefix = "numaflow-pbq"
+)
+
+func init() {
+	if err := wal.RegisterStoreType(string(dfv1.JetStreamType), newRegisteredManager); err != nil {
+		panic(err)
+	}
+}
+
+type jetStreamManager struct {
+	url             string
+	connectOpts     []natslib.Option
+	streamConfig    jetstreamlib.StreamConfig
+	subjectPrefix   string
+	replayBatchSize int
+	conn            *natslib.Conn
+	js              jetstreamlib.JetStream
+	stream          jetstreamlib.Stream
+	activeWals      map[string]wal.WAL
+	mu              sync.RWMutex
+}
+
+// NewJetStreamManager is a NATS JetStream WAL Manager. Each partition is persisted on the subject
+// <subject prefix>.<encoded partition ID> of the stream, the stream is created if it does not exist.
+func NewJetStreamManager(ctx context.Context, opts ...Option) (wal.Manager, error) {
+	s := &jetStreamManager{
+		url: natslib.DefaultURL,
+		streamConfig: jetstreamlib.StreamConfig{
+			Name:    defaultStreamName,
+			Storage: jetstreamlib.FileStorage,
+		},
+		subjectPrefix:   defaultSubjectPrefix,
+		replayBatchSize: dfv1.DefaultPBQReadBatchSize,
+		activeWals:      make(map[string]wal.WAL),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	if s.streamConfig.Name == "" {
+		return nil, fmt.Errorf("stream name is required for the jetstream WAL")
+	}
+	if s.subjectPrefix == "" {
+		return nil, fmt.Errorf("subject prefix is required for the jetstream WAL")
+	}
+	if s.replayBatchSize <= 0 {
+		return nil, fmt.Errorf("replay batch size should be greater than 0, got %d", s.replayBatchSize)
+	}
+
+	conn, err := natslib.Connect(s.url, s.connectOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats server %s, %w", s.url, err)
+	}
+	s.conn = conn
+	if s.js, err = jetstreamlib.New(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create the jetstream context, %w", err)
+	}
+	s.streamConfig.Subjects = []string{s.subjectPrefix + ".>"}
+	if s.stream, err = s.js.CreateOrUpdateStream(ctx, s.streamConfig); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create the stream %s, %w", s.streamConfig.Name, err)
+	}
+	return s, nil
+}
+
+// newRegisteredManager creates the manager of the jetstream store type on the JetStream of the inter-step buffer
+// service, the URL and the credentials are read from the env of the vertex. Every replica has a stream of its own, and
+// the subjects are prefixed by the pipeline, the vertex and the replica so that they do not overlap across the streams.
+func newRegisteredManager(ctx context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+	url, existing := os.LookupEnv(dfv1.EnvISBSvcJetStreamURL)
+	if !existing {
+		return nil, fmt.Errorf("environment variable %q not found", dfv1.EnvISBSvcJetStreamURL)
+	}
+	connectOpts := []natslib.Option{natslib.UserInfo(os.Getenv(dfv1.EnvISBSvcJetStreamUser), os.Getenv(dfv1.EnvISBSvcJetStreamPassword))}
+	if sharedutil.LookupEnvStringOr(dfv1.EnvISBSvcJetStreamTLSEnabled, "false") == "true" {
+		connectOpts = append(connectOpts, natslib.Secure(&tls.Config{
+			InsecureSkipVerify: true,
+		}))
+	}
+	return NewJetStreamManager(ctx,
+		WithURL(url),
+		WithConnectOptions(connectOpts...),
+		WithStreamConfig(jetstreamlib.StreamConfig{
+			Name:    fmt.Sprintf("%s-%s-%s-%d", defaultStreamName, opts.PipelineName, opts.VertexName, opts.Replica),
+			Storage: jetstreamlib.FileStorage,
+		}),
+		WithSubjectPrefix(fmt.Sprintf("%s.%s.%s.%d", defaultSubjectPrefix, opts.PipelineName, opts.VertexName, opts.Replica)),
+	)
+}
+
+// CreateWAL returns a WAL for the partition. Nothing is published until the first write.
+func (jm *jetStreamManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	// during crash recovery, we might have already created the WAL while replaying
+	jm.mu.RLock()
+	w, ok := jm.activeWals[partitionID.String()]
+	jm.mu.RUnlock()
+	if ok {
+		return w, nil
+	}
+
+	created := jm.newWAL(jm.partitionSubject(partitionID), &partitionID)
+	// the subject can hold messages already, e.g. if the partition was not discovered
+	size, err := created.loadSize(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the size of the WAL of partition %s, %w", partitionID.String(), err)
+	}
+	created.size.Store(size)
+	w = created
+	jm.mu.Lock()
+	jm.activeWals[partitionID.String()] = w
+	jm.mu.Unlock()
+	return w, nil
+}
+
+// DiscoverWALs returns a WAL for every partition which has at least one message in the stream.
+func (jm *jetStreamManager) DiscoverWALs(ctx context.Context) ([]wal.WAL, error) {
+	info, err := jm.stream.Info(ctx, jetstreamlib.WithSubjectFilter(jm.subjectPrefix+".>"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the partition subjects, %w", err)
+	}
+
+	partitions := make([]wal.WAL, 0, len(info.State.Subjects))
+	for subject, size := range info.State.Subjects {
+		raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(subject, jm.subjectPrefix+"."))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the partition of %s, %w", subject, err)
+		}
+		id, err := aligned.DecodePartitionID(raw)
+		if err != nil {
+			return nil, err
+		}
+		w := jm.newWAL(subject, id)
+		w.size.Store(int64(size))
+		partitions = append(partitions, w)
+	}
+
+	jm.mu.Lock()
+	for _, w := range partitions {
+		jm.activeWals[w.PartitionID().String()] = w
+	}
+	jm.mu.Unlock()
+	return partitions, nil
+}
+
+// DeleteWAL purges the subject of the given partitionID.
+func (jm *jetStreamManager) DeleteWAL(partitionID partition.ID) error {
+	if err := jm.stream.Purge(context.Background(), jetstreamlib.WithPurgeSubject(jm.partitionSubject(partitionID))); err != nil {
+		return fmt.Errorf("failed to purge the subject of partition %s, %w", partitionID.String(), err)
+	}
+	jm.mu.Lock()
+	delete(jm.activeWals, partitionID.String())
+	jm.mu.Unlock()
+	return nil
+}
+
+// ReleaseWAL forgets the WAL of the partition if it is w, its messages are kept.
+func (jm *jetStreamManager) ReleaseWAL(partitionID partition.ID, w wal.WAL) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	if jm.activeWals[partitionID.String()] == w {
+		delete(jm.activeWals, partitionID.String())
+	}
+}
+
+// Close closes the NATS connection.
+func (jm *jetStreamManager) Close() error {
+	jm.conn.Close()
+	return nil
+}
+
+// partitionSubject returns the subject of the partition. The partition ID is base64 encoded so that the slot can not
+// break the subject into more tokens.
+func (jm *jetStreamManager) partitionSubject(partitionID partition.ID) string {
+	return jm.subjectPrefix + "." + base64.RawURLEncoding.EncodeToString(aligned.EncodePartitionID(partitionID))
+}
+
+func (jm *jetStreamManager) newWAL(subject string, id *partition.ID) *jetStreamWAL {
+	return &jetStreamWAL{
+		js:              jm.js,
+		stream:          jm.stream,
+		streamName:      jm.streamConfig.Name,
+		subject:         subject,
+		partitionID:     id,
+		replayBatchSize: jm.replayBatchSize,
+	}
+}
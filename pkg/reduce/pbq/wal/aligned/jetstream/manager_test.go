@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	natstest "github.com/numaproj/numaflow/pkg/shared/clients/nats/test"
+)
+
+func readAll(t *testing.T, w wal.WAL) []*isb.ReadMessage {
+	msgCh, errCh := w.Replay()
+	readMessages := make([]*isb.ReadMessage, 0)
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				return readMessages
+			}
+			readMessages = append(readMessages, msg)
+		case err, ok := <-errCh:
+			if ok {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestJetStreamManager(t *testing.T) {
+	s := natstest.RunJetStreamServer(t)
+	defer natstest.ShutdownJetStreamServer(t, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	partitionIds := []partition.ID{
+		{
+			Start: time.Unix(60, 0),
+			End:   time.Unix(120, 0),
+			Slot:  "test-1",
+		},
+		{
+			Start: time.Unix(120, 0),
+			End:   time.Unix(180, 0),
+			Slot:  "test-2",
+		},
+	}
+
+	// a small replay batch size makes sure the replay spans multiple fetches
+	storeProvider, err := NewJetStreamManager(ctx, WithURL(s.ClientURL()), WithSubjectPrefix("test-pbq"), WithReplayBatchSize(3))
+	assert.NoError(t, err)
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+
+	writeMessages := testutils.BuildTestReadMessages(10, time.Unix(60, 0), nil)
+	for i, partitionID := range partitionIds {
+		store, err := storeProvider.CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), store.Size())
+		readMessages := readAll(t, store)
+		assert.Len(t, readMessages, 0)
+		// the first partition is written one message at a time and the second one in a single batch
+		if i == 0 {
+			for _, msg := range writeMessages {
+				assert.NoError(t, store.Write(ctx, &msg))
+			}
+		} else {
+			batch := make([]*isb.ReadMessage, 0, len(writeMessages))
+			for j := range writeMessages {
+				batch = append(batch, &writeMessages[j])
+			}
+			assert.NoError(t, store.WriteBatch(ctx, batch))
+		}
+		assert.NoError(t, store.Ping(ctx))
+	}
+
+	// a new manager simulates a restart of the pod
+	restarted, err := NewJetStreamManager(ctx, WithURL(s.ClientURL()), WithSubjectPrefix("test-pbq"), WithReplayBatchSize(3))
+	assert.NoError(t, err)
+	defer func() { _ = restarted.(io.Closer).Close() }()
+
+	discoveredStores, err := restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, len(partitionIds))
+	for _, store := range discoveredStores {
+		assert.Equal(t, int64(len(writeMessages)), store.Size())
+		readMessages := readAll(t, store)
+		assert.Len(t, readMessages, len(writeMessages))
+		for i, msg := range readMessages {
+			assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+		}
+	}
+
+	for _, partitionID := range partitionIds {
+		assert.NoError(t, restarted.DeleteWAL(partitionID))
+	}
+	discoveredStores, err = restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 0)
+}
+
+func TestJetStreamWAL_SizeWithoutServer(t *testing.T) {
+	s := natstest.RunJetStreamServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "test-size"}
+
+	storeProvider, err := NewJetStreamManager(ctx, WithURL(s.ClientURL()), WithSubjectPrefix("test-pbq"))
+	assert.NoError(t, err)
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessages(3, time.Unix(60, 0), nil)
+	for _, msg := range writeMessages {
+		assert.NoError(t, store.Write(ctx, &msg))
+	}
+
+	// the size is kept by the WAL, it does not drop to zero when the stream cannot be reached
+	natstest.ShutdownJetStreamServer(t, s)
+	assert.Equal(t, int64(len(writeMessages)), store.Size())
+}
+
+func TestNewJetStreamManager_InvalidOptions(t *testing.T) {
+	_, err := NewJetStreamManager(context.Background(), WithSubjectPrefix(""))
+	assert.Error(t, err)
+	_, err = NewJetStreamManager(context.Background(), WithReplayBatchSize(0))
+	assert.Error(t, err)
+}
+
+func TestJetStreamManager_Registered(t *testing.T) {
+	assert.Contains(t, wal.StoreTypes(), string(dfv1.JetStreamType))
+	s := natstest.RunJetStreamServer(t)
+	defer natstest.ShutdownJetStreamServer(t, s)
+	opts := wal.ManagerOptions{PipelineName: "p", VertexName: "v", Replica: 1}
+
+	_, err := wal.NewStoreManager(context.Background(), string(dfv1.JetStreamType), opts)
+	assert.Error(t, err)
+
+	t.Setenv(dfv1.EnvISBSvcJetStreamURL, s.ClientURL())
+	manager, err := wal.NewStoreManager(context.Background(), string(dfv1.JetStreamType), opts)
+	assert.NoError(t, err)
+	defer func() { _ = manager.(io.Closer).Close() }()
+	jm := manager.(*jetStreamManager)
+	assert.Equal(t, "numaflow-pbq-p-v-1", jm.streamConfig.Name)
+	assert.Equal(t, []string{"numaflow-pbq.p.v.1.>"}, jm.streamConfig.Subjects)
+}
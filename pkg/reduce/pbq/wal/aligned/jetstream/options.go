@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	natslib "github.com/nats-io/nats.go"
+	jetstreamlib "github.com/nats-io/nats.go/jetstream"
+)
+
+type Option func(stores *jetStreamManager)
+
+// WithURL sets the URL of the NATS server
+func WithURL(url string) Option {
+	return func(stores *jetStreamManager) {
+		stores.url = url
+	}
+}
+
+// WithStreamConfig sets the config of the stream which holds the partitions, the subjects of the config are replaced
+// by the subject prefix
+func WithStreamConfig(config jetstreamlib.StreamConfig) Option {
+	return func(stores *jetStreamManager) {
+		stores.streamConfig = config
+	}
+}
+
+// WithSubjectPrefix sets the prefix of the partition subjects, it has to be unique per vertex replica
+func WithSubjectPrefix(prefix string) Option {
+	return func(stores *jetStreamManager) {
+		stores.subjectPrefix = prefix
+	}
+}
+
+// WithReplayBatchSize sets the number of entries fetched from the ordered consumer in a single request during replay
+func WithReplayBatchSize(size int) Option {
+	return func(stores *jetStreamManager) {
+		stores.replayBatchSize = size
+	}
+}
+
+// WithConnectOptions sets the options of the connection to the NATS server, e.g. the credentials
+func WithConnectOptions(opts ...natslib.Option) Option {
+	return func(stores *jetStreamManager) {
+		stores.connectOpts = opts
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	jetstreamlib "github.com/nats-io/nats.go/jetstream"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+// replayFetchWait is the max time a single fetch of the replay waits for the batch to fill up.
+const replayFetchWait = time.Second
+
+// jetStreamWAL implements wal.WAL on top of a subject of a JetStream stream.
+type jetStreamWAL struct {
+	js              jetstreamlib.JetStream
+	stream          jetstreamlib.Stream
+	streamName      string
+	subject         string
+	partitionID     *partition.ID
+	replayBatchSize int
+	// size is the number of messages on the subject, it is read when the WAL is created and kept up to date by the
+	// writes, so that Size does not depend on the stream being reachable
+	size atomic.Int64
+	// closed is set by Close, which can race with the writes of the PBQ
+	closed atomic.Bool
+}
+
+var _ wal.WAL = (*jetStreamWAL)(nil)
+
+// Replay replays all the messages of the subject in the order they were published, the subject is read with an
+// ordered consumer in batches of replayBatchSize. The replay ends once the consumer has no more pending messages.
+func (j *jetStreamWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+
+		ctx := context.Background()
+		// the ordered consumer never ends on its own, the replay stops once the pending messages are drained
+		size, err := j.loadSize(ctx)
+		if err != nil {
+			errs <- fmt.Errorf("failed to read the size of %s, %w", j.subject, err)
+			return
+		}
+		pending := uint64(size)
+		if pending == 0 {
+			return
+		}
+		consumer, err := j.js.OrderedConsumer(ctx, j.streamName, jetstreamlib.OrderedConsumerConfig{
+			FilterSubjects: []string{j.subject},
+			DeliverPolicy:  jetstreamlib.DeliverAllPolicy,
+		})
+		if err != nil {
+			errs <- fmt.Errorf("failed to create the ordered consumer of %s, %w", j.subject, err)
+			return
+		}
+
+		for pending > 0 {
+			// the batch is capped at the pending messages so that the fetch does not wait for messages which will
+			// never arrive
+			batch, err := consumer.Fetch(int(min(pending, uint64(j.replayBatchSize))), jetstreamlib.FetchMaxWait(replayFetchWait))
+			if err != nil {
+				errs <- fmt.Errorf("failed to fetch from %s, %w", j.subject, err)
+				return
+			}
+			var received int
+			for m := range batch.Messages() {
+				received++
+				msg, err := aligned.DecodeEntry(m.Data())
+				if err != nil {
+					errs <- err
+					return
+				}
+				messages <- msg
+				meta, err := m.Metadata()
+				if err != nil {
+					errs <- err
+					return
+				}
+				pending = meta.NumPending
+			}
+			if err = batch.Error(); err != nil {
+				errs <- fmt.Errorf("failed to fetch from %s, %w", j.subject, err)
+				return
+			}
+			// an empty fetch means the subject was purged while replaying
+			if received == 0 {
+				return
+			}
+		}
+	}()
+	return messages, errs
+}
+
+// Write publishes the message to the subject, it returns once the stream has acknowledged the message.
+func (j *jetStreamWAL) Write(ctx context.Context, msg *isb.ReadMessage) error {
+	if j.closed.Load() {
+		return aligned.ErrWriteStoreClosed
+	}
+	entry, err := aligned.EncodeEntry(msg)
+	if err != nil {
+		return err
+	}
+	if _, err = j.js.Publish(ctx, j.subject, entry); err != nil {
+		// a failed publish could still have been persisted
+		j.reloadSize(ctx, 0)
+		return err
+	}
+	j.size.Add(1)
+	return nil
+}
+
+// WriteBatch publishes all the messages asynchronously and waits until the stream has acknowledged every one of them.
+// It returns wal.BatchWriteErr with the index of the first message which is not acknowledged, the messages after it
+// may have been persisted as well.
+func (j *jetStreamWAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	if j.closed.Load() {
+		return aligned.ErrWriteStoreClosed
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	acks := make([]jetstreamlib.PubAckFuture, 0, len(msgs))
+	var publishErr error
+	for _, msg := range msgs {
+		entry, err := aligned.EncodeEntry(msg)
+		if err == nil {
+			var ack jetstreamlib.PubAckFuture
+			if ack, err = j.js.PublishAsync(j.subject, entry); err == nil {
+				acks = append(acks, ack)
+				continue
+			}
+		}
+		publishErr = err
+		break
+	}
+	// the messages which are already published are waited for, so that the index of the failure is accurate
+	for i, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			j.reloadSize(ctx, int64(i))
+			return wal.BatchWriteErr{Index: i, Err: err}
+		case <-ctx.Done():
+			j.reloadSize(ctx, int64(i))
+			return wal.BatchWriteErr{Index: i, Err: ctx.Err()}
+		}
+	}
+	if publishErr != nil {
+		j.reloadSize(ctx, int64(len(acks)))
+		return wal.BatchWriteErr{Index: len(acks), Err: publishErr}
+	}
+	j.size.Add(int64(len(msgs)))
+	return nil
+}
+
+// reloadSize reads the size from the stream after a failed write, since the messages after the acknowledged ones may
+// have been persisted as well. If the stream cannot be read, only the acknowledged messages are counted.
+func (j *jetStreamWAL) reloadSize(ctx context.Context, acknowledged int64) {
+	size, err := j.loadSize(context.WithoutCancel(ctx))
+	if err != nil {
+		j.size.Add(acknowledged)
+		return
+	}
+	j.size.Store(size)
+}
+
+// loadSize reads the number of messages on the subject from the stream.
+func (j *jetStreamWAL) loadSize(ctx context.Context) (int64, error) {
+	info, err := j.stream.Info(ctx, jetstreamlib.WithSubjectFilter(j.subject))
+	if err != nil {
+		return 0, err
+	}
+	return int64(info.State.Subjects[j.subject]), nil
+}
+
+// PartitionID returns the partition ID of the WAL.
+func (j *jetStreamWAL) PartitionID() *partition.ID {
+	return j.partitionID
+}
+
+// Size returns the number of messages on the subject as of the last write.
+func (j *jetStreamWAL) Size() int64 {
+	return j.size.Load()
+}
+
+// Flush is a no-op, every write is acknowledged by the stream before it returns.
+func (j *jetStreamWAL) Flush() error {
+	return nil
+}
+
+// Ping reads the info of the stream, it fails if the NATS server cannot be reached.
+func (j *jetStreamWAL) Ping(ctx context.Context) error {
+	_, err := j.stream.Info(ctx)
+	return err
+}
+
+// Close closes the WAL, no more writes will be accepted. The connection is shared across partitions and is owned by
+// the manager.
+func (j *jetStreamWAL) Close() error {
+	j.closed.Store(true)
+	return nil
+}
@@ -21,23 +21,41 @@ import (
 	"errors"
 	"sync"
 
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 )
 
+// defaultCapacityHint is the number of slots allocated upfront for a store, the storage grows as the messages are written.
+const defaultCapacityHint = 64
+
+func init() {
+	if err := wal.RegisterStoreType(string(dfv1.MemoryType), newRegisteredManager); err != nil {
+		panic(err)
+	}
+}
+
 type memManager struct {
-	storeSize    int64
-	discoverFunc func(ctx context.Context) ([]wal.WAL, error)
-	partitions   map[partition.ID]*memoryStore
+	storeSize      int64
+	storeSizeBytes int64
+	capacityHint   int64
+	releaseOnRead  bool
+	copyOnRead     bool
+	// capacityThresholds are the percentages of the store size at which onCapacity is called
+	capacityThresholds []float64
+	onCapacity         CapacityCallback
+	discoverFunc       func(ctx context.Context) ([]wal.WAL, error)
+	partitions         map[partition.ID]*memoryStore
 	sync.RWMutex
 }
 
 func NewMemManager(opts ...Option) wal.Manager {
 	s := &memManager{
-		storeSize:  100000,
-		partitions: make(map[partition.ID]*memoryStore),
+		storeSize:    100000,
+		capacityHint: defaultCapacityHint,
+		partitions:   make(map[partition.ID]*memoryStore),
 	}
 
 	for _, o := range opts {
@@ -46,6 +64,11 @@ func NewMemManager(opts ...Option) wal.Manager {
 	return s
 }
 
+// newRegisteredManager creates the manager of the memory store type with the default store size.
+func newRegisteredManager(_ context.Context, _ wal.ManagerOptions) (wal.Manager, error) {
+	return NewMemManager(), nil
+}
+
 func (ms *memManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
 	ms.Lock()
 	defer ms.Unlock()
@@ -53,13 +76,21 @@ func (ms *memManager) CreateWAL(ctx context.Context, partitionID partition.ID) (
 		return memStore, nil
 	}
 	memStore := &memoryStore{
-		writePos:    0,
-		readPos:     0,
-		closed:      false,
-		storage:     make([]*isb.ReadMessage, ms.storeSize),
-		storeSize:   ms.storeSize,
-		log:         logging.FromContext(ctx).With("pbqStore", "Memory").With("partitionID", partitionID),
-		partitionID: partitionID,
+		writePos:           0,
+		readPos:            0,
+		closed:             false,
+		storage:            make([]*isb.ReadMessage, 0, min(ms.capacityHint, ms.storeSize)),
+		storeSize:          ms.storeSize,
+		storeSizeBytes:     ms.storeSizeBytes,
+		releaseOnRead:      ms.releaseOnRead,
+		copyOnRead:         ms.copyOnRead,
+		capacityThresholds: ms.capacityThresholds,
+		onCapacity:         ms.onCapacity,
+		log:                logging.FromContext(ctx).With("pbqStore", "Memory").With("partitionID", partitionID),
+		partitionID:        partitionID,
+	}
+	if ms.storeSizeBytes > 0 {
+		memStore.messageSizes = make([]int64, 0, cap(memStore.storage))
 	}
 	ms.partitions[partitionID] = memStore
 	return memStore, nil
@@ -86,8 +117,10 @@ func (ms *memManager) DeleteWAL(partitionID partition.ID) error {
 		return errors.New("store not found")
 	}
 
+	memStore.mu.Lock()
 	memStore.storage = nil
 	memStore.writePos = -1
+	memStore.mu.Unlock()
 	delete(ms.partitions, partitionID)
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 )
@@ -72,3 +73,10 @@ func TestMemoryStores(t *testing.T) {
 
 	assert.Len(t, discoveredStores, 0)
 }
+
+func TestMemManager_Registered(t *testing.T) {
+	assert.Contains(t, wal.StoreTypes(), string(dfv1.MemoryType))
+	manager, err := wal.NewStoreManager(context.Background(), string(dfv1.MemoryType), wal.ManagerOptions{PipelineName: "p", VertexName: "v", Replica: 1})
+	assert.NoError(t, err)
+	assert.IsType(t, &memManager{}, manager)
+}
@@ -19,6 +19,7 @@ package memory
 import (
 	"context"
 
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 )
 
@@ -37,3 +38,54 @@ func WithStoreSize(size int64) Option {
 		stores.storeSize = size
 	}
 }
+
+// WithCapacityHint sets the number of slots allocated when a store is created, the storage grows on demand up to the
+// store size as the messages are written. A hint as large as the store size allocates the whole storage upfront.
+func WithCapacityHint(hint int64) Option {
+	return func(stores *memManager) {
+		stores.capacityHint = max(hint, 0)
+	}
+}
+
+// WithStoreSizeBytes sets the maximum cumulative serialized size of the messages in a store, it is enforced along with
+// the store size and the write fails when either of them is exceeded. A non-positive value disables the limit.
+func WithStoreSizeBytes(size int64) Option {
+	return func(stores *memManager) {
+		stores.storeSizeBytes = size
+	}
+}
+
+// WithReleaseOnRead makes the stores release the messages once they are read at an offset, their slots are reused by
+// the following writes. It keeps the memory of a partition which is read while it is written bounded by the unread
+// messages, the released messages are not replayed.
+func WithReleaseOnRead() Option {
+	return func(stores *memManager) {
+		stores.releaseOnRead = true
+	}
+}
+
+// WithCopyOnRead makes the stores keep their own deep copy of a written message, and hand out deep copies of the stored
+// messages on Replay, ReadAt, Iterator and Snapshot, so that a reader which modifies a message, e.g. its payload or
+// keys, does not change what is read again from the store. By default the stores are zero-copy: the written message is
+// stored as is, it is shared with the reader of the PBQ, and the reads return the stored messages themselves, so the
+// messages should not be modified once they are written.
+func WithCopyOnRead() Option {
+	return func(stores *memManager) {
+		stores.copyOnRead = true
+	}
+}
+
+// CapacityCallback is called when a write makes the fill of a store cross a capacity threshold, which is a percentage of
+// the store size. current is the number of retained messages and max is the store size.
+type CapacityCallback func(partitionID partition.ID, threshold float64, current int64, max int64)
+
+// WithCapacityThreshold calls the callback when a write makes the fill of a store cross one of the thresholds, which are
+// percentages of the store size, e.g. 80 and 95. The fill is the number of retained messages, which is writePos unless
+// messages have been released or evicted. A threshold fires again if the fill drops below it and crosses it again. The
+// callback is called after the write, outside the lock of the store.
+func WithCapacityThreshold(callback CapacityCallback, thresholds ...float64) Option {
+	return func(stores *memManager) {
+		stores.onCapacity = callback
+		stores.capacityThresholds = append(stores.capacityThresholds, thresholds...)
+	}
+}
@@ -17,31 +17,79 @@ limitations under the License.
 package memory
 
 import (
+	"context"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 
 	"go.uber.org/zap"
 )
 
-// memoryStore implements PBQStore which stores the data in memory
+// memoryStore implements PBQStore which stores the data in memory. The storage is a ring of storeSize slots, the
+// messages between releasePos and writePos are stored at their position modulo storeSize. The slots are allocated as
+// the messages are written, the ring only wraps once all of them are allocated.
 type memoryStore struct {
-	closed      bool
-	writePos    int64
-	readPos     int64
-	storage     []*isb.ReadMessage
-	storeSize   int64
-	log         *zap.SugaredLogger
-	partitionID partition.ID
+	closed bool
+	// writePos is the position of the next message to be written.
+	writePos int64
+	// readPos is the position of the oldest message, it only moves when a message is evicted.
+	readPos int64
+	// releasePos is the position of the oldest retained message, the messages between readPos and releasePos have been
+	// released. It is always equal to readPos unless releaseOnRead is set.
+	releasePos int64
+	// releaseOnRead releases the messages once they are read by ReadAt, so that their slots can be reused.
+	releaseOnRead bool
+	// copyOnRead stores a copy of the written messages and returns copies of the stored messages, so that the stored
+	// messages are never shared.
+	copyOnRead bool
+	storage    []*isb.ReadMessage
+	storeSize  int64
+	// storeSizeBytes is the limit of the cumulative serialized size of the messages, it is disabled if not positive.
+	storeSizeBytes int64
+	// sizeBytes is the cumulative serialized size of the messages in the store.
+	sizeBytes int64
+	// messageSizes are the serialized sizes of the messages in the storage slots, nil if storeSizeBytes is disabled.
+	messageSizes []int64
+	// capacityThresholds are the percentages of the store size at which onCapacity is called after a write.
+	capacityThresholds []float64
+	onCapacity         CapacityCallback
+	log                *zap.SugaredLogger
+	partitionID        partition.ID
+	// metadata is the persisted metadata of the partition, nil if none has been persisted.
+	metadata *wal.PartitionMetadata
+	// mu guards the storage, the positions and the metadata, so that a snapshot can be taken while the store is written.
+	mu sync.RWMutex
 }
 
+var _ wal.OffsetReader = (*memoryStore)(nil)
+var _ wal.MetadataStore = (*memoryStore)(nil)
+var _ wal.Evicter = (*memoryStore)(nil)
+var _ wal.Snapshotter = (*memoryStore)(nil)
+var _ wal.Iterable = (*memoryStore)(nil)
+var _ wal.Truncater = (*memoryStore)(nil)
+
 // Replay will replay all the messages persisted in store
-// this function will be invoked during bootstrap if there is a restart
+// this function will be invoked during bootstrap if there is a restart. The released messages are not replayed, they
+// have already been read. Each message is read under the read lock, the messages which are released, evicted or
+// truncated while the store is replayed are skipped, and the messages written after the call are not replayed.
 func (m *memoryStore) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 	msgChan := make(chan *isb.ReadMessage)
 	errChan := make(chan error)
+	m.mu.RLock()
+	it := &memoryIterator{store: m, pos: m.releasePos, end: m.writePos}
+	m.mu.RUnlock()
 	go func() {
-		for _, msg := range m.storage {
+		for {
+			msg, err := it.Next()
+			if err != nil {
+				break
+			}
 			msgChan <- msg
 		}
 		close(msgChan)
@@ -50,28 +98,288 @@ func (m *memoryStore) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 	return msgChan, errChan
 }
 
-// WriteToStore writes a message to store
-func (m *memoryStore) Write(msg *isb.ReadMessage) error {
-	if m.writePos >= m.storeSize {
-		m.log.Errorw(aligned.ErrWriteStoreFull.Error(), zap.Any("msg header", msg.Header))
-		return aligned.ErrWriteStoreFull
+// ReadAt reads up to size messages starting at offset. The offset is relative to the oldest message in the store. The
+// read does not affect Replay, unless releaseOnRead is set, in which case the messages up to the end of the read are
+// released and cannot be read again.
+func (m *memoryStore) ReadAt(offset int64, size int64) ([]*isb.ReadMessage, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if offset < m.releasePos-m.readPos || offset > m.size() {
+		return nil, false, wal.OffsetOutOfRangeErr{Offset: offset, Size: m.size()}
+	}
+	end := min(offset+max(size, 0), m.size())
+	messages := make([]*isb.ReadMessage, 0, end-offset)
+	for pos := m.readPos + offset; pos < m.readPos+end; pos++ {
+		messages = append(messages, m.read(m.storage[pos%m.storeSize]))
+	}
+	if m.releaseOnRead {
+		for m.releasePos < m.readPos+end {
+			m.release()
+		}
+	}
+	return messages, end == m.size(), nil
+}
+
+// Snapshot returns a copy of the retained messages, the released and the evicted messages are not included. The copy is
+// taken under the read lock, the messages written after it are not included.
+func (m *memoryStore) Snapshot() ([]*isb.Message, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	messages := make([]*isb.Message, 0, m.retained())
+	for pos := m.releasePos; pos < m.writePos; pos++ {
+		message := m.read(m.storage[pos%m.storeSize]).Message
+		messages = append(messages, &message)
+	}
+	return messages, nil
+}
+
+// Iterator returns an iterator over the retained messages, the messages written after the call are not returned.
+func (m *memoryStore) Iterator() (wal.StoreIterator, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &memoryIterator{store: m, pos: m.releasePos, end: m.writePos}, nil
+}
+
+// memoryIterator iterates over the messages of a memoryStore between pos and end, each message is read under the read
+// lock of the store.
+type memoryIterator struct {
+	store *memoryStore
+	pos   int64
+	end   int64
+}
+
+// Next returns the message at pos, the messages which are evicted or released before they are returned are skipped, and
+// the iteration ends at the truncated messages.
+func (it *memoryIterator) Next() (*isb.ReadMessage, error) {
+	it.store.mu.RLock()
+	defer it.store.mu.RUnlock()
+	it.pos = max(it.pos, it.store.releasePos)
+	// the truncated messages are not returned either, nor the messages written after the truncation
+	it.end = min(it.end, it.store.writePos)
+	if it.pos >= it.end {
+		return nil, io.EOF
+	}
+	msg := it.store.read(it.store.storage[it.pos%it.store.storeSize])
+	it.pos++
+	return msg, nil
+}
+
+// Close is a no-op, the iterator does not hold any resource.
+func (it *memoryIterator) Close() error {
+	return nil
+}
+
+// read returns the stored message as it is handed out by the reads, a copy of it if copyOnRead is set.
+func (m *memoryStore) read(msg *isb.ReadMessage) *isb.ReadMessage {
+	if !m.copyOnRead {
+		return msg
+	}
+	return copyMessage(msg)
+}
+
+// copyMessage returns a deep copy of the message, the read offset is shared since it is never modified. A nil message,
+// e.g. of a slot which is freed, is returned as it is.
+func copyMessage(msg *isb.ReadMessage) *isb.ReadMessage {
+	if msg == nil {
+		return nil
 	}
+	copied := *msg
+	copied.Keys = slices.Clone(msg.Keys)
+	copied.Headers = maps.Clone(msg.Headers)
+	copied.Payload = slices.Clone(msg.Payload)
+	return &copied
+}
+
+// Write writes a message to store, the context is ignored since the store is in memory
+func (m *memoryStore) Write(_ context.Context, msg *isb.ReadMessage) (err error) {
+	defer func() {
+		if err != nil {
+			aligned.RecordWriteError("memory", err)
+		}
+	}()
+	// the deferred callbacks run after the lock is released, so that they can call the store
+	var crossed []float64
+	var current int64
+	defer func() {
+		for _, threshold := range crossed {
+			m.onCapacity(m.partitionID, threshold, current, m.storeSize)
+		}
+	}()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// a closed store refuses the writes even if it is full, so that a write which waits for room gives up
 	if m.closed {
 		m.log.Errorw(aligned.ErrWriteStoreClosed.Error(), zap.Any("msg header", msg.Header))
 		return aligned.ErrWriteStoreClosed
 	}
-	m.storage[m.writePos] = msg
+	if m.retained() >= m.storeSize {
+		m.log.Errorw(aligned.ErrWriteStoreFull.Error(), zap.Any("msg header", msg.Header))
+		return aligned.ErrWriteStoreFull
+	}
+	if m.copyOnRead {
+		msg = copyMessage(msg)
+	}
+	var size int64
+	if m.storeSizeBytes > 0 {
+		body, err := msg.Message.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		size = int64(len(body))
+		if m.sizeBytes+size > m.storeSizeBytes {
+			m.log.Errorw(aligned.ErrWriteStoreFull.Error(), zap.Any("msg header", msg.Header), zap.Int64("sizeBytes", m.sizeBytes), zap.Int64("msgSizeBytes", size))
+			return aligned.ErrWriteStoreFull
+		}
+	}
+	if slot := m.writePos % m.storeSize; slot < int64(len(m.storage)) {
+		m.storage[slot] = msg
+		if m.messageSizes != nil {
+			m.messageSizes[slot] = size
+		}
+	} else {
+		// the writes fill the slots in order before the ring wraps, so the next slot is the first unallocated one
+		m.storage = append(m.storage, msg)
+		if m.messageSizes != nil {
+			m.messageSizes = append(m.messageSizes, size)
+		}
+	}
 	m.writePos += 1
+	m.sizeBytes += size
+	current = m.retained()
+	crossed = m.crossedThresholds(current)
+	return nil
+}
+
+// crossedThresholds returns the capacity thresholds which the fill crossed with the write of a message, i.e. the fill is
+// at or above the threshold but was below it before the write.
+func (m *memoryStore) crossedThresholds(current int64) []float64 {
+	if m.onCapacity == nil {
+		return nil
+	}
+	var crossed []float64
+	for _, threshold := range m.capacityThresholds {
+		// compared as percentages, so that 80% of a store of 10 is exactly 8 messages
+		limit := threshold * float64(m.storeSize)
+		if float64(current-1)*100 < limit && float64(current)*100 >= limit {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
+
+// EvictOldest removes the oldest retained message from the store, which frees its slot and its bytes. The offsets are
+// kept if releaseOnRead is set, the evicted message is released as if it was read.
+func (m *memoryStore) EvictOldest() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.retained() <= 0 {
+		return false
+	}
+	m.release()
+	if !m.releaseOnRead {
+		m.readPos = m.releasePos
+	}
+	return true
+}
+
+// Truncate drops the messages at and after offset, which is relative to the oldest message in the store like the offset
+// of ReadAt. The slots and the bytes of the dropped messages are freed, the released messages cannot be truncated.
+func (m *memoryStore) Truncate(offset int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if offset < m.releasePos-m.readPos || offset > m.size() {
+		return wal.OffsetOutOfRangeErr{Offset: offset, Size: m.size()}
+	}
+	for m.writePos > m.readPos+offset {
+		m.writePos -= 1
+		slot := m.writePos % m.storeSize
+		if m.messageSizes != nil {
+			m.sizeBytes -= m.messageSizes[slot]
+		}
+		m.storage[slot] = nil
+	}
+	return nil
+}
+
+// release frees the slot and the bytes of the oldest retained message. Caller should hold the lock.
+func (m *memoryStore) release() {
+	slot := m.releasePos % m.storeSize
+	if m.messageSizes != nil {
+		m.sizeBytes -= m.messageSizes[slot]
+	}
+	m.storage[slot] = nil
+	m.releasePos += 1
+}
+
+// retained returns the number of messages which occupy a slot of the storage. Caller should hold the lock.
+func (m *memoryStore) retained() int64 {
+	return m.writePos - m.releasePos
+}
+
+// WriteBatch writes the messages to store one after the other, it stops at the first failed write and returns
+// wal.BatchWriteErr with the index of the failed message.
+func (m *memoryStore) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	for i, msg := range msgs {
+		if err := m.Write(ctx, msg); err != nil {
+			return wal.BatchWriteErr{Index: i, Err: err}
+		}
+	}
 	return nil
 }
 
 // Close closes the store, no more writes to persistent store
 // no implementation for in memory store
 func (m *memoryStore) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.closed = true
 	return nil
 }
 
+// Size returns the number of messages in the store, the evicted messages are not counted. The released messages are
+// counted, so that the offsets of the following messages do not change.
+func (m *memoryStore) Size() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size()
+}
+
+// size returns the number of messages in the store. Caller should hold the lock.
+func (m *memoryStore) size() int64 {
+	return m.writePos - m.readPos
+}
+
+// Flush is a no-op, the memory store has nothing to make durable.
+func (m *memoryStore) Flush() error {
+	return nil
+}
+
+// Ping always succeeds, the memory store has no backend which can become unavailable.
+func (m *memoryStore) Ping(_ context.Context) error {
+	return nil
+}
+
 func (m *memoryStore) PartitionID() *partition.ID {
 	return &m.partitionID
 }
+
+// PersistMetadata keeps a copy of the metadata of the partition in memory.
+func (m *memoryStore) PersistMetadata(metadata wal.PartitionMetadata) error {
+	metadata.Keys = append([]string(nil), metadata.Keys...)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metadata = &metadata
+	return nil
+}
+
+// LoadMetadata returns a copy of the persisted metadata of the partition.
+func (m *memoryStore) LoadMetadata() (*wal.PartitionMetadata, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.metadata == nil {
+		return nil, wal.ErrMetadataNotFound
+	}
+	metadata := *m.metadata
+	metadata.Keys = append([]string(nil), m.metadata.Keys...)
+	return &metadata, nil
+}
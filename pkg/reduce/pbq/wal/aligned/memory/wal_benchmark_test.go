@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+const benchmarkBatchSize = 100
+
+// benchmarkMessages returns test messages whose payload is padded to the given size.
+func benchmarkMessages(count int64, payloadSize int) []isb.ReadMessage {
+	messages := testutils.BuildTestReadMessagesIntOffset(count, time.Unix(1665109020, 0), nil)
+	for i := range messages {
+		if len(messages[i].Payload) < payloadSize {
+			payload := make([]byte, payloadSize)
+			copy(payload, messages[i].Payload)
+			messages[i].Payload = payload
+		}
+	}
+	return messages
+}
+
+// BenchmarkMemoryStore_Write writes a message per op, with and without the byte budget, which makes every write
+// serialize the message to compute its size.
+func BenchmarkMemoryStore_Write(b *testing.B) {
+	for _, payloadSize := range []int{128, 1024} {
+		for _, sizeBytes := range []int64{0, 1 << 40} {
+			b.Run(fmt.Sprintf("payload=%d/sizeBytes=%t", payloadSize, sizeBytes > 0), func(b *testing.B) {
+				store, err := NewMemManager(WithStoreSize(int64(b.N)), WithStoreSizeBytes(sizeBytes)).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+				if err != nil {
+					b.Fatal(err)
+				}
+				writeMessages := benchmarkMessages(benchmarkBatchSize, payloadSize)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if err = store.Write(context.Background(), &writeMessages[i%len(writeMessages)]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkMemoryStore_Replay replays the whole store per op, for stores of different sizes.
+func BenchmarkMemoryStore_Replay(b *testing.B) {
+	for _, size := range []int64{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			store, err := NewMemManager(WithStoreSize(size)).CreateWAL(context.Background(), partition.ID{Slot: "bench"})
+			if err != nil {
+				b.Fatal(err)
+			}
+			writeMessages := benchmarkMessages(size, 1024)
+			for i := range writeMessages {
+				if err = store.Write(context.Background(), &writeMessages[i]); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				msgCh, _ := store.Replay()
+				replayed := int64(0)
+				for range msgCh {
+					replayed++
+				}
+				if replayed != size {
+					b.Fatalf("replayed %d messages, expected %d", replayed, size)
+				}
+			}
+		})
+	}
+}
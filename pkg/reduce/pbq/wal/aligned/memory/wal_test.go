@@ -18,13 +18,17 @@ package memory
 
 import (
 	"context"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isb/testutils"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,9 +50,14 @@ func TestMemoryStore_WriteToStore(t *testing.T) {
 	writeMessages := testutils.BuildTestReadMessages(int64(msgCount), startTime, nil)
 
 	for _, msg := range writeMessages {
-		err := memStore.Write(&msg)
+		err := memStore.Write(ctx, &msg)
 		assert.NoError(t, err)
 	}
+	// flush is a no-op for the memory store
+	assert.NoError(t, memStore.Flush())
+	// the memory store is always healthy
+	assert.NoError(t, memStore.Ping(ctx))
+	assert.Equal(t, int64(msgCount), memStore.Size())
 }
 
 func TestMemoryStore_ReadFromStore(t *testing.T) {
@@ -70,7 +79,7 @@ func TestMemoryStore_ReadFromStore(t *testing.T) {
 	writeMessages := testutils.BuildTestReadMessages(int64(msgCount), startTime, nil)
 
 	for _, msg := range writeMessages {
-		err := memStore.Write(&msg)
+		err := memStore.Write(ctx, &msg)
 		assert.NoError(t, err)
 	}
 	msgCh, errCh := memStore.Replay()
@@ -95,6 +104,8 @@ outerLoop:
 	}
 	// number of read messages should be equal to msgCount
 	assert.Len(t, readMessages, msgCount)
+	// replay does not remove the messages from the store
+	assert.Equal(t, int64(msgCount), memStore.Size())
 }
 
 func TestEmptyStore_Read(t *testing.T) {
@@ -132,6 +143,7 @@ outerLoop:
 	}
 	// since store is empty, there should not be any messages to read
 	assert.Len(t, readMessages, 0)
+	assert.Equal(t, int64(0), memStore.Size())
 
 }
 
@@ -155,11 +167,599 @@ func TestFullStore_Write(t *testing.T) {
 	writeMessages := testutils.BuildTestReadMessages(int64(msgCount), startTime, nil)
 
 	for _, msg := range writeMessages {
-		err := memStore.Write(&msg)
+		err := memStore.Write(ctx, &msg)
 		assert.NoError(t, err)
 	}
 
 	// now the store is full, if we write to store we should get an error
-	err = memStore.Write(&writeMessages[0])
+	fullErrors := aligned.StoreWriteErrors.WithLabelValues("memory", "full")
+	before := testutil.ToFloat64(fullErrors)
+	err = memStore.Write(ctx, &writeMessages[0])
 	assert.ErrorContains(t, err, "store is full")
+	// the error is counted by its type
+	assert.Equal(t, before+1, testutil.ToFloat64(fullErrors))
+
+	// a closed store refuses the writes as closed, even though it is full
+	assert.NoError(t, memStore.Close())
+	assert.ErrorIs(t, memStore.Write(ctx, &writeMessages[0]), aligned.ErrWriteStoreClosed)
+}
+
+func TestFullStoreBytes_Write(t *testing.T) {
+	ctx := context.Background()
+
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	writeMessages := testutils.BuildTestReadMessages(10, time.Now(), nil)
+	// budget is the serialized size of the first 5 messages
+	var budget int64
+	for i := 0; i < 5; i++ {
+		body, err := writeMessages[i].Message.MarshalBinary()
+		assert.NoError(t, err)
+		budget += int64(len(body))
+	}
+
+	t.Run("small messages", func(t *testing.T) {
+		// the byte budget is enough for 5 messages while the store size allows 100
+		memStore, err := NewMemManager(WithStoreSize(100), WithStoreSizeBytes(budget)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+		}
+		err = memStore.Write(ctx, &writeMessages[5])
+		assert.ErrorIs(t, err, aligned.ErrWriteStoreFull)
+	})
+
+	t.Run("large message", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(100), WithStoreSizeBytes(budget)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+
+		large := writeMessages[0]
+		large.Payload = make([]byte, budget)
+		err = memStore.Write(ctx, &large)
+		assert.ErrorIs(t, err, aligned.ErrWriteStoreFull)
+
+		// a smaller message still fits in the budget
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[1]))
+	})
+
+	t.Run("count limit first", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(2), WithStoreSizeBytes(20*budget)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[0]))
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[1]))
+		err = memStore.Write(ctx, &writeMessages[2])
+		assert.ErrorIs(t, err, aligned.ErrWriteStoreFull)
+	})
+}
+
+func TestMemoryStore_WriteBatch(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	// the store has room for 2 messages, so the 3rd message of the batch fails
+	memStore, err := NewMemManager(WithStoreSize(2)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessages(4, time.Unix(60, 0), nil)
+	batch := make([]*isb.ReadMessage, 0, len(writeMessages))
+	for i := range writeMessages {
+		batch = append(batch, &writeMessages[i])
+	}
+
+	err = memStore.WriteBatch(ctx, batch)
+	var batchErr wal.BatchWriteErr
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, 2, batchErr.Index)
+	assert.ErrorIs(t, err, aligned.ErrWriteStoreFull)
+
+	// only the messages before the failed one are persisted
+	assert.Equal(t, int64(2), memStore.Size())
+	msgs, _, err := memStore.(wal.OffsetReader).ReadAt(0, 4)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 2)
+	for i, msg := range msgs {
+		assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+	}
+}
+
+func TestMemoryStore_ReadAt(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	memStore, err := NewMemManager(WithStoreSize(100)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessages(10, time.Now(), nil)
+	for i := range writeMessages {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+	}
+	reader := memStore.(wal.OffsetReader)
+
+	// offset 0
+	msgs, eof, err := reader.ReadAt(0, 4)
+	assert.NoError(t, err)
+	assert.False(t, eof)
+	assert.Len(t, msgs, 4)
+	assert.Equal(t, writeMessages[0].Header.ID, msgs[0].Header.ID)
+
+	// mid-store, the size is capped at the end
+	msgs, eof, err = reader.ReadAt(7, 5)
+	assert.NoError(t, err)
+	assert.True(t, eof)
+	assert.Len(t, msgs, 3)
+	assert.Equal(t, writeMessages[7].Header.ID, msgs[0].Header.ID)
+
+	// at the end
+	msgs, eof, err = reader.ReadAt(10, 5)
+	assert.NoError(t, err)
+	assert.True(t, eof)
+	assert.Len(t, msgs, 0)
+
+	// past the end
+	_, _, err = reader.ReadAt(11, 5)
+	assert.ErrorAs(t, err, &wal.OffsetOutOfRangeErr{})
+	_, _, err = reader.ReadAt(-1, 5)
+	assert.ErrorAs(t, err, &wal.OffsetOutOfRangeErr{})
+
+	// reads at an offset do not affect the replay
+	assert.Len(t, readAllNonNil(memStore), 10)
+}
+
+func readAllNonNil(store wal.WAL) []*isb.ReadMessage {
+	msgCh, _ := store.Replay()
+	readMessages := make([]*isb.ReadMessage, 0)
+	for msg := range msgCh {
+		if msg != nil {
+			readMessages = append(readMessages, msg)
+		}
+	}
+	return readMessages
+}
+
+func TestMemoryStore_EvictOldest(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+	writeMessages := testutils.BuildTestReadMessages(5, time.Now(), nil)
+	body, err := writeMessages[0].Message.MarshalBinary()
+	assert.NoError(t, err)
+
+	// the byte budget is enough for 3 messages while the store size allows 2
+	memStore, err := NewMemManager(WithStoreSize(2), WithStoreSizeBytes(int64(3*len(body)))).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	evicter := memStore.(wal.Evicter)
+	assert.False(t, evicter.EvictOldest())
+
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[0]))
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[1]))
+	assert.ErrorIs(t, memStore.Write(ctx, &writeMessages[2]), aligned.ErrWriteStoreFull)
+
+	// the evicted slot is reused by the next write
+	assert.True(t, evicter.EvictOldest())
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[2]))
+	assert.Equal(t, int64(2), memStore.Size())
+
+	replayed := readAllNonNil(memStore)
+	assert.Len(t, replayed, 2)
+	assert.Equal(t, writeMessages[1].Header.ID, replayed[0].Header.ID)
+	assert.Equal(t, writeMessages[2].Header.ID, replayed[1].Header.ID)
+
+	// the offsets are relative to the oldest message
+	msgs, eof, err := memStore.(wal.OffsetReader).ReadAt(1, 5)
+	assert.NoError(t, err)
+	assert.True(t, eof)
+	assert.Len(t, msgs, 1)
+	assert.Equal(t, writeMessages[2].Header.ID, msgs[0].Header.ID)
+}
+
+func TestMemoryStore_Iterator(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+	writeMessages := testutils.BuildTestReadMessages(5, time.Now(), nil)
+	memStore, err := NewMemManager(WithStoreSize(5)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	for i := range writeMessages[:4] {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+	}
+
+	it, err := memStore.(wal.Iterable).Iterator()
+	assert.NoError(t, err)
+	msg, err := it.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, writeMessages[0].Header.ID, msg.Header.ID)
+
+	// the evicted message is skipped and the message written after the iterator is created is not returned
+	assert.True(t, memStore.(wal.Evicter).EvictOldest())
+	assert.True(t, memStore.(wal.Evicter).EvictOldest())
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[4]))
+	for _, want := range writeMessages[2:4] {
+		msg, err = it.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, want.Header.ID, msg.Header.ID)
+	}
+	_, err = it.Next()
+	assert.ErrorIs(t, err, io.EOF)
+	assert.NoError(t, it.Close())
+}
+
+func TestMemoryStore_Truncate(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+	writeMessages := testutils.BuildTestReadMessages(11, time.Now(), nil)
+	memStore, err := NewMemManager(WithStoreSize(10)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	for i := range writeMessages[:10] {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+	}
+
+	truncater := memStore.(wal.Truncater)
+	assert.ErrorAs(t, truncater.Truncate(11), &wal.OffsetOutOfRangeErr{})
+	assert.NoError(t, truncater.Truncate(5))
+	assert.Equal(t, int64(5), memStore.Size())
+	replayed := readAllNonNil(memStore)
+	assert.Len(t, replayed, 5)
+	for i, msg := range replayed {
+		assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+	}
+
+	// the reads beyond the new end find the end of the store
+	msgs, eof, err := memStore.(wal.OffsetReader).ReadAt(5, 1)
+	assert.NoError(t, err)
+	assert.True(t, eof)
+	assert.Len(t, msgs, 0)
+	_, _, err = memStore.(wal.OffsetReader).ReadAt(6, 1)
+	assert.ErrorAs(t, err, &wal.OffsetOutOfRangeErr{})
+
+	// the next write is at the truncated offset
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[10]))
+	msgs, _, err = memStore.(wal.OffsetReader).ReadAt(5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, writeMessages[10].Header.ID, msgs[0].Header.ID)
+}
+
+func TestMemoryStore_ReleaseOnRead(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+	storeSize := int64(4)
+	memStore, err := NewMemManager(WithStoreSize(storeSize), WithReleaseOnRead()).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	store := memStore.(*memoryStore)
+	retained := func() int {
+		count := 0
+		for _, msg := range store.storage {
+			if msg != nil {
+				count++
+			}
+		}
+		return count
+	}
+
+	// many more messages than the store size are written and read interleaved
+	writeMessages := testutils.BuildTestReadMessages(50, time.Now(), nil)
+	var offset int64
+	for i := 0; i < len(writeMessages); i += 2 {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i+1]))
+		assert.LessOrEqual(t, retained(), 2)
+
+		msgs, eof, err := store.ReadAt(offset, 2)
+		assert.NoError(t, err)
+		assert.True(t, eof)
+		assert.Len(t, msgs, 2)
+		assert.Equal(t, writeMessages[i].Header.ID, msgs[0].Header.ID)
+		assert.Equal(t, writeMessages[i+1].Header.ID, msgs[1].Header.ID)
+		offset += 2
+		assert.Equal(t, 0, retained())
+	}
+	// the offsets do not change with the release
+	assert.Equal(t, int64(len(writeMessages)), memStore.Size())
+
+	// the released messages cannot be read again
+	_, _, err = store.ReadAt(offset-1, 1)
+	assert.ErrorAs(t, err, &wal.OffsetOutOfRangeErr{})
+
+	// only the unread messages are replayed
+	unread := testutils.BuildTestReadMessages(3, time.Now(), nil)
+	for i := range unread {
+		assert.NoError(t, memStore.Write(ctx, &unread[i]))
+	}
+	replayed := readAllNonNil(memStore)
+	assert.Len(t, replayed, 3)
+	for i, msg := range replayed {
+		assert.Equal(t, unread[i].Header.ID, msg.Header.ID)
+	}
+}
+
+func TestMemoryStore_LazyAllocation(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	t.Run("proportional to the written messages", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(1000000), WithStoreSizeBytes(1<<30)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		store := memStore.(*memoryStore)
+		assert.Equal(t, defaultCapacityHint, cap(store.storage))
+
+		writeMessages := testutils.BuildTestReadMessages(1000, time.Now(), nil)
+		for i := range writeMessages[:10] {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+		}
+		assert.Equal(t, defaultCapacityHint, cap(store.storage))
+		for i := range writeMessages[10:] {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[10+i]))
+		}
+		assert.LessOrEqual(t, cap(store.storage), 2*len(writeMessages))
+		assert.LessOrEqual(t, cap(store.messageSizes), 2*len(writeMessages))
+		assert.Len(t, readAllNonNil(memStore), len(writeMessages))
+	})
+
+	t.Run("full at the store size", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(3), WithCapacityHint(0)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		writeMessages := testutils.BuildTestReadMessages(4, time.Now(), nil)
+		for i := range writeMessages[:3] {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+		}
+		assert.ErrorIs(t, memStore.Write(ctx, &writeMessages[3]), aligned.ErrWriteStoreFull)
+		assert.Len(t, memStore.(*memoryStore).storage, 3)
+
+		// the ring wraps once all the slots are allocated
+		assert.True(t, memStore.(wal.Evicter).EvictOldest())
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[3]))
+		replayed := readAllNonNil(memStore)
+		assert.Len(t, replayed, 3)
+		for i, msg := range replayed {
+			assert.Equal(t, writeMessages[i+1].Header.ID, msg.Header.ID)
+		}
+	})
+}
+
+func TestMemoryStore_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+	memStore, err := NewMemManager(WithStoreSize(100)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessages(50, time.Now(), nil)
+	for i := range writeMessages[:10] {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+	}
+
+	// the snapshots are taken while the messages are written
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range writeMessages[10:] {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[10+i]))
+		}
+	}()
+	snapshot, err := memStore.(wal.Snapshotter).Snapshot()
+	assert.NoError(t, err)
+	taken := len(snapshot)
+	assert.GreaterOrEqual(t, taken, 10)
+	<-done
+
+	// the writes after the snapshot do not change it
+	assert.Len(t, snapshot, taken)
+	for i, msg := range snapshot {
+		assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+	}
+
+	// the evicted messages are not included
+	assert.True(t, memStore.(wal.Evicter).EvictOldest())
+	snapshot, err = memStore.(wal.Snapshotter).Snapshot()
+	assert.NoError(t, err)
+	assert.Len(t, snapshot, len(writeMessages)-1)
+	assert.Equal(t, writeMessages[1].Header.ID, snapshot[0].Header.ID)
+}
+
+func TestMemoryStore_CapacityThreshold(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+	type crossing struct {
+		threshold float64
+		current   int64
+		max       int64
+	}
+	var crossings []crossing
+	memStore, err := NewMemManager(WithStoreSize(10), WithCapacityThreshold(func(id partition.ID, threshold float64, current int64, max int64) {
+		assert.Equal(t, partitionID, id)
+		crossings = append(crossings, crossing{threshold: threshold, current: current, max: max})
+	}, 80, 95)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// writing past the 80% mark fires the 80% threshold once, when it is reached
+	writeMessages := testutils.BuildTestReadMessages(10, time.Now(), nil)
+	for i := range writeMessages[:9] {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+	}
+	assert.Equal(t, []crossing{{threshold: 80, current: 8, max: 10}}, crossings)
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[9]))
+	assert.Equal(t, []crossing{{threshold: 80, current: 8, max: 10}, {threshold: 95, current: 10, max: 10}}, crossings)
+
+	// the threshold fires again once the fill drops below it and crosses it again
+	assert.NoError(t, memStore.(wal.Truncater).Truncate(7))
+	for i := range writeMessages[7:] {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[7+i]))
+	}
+	assert.Len(t, crossings, 4)
+	assert.Equal(t, crossing{threshold: 80, current: 8, max: 10}, crossings[2])
+}
+
+func TestMemoryStore_CopyOnRead(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "copy-on-read"}
+	mutate := func(msg *isb.Message) {
+		msg.Payload[0] = 'x'
+		msg.Keys[0] = "mutated"
+		msg.Headers["key1"] = "mutated"
+		msg.ID.Offset = "mutated"
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(10), WithCopyOnRead()).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		writeMessages := testutils.BuildTestReadMessages(3, time.Unix(60, 0), []string{"key"})
+		expected := testutils.BuildTestReadMessages(3, time.Unix(60, 0), []string{"key"})
+		for i := range writeMessages {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+		}
+
+		// neither the written message, which is shared with the reader of the pbq, nor the read ones are stored
+		mutate(&writeMessages[0].Message)
+		read, _, err := memStore.(wal.OffsetReader).ReadAt(0, 3)
+		assert.NoError(t, err)
+		mutate(&read[1].Message)
+		it, err := memStore.(wal.Iterable).Iterator()
+		assert.NoError(t, err)
+		msg, err := it.Next()
+		assert.NoError(t, err)
+		mutate(&msg.Message)
+		replayed, _ := memStore.Replay()
+		for msg := range replayed {
+			mutate(&msg.Message)
+		}
+
+		snapshot, err := memStore.(wal.Snapshotter).Snapshot()
+		assert.NoError(t, err)
+		assert.Len(t, snapshot, 3)
+		for i, msg := range snapshot {
+			assert.Equal(t, expected[i].Message, *msg)
+		}
+		// the snapshot is a copy too
+		mutate(snapshot[2])
+		read, _, err = memStore.(wal.OffsetReader).ReadAt(0, 3)
+		assert.NoError(t, err)
+		for i, msg := range read {
+			assert.Equal(t, expected[i].Message, msg.Message)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(10)).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		writeMessages := testutils.BuildTestReadMessages(1, time.Unix(60, 0), []string{"key"})
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[0]))
+
+		// the reads are zero-copy, a mutation of a read message changes the stored one
+		read, _, err := memStore.(wal.OffsetReader).ReadAt(0, 1)
+		assert.NoError(t, err)
+		mutate(&read[0].Message)
+		snapshot, err := memStore.(wal.Snapshotter).Snapshot()
+		assert.NoError(t, err)
+		assert.Equal(t, "mutated", snapshot[0].Keys[0])
+		assert.Equal(t, byte('x'), snapshot[0].Payload[0])
+	})
+
+	t.Run("replay while evicted", func(t *testing.T) {
+		memStore, err := NewMemManager(WithStoreSize(10), WithCopyOnRead()).CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		writeMessages := testutils.BuildTestReadMessages(5, time.Unix(60, 0), []string{"key"})
+		for i := range writeMessages {
+			assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+		}
+
+		// the freed slots are never copied
+		replayed, _ := memStore.Replay()
+		assert.NotNil(t, <-replayed)
+		for memStore.(wal.Evicter).EvictOldest() {
+		}
+		for msg := range replayed {
+			assert.NotNil(t, msg)
+		}
+		assert.Nil(t, copyMessage(nil))
+	})
+}
+
+func TestMemoryStore_ReplayConcurrent(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "replay-concurrent"}
+	memStore, err := NewMemManager(WithStoreSize(100)).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(50, time.Unix(60, 0), nil)
+	for i := range writeMessages {
+		assert.NoError(t, memStore.Write(ctx, &writeMessages[i]))
+	}
+
+	replayed, _ := memStore.Replay()
+	var offsets []int64
+	for i := 0; i < 10; i++ {
+		msg := <-replayed
+		offset, err := msg.ReadOffset.Sequence()
+		assert.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+	// the store is changed while it is replayed, the evicted and the truncated messages are skipped
+	for i := 0; i < 15; i++ {
+		assert.True(t, memStore.(wal.Evicter).EvictOldest())
+	}
+	// the message written after the call is not replayed
+	assert.NoError(t, memStore.Write(ctx, &writeMessages[0]))
+	assert.NoError(t, memStore.(wal.Truncater).Truncate(15))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		metadataStore := memStore.(wal.MetadataStore)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, metadataStore.PersistMetadata(wal.PartitionMetadata{PartitionID: partitionID, Keys: []string{"key"}}))
+			_, err := metadataStore.LoadMetadata()
+			assert.NoError(t, err)
+		}
+	}()
+	for msg := range replayed {
+		offset, err := msg.ReadOffset.Sequence()
+		assert.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+	<-done
+
+	// the message read before the eviction may still be replayed
+	assert.GreaterOrEqual(t, len(offsets), 25)
+	assert.LessOrEqual(t, len(offsets), 26)
+	for i, offset := range offsets {
+		if i < 10 {
+			assert.Equal(t, int64(i), offset)
+		} else if i > 0 {
+			assert.Greater(t, offset, offsets[i-1])
+			assert.True(t, offset == 10 || (offset >= 15 && offset < 30), offset)
+		}
+	}
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aligned
+
+import (
+	"errors"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	LabelStoreBackend   = "backend"
+	LabelWriteErrorType = "type"
+)
+
+// StoreWriteErrors is the number of failed writes to the aligned WALs, by the type of the error and the backend of the
+// WAL.
+var StoreWriteErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "pbq",
+	Name:      "store_write_errors_total",
+	Help:      "Total number of errors writing to the PBQ store, by error type and store backend",
+}, []string{LabelStoreBackend, LabelWriteErrorType})
+
+// WriteErrorType classifies a write error of a WAL as "full", "closed", "corrupt" or "io".
+func WriteErrorType(err error) string {
+	switch {
+	case errors.Is(err, ErrWriteStoreFull):
+		return "full"
+	case errors.Is(err, ErrWriteStoreClosed), errors.Is(err, os.ErrClosed):
+		return "closed"
+	case errors.Is(err, ErrCorruptRecord):
+		return "corrupt"
+	default:
+		return "io"
+	}
+}
+
+// RecordWriteError increments StoreWriteErrors for the write error of a WAL of the backend.
+func RecordWriteError(backend string, err error) {
+	StoreWriteErrors.With(map[string]string{
+		LabelStoreBackend:   backend,
+		LabelWriteErrorType: WriteErrorType(err),
+	}).Inc()
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aligned
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteErrorType(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{err: ErrWriteStoreFull, expected: "full"},
+		{err: fmt.Errorf("failed to write, %w", ErrWriteStoreFull), expected: "full"},
+		{err: ErrWriteStoreClosed, expected: "closed"},
+		{err: &fs.PathError{Op: "write", Path: "segment", Err: os.ErrClosed}, expected: "closed"},
+		{err: fmt.Errorf("data checksum not match, %w", ErrCorruptRecord), expected: "corrupt"},
+		{err: errors.New("no space left on device"), expected: "io"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, WriteErrorType(tt.err))
+		})
+	}
+}
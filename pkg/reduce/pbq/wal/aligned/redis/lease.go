@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+var (
+	// acquireLeaseScript sets the lease to the owner unless another owner holds it, and returns the holder of the lease
+	acquireLeaseScript = redis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder == false or holder == ARGV[1] then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return ARGV[1]
+end
+return holder`)
+	// renewLeaseScript extends the lease if the owner holds it, and returns 0 otherwise
+	renewLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0`)
+	// releaseLeaseScript deletes the lease if the owner holds it
+	releaseLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0`)
+)
+
+var _ wal.Leaser = (*redisManager)(nil)
+
+// AcquireLease sets the lease key of the partition to the owner with the ttl as its expiry, unless another owner holds
+// it.
+func (rm *redisManager) AcquireLease(ctx context.Context, partitionID partition.ID, owner string, ttl time.Duration) error {
+	holder, err := acquireLeaseScript.Run(ctx, rm.client.Client, []string{rm.leaseKey(partitionID)}, owner, ttl.Milliseconds()).Text()
+	if err != nil {
+		return fmt.Errorf("failed to acquire the lease, %w", err)
+	}
+	if holder != owner {
+		return wal.LeaseHeldErr{PartitionID: partitionID, Holder: holder}
+	}
+	return nil
+}
+
+// RenewLease resets the expiry of the lease key of the partition to the ttl if the owner holds it.
+func (rm *redisManager) RenewLease(ctx context.Context, partitionID partition.ID, owner string, ttl time.Duration) error {
+	renewed, err := renewLeaseScript.Run(ctx, rm.client.Client, []string{rm.leaseKey(partitionID)}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("failed to renew the lease, %w", err)
+	}
+	if renewed == 0 {
+		return wal.LeaseLostErr{PartitionID: partitionID, Owner: owner}
+	}
+	return nil
+}
+
+// ReleaseLease deletes the lease key of the partition if the owner holds it.
+func (rm *redisManager) ReleaseLease(ctx context.Context, partitionID partition.ID, owner string) error {
+	return releaseLeaseScript.Run(ctx, rm.client.Client, []string{rm.leaseKey(partitionID)}, owner).Err()
+}
+
+// leaseKey is the key of the lease of the partition in the keyspace of the manager, it holds the owner of the lease.
+func (rm *redisManager) leaseKey(id partition.ID) string {
+	return fmt.Sprintf("%s:lease:%s", rm.keyPrefix, id.String())
+}
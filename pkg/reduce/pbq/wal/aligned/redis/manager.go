@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+	redisclient "github.com/numaproj/numaflow/pkg/shared/clients/redis"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+func init() {
+	if err := wal.RegisterStoreType(string(dfv1.RedisType), newRegisteredManager); err != nil {
+		panic(err)
+	}
+}
+
+type redisManager struct {
+	address         string
+	db              int
+	keyPrefix       string
+	replayBatchSize int64
+	client          *redisclient.RedisClient
+	activeWals      map[string]wal.WAL
+	mu              sync.RWMutex
+	log             *zap.SugaredLogger
+}
+
+// NewRedisManager is a Redis WAL Manager. Each partition is persisted in a list keyed by the partition ID, and the
+// partitions are indexed in a hash so that they can be discovered after a restart.
+func NewRedisManager(opts ...Option) wal.Manager {
+	s := &redisManager{
+		address:         ":6379",
+		keyPrefix:       "pbq",
+		replayBatchSize: dfv1.DefaultPBQReadBatchSize,
+		activeWals:      make(map[string]wal.WAL),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.client == nil {
+		s.client = redisclient.NewRedisClient(&redis.UniversalOptions{
+			Addrs: []string{s.address},
+			DB:    s.db,
+		})
+	}
+	if s.log == nil {
+		s.log = logging.NewLogger()
+	}
+	return s
+}
+
+// newRegisteredManager creates the manager of the redis store type on the Redis of the inter-step buffer service. The
+// keys of the partitions, of their index and of their leases are prefixed by the pipeline, the vertex and the replica,
+// so that the vertices and the replicas sharing the Redis do not discover each other's partitions, while the pod which
+// replaces a replica contends for the leases of its stale pod.
+func newRegisteredManager(ctx context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+	return NewRedisManager(
+		WithClient(redisclient.NewInClusterRedisClient()),
+		WithKeyPrefix(fmt.Sprintf("pbq:%s:%s:%d", opts.PipelineName, opts.VertexName, opts.Replica)),
+		WithLogger(logging.FromContext(ctx)),
+	), nil
+}
+
+// CreateWAL registers the partition in the index and returns the WAL.
+func (rm *redisManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	// during crash recovery, we might have already created the WAL while replaying
+	rm.mu.RLock()
+	w, ok := rm.activeWals[partitionID.String()]
+	rm.mu.RUnlock()
+	if ok {
+		return w, nil
+	}
+
+	if err := rm.client.Client.HSet(ctx, rm.indexKey(), partitionID.String(), aligned.EncodePartitionID(partitionID)).Err(); err != nil {
+		return nil, fmt.Errorf("failed to register the partition, %w", err)
+	}
+
+	w, err := rm.newWAL(ctx, &partitionID)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	rm.activeWals[partitionID.String()] = w
+	rm.mu.Unlock()
+	return w, nil
+}
+
+// DiscoverWALs returns a WAL for every partition registered in the index.
+func (rm *redisManager) DiscoverWALs(ctx context.Context) ([]wal.WAL, error) {
+	index, err := rm.client.Client.HGetAll(ctx, rm.indexKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make([]wal.WAL, 0, len(index))
+	for _, value := range index {
+		id, err := aligned.DecodePartitionID([]byte(value))
+		if err != nil {
+			return nil, err
+		}
+		w, err := rm.newWAL(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, w)
+	}
+
+	rm.mu.Lock()
+	for _, w := range partitions {
+		rm.activeWals[w.PartitionID().String()] = w
+	}
+	rm.mu.Unlock()
+	return partitions, nil
+}
+
+// DeleteWAL deletes the list of the given partitionID and removes it from the index.
+func (rm *redisManager) DeleteWAL(partitionID partition.ID) error {
+	_, err := rm.client.Client.TxPipelined(redisclient.RedisContext, func(pipe redis.Pipeliner) error {
+		pipe.Del(redisclient.RedisContext, rm.partitionKey(partitionID))
+		pipe.HDel(redisclient.RedisContext, rm.indexKey(), partitionID.String())
+		return nil
+	})
+	rm.mu.Lock()
+	delete(rm.activeWals, partitionID.String())
+	rm.mu.Unlock()
+	return err
+}
+
+// ReleaseWAL forgets the WAL of the partition if it is w, its messages are kept.
+func (rm *redisManager) ReleaseWAL(partitionID partition.ID, w wal.WAL) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.activeWals[partitionID.String()] == w {
+		delete(rm.activeWals, partitionID.String())
+	}
+}
+
+// Close closes the Redis client.
+func (rm *redisManager) Close() error {
+	rm.client.Close()
+	return nil
+}
+
+// newWAL returns the WAL of the partition, it fails if the length of its list cannot be read.
+func (rm *redisManager) newWAL(ctx context.Context, id *partition.ID) (*redisWAL, error) {
+	w := &redisWAL{
+		client:          rm.client,
+		key:             rm.partitionKey(*id),
+		partitionID:     id,
+		replayBatchSize: rm.replayBatchSize,
+		log:             rm.log.With("partitionID", id.String()),
+	}
+	size, err := rm.client.Client.LLen(ctx, w.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the size of the WAL of partition %s, %w", id.String(), err)
+	}
+	w.size.Store(size)
+	return w, nil
+}
+
+// indexKey is the key of the hash which holds all the partitions.
+func (rm *redisManager) indexKey() string {
+	return fmt.Sprintf("%s:partitions", rm.keyPrefix)
+}
+
+// partitionKey is the key of the list which holds the messages of the partition.
+func (rm *redisManager) partitionKey(id partition.ID) string {
+	return fmt.Sprintf("%s:%s", rm.keyPrefix, id.String())
+}
@@ -0,0 +1,143 @@
+//go:build isb_redis
+
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+func readAll(t *testing.T, w wal.WAL) []*isb.ReadMessage {
+	msgCh, errCh := w.Replay()
+	readMessages := make([]*isb.ReadMessage, 0)
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				return readMessages
+			}
+			readMessages = append(readMessages, msg)
+		case err, ok := <-errCh:
+			if ok {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestRedisManager(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	partitionIds := []partition.ID{
+		{
+			Start: time.Unix(60, 0),
+			End:   time.Unix(120, 0),
+			Slot:  "test-1",
+		},
+		{
+			Start: time.Unix(120, 0),
+			End:   time.Unix(180, 0),
+			Slot:  "test-2",
+		},
+	}
+
+	// a small replay batch size makes sure the replay spans multiple LRANGE calls
+	storeProvider := NewRedisManager(WithAddress(":6379"), WithKeyPrefix("test-pbq"), WithReplayBatchSize(3))
+	defer func() { _ = storeProvider.(io.Closer).Close() }()
+
+	writeMessages := testutils.BuildTestReadMessages(10, time.Unix(60, 0), nil)
+	for _, partitionID := range partitionIds {
+		store, err := storeProvider.CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		for _, msg := range writeMessages {
+			assert.NoError(t, store.Write(ctx, &msg))
+		}
+	}
+
+	// a new manager simulates a restart of the pod
+	restarted := NewRedisManager(WithAddress(":6379"), WithKeyPrefix("test-pbq"), WithReplayBatchSize(3))
+	defer func() { _ = restarted.(io.Closer).Close() }()
+
+	discoveredStores, err := restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, len(partitionIds))
+	for _, store := range discoveredStores {
+		assert.Equal(t, int64(len(writeMessages)), store.Size())
+		readMessages := readAll(t, store)
+		assert.Len(t, readMessages, len(writeMessages))
+		for i, msg := range readMessages {
+			assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+		}
+	}
+
+	for _, partitionID := range partitionIds {
+		assert.NoError(t, restarted.DeleteWAL(partitionID))
+	}
+	discoveredStores, err = restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 0)
+}
+
+func TestRedisManager_Lease(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "test-lease"}
+
+	// the stale pod of the replica and the pod which replaces it share the key prefix
+	stalePod := NewRedisManager(WithAddress(":6379"), WithKeyPrefix("test-pbq-0"))
+	defer func() { _ = stalePod.(io.Closer).Close() }()
+	newPod := NewRedisManager(WithAddress(":6379"), WithKeyPrefix("test-pbq-0"))
+	defer func() { _ = newPod.(io.Closer).Close() }()
+	leaser0, leaser1 := stalePod.(wal.Leaser), newPod.(wal.Leaser)
+
+	assert.NoError(t, leaser0.AcquireLease(ctx, partitionID, "pod-0", time.Second))
+	// the holder can acquire the lease again
+	assert.NoError(t, leaser0.AcquireLease(ctx, partitionID, "pod-0", time.Second))
+	var heldErr wal.LeaseHeldErr
+	assert.ErrorAs(t, leaser1.AcquireLease(ctx, partitionID, "pod-1", time.Second), &heldErr)
+	assert.Equal(t, "pod-0", heldErr.Holder)
+	assert.NoError(t, leaser0.RenewLease(ctx, partitionID, "pod-0", time.Second))
+	assert.ErrorAs(t, leaser1.RenewLease(ctx, partitionID, "pod-1", time.Second), &wal.LeaseLostErr{})
+
+	// another replica has the same partition in its own keyspace
+	otherReplica := NewRedisManager(WithAddress(":6379"), WithKeyPrefix("test-pbq-1"))
+	defer func() { _ = otherReplica.(io.Closer).Close() }()
+	assert.NoError(t, otherReplica.(wal.Leaser).AcquireLease(ctx, partitionID, "pod-2", time.Second))
+	assert.NoError(t, otherReplica.(wal.Leaser).ReleaseLease(ctx, partitionID, "pod-2"))
+
+	// the lease is taken over once it expires
+	time.Sleep(1100 * time.Millisecond)
+	assert.NoError(t, leaser1.AcquireLease(ctx, partitionID, "pod-1", time.Second))
+	assert.ErrorAs(t, leaser0.RenewLease(ctx, partitionID, "pod-0", time.Second), &wal.LeaseLostErr{})
+	// only the holder releases the lease
+	assert.NoError(t, leaser0.ReleaseLease(ctx, partitionID, "pod-0"))
+	assert.ErrorAs(t, leaser0.AcquireLease(ctx, partitionID, "pod-0", time.Second), &heldErr)
+	assert.NoError(t, leaser1.ReleaseLease(ctx, partitionID, "pod-1"))
+	assert.NoError(t, leaser0.AcquireLease(ctx, partitionID, "pod-0", time.Second))
+	assert.NoError(t, leaser0.ReleaseLease(ctx, partitionID, "pod-0"))
+}
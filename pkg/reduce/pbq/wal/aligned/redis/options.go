@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"go.uber.org/zap"
+
+	redisclient "github.com/numaproj/numaflow/pkg/shared/clients/redis"
+)
+
+type Option func(stores *redisManager)
+
+// WithAddress sets the address of the Redis instance
+func WithAddress(address string) Option {
+	return func(stores *redisManager) {
+		stores.address = address
+	}
+}
+
+// WithDB sets the Redis DB index
+func WithDB(db int) Option {
+	return func(stores *redisManager) {
+		stores.db = db
+	}
+}
+
+// WithKeyPrefix sets the prefix of the Redis keys, it has to be unique per vertex replica
+func WithKeyPrefix(prefix string) Option {
+	return func(stores *redisManager) {
+		stores.keyPrefix = prefix
+	}
+}
+
+// WithReplayBatchSize sets the number of entries fetched in a single LRANGE during replay
+func WithReplayBatchSize(size int64) Option {
+	return func(stores *redisManager) {
+		stores.replayBatchSize = size
+	}
+}
+
+// WithClient sets the Redis client, the address and the DB are ignored if it is set. The client is closed by the manager.
+func WithClient(client *redisclient.RedisClient) Option {
+	return func(stores *redisManager) {
+		stores.client = client
+	}
+}
+
+// WithLogger sets the logger
+func WithLogger(log *zap.SugaredLogger) Option {
+	return func(stores *redisManager) {
+		stores.log = log
+	}
+}
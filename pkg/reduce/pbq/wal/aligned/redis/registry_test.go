@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+func TestRedisManager_Registered(t *testing.T) {
+	assert.Contains(t, wal.StoreTypes(), string(dfv1.RedisType))
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-0"}
+	manager, err := wal.NewStoreManager(context.Background(), string(dfv1.RedisType), wal.ManagerOptions{PipelineName: "p", VertexName: "v", Replica: 1})
+	assert.NoError(t, err)
+	defer func() { _ = manager.(*redisManager).Close() }()
+
+	// the replicas of the vertex have their own partitions and leases
+	rm := manager.(*redisManager)
+	assert.Equal(t, "pbq:p:v:1:partitions", rm.indexKey())
+	assert.Equal(t, "pbq:p:v:1:"+partitionID.String(), rm.partitionKey(partitionID))
+	assert.Equal(t, "pbq:p:v:1:lease:"+partitionID.String(), rm.leaseKey(partitionID))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+	redisclient "github.com/numaproj/numaflow/pkg/shared/clients/redis"
+)
+
+// redisWAL implements wal.WAL on top of a Redis list.
+type redisWAL struct {
+	client          *redisclient.RedisClient
+	key             string
+	partitionID     *partition.ID
+	replayBatchSize int64
+	// size is the length of the list, it is read when the WAL is created and kept up to date by the writes, so that
+	// Size does not depend on Redis being reachable
+	size atomic.Int64
+	// closed is set by Close, which can race with the writes of the PBQ
+	closed atomic.Bool
+	log    *zap.SugaredLogger
+}
+
+var _ wal.WAL = (*redisWAL)(nil)
+
+// Replay replays all the entries of the list in the order they were written, the list is read in batches of
+// replayBatchSize.
+func (r *redisWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+
+		var readPos int64
+		for {
+			entries, err := r.client.Client.LRange(redisclient.RedisContext, r.key, readPos, readPos+r.replayBatchSize-1).Result()
+			if err != nil {
+				errs <- err
+				return
+			}
+			for _, entry := range entries {
+				msg, err := aligned.DecodeEntry([]byte(entry))
+				if err != nil {
+					errs <- err
+					return
+				}
+				messages <- msg
+			}
+			if int64(len(entries)) < r.replayBatchSize {
+				return
+			}
+			readPos += int64(len(entries))
+		}
+	}()
+	return messages, errs
+}
+
+// Write appends the message to the list, it returns ctx.Err() if the context is canceled before the write completes.
+func (r *redisWAL) Write(ctx context.Context, msg *isb.ReadMessage) error {
+	if r.closed.Load() {
+		return aligned.ErrWriteStoreClosed
+	}
+	entry, err := aligned.EncodeEntry(msg)
+	if err != nil {
+		return err
+	}
+	return r.push(ctx, entry)
+}
+
+// WriteBatch appends all the messages to the list using a single RPUSH.
+func (r *redisWAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	if r.closed.Load() {
+		return aligned.ErrWriteStoreClosed
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	entries := make([]interface{}, 0, len(msgs))
+	for _, msg := range msgs {
+		entry, err := aligned.EncodeEntry(msg)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	return r.push(ctx, entries...)
+}
+
+// push appends the entries to the list and updates the size with the length returned by RPUSH. A failed push could
+// still have appended the entries, so the size is read again.
+func (r *redisWAL) push(ctx context.Context, entries ...interface{}) error {
+	size, err := r.client.Client.RPush(ctx, r.key, entries...).Result()
+	if err != nil {
+		if size, lenErr := r.client.Client.LLen(redisclient.RedisContext, r.key).Result(); lenErr == nil {
+			r.size.Store(size)
+		} else {
+			r.log.Errorw("Failed to read the size of the WAL after a failed write", zap.String("key", r.key), zap.Error(lenErr))
+		}
+		return err
+	}
+	r.size.Store(size)
+	return nil
+}
+
+// PartitionID returns the partition ID of the WAL.
+func (r *redisWAL) PartitionID() *partition.ID {
+	return r.partitionID
+}
+
+// Size returns the length of the list as of the last write.
+func (r *redisWAL) Size() int64 {
+	return r.size.Load()
+}
+
+// Flush is a no-op, every write is acknowledged by Redis before it returns.
+func (r *redisWAL) Flush() error {
+	return nil
+}
+
+// Ping pings the Redis server.
+func (r *redisWAL) Ping(ctx context.Context) error {
+	return r.client.Client.Ping(ctx).Err()
+}
+
+// Close closes the WAL, no more writes will be accepted. The client is shared across partitions and is owned by
+// the manager.
+func (r *redisWAL) Close() error {
+	r.closed.Store(true)
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// maxDeleteKeys is the maximum number of keys of a single DeleteObjects request.
+const maxDeleteKeys = 1000
+
+// awsClient is the ObjectClient of the AWS SDK, it works with any S3 compatible object store.
+type awsClient struct {
+	client *s3.Client
+}
+
+var _ ObjectClient = (*awsClient)(nil)
+
+// NewAWSClientFactory returns a ClientFactory which creates the clients using the default AWS credential chain, e.g. the
+// env or the service account of the pod. If the endpoint is not empty it replaces the AWS endpoints, e.g. for minio, and
+// the buckets are addressed by the path.
+func NewAWSClientFactory(endpoint string) ClientFactory {
+	return func(region string) (ObjectClient, error) {
+		var opts []func(*config.LoadOptions) error
+		if region != "" {
+			opts = append(opts, config.WithRegion(region))
+		}
+		cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the AWS config, %w", err)
+		}
+		client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+				o.UsePathStyle = true
+			}
+		})
+		return &awsClient{client: client}, nil
+	}
+}
+
+// PutObject uploads the data as the object with the given key.
+func (c *awsClient) PutObject(ctx context.Context, bucket string, key string, data []byte) error {
+	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+// GetObject downloads the object with the given key.
+func (c *awsClient) GetObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = out.Body.Close() }()
+	return io.ReadAll(out.Body)
+}
+
+// ListObjects returns the keys of all the objects which start with the given prefix, it follows the pagination.
+func (c *awsClient) ListObjects(ctx context.Context, bucket string, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			keys = append(keys, aws.ToString(obj.Key))
+		}
+	}
+	return keys, nil
+}
+
+// DeleteObjects deletes the objects with the given keys, in batches of the maximum number of keys per request.
+func (c *awsClient) DeleteObjects(ctx context.Context, bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += maxDeleteKeys {
+		end := min(start+maxDeleteKeys, len(keys))
+		objects := make([]types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, types.ObjectIdentifier{Key: aws.String(key)})
+		}
+		out, err := c.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return err
+		}
+		if len(out.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d objects, e.g. %s, %s", len(out.Errors), aws.ToString(out.Errors[0].Key), aws.ToString(out.Errors[0].Message))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+)
+
+// ObjectClient is the subset of an S3 compatible object store API used by the WAL. It is injected so that the WAL does
+// not depend on a particular SDK, and so that it can be tested against an in-memory or a minio/localstack backed client.
+type ObjectClient interface {
+	// PutObject uploads the data as the object with the given key, it replaces the object if it exists.
+	PutObject(ctx context.Context, bucket string, key string, data []byte) error
+	// GetObject downloads the object with the given key.
+	GetObject(ctx context.Context, bucket string, key string) ([]byte, error)
+	// ListObjects returns the keys of all the objects which start with the given prefix.
+	ListObjects(ctx context.Context, bucket string, prefix string) ([]string, error)
+	// DeleteObjects deletes the objects with the given keys, keys which do not exist are ignored.
+	DeleteObjects(ctx context.Context, bucket string, keys []string) error
+}
+
+// ClientFactory creates the ObjectClient for the given region.
+type ClientFactory func(region string) (ObjectClient, error)
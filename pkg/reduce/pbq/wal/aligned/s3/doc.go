@@ -0,0 +1,21 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3 implements write-ahead-log on an S3 compatible object store, it is meant for cold partitions which would
+// rather not hold local disk. Writes are buffered in memory and uploaded as sealed segment objects keyed by the
+// partition ID, replay downloads the segments in the order they were sealed, and deleting the WAL of a partition
+// deletes all of its objects.
+package s3
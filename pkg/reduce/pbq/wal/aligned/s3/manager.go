@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+const (
+	// defaultPrefix is the default prefix of the object keys.
+	defaultPrefix = "numaflow-pbq"
+)
+
+func init() {
+	if err := wal.RegisterStoreType(string(dfv1.S3Type), newRegisteredManager); err != nil {
+		panic(err)
+	}
+}
+
+type s3Manager struct {
+	bucket      string
+	prefix      string
+	region      string
+	segmentSize int64
+	client      ObjectClient
+	activeWals  map[string]wal.WAL
+	mu          sync.RWMutex
+}
+
+// NewS3Manager is an object store WAL Manager. The segments of a partition are stored under
+// <prefix>/<encoded partition ID>/ in the bucket, the client is created by newClient for the configured region.
+func NewS3Manager(newClient ClientFactory, opts ...Option) (wal.Manager, error) {
+	s := &s3Manager{
+		prefix:     defaultPrefix,
+		activeWals: make(map[string]wal.WAL),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	if s.bucket == "" {
+		return nil, fmt.Errorf("bucket is required for the s3 WAL")
+	}
+	client, err := newClient(s.region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the object store client, %w", err)
+	}
+	s.client = client
+	return s, nil
+}
+
+// newRegisteredManager creates the manager of the s3 store type using the AWS SDK. The bucket, the region and the
+// endpoint are read from the env, and the object keys are prefixed by the pipeline, the vertex and the replica.
+func newRegisteredManager(_ context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+	return NewS3Manager(NewAWSClientFactory(os.Getenv(dfv1.EnvPBQS3Endpoint)),
+		WithBucket(os.Getenv(dfv1.EnvPBQS3Bucket)),
+		WithRegion(os.Getenv(dfv1.EnvPBQS3Region)),
+		WithPrefix(fmt.Sprintf("%s/%s/%s/%d", defaultPrefix, opts.PipelineName, opts.VertexName, opts.Replica)),
+	)
+}
+
+// CreateWAL returns a WAL for the partition. Nothing is uploaded until the first segment is sealed.
+func (sm *s3Manager) CreateWAL(_ context.Context, partitionID partition.ID) (wal.WAL, error) {
+	// during crash recovery, we might have already created the WAL while replaying
+	sm.mu.RLock()
+	w, ok := sm.activeWals[partitionID.String()]
+	sm.mu.RUnlock()
+	if ok {
+		return w, nil
+	}
+
+	w = sm.newWAL(sm.partitionPrefix(partitionID), &partitionID, nil)
+	sm.mu.Lock()
+	sm.activeWals[partitionID.String()] = w
+	sm.mu.Unlock()
+	return w, nil
+}
+
+// DiscoverWALs returns a WAL for every partition which has at least one segment in the bucket.
+func (sm *s3Manager) DiscoverWALs(ctx context.Context) ([]wal.WAL, error) {
+	keys, err := sm.client.ListObjects(ctx, sm.bucket, sm.prefix+"/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the segments, %w", err)
+	}
+
+	segmentsByPartition := make(map[string][]segment)
+	for _, key := range keys {
+		encodedID, name := path.Split(strings.TrimPrefix(key, sm.prefix+"/"))
+		encodedID = strings.TrimSuffix(encodedID, "/")
+		seg, err := parseSegment(key, name)
+		if err != nil {
+			return nil, err
+		}
+		segmentsByPartition[encodedID] = append(segmentsByPartition[encodedID], seg)
+	}
+
+	partitions := make([]wal.WAL, 0, len(segmentsByPartition))
+	for encodedID, segments := range segmentsByPartition {
+		raw, err := base64.RawURLEncoding.DecodeString(encodedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the partition of %s, %w", encodedID, err)
+		}
+		id, err := aligned.DecodePartitionID(raw)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(segments, func(i, j int) bool { return segments[i].seq < segments[j].seq })
+		partitions = append(partitions, sm.newWAL(sm.prefix+"/"+encodedID+"/", id, segments))
+	}
+
+	sm.mu.Lock()
+	for _, w := range partitions {
+		sm.activeWals[w.PartitionID().String()] = w
+	}
+	sm.mu.Unlock()
+	return partitions, nil
+}
+
+// DeleteWAL deletes all the segments of the given partitionID.
+func (sm *s3Manager) DeleteWAL(partitionID partition.ID) error {
+	ctx := context.Background()
+	keys, err := sm.client.ListObjects(ctx, sm.bucket, sm.partitionPrefix(partitionID))
+	if err == nil && len(keys) > 0 {
+		err = sm.client.DeleteObjects(ctx, sm.bucket, keys)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete the segments of partition %s, %w", partitionID.String(), err)
+	}
+	sm.mu.Lock()
+	delete(sm.activeWals, partitionID.String())
+	sm.mu.Unlock()
+	return nil
+}
+
+// ReleaseWAL forgets the WAL of the partition if it is w, its messages are kept.
+func (sm *s3Manager) ReleaseWAL(partitionID partition.ID, w wal.WAL) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.activeWals[partitionID.String()] == w {
+		delete(sm.activeWals, partitionID.String())
+	}
+}
+
+// partitionPrefix returns the key prefix of the segments of the partition. The partition ID is base64 encoded so that
+// the slot can not break the key layout.
+func (sm *s3Manager) partitionPrefix(partitionID partition.ID) string {
+	return sm.prefix + "/" + base64.RawURLEncoding.EncodeToString(aligned.EncodePartitionID(partitionID)) + "/"
+}
+
+func (sm *s3Manager) newWAL(keyPrefix string, id *partition.ID, segments []segment) *s3WAL {
+	w := &s3WAL{
+		client:      sm.client,
+		bucket:      sm.bucket,
+		keyPrefix:   keyPrefix,
+		partitionID: id,
+		segmentSize: sm.segmentSize,
+		segments:    segments,
+	}
+	if len(segments) > 0 {
+		w.nextSeq = segments[len(segments)-1].seq + 1
+	}
+	return w
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// memClient is an in-memory ObjectClient, putErr fails the uploads while it is set.
+type memClient struct {
+	objects map[string][]byte
+	putErr  error
+	mu      sync.Mutex
+}
+
+func newMemClient() *memClient {
+	return &memClient{objects: make(map[string][]byte)}
+}
+
+func (m *memClient) factory(string) (ObjectClient, error) {
+	return m, nil
+}
+
+func (m *memClient) PutObject(_ context.Context, bucket string, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.putErr != nil {
+		return m.putErr
+	}
+	m.objects[bucket+"/"+key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *memClient) GetObject(_ context.Context, bucket string, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[bucket+"/"+key]
+	if !ok {
+		return nil, errors.New("no such key")
+	}
+	return data, nil
+}
+
+func (m *memClient) ListObjects(_ context.Context, bucket string, prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0)
+	for k := range m.objects {
+		if strings.HasPrefix(k, bucket+"/"+prefix) {
+			keys = append(keys, strings.TrimPrefix(k, bucket+"/"))
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m *memClient) DeleteObjects(_ context.Context, bucket string, keys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range keys {
+		delete(m.objects, bucket+"/"+k)
+	}
+	return nil
+}
+
+func readAll(t *testing.T, w wal.WAL) []*isb.ReadMessage {
+	msgCh, errCh := w.Replay()
+	readMessages := make([]*isb.ReadMessage, 0)
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				return readMessages
+			}
+			readMessages = append(readMessages, msg)
+		case err, ok := <-errCh:
+			if ok {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestS3Manager(t *testing.T) {
+	ctx := context.Background()
+	partitionIds := []partition.ID{
+		{
+			Start: time.Unix(60, 0),
+			End:   time.Unix(120, 0),
+			Slot:  "test/1",
+		},
+		{
+			Start: time.Unix(120, 0),
+			End:   time.Unix(180, 0),
+			Slot:  "test-2",
+		},
+	}
+
+	client := newMemClient()
+	// a small segment size makes sure the partition spans multiple segments
+	storeProvider, err := NewS3Manager(client.factory, WithBucket("pbq"), WithPrefix("vertex-0"), WithRegion("us-west-2"), WithSegmentSize(512))
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessages(10, time.Unix(60, 0), nil)
+	for _, partitionID := range partitionIds {
+		store, err := storeProvider.CreateWAL(ctx, partitionID)
+		assert.NoError(t, err)
+		for _, msg := range writeMessages {
+			assert.NoError(t, store.Write(ctx, &msg))
+		}
+		assert.Equal(t, int64(len(writeMessages)), store.Size())
+		assert.NoError(t, store.Close())
+	}
+
+	// a new manager simulates a restart of the pod
+	restarted, err := NewS3Manager(client.factory, WithBucket("pbq"), WithPrefix("vertex-0"))
+	assert.NoError(t, err)
+
+	discoveredStores, err := restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, len(partitionIds))
+	for _, store := range discoveredStores {
+		assert.Contains(t, partitionIds, *store.PartitionID())
+		assert.Equal(t, int64(len(writeMessages)), store.Size())
+		readMessages := readAll(t, store)
+		assert.Len(t, readMessages, len(writeMessages))
+		for i, msg := range readMessages {
+			assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+		}
+	}
+
+	for _, partitionID := range partitionIds {
+		assert.NoError(t, restarted.DeleteWAL(partitionID))
+	}
+	assert.Len(t, client.objects, 0)
+	discoveredStores, err = restarted.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discoveredStores, 0)
+}
+
+func TestNewS3Manager_NoBucket(t *testing.T) {
+	_, err := NewS3Manager(newMemClient().factory)
+	assert.Error(t, err)
+}
+
+func TestS3Manager_Registered(t *testing.T) {
+	assert.Contains(t, wal.StoreTypes(), string(dfv1.S3Type))
+	opts := wal.ManagerOptions{PipelineName: "p", VertexName: "v", Replica: 1}
+
+	// the bucket is required
+	t.Setenv(dfv1.EnvPBQS3Bucket, "")
+	_, err := wal.NewStoreManager(context.Background(), string(dfv1.S3Type), opts)
+	assert.Error(t, err)
+
+	t.Setenv(dfv1.EnvPBQS3Bucket, "pbq-bucket")
+	t.Setenv(dfv1.EnvPBQS3Region, "us-west-2")
+	manager, err := wal.NewStoreManager(context.Background(), string(dfv1.S3Type), opts)
+	assert.NoError(t, err)
+	sm := manager.(*s3Manager)
+	assert.Equal(t, "pbq-bucket", sm.bucket)
+	assert.Equal(t, "us-west-2", sm.region)
+	assert.Equal(t, "numaflow-pbq/p/v/1", sm.prefix)
+}
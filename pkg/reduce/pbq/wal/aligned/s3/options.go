@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+type Option func(stores *s3Manager)
+
+// WithBucket sets the bucket in which the segments are stored
+func WithBucket(bucket string) Option {
+	return func(stores *s3Manager) {
+		stores.bucket = bucket
+	}
+}
+
+// WithPrefix sets the prefix of the object keys, it has to be unique per vertex replica
+func WithPrefix(prefix string) Option {
+	return func(stores *s3Manager) {
+		stores.prefix = prefix
+	}
+}
+
+// WithRegion sets the region of the bucket, it is passed to the ClientFactory
+func WithRegion(region string) Option {
+	return func(stores *s3Manager) {
+		stores.region = region
+	}
+}
+
+// WithSegmentSize sets the number of bytes buffered before a segment is sealed and uploaded. The buffered entries which
+// are not yet uploaded are lost if the pod crashes, the default of 0 uploads a segment on every write.
+func WithSegmentSize(size int64) Option {
+	return func(stores *s3Manager) {
+		stores.segmentSize = size
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+// segment is a sealed object of the partition. The sequence and the number of entries are part of the object name so
+// that the segments can be ordered and counted without downloading them.
+type segment struct {
+	key     string
+	seq     int64
+	entries int64
+}
+
+// segmentName returns the name of the segment object, the sequence is zero padded so that the names sort in the order
+// the segments were sealed.
+func segmentName(seq int64, entries int64) string {
+	return fmt.Sprintf("segment-%020d-%d", seq, entries)
+}
+
+// parseSegment parses the segment object name created by segmentName.
+func parseSegment(key string, name string) (segment, error) {
+	seg := segment{key: key}
+	if _, err := fmt.Sscanf(name, "segment-%d-%d", &seg.seq, &seg.entries); err != nil {
+		return seg, fmt.Errorf("invalid segment object %s, %w", key, err)
+	}
+	return seg, nil
+}
+
+// s3WAL implements wal.WAL on top of the segment objects of a partition. Every segment is a sequence of length
+// prefixed entries.
+//
+//	+----------------+----------------+----------------+----------------+-----+
+//	| length uint32  | entry []byte   | length uint32  | entry []byte   | ... |
+//	+----------------+----------------+----------------+----------------+-----+
+type s3WAL struct {
+	client      ObjectClient
+	bucket      string
+	keyPrefix   string
+	partitionID *partition.ID
+	segmentSize int64
+	// mu guards the segments, the buffer and closed, Close and the flush of the buffer can race with the writes of the
+	// PBQ and with its GC.
+	mu sync.Mutex
+	// segments are the sealed segments in the order they were sealed.
+	segments []segment
+	nextSeq  int64
+	// buffer holds the entries which are not yet sealed.
+	buffer          bytes.Buffer
+	bufferedEntries int64
+	closed          bool
+}
+
+var _ wal.WAL = (*s3WAL)(nil)
+
+// Replay downloads the sealed segments in the order they were sealed and replays their entries.
+func (s *s3WAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+
+	s.mu.Lock()
+	segments := append([]segment(nil), s.segments...)
+	s.mu.Unlock()
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+
+		for _, seg := range segments {
+			data, err := s.client.GetObject(context.Background(), s.bucket, seg.key)
+			if err != nil {
+				errs <- fmt.Errorf("failed to get segment %s, %w", seg.key, err)
+				return
+			}
+			if err = decodeSegment(data, messages); err != nil {
+				errs <- fmt.Errorf("failed to decode segment %s, %w", seg.key, err)
+				return
+			}
+		}
+	}()
+	return messages, errs
+}
+
+// Write writes the message to the WAL, the segment is sealed and uploaded if the buffer reaches the segment size.
+func (s *s3WAL) Write(ctx context.Context, msg *isb.ReadMessage) error {
+	return s.WriteBatch(ctx, []*isb.ReadMessage{msg})
+}
+
+// WriteBatch writes all the messages to the WAL, the segment is sealed and uploaded if the buffer reaches the segment
+// size. The messages are not buffered if the upload fails, so that the batch can be retried without duplicates.
+func (s *s3WAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	var entries bytes.Buffer
+	for _, msg := range msgs {
+		entry, err := aligned.EncodeEntry(msg)
+		if err != nil {
+			return err
+		}
+		_ = binary.Write(&entries, binary.LittleEndian, uint32(len(entry)))
+		entries.Write(entry)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return aligned.ErrWriteStoreClosed
+	}
+	if int64(s.buffer.Len()+entries.Len()) < s.segmentSize {
+		s.buffer.Write(entries.Bytes())
+		s.bufferedEntries += int64(len(msgs))
+		return nil
+	}
+
+	data := make([]byte, 0, s.buffer.Len()+entries.Len())
+	data = append(append(data, s.buffer.Bytes()...), entries.Bytes()...)
+	if err := s.seal(ctx, data, s.bufferedEntries+int64(len(msgs))); err != nil {
+		return err
+	}
+	s.buffer.Reset()
+	s.bufferedEntries = 0
+	return nil
+}
+
+// seal uploads the data as the next segment of the partition, s.mu has to be held.
+func (s *s3WAL) seal(ctx context.Context, data []byte, entries int64) error {
+	seg := segment{key: s.keyPrefix + segmentName(s.nextSeq, entries), seq: s.nextSeq, entries: entries}
+	if err := s.client.PutObject(ctx, s.bucket, seg.key, data); err != nil {
+		return fmt.Errorf("failed to upload segment %s, %w", seg.key, err)
+	}
+	s.segments = append(s.segments, seg)
+	s.nextSeq++
+	return nil
+}
+
+// PartitionID returns the partition ID of the WAL.
+func (s *s3WAL) PartitionID() *partition.ID {
+	return s.partitionID
+}
+
+// Size returns the number of entries in the sealed segments and the buffer.
+func (s *s3WAL) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	size := s.bufferedEntries
+	for _, seg := range s.segments {
+		size += seg.entries
+	}
+	return size
+}
+
+// Flush seals and uploads the buffered entries, the buffer is retained if the upload fails.
+func (s *s3WAL) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flush()
+}
+
+// flush seals and uploads the buffered entries, s.mu has to be held.
+func (s *s3WAL) flush() error {
+	if s.bufferedEntries == 0 {
+		return nil
+	}
+	if err := s.seal(context.Background(), s.buffer.Bytes(), s.bufferedEntries); err != nil {
+		return err
+	}
+	s.buffer.Reset()
+	s.bufferedEntries = 0
+	return nil
+}
+
+// Ping lists the segment objects of the partition, it fails if the bucket cannot be reached.
+func (s *s3WAL) Ping(ctx context.Context) error {
+	_, err := s.client.ListObjects(ctx, s.bucket, s.keyPrefix)
+	return err
+}
+
+// Close seals and uploads the buffered entries, no more writes will be accepted once it succeeds.
+func (s *s3WAL) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	if err := s.flush(); err != nil {
+		return err
+	}
+	s.closed = true
+	return nil
+}
+
+// decodeSegment decodes the entries of the segment and sends them to the messages channel.
+func decodeSegment(data []byte, messages chan<- *isb.ReadMessage) error {
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return aligned.ErrCorruptRecord
+		}
+		size := binary.LittleEndian.Uint32(data[:4])
+		data = data[4:]
+		if uint64(len(data)) < uint64(size) {
+			return aligned.ErrCorruptRecord
+		}
+		msg, err := aligned.DecodeEntry(data[:size])
+		if err != nil {
+			return err
+		}
+		messages <- msg
+		data = data[size:]
+	}
+	return nil
+}
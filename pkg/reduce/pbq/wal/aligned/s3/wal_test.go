@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned"
+)
+
+func TestS3WAL_WriteBatchUploadFailure(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	client := newMemClient()
+	storeProvider, err := NewS3Manager(client.factory, WithBucket("pbq"))
+	assert.NoError(t, err)
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessages(4, time.Unix(60, 0), nil)
+	batch := []*isb.ReadMessage{&writeMessages[0], &writeMessages[1]}
+
+	// the failed batch is not buffered, so the retry does not duplicate it
+	client.putErr = errors.New("service unavailable")
+	assert.Error(t, store.WriteBatch(ctx, batch))
+	assert.Equal(t, int64(0), store.Size())
+
+	client.putErr = nil
+	assert.NoError(t, store.WriteBatch(ctx, batch))
+	assert.NoError(t, store.WriteBatch(ctx, []*isb.ReadMessage{&writeMessages[2], &writeMessages[3]}))
+	assert.Equal(t, int64(4), store.Size())
+	// every write is uploaded as its own segment with the default segment size
+	assert.Len(t, client.objects, 2)
+
+	readMessages := readAll(t, store)
+	assert.Len(t, readMessages, 4)
+	for i, msg := range readMessages {
+		assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+	}
+
+	assert.NoError(t, store.Close())
+	assert.ErrorIs(t, store.Write(ctx, &writeMessages[0]), aligned.ErrWriteStoreClosed)
+}
+
+func TestS3WAL_CloseSealsBuffer(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	client := newMemClient()
+	storeProvider, err := NewS3Manager(client.factory, WithBucket("pbq"), WithSegmentSize(1<<20))
+	assert.NoError(t, err)
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessages(5, time.Unix(60, 0), nil)
+	for _, msg := range writeMessages {
+		assert.NoError(t, store.Write(ctx, &msg))
+	}
+	assert.Len(t, client.objects, 0)
+	assert.Equal(t, int64(5), store.Size())
+
+	// a failed close keeps the buffer, so the close can be retried
+	client.putErr = errors.New("service unavailable")
+	assert.Error(t, store.Close())
+	client.putErr = nil
+	assert.NoError(t, store.Flush())
+	assert.Len(t, client.objects, 1)
+	assert.NoError(t, store.Close())
+	assert.Len(t, client.objects, 1)
+
+	discovered, err := storeProvider.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 1)
+	assert.Len(t, readAll(t, discovered[0]), 5)
+}
+
+func TestS3WAL_CloseWhileWriting(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{
+		Start: time.Unix(60, 0),
+		End:   time.Unix(120, 0),
+		Slot:  "new-partition",
+	}
+
+	client := newMemClient()
+	storeProvider, err := NewS3Manager(client.factory, WithBucket("pbq"), WithSegmentSize(1<<10))
+	assert.NoError(t, err)
+	store, err := storeProvider.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// the close races with the writes, every accepted message is sealed by the close
+	writeMessages := testutils.BuildTestReadMessages(100, time.Unix(60, 0), nil)
+	var written []*isb.ReadMessage
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range writeMessages {
+			if err := store.Write(ctx, &writeMessages[i]); err != nil {
+				assert.ErrorIs(t, err, aligned.ErrWriteStoreClosed)
+				return
+			}
+			written = append(written, &writeMessages[i])
+			_ = store.Size()
+		}
+	}()
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, store.Close())
+	wg.Wait()
+
+	assert.Equal(t, int64(len(written)), store.Size())
+	assert.Len(t, readAll(t, store), len(written))
+}
+
+func Test_parseSegment(t *testing.T) {
+	seg, err := parseSegment("p/a/"+segmentName(12, 7), segmentName(12, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), seg.seq)
+	assert.Equal(t, int64(7), seg.entries)
+
+	_, err = parseSegment("p/a/garbage", "garbage")
+	assert.Error(t, err)
+}
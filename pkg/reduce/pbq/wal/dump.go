@@ -0,0 +1,50 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// Dump returns the messages persisted for the partition by the manager, without opening the WAL of the partition for
+// writes or disturbing it if it is active. It is meant to be called from the debugging tools during an incident, the
+// manager has to implement Dumper.
+func Dump(ctx context.Context, manager Manager, partitionID partition.ID) ([]*isb.ReadMessage, error) {
+	dumper, ok := manager.(Dumper)
+	if !ok {
+		return nil, fmt.Errorf("dump is not supported by the WAL manager %T", manager)
+	}
+	return dumper.Dump(ctx, partitionID)
+}
+
+// PrintDump writes a line per dumped message with its offset, ID, event time and keys.
+func PrintDump(w io.Writer, msgs []*isb.ReadMessage) error {
+	for offset, msg := range msgs {
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", offset, msg.ID.String(), msg.EventTime.UTC().Format(time.RFC3339Nano),
+			strings.Join(msg.Keys, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// managerWithoutDump is a Manager which does not implement Dumper.
+type managerWithoutDump struct {
+	Manager
+}
+
+func TestDump_NotSupported(t *testing.T) {
+	_, err := Dump(context.Background(), managerWithoutDump{}, partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"})
+	assert.Error(t, err)
+}
+
+func TestPrintDump(t *testing.T) {
+	messages := testutils.BuildTestReadMessages(2, time.Unix(60, 0), nil)
+	messages[0].Keys = []string{"a", "b"}
+	var buf bytes.Buffer
+	assert.NoError(t, PrintDump(&buf, []*isb.ReadMessage{&messages[0], &messages[1]}))
+	assert.Equal(t, "0\t"+messages[0].ID.String()+"\t1970-01-01T00:01:00Z\ta,b\n"+
+		"1\t"+messages[1].ID.String()+"\t1970-01-01T00:01:01Z\t\n", buf.String())
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// ErrMetadataNotFound is returned by MetadataStore.LoadMetadata when no metadata has been persisted for the partition.
+var ErrMetadataNotFound = errors.New("partition metadata not found")
+
+// ErrPartitionNotFound is returned by Dumper.Dump when nothing is persisted for the partition.
+var ErrPartitionNotFound = errors.New("partition not found")
+
+// OffsetOutOfRangeErr is returned by OffsetReader.ReadAt and Truncater.Truncate when the offset is not within the
+// messages of the WAL.
+type OffsetOutOfRangeErr struct {
+	Offset int64
+	// Size is the number of messages in the WAL.
+	Size int64
+}
+
+func (e OffsetOutOfRangeErr) Error() string {
+	return fmt.Sprintf("offset %d is out of range, the WAL has %d messages", e.Offset, e.Size)
+}
+
+// InvalidPartitionIDErr is returned when a partition ID cannot be used to name the persisted data of the partition.
+type InvalidPartitionIDErr struct {
+	PartitionID partition.ID
+	Reason      string
+}
+
+func (e InvalidPartitionIDErr) Error() string {
+	return fmt.Sprintf("invalid partition ID %q, %s", e.PartitionID.String(), e.Reason)
+}
+
+// LeaseHeldErr is returned by Leaser.AcquireLease when the lease of the partition is held by another owner.
+type LeaseHeldErr struct {
+	PartitionID partition.ID
+	// Holder is the owner which holds the lease.
+	Holder string
+}
+
+func (e LeaseHeldErr) Error() string {
+	return fmt.Sprintf("lease of partition %s is held by %s", e.PartitionID.String(), e.Holder)
+}
+
+// LeaseLostErr is returned when the owner no longer holds the lease of the partition, e.g. the lease expired before it
+// was renewed and another owner acquired it.
+type LeaseLostErr struct {
+	PartitionID partition.ID
+	Owner       string
+}
+
+func (e LeaseLostErr) Error() string {
+	return fmt.Sprintf("lease of partition %s is lost by %s", e.PartitionID.String(), e.Owner)
+}
+
+// BatchWriteErr is returned by WAL.WriteBatch when the batch fails after some of its messages are written, so that the
+// caller can retry only the messages which are not written.
+type BatchWriteErr struct {
+	// Index is the position in the batch of the first message which is not written, the messages before it are
+	// persisted.
+	Index int
+	Err   error
+}
+
+func (e BatchWriteErr) Error() string {
+	return fmt.Sprintf("failed to write the message at index %d of the batch, %v", e.Index, e.Err)
+}
+
+func (e BatchWriteErr) Unwrap() error {
+	return e.Err
+}
+
+// ReplayOrderErr is returned by a replay whose messages are not in the order of their event times, see
+// CheckReplayOrder.
+type ReplayOrderErr struct {
+	// Offset is the position of the out of order message in the replay.
+	Offset    int64
+	MessageID string
+	EventTime time.Time
+	// Previous is the event time of the message replayed before it.
+	Previous time.Time
+}
+
+func (e ReplayOrderErr) Error() string {
+	return fmt.Sprintf("message %s at offset %d is replayed out of order, its event time %s is before %s", e.MessageID, e.Offset,
+		e.EventTime.Format(time.RFC3339Nano), e.Previous.Format(time.RFC3339Nano))
+}
+
+// TimeoutErr is returned when an operation on the WAL of a partition, e.g. opening or closing a WAL backed by a remote
+// store, does not complete within its timeout.
+type TimeoutErr struct {
+	PartitionID partition.ID
+	Operation   string
+	Timeout     time.Duration
+}
+
+func (e TimeoutErr) Error() string {
+	return fmt.Sprintf("failed to %s the WAL of partition %s within %v", e.Operation, e.PartitionID.String(), e.Timeout)
+}
@@ -0,0 +1,20 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake implements a programmable WAL and WAL manager for unit tests. Messages can be preloaded to be replayed,
+// errors can be injected on the Nth write or the Nth replayed message, and the deleted partitions are recorded so that
+// tests can assert the WALs were garbage collected. It should only be used in tests.
+package fake
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// lease is the lease of a partition held by the owner until the expiry.
+type lease struct {
+	owner  string
+	expiry time.Time
+}
+
+var _ wal.Leaser = (*Manager)(nil)
+
+// AcquireLease takes the lease of the partition for the owner unless another owner holds an unexpired lease.
+func (m *Manager) AcquireLease(_ context.Context, partitionID partition.ID, owner string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[partitionID.String()]; ok && l.owner != owner && time.Now().Before(l.expiry) {
+		return wal.LeaseHeldErr{PartitionID: partitionID, Holder: l.owner}
+	}
+	m.leases[partitionID.String()] = lease{owner: owner, expiry: time.Now().Add(ttl)}
+	return nil
+}
+
+// RenewLease extends the unexpired lease of the owner.
+func (m *Manager) RenewLease(_ context.Context, partitionID partition.ID, owner string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[partitionID.String()]; !ok || l.owner != owner || !time.Now().Before(l.expiry) {
+		return wal.LeaseLostErr{PartitionID: partitionID, Owner: owner}
+	}
+	m.leases[partitionID.String()] = lease{owner: owner, expiry: time.Now().Add(ttl)}
+	return nil
+}
+
+// ReleaseLease releases the lease of the partition if the owner holds it.
+func (m *Manager) ReleaseLease(_ context.Context, partitionID partition.ID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[partitionID.String()]; ok && l.owner == owner {
+		delete(m.leases, partitionID.String())
+	}
+	return nil
+}
+
+// RevokeLease discards the lease of the partition, as if it expired, so that its owner fails to renew it.
+func (m *Manager) RevokeLease(partitionID partition.ID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.leases, partitionID.String())
+}
+
+// LeaseHolder returns the owner of the unexpired lease of the partition, and false if the partition is not leased.
+func (m *Manager) LeaseHolder(partitionID partition.ID) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.leases[partitionID.String()]
+	if !ok || !time.Now().Before(l.expiry) {
+		return "", false
+	}
+	return l.owner, true
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// Manager is a wal.Manager of fake WALs, it records the deleted partitions.
+type Manager struct {
+	// opts are applied to every WAL created by the manager
+	opts    []Option
+	wals    map[string]*WAL
+	deleted []partition.ID
+	// leases are the leases of the partitions, keyed by the partition ID
+	leases map[string]lease
+	mu     sync.Mutex
+}
+
+var _ wal.Manager = (*Manager)(nil)
+
+// NewManager returns a fake WAL manager, the options are applied to every WAL it creates.
+func NewManager(opts ...Option) *Manager {
+	return &Manager{
+		opts:   opts,
+		wals:   make(map[string]*WAL),
+		leases: make(map[string]lease),
+	}
+}
+
+// AddWAL adds an existing WAL to the manager, it will be returned by DiscoverWALs as if it was persisted before a
+// restart.
+func (m *Manager) AddWAL(w *WAL) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.wals[w.partitionID.String()] = w
+}
+
+// CreateWAL returns the WAL of the partition, a new one is created if it does not exist.
+func (m *Manager) CreateWAL(_ context.Context, partitionID partition.ID) (wal.WAL, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if w, ok := m.wals[partitionID.String()]; ok {
+		return w, nil
+	}
+	w := NewWAL(partitionID, m.opts...)
+	m.wals[partitionID.String()] = w
+	return w, nil
+}
+
+// DiscoverWALs returns all the WALs of the manager.
+func (m *Manager) DiscoverWALs(_ context.Context) ([]wal.WAL, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wals := make([]wal.WAL, 0, len(m.wals))
+	for _, w := range m.wals {
+		wals = append(wals, w)
+	}
+	return wals, nil
+}
+
+// DeleteWAL deletes the WAL of the partition and records the deletion.
+func (m *Manager) DeleteWAL(partitionID partition.ID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.wals, partitionID.String())
+	m.deleted = append(m.deleted, partitionID)
+	return nil
+}
+
+// GetWAL returns the WAL of the partition if it exists.
+func (m *Manager) GetWAL(partitionID partition.ID) (*WAL, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.wals[partitionID.String()]
+	return w, ok
+}
+
+// Deleted returns the partitions whose WALs were deleted, in the order they were deleted.
+func (m *Manager) Deleted() []partition.ID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]partition.ID(nil), m.deleted...)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// TestManager_WriteErrInjection shows how a fake manager is used to make the second write of a PBQ fail and to
+// assert the WAL was deleted on GC.
+func TestManager_WriteErrInjection(t *testing.T) {
+	ctx := context.Background()
+	writeErr := errors.New("injected write error")
+	walManager := NewManager(WithWriteErr(2, writeErr))
+
+	qManager, err := pbq.NewManager(ctx, "reduce", "test-pipeline", 0, walManager, window.Aligned, pbq.WithChannelBufferSize(10))
+	assert.NoError(t, err)
+
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	q, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	requests := testutils.BuildTestWindowRequests(3, time.Unix(60, 0), window.Append)
+	assert.NoError(t, q.Write(ctx, &requests[0], true))
+	assert.ErrorIs(t, q.Write(ctx, &requests[1], true), writeErr)
+	assert.NoError(t, q.Write(ctx, &requests[2], true))
+
+	w, ok := walManager.GetWAL(partitionID)
+	assert.True(t, ok)
+	assert.Len(t, w.Messages(), 2)
+
+	q.CloseOfBook()
+	assert.NoError(t, q.GC())
+	assert.Equal(t, []partition.ID{partitionID}, walManager.Deleted())
+	_, ok = walManager.GetWAL(partitionID)
+	assert.False(t, ok)
+}
+
+func TestWAL_ReadErrInjection(t *testing.T) {
+	readErr := errors.New("injected read error")
+	messages := testutils.BuildTestReadMessages(3, time.Unix(60, 0), nil)
+	partitionID := partition.ID{Slot: "slot-1"}
+
+	walManager := NewManager()
+	walManager.AddWAL(NewWAL(partitionID, WithMessages(&messages[0], &messages[1], &messages[2]), WithReadErr(3, readErr)))
+
+	wals, err := walManager.DiscoverWALs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, wals, 1)
+	assert.Equal(t, int64(3), wals[0].Size())
+
+	msgCh, errCh := wals[0].Replay()
+	replayed := make([]*isb.ReadMessage, 0)
+	var replayErr error
+	for msgCh != nil || errCh != nil {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				msgCh = nil
+				continue
+			}
+			replayed = append(replayed, msg)
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			replayErr = err
+		}
+	}
+	assert.ErrorIs(t, replayErr, readErr)
+	assert.Len(t, replayed, 2)
+}
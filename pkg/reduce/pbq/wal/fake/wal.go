@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// WAL is a programmable wal.WAL which keeps the messages in memory.
+type WAL struct {
+	partitionID partition.ID
+	messages    []*isb.ReadMessage
+	// writeErrs are the errors to be returned by the writes, keyed by the write call number starting from 1.
+	writeErrs map[int]error
+	// readErrs are the errors to be returned by the replay in place of the messages, keyed by the message number
+	// starting from 1.
+	readErrs map[int]error
+	// pingErr is the error to be returned by Ping.
+	pingErr error
+	// closeErr is the error to be returned by Close.
+	closeErr error
+	writes   int
+	closed   bool
+	mu       sync.Mutex
+}
+
+var _ wal.WAL = (*WAL)(nil)
+
+// Option sets an option of the fake WAL.
+type Option func(*WAL)
+
+// WithMessages preloads the messages to be replayed.
+func WithMessages(msgs ...*isb.ReadMessage) Option {
+	return func(w *WAL) {
+		w.messages = append(w.messages, msgs...)
+	}
+}
+
+// WithWriteErr makes the nth call to Write or WriteBatch fail with the given error, n starts from 1.
+func WithWriteErr(n int, err error) Option {
+	return func(w *WAL) {
+		w.writeErrs[n] = err
+	}
+}
+
+// WithReadErr makes the replay fail with the given error in place of the nth message, n starts from 1.
+func WithReadErr(n int, err error) Option {
+	return func(w *WAL) {
+		w.readErrs[n] = err
+	}
+}
+
+// WithPingErr makes Ping fail with the given error.
+func WithPingErr(err error) Option {
+	return func(w *WAL) {
+		w.pingErr = err
+	}
+}
+
+// WithCloseErr makes Close fail with the given error, the WAL is closed nonetheless.
+func WithCloseErr(err error) Option {
+	return func(w *WAL) {
+		w.closeErr = err
+	}
+}
+
+// NewWAL returns a fake WAL for the given partition.
+func NewWAL(partitionID partition.ID, opts ...Option) *WAL {
+	w := &WAL{
+		partitionID: partitionID,
+		messages:    make([]*isb.ReadMessage, 0),
+		writeErrs:   make(map[int]error),
+		readErrs:    make(map[int]error),
+	}
+	for _, o := range opts {
+		o(w)
+	}
+	return w
+}
+
+// Replay replays the preloaded and the written messages, it stops at the first injected read error.
+func (w *WAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+
+	w.mu.Lock()
+	toReplay := append([]*isb.ReadMessage(nil), w.messages...)
+	w.mu.Unlock()
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+		for i, msg := range toReplay {
+			w.mu.Lock()
+			err, ok := w.readErrs[i+1]
+			w.mu.Unlock()
+			if ok {
+				errs <- err
+				return
+			}
+			messages <- msg
+		}
+	}()
+	return messages, errs
+}
+
+// Write writes the message unless an error is injected for this write.
+func (w *WAL) Write(ctx context.Context, msg *isb.ReadMessage) error {
+	return w.WriteBatch(ctx, []*isb.ReadMessage{msg})
+}
+
+// WriteBatch writes the messages unless an error is injected for this write, none of the messages are written if it
+// fails.
+func (w *WAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writes++
+	if err, ok := w.writeErrs[w.writes]; ok {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+// PartitionID returns the partition ID of the WAL.
+func (w *WAL) PartitionID() *partition.ID {
+	return &w.partitionID
+}
+
+// Size returns the number of preloaded and written messages.
+func (w *WAL) Size() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return int64(len(w.messages))
+}
+
+// Flush is a no-op, the written messages are kept in memory.
+func (w *WAL) Flush() error {
+	return nil
+}
+
+// Ping returns the injected ping error, if any.
+func (w *WAL) Ping(_ context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.pingErr
+}
+
+// Close marks the WAL as closed.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return w.closeErr
+}
+
+// Messages returns the preloaded and written messages.
+func (w *WAL) Messages() []*isb.ReadMessage {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]*isb.ReadMessage(nil), w.messages...)
+}
+
+// Writes returns the number of calls to Write and WriteBatch, including the failed ones.
+func (w *WAL) Writes() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writes
+}
+
+// IsClosed returns true if the WAL has been closed.
+func (w *WAL) IsClosed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closed
+}
@@ -18,6 +18,7 @@ package wal
 
 import (
 	"context"
+	"time"
 
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
@@ -28,14 +29,117 @@ type WAL interface {
 	// Replay to replay persisted messages during startup
 	// returns a channel to read messages and a channel to read errors
 	Replay() (<-chan *isb.ReadMessage, <-chan error)
-	// Write writes message to the WAL.
-	Write(msg *isb.ReadMessage) error
+	// Write writes message to the WAL. A WAL backed by a remote store returns ctx.Err() if the context is canceled
+	// before the write completes, a local WAL can ignore the context.
+	Write(ctx context.Context, msg *isb.ReadMessage) error
+	// WriteBatch writes a batch of messages to the WAL in order, it lets the WAL amortize the cost of the write
+	// across the messages. A WAL which can fail in the middle of a batch returns BatchWriteErr with the index of the
+	// first message which is not written.
+	WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error
 	// PartitionID returns the partition ID of the WAL.
 	PartitionID() *partition.ID
+	// Size returns the number of messages persisted in the WAL, including the ones which have already been replayed.
+	Size() int64
+	// Flush makes the messages written so far durable, regardless of how the WAL batches its syncs. It is a no-op for
+	// a WAL whose writes are durable as soon as they return.
+	Flush() error
+	// Ping checks that the backend of the WAL can accept writes, it is used by the health checks. It does not write
+	// anything which would be replayed.
+	Ping(ctx context.Context) error
 	// Close closes WAL.
 	Close() error
 }
 
+// OffsetReader is implemented by the WALs which can read the persisted messages from an arbitrary offset, it is used
+// for debugging and for the replays which do not start at the beginning of the WAL.
+type OffsetReader interface {
+	// ReadAt reads up to size messages starting at offset, the position of the message among the messages of the WAL
+	// in the write order. It does not affect Replay. The boolean is true if the read reached the end of the WAL, and
+	// OffsetOutOfRangeErr is returned if the offset is negative or beyond the end of the WAL.
+	ReadAt(offset int64, size int64) ([]*isb.ReadMessage, bool, error)
+}
+
+// MetadataStore is implemented by the WALs which can persist the metadata of their partition alongside the messages, it
+// is used to reconstruct the window a partition belongs to after a restart.
+type MetadataStore interface {
+	// PersistMetadata durably stores the metadata of the partition, replacing the previously persisted one.
+	PersistMetadata(PartitionMetadata) error
+	// LoadMetadata returns the persisted metadata of the partition, ErrMetadataNotFound is returned if none has been
+	// persisted.
+	LoadMetadata() (*PartitionMetadata, error)
+}
+
+// Evicter is implemented by the bounded WALs which can evict their oldest messages, it is used to make room for a new
+// message when the WAL is full.
+type Evicter interface {
+	// EvictOldest removes the oldest persisted message, the offsets of the remaining messages shift by one. It returns
+	// false if the WAL has no message to evict.
+	EvictOldest() bool
+}
+
+// Truncater is implemented by the WALs which can discard their newest messages, it is used for the manual repairs of a
+// partition, e.g. to drop the poisoned messages at the tail of the WAL.
+type Truncater interface {
+	// Truncate removes the persisted messages at and after offset, the position of the message among the messages of
+	// the WAL in the write order as for OffsetReader. The reads beyond the new end find the end of the WAL, and the
+	// next write is at offset. OffsetOutOfRangeErr is returned if the offset is negative or beyond the end of the WAL.
+	Truncate(offset int64) error
+}
+
+// Snapshotter is implemented by the WALs which can copy their persisted messages without blocking the writes, it is used
+// to inspect a partition, e.g. to compute the intermediate results of a window.
+type Snapshotter interface {
+	// Snapshot returns a copy of the messages persisted in the WAL at the time of the call, in the write order. The
+	// messages written after the call are not included.
+	Snapshot() ([]*isb.Message, error)
+}
+
+// Iterable is implemented by the WALs which can stream their persisted messages one at a time, it is used for the
+// replays of the large WALs which should not be read into memory in batches.
+type Iterable interface {
+	// Iterator returns a StoreIterator over the messages persisted in the WAL at the time of the call, in the write
+	// order. It does not affect Replay.
+	Iterator() (StoreIterator, error)
+}
+
+// StoreIterator streams the persisted messages of a WAL, only the message being returned is held in memory.
+type StoreIterator interface {
+	// Next returns the next message, io.EOF is returned once all the messages have been returned.
+	Next() (*isb.ReadMessage, error)
+	// Close releases the resources held by the iterator, it can be called before the iterator is exhausted.
+	Close() error
+}
+
+// Dumper is implemented by the managers which can read the persisted messages of a partition without opening its WAL for
+// writes, it is used to inspect the WAL of a partition during an incident, see Dump.
+type Dumper interface {
+	// Dump returns the messages persisted for the partition in the write order, the position of a message is its
+	// offset as for OffsetReader. It does not affect the WAL of the partition if it is active, in particular its
+	// Replay. ErrPartitionNotFound is returned if nothing is persisted for the partition.
+	Dump(ctx context.Context, partitionID partition.ID) ([]*isb.ReadMessage, error)
+}
+
+// Leaser is implemented by the managers whose WALs live in a store shared by the replicas, e.g. Redis. The lease of a
+// partition makes sure that a single replica operates the partition at a time, e.g. after a rebalance.
+type Leaser interface {
+	// AcquireLease takes the lease of the partition for the owner until ttl passes, the owner which holds the lease can
+	// acquire it again. LeaseHeldErr is returned if another owner holds the lease.
+	AcquireLease(ctx context.Context, partitionID partition.ID, owner string, ttl time.Duration) error
+	// RenewLease extends the lease of the owner until ttl passes, LeaseLostErr is returned if the lease has expired or
+	// is held by another owner.
+	RenewLease(ctx context.Context, partitionID partition.ID, owner string, ttl time.Duration) error
+	// ReleaseLease releases the lease of the partition if the owner holds it.
+	ReleaseLease(ctx context.Context, partitionID partition.ID, owner string) error
+}
+
+// Releaser is implemented by the managers which keep the WALs they return, so that CreateWAL returns the same WAL of a
+// partition. It is used to drop a WAL which is closed without deleting its messages.
+type Releaser interface {
+	// ReleaseWAL forgets the WAL of the partition if it is w, the next CreateWAL of the partition returns a new WAL over
+	// the persisted messages.
+	ReleaseWAL(partitionID partition.ID, w WAL)
+}
+
 // Manager defines the interface to manage the WALs.
 type Manager interface {
 	// CreateWAL returns a new WAL instance.
@@ -0,0 +1,33 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// PartitionMetadata describes the window a partition was created for. It is persisted alongside the messages of the
+// partition, so that the window can be reconstructed after a crash.
+type PartitionMetadata struct {
+	// PartitionID has the start and the end time of the window, and the slot of the partition.
+	PartitionID partition.ID `json:"partitionID"`
+	// Keys are the keys of the messages the partition was created for, empty if the partition is shared by all keys.
+	Keys []string `json:"keys,omitempty"`
+	// BookClosed is true once the book of the partition is closed, no new messages are accepted for the partition even
+	// after a restart.
+	BookClosed bool `json:"bookClosed,omitempty"`
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// migrateBatchSize is the number of messages written to the destination WAL at once during a migration.
+const migrateBatchSize = 100
+
+// Migrate copies the messages of the src WAL to the dst WAL preserving their order, and the metadata of the partition
+// if both the WALs support it. The src WAL is replayed, so it should not be written to during the migration. The dst
+// WAL is flushed before Migrate returns.
+func Migrate(ctx context.Context, src WAL, dst WAL) error {
+	if err := migrateMetadata(src, dst); err != nil {
+		return err
+	}
+
+	msgCh, errCh := src.Replay()
+	batch := make([]*isb.ReadMessage, 0, migrateBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err, ok := <-errCh:
+			if !ok {
+				// the errors channel can be closed before the messages channel
+				errCh = nil
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		case msg, ok := <-msgCh:
+			if !ok {
+				if len(batch) > 0 {
+					if err := dst.WriteBatch(ctx, batch); err != nil {
+						return err
+					}
+				}
+				return dst.Flush()
+			}
+			if msg == nil {
+				continue
+			}
+			batch = append(batch, msg)
+			if len(batch) == migrateBatchSize {
+				if err := dst.WriteBatch(ctx, batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// migrateMetadata copies the metadata of the partition from the src WAL to the dst WAL.
+func migrateMetadata(src WAL, dst WAL) error {
+	srcMetadata, ok := src.(MetadataStore)
+	if !ok {
+		return nil
+	}
+	dstMetadata, ok := dst.(MetadataStore)
+	if !ok {
+		return nil
+	}
+	metadata, err := srcMetadata.LoadMetadata()
+	if errors.Is(err, ErrMetadataNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return dstMetadata.PersistMetadata(*metadata)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package noop
 
 import (
+	"context"
+
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
@@ -36,7 +38,23 @@ func (p *noopWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 	return nil, nil
 }
 
-func (p *noopWAL) Write(msg *isb.ReadMessage) error {
+func (p *noopWAL) Write(ctx context.Context, msg *isb.ReadMessage) error {
+	return nil
+}
+
+func (p *noopWAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	return nil
+}
+
+func (p *noopWAL) Size() int64 {
+	return 0
+}
+
+func (p *noopWAL) Flush() error {
+	return nil
+}
+
+func (p *noopWAL) Ping(ctx context.Context) error {
 	return nil
 }
 
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// CheckReplayOrder forwards a replay, checking that the event times of the messages never decrease. The replay stops at
+// the first message which is out of order, ReplayOrderErr is sent in its place and the rest of the replay is drained in
+// the background. It is a guard for the WALs which could reorder the messages, the messages of a partition are only
+// in the order of their event times if the ISB delivers them so. The errors are forwarded as they are. Once the ctx is
+// done the returned channels are closed.
+func CheckReplayOrder(ctx context.Context, msgCh <-chan *isb.ReadMessage, errCh <-chan error) (<-chan *isb.ReadMessage, <-chan error) {
+	ordered := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(ordered)
+		var offset int64
+		var previous time.Time
+		for msgCh != nil {
+			select {
+			case <-ctx.Done():
+				go drainReplay(msgCh, errCh)
+				return
+			case err, ok := <-errCh:
+				if !ok {
+					errCh = nil
+					continue
+				}
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					go drainReplay(msgCh, nil)
+					return
+				}
+			case msg, ok := <-msgCh:
+				if !ok {
+					msgCh = nil
+					continue
+				}
+				// some WALs replay nil messages in place of the unused slots
+				if msg == nil {
+					continue
+				}
+				if msg.EventTime.Before(previous) {
+					go drainReplay(msgCh, errCh)
+					select {
+					case errs <- ReplayOrderErr{Offset: offset, MessageID: msg.ID.String(), EventTime: msg.EventTime, Previous: previous}:
+					case <-ctx.Done():
+					}
+					return
+				}
+				previous = msg.EventTime
+				offset++
+				select {
+				case ordered <- msg:
+				case <-ctx.Done():
+					go drainReplay(msgCh, errCh)
+					return
+				}
+			}
+		}
+	}()
+	return ordered, errs
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+func TestCheckReplayOrder(t *testing.T) {
+	replay := func(messages []isb.ReadMessage) ([]*isb.ReadMessage, error) {
+		w := &slowWAL{messages: messages, batchSize: 1}
+		msgCh, errCh := w.Replay()
+		msgCh, errCh = CheckReplayOrder(context.Background(), msgCh, errCh)
+		var replayed []*isb.ReadMessage
+		var replayErr error
+		for msgCh != nil || errCh != nil {
+			select {
+			case msg, ok := <-msgCh:
+				if !ok {
+					msgCh = nil
+					continue
+				}
+				replayed = append(replayed, msg)
+			case err, ok := <-errCh:
+				if !ok {
+					errCh = nil
+					continue
+				}
+				replayErr = err
+			}
+		}
+		return replayed, replayErr
+	}
+
+	// the equal event times are in order
+	messages := testutils.BuildTestReadMessages(5, time.Unix(60, 0), nil)
+	messages[2].EventTime = messages[1].EventTime
+	replayed, err := replay(messages)
+	assert.NoError(t, err)
+	assert.Len(t, replayed, len(messages))
+
+	// the replay stops at the message which is out of order
+	messages = testutils.BuildTestReadMessages(5, time.Unix(60, 0), nil)
+	messages[3].EventTime = messages[0].EventTime
+	replayed, err = replay(messages)
+	assert.Len(t, replayed, 3)
+	var orderErr ReplayOrderErr
+	assert.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, int64(3), orderErr.Offset)
+	assert.Equal(t, messages[3].ID.String(), orderErr.MessageID)
+	assert.Equal(t, messages[2].EventTime, orderErr.Previous)
+}
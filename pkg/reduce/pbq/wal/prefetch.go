@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// ReplayWithPrefetch replays the WAL like Replay, while reading up to depth messages ahead of the consumer, see Prefetch.
+func ReplayWithPrefetch(ctx context.Context, w WAL, depth int) (<-chan *isb.ReadMessage, <-chan error) {
+	msgCh, errCh := w.Replay()
+	return Prefetch(ctx, msgCh, errCh, depth)
+}
+
+// Prefetch reads up to depth messages of a replay ahead of the consumer, so that the I/O of the WAL overlaps with the
+// processing of the replayed messages. The messages keep their order. Once the ctx is done the returned channels are
+// closed, and the rest of the replay is drained in the background so that the replay of the WAL does not block forever.
+func Prefetch(ctx context.Context, msgCh <-chan *isb.ReadMessage, errCh <-chan error, depth int) (<-chan *isb.ReadMessage, <-chan error) {
+	prefetched := make(chan *isb.ReadMessage, depth)
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(prefetched)
+		for msgCh != nil {
+			select {
+			case <-ctx.Done():
+				go drainReplay(msgCh, errCh)
+				return
+			case err, ok := <-errCh:
+				if !ok {
+					errCh = nil
+					continue
+				}
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					go drainReplay(msgCh, nil)
+					return
+				}
+			case msg, ok := <-msgCh:
+				if !ok {
+					msgCh = nil
+					continue
+				}
+				select {
+				case prefetched <- msg:
+				case <-ctx.Done():
+					go drainReplay(msgCh, errCh)
+					return
+				}
+			}
+		}
+	}()
+	return prefetched, errs
+}
+
+// drainReplay discards the rest of a replay, which is over once the messages channel is closed, some WALs never close
+// the errors channel.
+func drainReplay(msgCh <-chan *isb.ReadMessage, errCh <-chan error) {
+	for msgCh != nil {
+		select {
+		case _, ok := <-msgCh:
+			if !ok {
+				msgCh = nil
+			}
+		case _, ok := <-errCh:
+			if !ok {
+				errCh = nil
+			}
+		}
+	}
+}
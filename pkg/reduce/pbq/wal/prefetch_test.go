@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+// slowWAL is a WAL whose replay reads the messages in batches, and every batch takes readLatency.
+type slowWAL struct {
+	WAL
+	messages    []isb.ReadMessage
+	batchSize   int
+	readLatency time.Duration
+}
+
+func (s *slowWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	msgCh := make(chan *isb.ReadMessage)
+	errCh := make(chan error)
+	go func() {
+		defer close(msgCh)
+		for i := range s.messages {
+			if i%s.batchSize == 0 {
+				time.Sleep(s.readLatency)
+			}
+			msgCh <- &s.messages[i]
+		}
+	}()
+	return msgCh, errCh
+}
+
+func TestReplayWithPrefetch(t *testing.T) {
+	messages := testutils.BuildTestReadMessages(50, time.Now(), nil)
+	w := &slowWAL{messages: messages, batchSize: 10}
+
+	msgCh, _ := ReplayWithPrefetch(context.Background(), w, 5)
+	var replayed []*isb.ReadMessage
+	for msg := range msgCh {
+		replayed = append(replayed, msg)
+	}
+	assert.Len(t, replayed, len(messages))
+	for i, msg := range replayed {
+		assert.Equal(t, messages[i].Header.ID, msg.Header.ID)
+	}
+
+	// the replay stops once the ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	msgCh, errCh := ReplayWithPrefetch(ctx, w, 5)
+	<-msgCh
+	cancel()
+	assert.Eventually(t, func() bool {
+		for range msgCh {
+		}
+		_, ok := <-errCh
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func BenchmarkReplayWithPrefetch(b *testing.B) {
+	messages := testutils.BuildTestReadMessages(200, time.Now(), nil)
+	w := &slowWAL{messages: messages, batchSize: 20, readLatency: 2 * time.Millisecond}
+	// the time taken to process a replayed message, e.g. writing it to the PBQ
+	processLatency := 100 * time.Microsecond
+
+	consume := func(msgCh <-chan *isb.ReadMessage) {
+		for range msgCh {
+			// spin, the sleeps are too coarse for the latency
+			for start := time.Now(); time.Since(start) < processLatency; {
+			}
+		}
+	}
+	b.Run("no prefetch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			msgCh, _ := w.Replay()
+			consume(msgCh)
+		}
+	})
+	b.Run("prefetch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			msgCh, _ := ReplayWithPrefetch(context.Background(), w, w.batchSize)
+			consume(msgCh)
+		}
+	})
+}
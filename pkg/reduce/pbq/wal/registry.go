@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ManagerOptions identify the vertex replica whose WALs a registered store type manages.
+type ManagerOptions struct {
+	PipelineName string
+	VertexName   string
+	Replica      int32
+}
+
+// ManagerConstructor creates the manager of the WALs of a registered store type.
+type ManagerConstructor func(ctx context.Context, opts ManagerOptions) (Manager, error)
+
+var (
+	storeTypes   = make(map[string]ManagerConstructor)
+	storeTypesMu sync.RWMutex
+)
+
+// RegisterStoreType makes a store type available by name, so that a store which lives outside this repository can back
+// the PBQs without changing the code which creates the WAL manager, see NewStoreManager. It is usually invoked from the
+// init of the package of the store. A name cannot be registered twice.
+func RegisterStoreType(name string, constructor ManagerConstructor) error {
+	if name == "" {
+		return fmt.Errorf("store type name should not be empty")
+	}
+	if constructor == nil {
+		return fmt.Errorf("constructor of store type %q should not be nil", name)
+	}
+	storeTypesMu.Lock()
+	defer storeTypesMu.Unlock()
+	if _, ok := storeTypes[name]; ok {
+		return fmt.Errorf("store type %q is already registered", name)
+	}
+	storeTypes[name] = constructor
+	return nil
+}
+
+// NewStoreManager creates the WAL manager of the store type registered under the name.
+func NewStoreManager(ctx context.Context, name string, opts ManagerOptions) (Manager, error) {
+	storeTypesMu.RLock()
+	constructor, ok := storeTypes[name]
+	storeTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("store type %q is not registered, the registered store types are %v", name, StoreTypes())
+	}
+	manager, err := constructor(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the manager of store type %q, %w", name, err)
+	}
+	return manager, nil
+}
+
+// StoreTypes returns the names of the registered store types in order.
+func StoreTypes() []string {
+	storeTypesMu.RLock()
+	defer storeTypesMu.RUnlock()
+	names := make([]string, 0, len(storeTypes))
+	for name := range storeTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/noop"
+)
+
+func TestRegisterStoreType(t *testing.T) {
+	ctx := context.Background()
+	// the registry is global, the names are unique across the runs of the test
+	name := fmt.Sprintf("test-noop-%d", time.Now().UnixNano())
+	var created wal.ManagerOptions
+	assert.NoError(t, wal.RegisterStoreType(name, func(_ context.Context, opts wal.ManagerOptions) (wal.Manager, error) {
+		created = opts
+		return noop.NewNoopStores(), nil
+	}))
+	assert.Contains(t, wal.StoreTypes(), name)
+
+	opts := wal.ManagerOptions{PipelineName: "test-pipeline", VertexName: "reduce", Replica: 1}
+	manager, err := wal.NewStoreManager(ctx, name, opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, manager)
+	assert.Equal(t, opts, created)
+
+	// a name is registered once
+	assert.Error(t, wal.RegisterStoreType(name, func(context.Context, wal.ManagerOptions) (wal.Manager, error) {
+		return noop.NewNoopStores(), nil
+	}))
+	assert.Error(t, wal.RegisterStoreType("", func(context.Context, wal.ManagerOptions) (wal.Manager, error) {
+		return noop.NewNoopStores(), nil
+	}))
+	assert.Error(t, wal.RegisterStoreType(name+"-nil", nil))
+
+	_, err = wal.NewStoreManager(ctx, name+"-unknown", opts)
+	assert.Error(t, err)
+
+	failing := name + "-failing"
+	constructErr := errors.New("failed to connect")
+	assert.NoError(t, wal.RegisterStoreType(failing, func(context.Context, wal.ManagerOptions) (wal.Manager, error) {
+		return nil, constructErr
+	}))
+	_, err = wal.NewStoreManager(ctx, failing, opts)
+	assert.ErrorIs(t, err, constructErr)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// ReplayInBatches replays the WAL by reading up to size messages at a time with OffsetReader.ReadAt, regardless of how
+// the WAL batches its own Replay. The WALs which cannot read from an offset are replayed with Replay.
+func ReplayInBatches(ctx context.Context, w WAL, size int64) (<-chan *isb.ReadMessage, <-chan error) {
+	reader, ok := w.(OffsetReader)
+	if !ok {
+		return w.Replay()
+	}
+
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+	go func() {
+		defer close(messages)
+		defer close(errs)
+		// the size is taken upfront, an empty WAL would fail the first read with OffsetOutOfRangeErr
+		end := w.Size()
+		for offset := int64(0); offset < end; {
+			msgs, eof, err := reader.ReadAt(offset, size)
+			if err != nil {
+				select {
+				case errs <- fmt.Errorf("failed to read the WAL at offset %d, %w", offset, err):
+				case <-ctx.Done():
+				}
+				return
+			}
+			for _, msg := range msgs {
+				select {
+				case messages <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if eof || len(msgs) == 0 {
+				return
+			}
+			offset += int64(len(msgs))
+		}
+	}()
+	return messages, errs
+}
+
+// ReplayWithIterator replays the WAL with its StoreIterator, one message at a time, so that the replay of a large WAL
+// does not read it into memory in batches. The WALs which are not Iterable are replayed with Replay.
+func ReplayWithIterator(ctx context.Context, w WAL) (<-chan *isb.ReadMessage, <-chan error) {
+	iterable, ok := w.(Iterable)
+	if !ok {
+		return w.Replay()
+	}
+
+	messages := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+	go func() {
+		defer close(messages)
+		defer close(errs)
+		sendErr := func(err error) {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+		}
+		it, err := iterable.Iterator()
+		if err != nil {
+			sendErr(fmt.Errorf("failed to iterate the WAL, %w", err))
+			return
+		}
+		defer func() { _ = it.Close() }()
+		for {
+			msg, err := it.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				sendErr(fmt.Errorf("failed to iterate the WAL, %w", err))
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return messages, errs
+}
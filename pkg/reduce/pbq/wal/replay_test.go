@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+// offsetWAL is a WAL which reads its messages from an offset, and records the sizes requested from ReadAt.
+type offsetWAL struct {
+	WAL
+	messages []isb.ReadMessage
+	sizes    []int64
+}
+
+func (o *offsetWAL) Size() int64 {
+	return int64(len(o.messages))
+}
+
+func (o *offsetWAL) ReadAt(offset int64, size int64) ([]*isb.ReadMessage, bool, error) {
+	o.sizes = append(o.sizes, size)
+	if offset < 0 || offset >= int64(len(o.messages)) {
+		return nil, false, OffsetOutOfRangeErr{Offset: offset, Size: int64(len(o.messages))}
+	}
+	end := min(offset+size, int64(len(o.messages)))
+	msgs := make([]*isb.ReadMessage, 0, end-offset)
+	for i := offset; i < end; i++ {
+		msgs = append(msgs, &o.messages[i])
+	}
+	return msgs, end == int64(len(o.messages)), nil
+}
+
+// iterableWAL is a WAL which streams its messages with an iterator.
+type iterableWAL struct {
+	WAL
+	messages []isb.ReadMessage
+	closed   bool
+}
+
+func (i *iterableWAL) Iterator() (StoreIterator, error) {
+	return &sliceIterator{wal: i}, nil
+}
+
+type sliceIterator struct {
+	wal  *iterableWAL
+	next int
+}
+
+func (s *sliceIterator) Next() (*isb.ReadMessage, error) {
+	if s.next >= len(s.wal.messages) {
+		return nil, io.EOF
+	}
+	s.next++
+	return &s.wal.messages[s.next-1], nil
+}
+
+func (s *sliceIterator) Close() error {
+	s.wal.closed = true
+	return nil
+}
+
+func TestReplayInBatches(t *testing.T) {
+	ctx := context.Background()
+	messages := testutils.BuildTestReadMessages(10, time.Unix(60, 0), nil)
+	w := &offsetWAL{messages: messages}
+
+	msgCh, errCh := ReplayInBatches(ctx, w, 4)
+	var replayed []*isb.ReadMessage
+	for msg := range msgCh {
+		replayed = append(replayed, msg)
+	}
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, []int64{4, 4, 4}, w.sizes)
+	assert.Len(t, replayed, len(messages))
+	for i := range messages {
+		assert.Equal(t, messages[i].Header.ID, replayed[i].Header.ID)
+	}
+
+	// an empty WAL is not read
+	empty := &offsetWAL{}
+	msgCh, errCh = ReplayInBatches(ctx, empty, 4)
+	_, ok := <-msgCh
+	assert.False(t, ok)
+	assert.NoError(t, <-errCh)
+	assert.Empty(t, empty.sizes)
+
+	// the WALs which cannot read from an offset are replayed with Replay
+	slow := &slowWAL{messages: messages, batchSize: 3}
+	msgCh, _ = ReplayInBatches(ctx, slow, 4)
+	replayed = nil
+	for msg := range msgCh {
+		replayed = append(replayed, msg)
+	}
+	assert.Len(t, replayed, len(messages))
+}
+
+func TestReplayWithIterator(t *testing.T) {
+	ctx := context.Background()
+	messages := testutils.BuildTestReadMessages(10, time.Unix(60, 0), nil)
+	w := &iterableWAL{messages: messages}
+
+	msgCh, errCh := ReplayWithIterator(ctx, w)
+	var replayed []*isb.ReadMessage
+	for msg := range msgCh {
+		replayed = append(replayed, msg)
+	}
+	assert.NoError(t, <-errCh)
+	assert.True(t, w.closed)
+	assert.Len(t, replayed, len(messages))
+	for i := range messages {
+		assert.Equal(t, messages[i].Header.ID, replayed[i].Header.ID)
+	}
+
+	// the WALs which are not iterable are replayed with Replay
+	msgCh, _ = ReplayWithIterator(ctx, &slowWAL{messages: messages, batchSize: 3})
+	replayed = nil
+	for msg := range msgCh {
+		replayed = append(replayed, msg)
+	}
+	assert.Len(t, replayed, len(messages))
+}
@@ -0,0 +1,20 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tiered implements a WAL which composes a memory tier and a disk tier. The writes land in both tiers, the disk
+// tier makes them durable and the memory tier serves the reads of the live messages. The replay always reads the disk
+// tier, so the memory tier does not have to survive a restart.
+package tiered
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiered
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+type tieredManager struct {
+	memoryTier wal.Manager
+	diskTier   wal.Manager
+	// activeWals are the WALs which are created or discovered, a partition keeps its WAL until it is deleted, like the
+	// WALs of the tiers.
+	activeWals map[string]wal.WAL
+	mu         sync.Mutex
+}
+
+// NewManager returns a WAL manager whose WALs write to a WAL of the memoryTier and to a WAL of the diskTier. The WALs
+// of the memoryTier should be in-memory stores which keep every message until they are deleted.
+func NewManager(memoryTier wal.Manager, diskTier wal.Manager) wal.Manager {
+	return &tieredManager{
+		memoryTier: memoryTier,
+		diskTier:   diskTier,
+		activeWals: make(map[string]wal.WAL),
+	}
+}
+
+// CreateWAL creates the WAL of the partition in both tiers.
+func (m *tieredManager) CreateWAL(ctx context.Context, partitionID partition.ID) (wal.WAL, error) {
+	disk, err := m.diskTier.CreateWAL(ctx, partitionID)
+	if err != nil {
+		return nil, err
+	}
+	return m.newTieredWAL(ctx, disk)
+}
+
+// DiscoverWALs discovers the WALs of the disk tier, the memory tier of a discovered WAL only holds the messages which
+// are written after the discovery.
+func (m *tieredManager) DiscoverWALs(ctx context.Context) ([]wal.WAL, error) {
+	discovered, err := m.diskTier.DiscoverWALs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wals := make([]wal.WAL, 0, len(discovered))
+	for _, disk := range discovered {
+		w, err := m.newTieredWAL(ctx, disk)
+		if err != nil {
+			return nil, err
+		}
+		wals = append(wals, w)
+	}
+	return wals, nil
+}
+
+// DeleteWAL deletes the WAL of the partition from both tiers.
+func (m *tieredManager) DeleteWAL(partitionID partition.ID) error {
+	// the memory tier is keyed by the partition ID it was created with, whose times can be in another location
+	memoryPartitionID := partitionID
+	m.mu.Lock()
+	if w, ok := m.activeWals[partitionID.String()]; ok {
+		memoryPartitionID = tierOf(w).memoryPartitionID
+		delete(m.activeWals, partitionID.String())
+	}
+	m.mu.Unlock()
+	return errors.Join(m.diskTier.DeleteWAL(partitionID), m.memoryTier.DeleteWAL(memoryPartitionID))
+}
+
+// ReleaseWAL forgets the WAL of the partition if it is w, and releases its WALs of the tiers which can be released.
+func (m *tieredManager) ReleaseWAL(partitionID partition.ID, w wal.WAL) {
+	m.mu.Lock()
+	if m.activeWals[partitionID.String()] != w {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.activeWals, partitionID.String())
+	m.mu.Unlock()
+	tier := tierOf(w)
+	if releaser, ok := m.diskTier.(wal.Releaser); ok {
+		releaser.ReleaseWAL(partitionID, tier.disk)
+	}
+	if releaser, ok := m.memoryTier.(wal.Releaser); ok && tier.memory != nil {
+		releaser.ReleaseWAL(tier.memoryPartitionID, tier.memory)
+	}
+}
+
+// newTieredWAL creates the memory tier of the WAL of the disk tier, the messages already on the disk are not loaded. The
+// active WAL of the partition is returned if there is one.
+func (m *tieredManager) newTieredWAL(ctx context.Context, disk wal.WAL) (wal.WAL, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	partitionID := *disk.PartitionID()
+	if w, ok := m.activeWals[partitionID.String()]; ok {
+		return w, nil
+	}
+	memory, err := m.memoryTier.CreateWAL(ctx, partitionID)
+	if err != nil {
+		return nil, err
+	}
+	// the memory tier holds the newest messages of the disk tier
+	w := &tieredWAL{disk: disk, base: disk.Size() - memory.Size(), memoryPartitionID: partitionID}
+	// the memory tier can only serve the reads if it can read at an offset
+	if reader, ok := memory.(wal.OffsetReader); ok {
+		w.memory, w.memoryReader = memory, reader
+	}
+	var tiered wal.WAL = w
+	if metadataStore, ok := disk.(wal.MetadataStore); ok {
+		tiered = &metadataWAL{tieredWAL: w, MetadataStore: metadataStore}
+	}
+	m.activeWals[partitionID.String()] = tiered
+	return tiered, nil
+}
+
+// tierOf returns the tieredWAL of a WAL returned by newTieredWAL.
+func tierOf(w wal.WAL) *tieredWAL {
+	if mw, ok := w.(*metadataWAL); ok {
+		return mw.tieredWAL
+	}
+	return w.(*tieredWAL)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiered
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+)
+
+// tieredWAL writes every message to the disk tier and then to the memory tier. The memory tier holds the messages
+// written since the WAL was created or discovered, i.e. the messages at and after base. If a write to the memory tier
+// fails, e.g. because it is full, the memory tier is dropped and the reads are served by the disk tier from then on.
+type tieredWAL struct {
+	disk wal.WAL
+	// memory is the memory tier, nil if it is dropped.
+	memory       wal.WAL
+	memoryReader wal.OffsetReader
+	// memoryPartitionID is the partition ID which the memory tier was created with.
+	memoryPartitionID partition.ID
+	// base is the offset of the first message of the memory tier.
+	base int64
+	// mu guards the memory tier, so that a read does not see the memory tier lag behind the disk tier.
+	mu sync.RWMutex
+}
+
+var _ wal.OffsetReader = (*tieredWAL)(nil)
+var _ wal.MetadataStore = (*metadataWAL)(nil)
+
+// metadataWAL is a tieredWAL whose disk tier can persist the metadata of the partition, the metadata is only persisted
+// in the disk tier.
+type metadataWAL struct {
+	*tieredWAL
+	wal.MetadataStore
+}
+
+// Replay replays the messages from the disk tier.
+func (w *tieredWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
+	return w.disk.Replay()
+}
+
+// Write writes the message to the disk tier, and then to the memory tier.
+func (w *tieredWAL) Write(ctx context.Context, msg *isb.ReadMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.disk.Write(ctx, msg); err != nil {
+		return err
+	}
+	if w.memory != nil {
+		if err := w.memory.Write(ctx, msg); err != nil {
+			w.dropMemoryTier()
+		}
+	}
+	return nil
+}
+
+// WriteBatch writes the messages to the disk tier, and then to the memory tier.
+func (w *tieredWAL) WriteBatch(ctx context.Context, msgs []*isb.ReadMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.disk.WriteBatch(ctx, msgs); err != nil {
+		// the memory tier cannot tell which of the messages made it to the disk
+		w.dropMemoryTier()
+		return err
+	}
+	if w.memory != nil {
+		if err := w.memory.WriteBatch(ctx, msgs); err != nil {
+			w.dropMemoryTier()
+		}
+	}
+	return nil
+}
+
+// dropMemoryTier stops serving the reads from the memory tier, and closes it. Caller should hold the lock.
+func (w *tieredWAL) dropMemoryTier() {
+	if w.memory == nil {
+		return
+	}
+	_ = w.memory.Close()
+	w.memory, w.memoryReader = nil, nil
+}
+
+// ReadAt reads the messages from the memory tier if it holds the offset, and from the disk tier otherwise. The offsets
+// before the memory tier cannot be read if the disk tier does not implement wal.OffsetReader.
+func (w *tieredWAL) ReadAt(offset int64, size int64) ([]*isb.ReadMessage, bool, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.memoryReader != nil && offset >= w.base {
+		msgs, end, err := w.memoryReader.ReadAt(offset-w.base, size)
+		if err != nil {
+			if oor, ok := err.(wal.OffsetOutOfRangeErr); ok {
+				return nil, false, wal.OffsetOutOfRangeErr{Offset: offset, Size: w.base + oor.Size}
+			}
+			return nil, false, err
+		}
+		return msgs, end, nil
+	}
+	reader, ok := w.disk.(wal.OffsetReader)
+	if !ok {
+		return nil, false, fmt.Errorf("the disk tier %T cannot read at an offset", w.disk)
+	}
+	return reader.ReadAt(offset, size)
+}
+
+// PartitionID returns the partition ID of the disk tier.
+func (w *tieredWAL) PartitionID() *partition.ID {
+	return w.disk.PartitionID()
+}
+
+// Size returns the number of messages of the disk tier.
+func (w *tieredWAL) Size() int64 {
+	return w.disk.Size()
+}
+
+// Flush flushes the disk tier, the memory tier has nothing to flush.
+func (w *tieredWAL) Flush() error {
+	return w.disk.Flush()
+}
+
+// Ping pings the disk tier.
+func (w *tieredWAL) Ping(ctx context.Context) error {
+	return w.disk.Ping(ctx)
+}
+
+// Close closes both tiers.
+func (w *tieredWAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.dropMemoryTier()
+	return w.disk.Close()
+}
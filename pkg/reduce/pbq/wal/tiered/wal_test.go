@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiered
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/fs"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+)
+
+var vi = &dfv1.VertexInstance{
+	Vertex: &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}},
+	Hostname: "test-host",
+	Replica:  0,
+}
+
+func replayAll(t *testing.T, w wal.WAL) []*isb.ReadMessage {
+	msgCh, errCh := w.Replay()
+	readMessages := make([]*isb.ReadMessage, 0)
+	for {
+		select {
+		case msg, ok := <-msgCh:
+			if !ok {
+				return readMessages
+			}
+			readMessages = append(readMessages, msg)
+		case err, ok := <-errCh:
+			if ok {
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestTieredWAL(t *testing.T) {
+	ctx := context.Background()
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(120, 0), Slot: "slot-1"}
+	storePath := t.TempDir()
+	memoryTier := memory.NewMemManager(memory.WithStoreSize(100))
+	store, err := NewManager(memoryTier, fs.NewFSManager(vi, fs.WithStorePath(storePath))).CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+
+	writeMessages := testutils.BuildTestReadMessagesIntOffset(10, time.Unix(60, 0), nil)
+	for i := range writeMessages[:5] {
+		assert.NoError(t, store.Write(ctx, &writeMessages[i]))
+	}
+	batch := make([]*isb.ReadMessage, 0, 5)
+	for i := range writeMessages[5:] {
+		batch = append(batch, &writeMessages[5+i])
+	}
+	assert.NoError(t, store.WriteBatch(ctx, batch))
+
+	// the writes land in both tiers
+	memoryWALs, err := memoryTier.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, memoryWALs, 1)
+	assert.Equal(t, int64(len(writeMessages)), memoryWALs[0].Size())
+	assert.Equal(t, int64(len(writeMessages)), store.Size())
+	msgs, end, err := store.(wal.OffsetReader).ReadAt(3, 4)
+	assert.NoError(t, err)
+	assert.False(t, end)
+	assert.Len(t, msgs, 4)
+	assert.Same(t, &writeMessages[3], msgs[0])
+	assert.NoError(t, store.Close())
+
+	// the memory tier is cleared, the restarted manager replays the messages from the disk tier
+	assert.NoError(t, memoryTier.DeleteWAL(partitionID))
+	memoryTier = memory.NewMemManager(memory.WithStoreSize(100))
+	diskTier := fs.NewFSManager(vi, fs.WithStorePath(storePath))
+	manager := NewManager(memoryTier, diskTier)
+	discovered, err := manager.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, discovered, 1)
+	store = discovered[0]
+	replayed := replayAll(t, store)
+	assert.Len(t, replayed, len(writeMessages))
+	for i, msg := range replayed {
+		assert.Equal(t, writeMessages[i].Header.ID, msg.Header.ID)
+	}
+	created, err := manager.CreateWAL(ctx, partitionID)
+	assert.NoError(t, err)
+	assert.Same(t, store, created)
+
+	// the messages on the disk are read from the disk tier, the new ones from the memory tier
+	extra := testutils.BuildTestReadMessagesIntOffset(1, time.Unix(100, 0), nil)
+	assert.NoError(t, store.Write(ctx, &extra[0]))
+	msgs, end, err = store.(wal.OffsetReader).ReadAt(9, 2)
+	assert.NoError(t, err)
+	assert.True(t, end)
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, writeMessages[9].Header.ID, msgs[0].Header.ID)
+	assert.NotSame(t, &extra[0], msgs[1])
+	msgs, end, err = store.(wal.OffsetReader).ReadAt(10, 2)
+	assert.NoError(t, err)
+	assert.True(t, end)
+	assert.Same(t, &extra[0], msgs[0])
+
+	// the GC cleans both tiers
+	assert.NoError(t, manager.DeleteWAL(partitionID))
+	memoryWALs, err = memoryTier.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, memoryWALs, 0)
+	diskWALs, err := diskTier.DiscoverWALs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, diskWALs, 0)
+}
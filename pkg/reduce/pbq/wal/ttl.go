@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// Expired returns true if a message with the given event time is older than the ttl at now, a ttl of zero never
+// expires. The event time is the one in the header of the message, the same event time used to assign the windows.
+func Expired(eventTime time.Time, ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && eventTime.Before(now.Add(-ttl))
+}
+
+// SkipExpired forwards a replay, skipping the messages which have expired by the time they are replayed. The errors
+// are forwarded as they are. Once the ctx is done the returned channels are closed, and the rest of the replay is
+// drained in the background.
+func SkipExpired(ctx context.Context, msgCh <-chan *isb.ReadMessage, errCh <-chan error, ttl time.Duration) (<-chan *isb.ReadMessage, <-chan error) {
+	unexpired := make(chan *isb.ReadMessage)
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(unexpired)
+		for msgCh != nil {
+			select {
+			case <-ctx.Done():
+				go drainReplay(msgCh, errCh)
+				return
+			case err, ok := <-errCh:
+				if !ok {
+					errCh = nil
+					continue
+				}
+				select {
+				case errs <- err:
+				case <-ctx.Done():
+					go drainReplay(msgCh, nil)
+					return
+				}
+			case msg, ok := <-msgCh:
+				if !ok {
+					msgCh = nil
+					continue
+				}
+				// some WALs replay nil messages in place of the unused slots
+				if msg == nil || Expired(msg.EventTime, ttl, time.Now()) {
+					continue
+				}
+				select {
+				case unexpired <- msg:
+				case <-ctx.Done():
+					go drainReplay(msgCh, errCh)
+					return
+				}
+			}
+		}
+	}()
+	return unexpired, errs
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+func TestExpired(t *testing.T) {
+	now := time.Unix(120, 0)
+	assert.False(t, Expired(time.Unix(0, 0), 0, now))
+	assert.False(t, Expired(time.Unix(60, 0), time.Minute, now))
+	assert.True(t, Expired(time.Unix(59, 0), time.Minute, now))
+}
+
+func TestSkipExpired(t *testing.T) {
+	// the event times are staggered by a second, the newest message is a second old
+	messages := testutils.BuildTestReadMessages(10, time.Now().Add(-10*time.Second), nil)
+	w := &slowWAL{messages: messages, batchSize: 1}
+	ttl := 10*time.Second + 500*time.Millisecond
+
+	replay := func() []*isb.ReadMessage {
+		msgCh, errCh := w.Replay()
+		msgCh, _ = SkipExpired(context.Background(), msgCh, errCh, ttl)
+		var replayed []*isb.ReadMessage
+		for msg := range msgCh {
+			replayed = append(replayed, msg)
+		}
+		return replayed
+	}
+
+	// none of the messages has expired yet
+	assert.Len(t, replay(), len(messages))
+
+	// the oldest message expires once it is older than the ttl
+	time.Sleep(time.Second)
+	replayed := replay()
+	assert.Len(t, replayed, len(messages)-1)
+	for i, msg := range replayed {
+		assert.Equal(t, messages[i+1].Header.ID, msg.Header.ID)
+	}
+}
@@ -35,6 +35,7 @@ import (
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/unaligned"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 )
@@ -67,6 +68,7 @@ type compactor struct {
 	stopSignal          chan struct{}
 	doneCh              chan struct{}
 	latestWatermark     int64
+	messageTTL          time.Duration // messageTTL is the age after which the messages are discarded, disabled if zero
 	log                 *zap.SugaredLogger
 }
 
@@ -497,6 +499,11 @@ readLoop:
 
 // shouldKeepMessage checks if the message should be discarded or not
 func (c *compactor) shouldKeepMessage(eventTime int64, key string) bool {
+	// the expired messages are discarded regardless of their window
+	if wal.Expired(time.UnixMilli(eventTime), c.messageTTL, time.Now()) {
+		return false
+	}
+
 	// check if the key is present in the compaction key map
 	ce, ok := c.compactKeyMap[key]
 
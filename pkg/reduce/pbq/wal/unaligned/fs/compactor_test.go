@@ -64,7 +64,7 @@ func TestCompactor(t *testing.T) {
 
 	// write the messages
 	for _, readMessage := range readMessages {
-		err = s.Write(&readMessage)
+		err = s.Write(ctx, &readMessage)
 		assert.NoError(t, err)
 	}
 
@@ -173,7 +173,7 @@ func TestReplay_AfterCompaction(t *testing.T) {
 
 	// write the messages
 	for _, readMessage := range readMessages {
-		err = s.Write(&readMessage)
+		err = s.Write(ctx, &readMessage)
 		assert.NoError(t, err)
 	}
 
@@ -353,7 +353,7 @@ func TestCompactor_ContextClose(t *testing.T) {
 
 	// write the messages
 	for _, readMessage := range readMessages {
-		err = s.Write(&readMessage)
+		err = s.Write(ctx, &readMessage)
 		assert.NoError(t, err)
 	}
 
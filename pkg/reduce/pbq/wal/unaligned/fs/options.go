@@ -116,3 +116,11 @@ func WithCompactionDuration(maxDuration time.Duration) CompactorOption {
 		c.compactionDuration = maxDuration
 	}
 }
+
+// WithCompactorMessageTTL sets the age, by the event time, after which the messages are discarded by the compactor even
+// if their window has not been garbage collected yet.
+func WithCompactorMessageTTL(ttl time.Duration) CompactorOption {
+	return func(c *compactor) {
+		c.messageTTL = ttl
+	}
+}
@@ -62,6 +62,7 @@ type unalignedWAL struct {
 	maxBatchSize            int64         // maxBatchSize is the maximum batch size before the data is synced to the disk
 	segmentRotationDuration time.Duration // segmentRotationDuration is the duration after which the segment is rotated
 	filesToReplay           []string
+	numOfEntries            int64 // numOfEntries is the number of entries replayed and written by this instance
 	latestWm                time.Time
 	log                     *zap.SugaredLogger
 }
@@ -154,7 +155,7 @@ func NewUnalignedReadWriteWAL(ctx context.Context, pipelineName string, vertexNa
 //	+--------------------+-------------------+-----------------+------------------+-----------------+-------------+------------+----------------+
 //
 // CRC will be used for detecting ReadMessage corruptions.
-func (s *unalignedWAL) Write(message *isb.ReadMessage) error {
+func (s *unalignedWAL) Write(_ context.Context, message *isb.ReadMessage) error {
 
 	// encode the message
 	entry, err := s.encoder.encodeMessage(message)
@@ -203,12 +204,25 @@ func (s *unalignedWAL) Write(message *isb.ReadMessage) error {
 		}
 	}
 
+	s.numOfEntries++
 	segmentWALEntriesCount.WithLabelValues(s.pipelineName, s.vertexName, strconv.Itoa(int(s.replicaIndex))).Inc()
 	segmentWALBytes.WithLabelValues(s.vertexName, s.pipelineName, strconv.Itoa(int(s.replicaIndex))).Add(float64(wrote))
 
 	return nil
 }
 
+// WriteBatch writes the messages to the unalignedWAL one after the other, writes are already buffered so there is no
+// need to build a separate batch. It stops at the first failed write and returns wal.BatchWriteErr with the index of
+// the failed message.
+func (s *unalignedWAL) WriteBatch(ctx context.Context, messages []*isb.ReadMessage) error {
+	for i, message := range messages {
+		if err := s.Write(ctx, message); err != nil {
+			return wal.BatchWriteErr{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
 // Replay replays persisted messages during startup
 // It returns a channel to read messages from replay files and a channel to read errors
 func (s *unalignedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
@@ -246,6 +260,7 @@ func (s *unalignedWAL) Replay() (<-chan *isb.ReadMessage, <-chan error) {
 				}
 
 				// Successful decode, send the message on the message channel
+				s.numOfEntries++
 				msgChan <- msg
 			}
 
@@ -276,6 +291,39 @@ func (s *unalignedWAL) PartitionID() *partition.ID {
 	return s.partitionID
 }
 
+// Size returns the number of entries replayed and written by this unalignedWAL instance. Entries of the closed
+// windows which are removed by the compactor are still counted.
+func (s *unalignedWAL) Size() int64 {
+	return s.numOfEntries
+}
+
+// Flush flushes the buffered entries to the current segment and syncs it to the disk.
+func (s *unalignedWAL) Flush() error {
+	// the current segment of a read-write WAL is only opened once the replay is done
+	if s.dataBufWriter == nil {
+		return nil
+	}
+	if err := s.flushAndSync(); err != nil {
+		segmentWALErrors.WithLabelValues(s.pipelineName, s.vertexName, strconv.Itoa(int(s.replicaIndex)), "flushAndSync").Inc()
+		return err
+	}
+	return nil
+}
+
+// Ping creates and removes a temporary file in the segment directory, it fails if the directory is not writable.
+func (s *unalignedWAL) Ping(_ context.Context) error {
+	fp, err := os.CreateTemp(s.segmentWALPath, ".ping-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(fp.Name()) }()
+	_, err = fp.Write([]byte{0})
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 func (s *unalignedWAL) openReadFile(filePath string) (*os.File, *partition.ID, error) {
 
 	// Open the first file in the list
@@ -67,7 +67,7 @@ func TestUnalignedWAL_Write(t *testing.T) {
 
 	// write the messages
 	for _, readMessage := range readMessages {
-		err = s.Write(&readMessage)
+		err = s.Write(ctx, &readMessage)
 		assert.NoError(t, err)
 	}
 
@@ -102,10 +102,12 @@ func TestUnalignedWAL_Replay(t *testing.T) {
 
 	// write the messages
 	for _, readMessage := range readMessages {
-		err = s.Write(&readMessage)
+		err = s.Write(ctx, &readMessage)
 		assert.NoError(t, err)
 	}
 
+	assert.Equal(t, int64(len(readMessages)), s.Size())
+
 	// close the unalignedWAL
 	err = s.Close()
 	assert.NoError(t, err)
@@ -131,6 +133,7 @@ readLoop:
 	}
 	assert.NoError(t, err)
 	assert.Equal(t, len(readMessages), len(replayedMessages))
+	assert.Equal(t, int64(len(readMessages)), wls[0].Size())
 
 	// order is important
 	for i := 0; i < len(readMessages); i++ {
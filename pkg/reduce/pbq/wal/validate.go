@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wal
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+)
+
+// ValidatePartitionID returns InvalidPartitionIDErr if the slot of the partition contains a path separator or a control
+// character, since the partition IDs are used in the file paths and the keys of the WALs.
+func ValidatePartitionID(id partition.ID) error {
+	if strings.ContainsAny(id.Slot, `/\`) {
+		return InvalidPartitionIDErr{PartitionID: id, Reason: "slot contains a path separator"}
+	}
+	if strings.IndexFunc(id.Slot, unicode.IsControl) >= 0 {
+		return InvalidPartitionIDErr{PartitionID: id, Reason: "slot contains a control character"}
+	}
+	return nil
+}
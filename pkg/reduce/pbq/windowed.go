@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+// ReadWindowed reads the requests which are available in the PBQ, up to the read batch size, and groups their messages
+// by the tumbling window of windowSize their event time falls into, so that the reducer does not have to bucket them
+// itself. The windows are aligned to the unix epoch and span [Start, End), a message whose event time is on a boundary
+// belongs to the window which starts there, so the windows never overlap and every message is in exactly one of them.
+// A window is identified by a partition.ID with the slot of the PBQ, and its messages are in the order they were read.
+//
+// ReadWindowed waits for the first request unless the ctx is done, in which case ctx.Err() is returned, and then only
+// takes the requests which are already written. It consumes the output channel like ReadCh, the requests which carry no
+// message, e.g. the close of a window, are skipped, so it is meant for the aligned windows. io.EOF is returned once the
+// book is closed and all the requests are read.
+func (p *PBQ) ReadWindowed(ctx context.Context, windowSize time.Duration) (map[partition.ID][]*isb.Message, error) {
+	if windowSize <= 0 {
+		return nil, fmt.Errorf("window size should be positive, got %v", windowSize)
+	}
+
+	windowed := make(map[partition.ID][]*isb.Message)
+	add := func(request *window.TimedWindowRequest) {
+		if request.ReadMessage == nil {
+			return
+		}
+		id := p.eventTimeWindow(request.ReadMessage.EventTime, windowSize)
+		windowed[id] = append(windowed[id], &request.ReadMessage.Message)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case request, ok := <-p.output:
+		if !ok {
+			return nil, io.EOF
+		}
+		add(request)
+	}
+	for read := int64(1); read < p.options.readBatchSize; read++ {
+		select {
+		case request, ok := <-p.output:
+			if !ok {
+				// the messages which are read are returned, the next read finds the end
+				return windowed, nil
+			}
+			add(request)
+		default:
+			return windowed, nil
+		}
+	}
+	return windowed, nil
+}
+
+// eventTimeWindow returns the ID of the tumbling window of windowSize which the event time falls into.
+func (p *PBQ) eventTimeWindow(eventTime time.Time, windowSize time.Duration) partition.ID {
+	size := windowSize.Nanoseconds()
+	start := eventTime.UnixNano() / size * size
+	if start > eventTime.UnixNano() {
+		// the division truncates toward zero, the event times before the epoch belong to the window below
+		start -= size
+	}
+	return partition.ID{
+		Start: time.Unix(0, start),
+		End:   time.Unix(0, start+size),
+		Slot:  p.PartitionID.Slot,
+	}
+}
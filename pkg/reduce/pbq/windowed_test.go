@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Numaproj Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbq
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/partition"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	"github.com/numaproj/numaflow/pkg/window"
+)
+
+func TestPBQ_ReadWindowed(t *testing.T) {
+	ctx := context.Background()
+	qManager, err := NewManager(ctx, "reduce", "test-pipeline", 0, memory.NewMemManager(memory.WithStoreSize(100)), window.Aligned,
+		WithChannelBufferSize(100), WithReadBatchSize(100))
+	assert.NoError(t, err)
+	partitionID := partition.ID{Start: time.Unix(60, 0), End: time.Unix(90, 0), Slot: "slot-1"}
+	pq, err := qManager.CreateNewPBQ(ctx, partitionID)
+	assert.NoError(t, err)
+
+	// the event times are a second apart from 60s, so each of the windows [60s, 70s), [70s, 80s) and [80s, 90s) has 10
+	// messages, the ones at 70s and 80s start their windows
+	count := 30
+	windowRequests := testutils.BuildTestWindowRequests(int64(count), time.Unix(60, 0), window.Append)
+	for i := 0; i < count; i++ {
+		assert.NoError(t, pq.Write(ctx, &windowRequests[i], true))
+		if i == count/2 {
+			// a request without a message is skipped
+			assert.NoError(t, pq.Write(ctx, &window.TimedWindowRequest{Operation: window.Close}, true))
+		}
+	}
+
+	windowed, err := pq.(*PBQ).ReadWindowed(ctx, 10*time.Second)
+	assert.NoError(t, err)
+	assert.Len(t, windowed, 3)
+	for w := 0; w < 3; w++ {
+		id := partition.ID{Start: time.Unix(60+int64(w)*10, 0), End: time.Unix(70+int64(w)*10, 0), Slot: "slot-1"}
+		msgs, ok := windowed[id]
+		assert.True(t, ok, id.String())
+		assert.Len(t, msgs, 10)
+		for i, msg := range msgs {
+			assert.Equal(t, windowRequests[w*10+i].ReadMessage.Header.ID, msg.Header.ID)
+			assert.False(t, msg.EventTime.Before(id.Start))
+			assert.True(t, msg.EventTime.Before(id.End))
+		}
+	}
+
+	// nothing is available
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = pq.(*PBQ).ReadWindowed(canceled, 10*time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	pq.CloseOfBook()
+	_, err = pq.(*PBQ).ReadWindowed(ctx, 10*time.Second)
+	assert.ErrorIs(t, err, io.EOF)
+	_, err = pq.(*PBQ).ReadWindowed(ctx, 0)
+	assert.Error(t, err)
+}
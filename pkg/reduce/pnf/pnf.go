@@ -272,10 +272,20 @@ func (pf *ProcessAndForward) handleEOFResponse(ctx context.Context, response *wi
 		}
 	} else {
 		pid := *response.Window.Partition()
-		pbqReader := pf.pbqManager.GetPBQ(*response.Window.Partition())
+		pbqReader, ok := pf.pbqManager.GetPBQ(*response.Window.Partition())
+		// the pbq could have been evicted by the manager after it was idle beyond the partition TTL
+		if !ok {
+			pf.log.Infow("Partition already evicted", zap.String("partitionID", pid.String()))
+			return nil
+		}
 		err := wait.ExponentialBackoff(infiniteBackoff, func() (done bool, err error) {
 			var attempt int
-			err = pbqReader.GC()
+			// the garbage collection is traced with the span of the ctx if the pbq supports it
+			if contextGC, ok := pbqReader.(pbq.ContextGC); ok {
+				err = contextGC.GCWithContext(ctx)
+			} else {
+				err = pbqReader.GC()
+			}
 			if err != nil {
 				attempt++
 				pf.log.Errorw("Got an error while invoking GC on PBQ", zap.Error(err), zap.String("partitionID", pid.String()), zap.Int("attempt", attempt))
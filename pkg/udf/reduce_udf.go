@@ -19,6 +19,7 @@ package udf
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
@@ -32,7 +33,13 @@ import (
 	"github.com/numaproj/numaflow/pkg/reduce"
 	"github.com/numaproj/numaflow/pkg/reduce/applier"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq"
+	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal"
+	_ "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/boltdb"
 	alignedfs "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/fs"
+	_ "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/jetstream"
+	_ "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/memory"
+	_ "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/redis"
+	_ "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/aligned/s3"
 	noopwal "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/noop"
 	"github.com/numaproj/numaflow/pkg/reduce/pbq/wal/unaligned"
 	unalignedfs "github.com/numaproj/numaflow/pkg/reduce/pbq/wal/unaligned/fs"
@@ -303,9 +310,23 @@ func (u *ReduceUDFProcessor) Start(ctx context.Context) error {
 
 	// create noop wal manager
 	walManager := noopwal.NewNoopStores()
-	// if the vertex has a persistent volume claim or empty dir, create a file system based wal manager
-	if u.VertexInstance.Vertex.Spec.UDF.GroupBy.Storage.PersistentVolumeClaim != nil ||
-		u.VertexInstance.Vertex.Spec.UDF.GroupBy.Storage.EmptyDir != nil {
+	storage := u.VertexInstance.Vertex.Spec.UDF.GroupBy.Storage
+	switch {
+	case storage.Type != "":
+		// if the vertex has a store type, create the wal manager registered for it
+		if windower.Type() != window.Aligned {
+			return fmt.Errorf("store type %q is not supported by the unaligned windows", storage.Type)
+		}
+		walManager, err = wal.NewStoreManager(ctx, string(storage.Type), wal.ManagerOptions{PipelineName: pipelineName, VertexName: vertexName, Replica: vertexReplica})
+		if err != nil {
+			log.Errorw("Failed to create wal manager", zap.Error(err))
+			return fmt.Errorf("failed to create wal manager, %w", err)
+		}
+		if closer, ok := walManager.(io.Closer); ok {
+			defer func() { _ = closer.Close() }()
+		}
+	case storage.PersistentVolumeClaim != nil || storage.EmptyDir != nil:
+		// if the vertex has a persistent volume claim or empty dir, create a file system based wal manager
 		if windower.Type() == window.Aligned {
 			walManager = alignedfs.NewFSManager(u.VertexInstance)
 		} else {
@@ -16,24 +16,49 @@ limitations under the License.
 
 package wmb
 
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+)
+
 // WMBChecker checks if the idle watermark is valid. It checks by making sure the Idle WMB's offset has not been
 // changed in X iterations. This check is required because we have to make sure the time at which the idleness has been
 // detected matches with reality (processes could hang in between). The only way to do that is by multiple iterations.
 type WMBChecker struct {
 	iterationCounter int
 	iterations       int
-	w                WMB
+	// tolerance is the number of mismatching wmb offsets allowed while validating a wmb before starting over
+	tolerance  int
+	mismatches int
+	w          WMB
+}
+
+// WMBCheckerOption sets an option of the WMBChecker.
+type WMBCheckerOption func(*WMBChecker)
+
+// WithTolerance allows up to tolerance idle wmbs with a different offset while validating a wmb, e.g. when a late
+// arrival shows up in between. The tolerated wmbs are not counted as iterations, so the validated wmb is still the one
+// seen in all the iterations.
+func WithTolerance(tolerance int) WMBCheckerOption {
+	return func(c *WMBChecker) {
+		c.tolerance = tolerance
+	}
 }
 
 // NewWMBChecker returns a WMBChecker to check if the wmb is idle.
 // If all the iterations get the same wmb offset, the wmb is considered as valid
 // and will be used to publish a wmb to the toBuffer partitions of the next vertex.
-func NewWMBChecker(numOfIteration int) WMBChecker {
-	return WMBChecker{
+func NewWMBChecker(numOfIteration int, opts ...WMBCheckerOption) WMBChecker {
+	c := WMBChecker{
 		iterationCounter: 0,
 		iterations:       numOfIteration,
 		w:                WMB{},
 	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
 }
 
 // ValidateHeadWMB checks if the head wmb is idle, and it has the same wmb offset from the previous iteration.
@@ -44,11 +69,17 @@ func (c *WMBChecker) ValidateHeadWMB(w WMB) bool {
 		c.iterationCounter = 0
 		return false
 	}
+	if c.iterationCounter > 0 && c.w.Offset != w.Offset && c.mismatches < c.tolerance {
+		// tolerate the different offset, it neither counts as an iteration nor replaces the wmb being validated
+		c.mismatches++
+		return false
+	}
 	// check the iterationCounter value
 	if c.iterationCounter == 0 {
 		c.iterationCounter++
+		c.mismatches = 0
 		// the wmb only writes once when iterationCounter is zero
-		c.w.Offset = w.Offset
+		c.w = w
 	} else if c.iterationCounter < c.iterations-1 {
 		c.iterationCounter++
 		if c.w.Offset == w.Offset {
@@ -72,3 +103,150 @@ func (c *WMBChecker) ValidateHeadWMB(w WMB) bool {
 func (c *WMBChecker) GetCounter() int {
 	return c.iterationCounter
 }
+
+// GetWMB gets the wmb which is being validated, or the last validated wmb if a new validation has not started yet.
+func (c *WMBChecker) GetWMB() WMB {
+	return c.w
+}
+
+// Reset discards the idle detection state, the next idle wmb will start a new validation.
+func (c *WMBChecker) Reset() {
+	c.iterationCounter = 0
+	c.mismatches = 0
+	c.w = WMB{}
+}
+
+// SetMaxIterations updates the number of iterations required to validate an idle wmb. The iterationCounter is reset if
+// it has already gone past the new number of iterations, and the validation starts over.
+func (c *WMBChecker) SetMaxIterations(numOfIteration int) error {
+	if numOfIteration <= 0 {
+		return fmt.Errorf("number of iterations should be positive, got %d", numOfIteration)
+	}
+	c.iterations = numOfIteration
+	if numOfIteration < c.iterationCounter {
+		c.iterationCounter = 0
+	}
+	return nil
+}
+
+// checkerStateVersion is the version of the encoded WMBChecker state, it is bumped whenever the encoding changes.
+const checkerStateVersion byte = 1
+
+var (
+	_ encoding.BinaryMarshaler   = (*WMBChecker)(nil)
+	_ encoding.BinaryUnmarshaler = (*WMBChecker)(nil)
+)
+
+// MarshalBinary encodes the idle detection state of the WMBChecker, so that it can be checkpointed and restored after
+// a restart without having to observe all the iterations again.
+func (c *WMBChecker) MarshalBinary() ([]byte, error) {
+	w, err := c.w.EncodeToBytes()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, 1+4*binary.MaxVarintLen64+len(w))
+	b = append(b, checkerStateVersion)
+	b = binary.AppendUvarint(b, uint64(c.iterationCounter))
+	b = binary.AppendUvarint(b, uint64(c.iterations))
+	b = binary.AppendUvarint(b, uint64(c.tolerance))
+	b = binary.AppendUvarint(b, uint64(c.mismatches))
+	return append(b, w...), nil
+}
+
+// UnmarshalBinary restores the idle detection state of the WMBChecker encoded by MarshalBinary, the validation of the
+// wmb continues from the restored iterationCounter.
+func (c *WMBChecker) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 {
+		return fmt.Errorf("failed to decode the wmb checker state, empty data")
+	}
+	if b[0] != checkerStateVersion {
+		return fmt.Errorf("failed to decode the wmb checker state, unsupported version %d", b[0])
+	}
+	b = b[1:]
+	var fields [4]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return fmt.Errorf("failed to decode the wmb checker state, invalid field %d", i)
+		}
+		fields[i] = v
+		b = b[n:]
+	}
+	w, err := DecodeToWMB(b)
+	if err != nil {
+		return fmt.Errorf("failed to decode the wmb checker state, %w", err)
+	}
+	if fields[1] == 0 {
+		return fmt.Errorf("failed to decode the wmb checker state, number of iterations should be positive")
+	}
+	c.iterationCounter = int(fields[0])
+	c.iterations = int(fields[1])
+	c.tolerance = int(fields[2])
+	c.mismatches = int(fields[3])
+	c.w = w
+	return nil
+}
+
+// MultiWMBChecker checks if the idle watermark of a vertex which reads from multiple partitions is valid. Every
+// partition has its own WMBChecker, and the idle watermark is valid only once the idle wmb of every partition has been
+// validated and has stayed the same since.
+type MultiWMBChecker struct {
+	checkers []WMBChecker
+	// validated is true for the partitions whose idle wmb has been validated and has not changed since.
+	validated []bool
+}
+
+// NewMultiWMBChecker returns a MultiWMBChecker for the given number of partitions, every partition is validated with
+// the given number of iterations and options like a WMBChecker.
+func NewMultiWMBChecker(partitionCount int, numOfIteration int, opts ...WMBCheckerOption) MultiWMBChecker {
+	c := MultiWMBChecker{
+		checkers:  make([]WMBChecker, partitionCount),
+		validated: make([]bool, partitionCount),
+	}
+	for i := range c.checkers {
+		c.checkers[i] = NewWMBChecker(numOfIteration, opts...)
+	}
+	return c
+}
+
+// ValidateHeadWMB checks the head wmb of the partition, and returns true once the idle wmbs of all the partitions are
+// valid. A partition whose wmb is not idle, or whose idle wmb has changed since it was validated, has to be validated
+// again. Like the WMBChecker, the partitions start over after the idle watermark has been validated.
+func (c *MultiWMBChecker) ValidateHeadWMB(partitionIdx int32, w WMB) bool {
+	if partitionIdx < 0 || int(partitionIdx) >= len(c.checkers) {
+		return false
+	}
+	checker := &c.checkers[partitionIdx]
+	if c.validated[partitionIdx] && (!w.Idle || checker.GetWMB().Offset != w.Offset) {
+		c.validated[partitionIdx] = false
+	}
+	if checker.ValidateHeadWMB(w) {
+		c.validated[partitionIdx] = true
+	}
+
+	for _, validated := range c.validated {
+		if !validated {
+			return false
+		}
+	}
+	for i := range c.validated {
+		c.validated[i] = false
+	}
+	return true
+}
+
+// GetWMB gets the wmb which is being validated for the partition, or its last validated wmb.
+func (c *MultiWMBChecker) GetWMB(partitionIdx int32) WMB {
+	if partitionIdx < 0 || int(partitionIdx) >= len(c.checkers) {
+		return WMB{}
+	}
+	return c.checkers[partitionIdx].GetWMB()
+}
+
+// Reset discards the idle detection state of all the partitions.
+func (c *MultiWMBChecker) Reset() {
+	for i := range c.checkers {
+		c.checkers[i].Reset()
+		c.validated[i] = false
+	}
+}
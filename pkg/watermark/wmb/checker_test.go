@@ -134,3 +134,193 @@ func TestWMBChecker_ValidateHeadWMB(t *testing.T) {
 	}
 
 }
+
+func TestWMBChecker_Reset(t *testing.T) {
+	c := NewWMBChecker(3)
+	idle := WMB{Idle: true, Offset: 5, Watermark: 1000}
+
+	assert.False(t, c.ValidateHeadWMB(idle))
+	assert.False(t, c.ValidateHeadWMB(idle))
+	assert.Equal(t, 2, c.GetCounter())
+	assert.Equal(t, idle, c.GetWMB())
+
+	// reset in the middle of the iterations discards the progress
+	c.Reset()
+	assert.Equal(t, 0, c.GetCounter())
+	assert.Equal(t, WMB{}, c.GetWMB())
+	assert.False(t, c.ValidateHeadWMB(idle))
+	assert.Equal(t, 1, c.GetCounter())
+	assert.False(t, c.ValidateHeadWMB(idle))
+	assert.True(t, c.ValidateHeadWMB(idle))
+}
+
+func TestWMBChecker_MarshalBinary(t *testing.T) {
+	c := NewWMBChecker(4, WithTolerance(1))
+	idle := WMB{Idle: true, Offset: 5, Watermark: 1000, Partition: 2}
+
+	assert.False(t, c.ValidateHeadWMB(idle))
+	assert.False(t, c.ValidateHeadWMB(WMB{Idle: true, Offset: 6, Watermark: 2000}))
+	assert.False(t, c.ValidateHeadWMB(idle))
+	assert.Equal(t, 2, c.GetCounter())
+
+	b, err := c.MarshalBinary()
+	assert.NoError(t, err)
+
+	// the restored checker continues the validation where the checkpoint left it
+	var restored WMBChecker
+	assert.NoError(t, restored.UnmarshalBinary(b))
+	assert.Equal(t, c, restored)
+	assert.Equal(t, 2, restored.GetCounter())
+	assert.Equal(t, idle, restored.GetWMB())
+	assert.False(t, restored.ValidateHeadWMB(idle))
+	assert.Equal(t, 3, restored.GetCounter())
+	// the tolerance has already been used up before the checkpoint
+	assert.False(t, restored.ValidateHeadWMB(WMB{Idle: true, Offset: 6, Watermark: 2000}))
+	assert.Equal(t, 0, restored.GetCounter())
+
+	restored = WMBChecker{}
+	assert.NoError(t, restored.UnmarshalBinary(b))
+	assert.False(t, restored.ValidateHeadWMB(idle))
+	assert.True(t, restored.ValidateHeadWMB(idle))
+
+	assert.Error(t, restored.UnmarshalBinary(nil))
+	assert.Error(t, restored.UnmarshalBinary(append([]byte{checkerStateVersion + 1}, b[1:]...)))
+	assert.Error(t, restored.UnmarshalBinary(b[:2]))
+}
+
+func TestWMBChecker_GetWMB(t *testing.T) {
+	c := NewWMBChecker(2)
+	assert.Equal(t, WMB{}, c.GetWMB())
+
+	assert.False(t, c.ValidateHeadWMB(WMB{Idle: true, Offset: 1, Watermark: 1000}))
+	assert.False(t, c.ValidateHeadWMB(WMB{Idle: true, Offset: 2, Watermark: 2000}))
+	assert.False(t, c.ValidateHeadWMB(WMB{Idle: true, Offset: 3, Watermark: 3000}))
+	assert.True(t, c.ValidateHeadWMB(WMB{Idle: true, Offset: 3, Watermark: 3000}))
+	// the validated wmb is kept until the next validation starts
+	assert.Equal(t, int64(3), c.GetWMB().Offset)
+	assert.Equal(t, int64(3000), c.GetWMB().Watermark)
+}
+
+func TestWMBChecker_SetMaxIterations(t *testing.T) {
+	idle := WMB{Idle: true, Offset: 5, Watermark: 1000}
+
+	t.Run("lower_above_counter", func(t *testing.T) {
+		c := NewWMBChecker(5)
+		for i := 0; i < 3; i++ {
+			assert.False(t, c.ValidateHeadWMB(idle))
+		}
+		assert.NoError(t, c.SetMaxIterations(4))
+		assert.Equal(t, 3, c.GetCounter())
+		// the next matching iteration reaches the new max
+		assert.True(t, c.ValidateHeadWMB(idle))
+	})
+
+	t.Run("lower_below_counter", func(t *testing.T) {
+		c := NewWMBChecker(5)
+		for i := 0; i < 3; i++ {
+			assert.False(t, c.ValidateHeadWMB(idle))
+		}
+		assert.NoError(t, c.SetMaxIterations(2))
+		assert.Equal(t, 0, c.GetCounter())
+		assert.False(t, c.ValidateHeadWMB(idle))
+		assert.True(t, c.ValidateHeadWMB(idle))
+	})
+
+	t.Run("non_positive", func(t *testing.T) {
+		c := NewWMBChecker(2)
+		assert.Error(t, c.SetMaxIterations(0))
+		assert.Error(t, c.SetMaxIterations(-1))
+		assert.False(t, c.ValidateHeadWMB(idle))
+		assert.True(t, c.ValidateHeadWMB(idle))
+	})
+}
+
+func TestWMBChecker_WithTolerance(t *testing.T) {
+	tests := []struct {
+		name        string
+		offsets     []int64
+		wantCounter []int
+		want        []bool
+	}{
+		{
+			name:        "below_tolerance",
+			offsets:     []int64{5, 7, 5, 8, 5},
+			wantCounter: []int{1, 1, 2, 2, 0},
+			want:        []bool{false, false, false, false, true},
+		},
+		{
+			name:        "above_tolerance",
+			offsets:     []int64{5, 7, 8, 9, 5, 5, 5},
+			wantCounter: []int{1, 1, 1, 0, 1, 2, 0},
+			want:        []bool{false, false, false, false, false, false, true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewWMBChecker(3, WithTolerance(2))
+			for i, offset := range test.offsets {
+				assert.Equal(t, test.want[i], c.ValidateHeadWMB(WMB{Idle: true, Offset: offset, Watermark: 1000}), "iteration %d", i)
+				assert.Equal(t, test.wantCounter[i], c.GetCounter(), "iteration %d", i)
+			}
+			// the validated wmb is the one seen in all the iterations
+			assert.Equal(t, int64(5), c.GetWMB().Offset)
+		})
+	}
+
+	// an active wmb still resets right away
+	c := NewWMBChecker(3, WithTolerance(2))
+	assert.False(t, c.ValidateHeadWMB(WMB{Idle: true, Offset: 5}))
+	assert.False(t, c.ValidateHeadWMB(WMB{Idle: false, Offset: 6}))
+	assert.Equal(t, 0, c.GetCounter())
+}
+
+func TestMultiWMBChecker_ValidateHeadWMB(t *testing.T) {
+	idle0 := WMB{Idle: true, Offset: 5, Watermark: 1000}
+	idle1 := WMB{Idle: true, Offset: 7, Watermark: 1000}
+	busy1 := WMB{Idle: false, Offset: 8, Watermark: 2000}
+
+	t.Run("one_partition_busy", func(t *testing.T) {
+		c := NewMultiWMBChecker(2, 2)
+		// partition 0 stays idle while partition 1 keeps getting data, the aggregate never validates
+		for i := 0; i < 10; i++ {
+			assert.False(t, c.ValidateHeadWMB(0, idle0))
+			assert.False(t, c.ValidateHeadWMB(1, busy1))
+		}
+	})
+
+	t.Run("all_partitions_idle", func(t *testing.T) {
+		c := NewMultiWMBChecker(2, 2)
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		// partition 0 is validated, partition 1 is not yet
+		assert.False(t, c.ValidateHeadWMB(1, idle1))
+		assert.True(t, c.ValidateHeadWMB(1, idle1))
+		assert.Equal(t, idle1, c.GetWMB(1))
+		// the partitions start over after the idle watermark is validated
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+	})
+
+	t.Run("validated_partition_gets_busy", func(t *testing.T) {
+		c := NewMultiWMBChecker(2, 2)
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		// partition 0 gets data after it was validated, so it has to be validated again
+		assert.False(t, c.ValidateHeadWMB(0, WMB{Idle: false, Offset: 6, Watermark: 2000}))
+		assert.False(t, c.ValidateHeadWMB(1, idle1))
+		assert.False(t, c.ValidateHeadWMB(1, idle1))
+		idle0 = WMB{Idle: true, Offset: 9, Watermark: 3000}
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		assert.True(t, c.ValidateHeadWMB(0, idle0))
+	})
+
+	t.Run("unknown_partition", func(t *testing.T) {
+		c := NewMultiWMBChecker(1, 2)
+		assert.False(t, c.ValidateHeadWMB(1, idle0))
+		assert.Equal(t, WMB{}, c.GetWMB(-1))
+		assert.False(t, c.ValidateHeadWMB(0, idle0))
+		assert.True(t, c.ValidateHeadWMB(0, idle0))
+		c.Reset()
+		assert.Equal(t, WMB{}, c.GetWMB(0))
+	})
+}
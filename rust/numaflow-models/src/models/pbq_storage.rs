@@ -29,6 +29,9 @@ pub struct PbqStorage {
         skip_serializing_if = "Option::is_none"
     )]
     pub persistent_volume_claim: Option<Box<crate::models::PersistenceStrategy>>,
+    /// Type is the name of the store type which persists the PBQs, e.g. boltdb, redis, s3 or jetstream, it takes precedence over the persistent volume claim and the empty dir. The store type has to be registered with the WAL registry of the reduce vertex, and is only supported by the aligned windows.
+    #[serde(rename = "type", skip_serializing_if = "Option::is_none")]
+    pub r#type: Option<String>,
 }
 
 impl PbqStorage {
@@ -38,6 +41,7 @@ impl PbqStorage {
             empty_dir: None,
             no_store: None,
             persistent_volume_claim: None,
+            r#type: None,
         }
     }
 }